@@ -0,0 +1,370 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"strconv"
+	"swapp-go/cmd/internal/adapters/handlers/responses"
+	"swapp-go/cmd/internal/application/services"
+	"swapp-go/cmd/internal/domain"
+	"time"
+)
+
+type AdminHandler struct {
+	adminService       services.AdminServiceInterface
+	swapRequestService services.SwapRequestServiceInterface
+	emailOutboxService services.EmailOutboxServiceInterface
+}
+
+func NewAdminHandler(
+	adminServiceInterface services.AdminServiceInterface,
+	swapRequestServiceInterface services.SwapRequestServiceInterface,
+	emailOutboxServiceInterface services.EmailOutboxServiceInterface,
+) *AdminHandler {
+	return &AdminHandler{
+		adminService:       adminServiceInterface,
+		swapRequestService: swapRequestServiceInterface,
+		emailOutboxService: emailOutboxServiceInterface,
+	}
+}
+
+type SetRoleRequest struct {
+	Role domain.Role `json:"role" binding:"required"`
+}
+
+// AdminUserResponse is a user as seen by moderators.
+type AdminUserResponse struct {
+	UserResponse
+	Role        string     `json:"role"`
+	SuspendedAt *time.Time `json:"suspended_at,omitempty"`
+}
+
+type AdminUserSuccessResponse struct {
+	Message string             `json:"message"`
+	User    *AdminUserResponse `json:"user"`
+}
+
+type AdminUserListResponse struct {
+	Message string              `json:"message"`
+	Users   []AdminUserResponse `json:"users"`
+}
+
+// AdminEmailResponse is an outbox message as seen by admins. The template data
+// is left out, since it may carry single-use links.
+type AdminEmailResponse struct {
+	ID            uuid.UUID  `json:"id"`
+	Recipient     string     `json:"recipient"`
+	Template      string     `json:"template"`
+	Status        string     `json:"status"`
+	Attempts      int        `json:"attempts"`
+	NextAttemptAt time.Time  `json:"next_attempt_at"`
+	LastError     string     `json:"last_error,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	SentAt        *time.Time `json:"sent_at,omitempty"`
+}
+
+type AdminEmailSuccessResponse struct {
+	Message string              `json:"message"`
+	Email   *AdminEmailResponse `json:"email"`
+}
+
+type AdminEmailListResponse struct {
+	Message string               `json:"message"`
+	Emails  []AdminEmailResponse `json:"emails"`
+}
+
+// ListUsers pages through users. Supported query parameters: role, suspended,
+// limit and offset.
+func (handler *AdminHandler) ListUsers(context *gin.Context) {
+	query, err := parseUserListQuery(context)
+	if err != nil {
+		responses.BadRequest(context, "Invalid query parameters", err)
+		return
+	}
+
+	users, err := handler.adminService.ListUsers(query)
+	if err != nil {
+		respondWithAdminError(context, "Failed to list users", err)
+		return
+	}
+
+	response := make([]AdminUserResponse, 0, len(users))
+	for _, user := range users {
+		response = append(response, *toAdminUserResponse(&user))
+	}
+
+	context.JSON(http.StatusOK, AdminUserListResponse{
+		Message: "Users fetched successfully",
+		Users:   response,
+	})
+}
+
+func (handler *AdminHandler) SuspendUser(context *gin.Context) {
+	actorID, userID, ok := bindModeratedUser(context)
+	if !ok {
+		return
+	}
+
+	user, err := handler.adminService.SuspendUser(actorID, userID)
+	if err != nil {
+		respondWithAdminError(context, "Failed to suspend user", err)
+		return
+	}
+
+	respondWithAdminUser(context, "User suspended successfully!", user)
+}
+
+func (handler *AdminHandler) UnsuspendUser(context *gin.Context) {
+	actorID, userID, ok := bindModeratedUser(context)
+	if !ok {
+		return
+	}
+
+	user, err := handler.adminService.UnsuspendUser(actorID, userID)
+	if err != nil {
+		respondWithAdminError(context, "Failed to unsuspend user", err)
+		return
+	}
+
+	respondWithAdminUser(context, "User unsuspended successfully!", user)
+}
+
+func (handler *AdminHandler) SetRole(context *gin.Context) {
+	actorID, userID, ok := bindModeratedUser(context)
+	if !ok {
+		return
+	}
+
+	var request SetRoleRequest
+	if err := context.ShouldBindJSON(&request); err != nil {
+		responses.BadRequest(context, "Invalid request", err)
+		return
+	}
+
+	user, err := handler.adminService.SetRole(actorID, userID, request.Role)
+	if err != nil {
+		respondWithAdminError(context, "Failed to change role", err)
+		return
+	}
+
+	respondWithAdminUser(context, "Role updated successfully!", user)
+}
+
+func (handler *AdminHandler) CancelSwapRequest(context *gin.Context) {
+	requestID, err := uuid.Parse(context.Param("id"))
+	if err != nil {
+		responses.BadRequest(context, "Invalid ID format", err)
+		return
+	}
+
+	swapRequest, err := handler.swapRequestService.ForceCancel(requestID)
+	if err != nil {
+		respondWithSwapRequestError(context, "Failed to cancel swap request", err)
+		return
+	}
+
+	respondWithSwapRequest(context, http.StatusOK, "Swap request cancelled successfully!", swapRequest)
+}
+
+// ListEmails pages through the email outbox. Supported query parameters:
+// status, limit and offset.
+func (handler *AdminHandler) ListEmails(context *gin.Context) {
+	query, err := parseOutboxQuery(context)
+	if err != nil {
+		responses.BadRequest(context, "Invalid query parameters", err)
+		return
+	}
+
+	messages, err := handler.emailOutboxService.ListMessages(query)
+	if err != nil {
+		respondWithOutboxError(context, "Failed to list emails", err)
+		return
+	}
+
+	response := make([]AdminEmailResponse, 0, len(messages))
+	for _, message := range messages {
+		response = append(response, *toAdminEmailResponse(&message))
+	}
+
+	context.JSON(http.StatusOK, AdminEmailListResponse{
+		Message: "Emails fetched successfully",
+		Emails:  response,
+	})
+}
+
+func (handler *AdminHandler) GetEmail(context *gin.Context) {
+	messageID, err := uuid.Parse(context.Param("id"))
+	if err != nil {
+		responses.BadRequest(context, "Invalid ID format", err)
+		return
+	}
+
+	message, err := handler.emailOutboxService.FindMessage(messageID)
+	if err != nil {
+		respondWithOutboxError(context, "Failed to fetch email", err)
+		return
+	}
+
+	respondWithAdminEmail(context, "Email fetched successfully", message)
+}
+
+// ReplayEmail gives a dead email a fresh set of delivery attempts.
+func (handler *AdminHandler) ReplayEmail(context *gin.Context) {
+	messageID, err := uuid.Parse(context.Param("id"))
+	if err != nil {
+		responses.BadRequest(context, "Invalid ID format", err)
+		return
+	}
+
+	message, err := handler.emailOutboxService.Replay(messageID)
+	if err != nil {
+		respondWithOutboxError(context, "Failed to replay email", err)
+		return
+	}
+
+	respondWithAdminEmail(context, "Email queued for delivery again!", message)
+}
+
+// bindModeratedUser reads the moderator making the request and the user it targets.
+func bindModeratedUser(context *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	actorID, err := getUserIDFromContext(context)
+	if err != nil {
+		responses.Unauthorized(context, "Unauthorized", err)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	userID, err := uuid.Parse(context.Param("id"))
+	if err != nil {
+		responses.BadRequest(context, "Invalid ID format", err)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return actorID, userID, true
+}
+
+func parseUserListQuery(context *gin.Context) (domain.UserListQuery, error) {
+	var query domain.UserListQuery
+
+	if raw := context.Query("role"); raw != "" {
+		role := domain.Role(raw)
+		query.Role = &role
+	}
+
+	if raw := context.Query("suspended"); raw != "" {
+		suspended, err := strconv.ParseBool(raw)
+		if err != nil {
+			return query, err
+		}
+		query.Suspended = &suspended
+	}
+
+	var err error
+	query.Limit, query.Offset, err = parsePage(context)
+
+	return query, err
+}
+
+func parseOutboxQuery(context *gin.Context) (domain.OutboxQuery, error) {
+	var query domain.OutboxQuery
+
+	if raw := context.Query("status"); raw != "" {
+		status := domain.OutboxStatus(raw)
+		query.Status = &status
+	}
+
+	var err error
+	query.Limit, query.Offset, err = parsePage(context)
+
+	return query, err
+}
+
+// parsePage reads the limit and offset query parameters, leaving them zero
+// when absent.
+func parsePage(context *gin.Context) (int, int, error) {
+	var limit, offset int
+	var err error
+
+	if raw := context.Query("limit"); raw != "" {
+		limit, err = strconv.Atoi(raw)
+		if err != nil || limit < 1 {
+			return 0, 0, errors.New("limit must be a positive integer")
+		}
+	}
+
+	if raw := context.Query("offset"); raw != "" {
+		offset, err = strconv.Atoi(raw)
+		if err != nil || offset < 0 {
+			return 0, 0, errors.New("offset must be a non-negative integer")
+		}
+	}
+
+	return limit, offset, nil
+}
+
+func respondWithAdminError(context *gin.Context, message string, err error) {
+	switch {
+	case errors.Is(err, services.ModeratedUserNotFoundErr):
+		responses.NotFound(context, "User not found", err)
+	case errors.Is(err, services.CannotModerateSelfErr):
+		responses.BadRequest(context, "You cannot moderate your own account", err)
+	case errors.Is(err, services.InsufficientRoleErr):
+		responses.Forbidden(context, "Insufficient permissions", err)
+	case errors.Is(err, domain.InvalidRoleErr):
+		responses.BadRequest(context, "Invalid role", err)
+	default:
+		responses.InternalServerError(context, message, err)
+	}
+}
+
+func respondWithAdminUser(context *gin.Context, message string, user *domain.User) {
+	context.JSON(http.StatusOK, AdminUserSuccessResponse{
+		Message: message,
+		User:    toAdminUserResponse(user),
+	})
+}
+
+func toAdminUserResponse(user *domain.User) *AdminUserResponse {
+	return &AdminUserResponse{
+		UserResponse: *toUserResponse(user),
+		Role:         string(user.Role),
+		SuspendedAt:  user.SuspendedAt,
+	}
+}
+
+func respondWithOutboxError(context *gin.Context, message string, err error) {
+	switch {
+	case errors.Is(err, services.OutboxMessageNotFoundErr):
+		responses.NotFound(context, "Email not found", err)
+	case errors.Is(err, services.OutboxMessageNotDeadErr):
+		responses.Conflict(context, "Only dead emails can be replayed", err)
+	case errors.Is(err, services.OutboxMessageLinkDiscardedErr):
+		responses.Conflict(context, "This email's link was discarded, the user has to request a new one", err)
+	case errors.Is(err, domain.InvalidOutboxStatusErr):
+		responses.BadRequest(context, "Invalid status", err)
+	default:
+		responses.InternalServerError(context, message, err)
+	}
+}
+
+func respondWithAdminEmail(context *gin.Context, message string, outboxMessage *domain.OutboxMessage) {
+	context.JSON(http.StatusOK, AdminEmailSuccessResponse{
+		Message: message,
+		Email:   toAdminEmailResponse(outboxMessage),
+	})
+}
+
+func toAdminEmailResponse(message *domain.OutboxMessage) *AdminEmailResponse {
+	return &AdminEmailResponse{
+		ID:            message.ID,
+		Recipient:     message.Recipient,
+		Template:      string(message.Template),
+		Status:        string(message.Status),
+		Attempts:      message.Attempts,
+		NextAttemptAt: message.NextAttemptAt,
+		LastError:     message.LastError,
+		CreatedAt:     message.CreatedAt,
+		SentAt:        message.SentAt,
+	}
+}
@@ -0,0 +1,283 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"swapp-go/cmd/internal/adapters/handlers"
+	"swapp-go/cmd/internal/adapters/handlers/mocks"
+	"swapp-go/cmd/internal/application/services"
+	"swapp-go/cmd/internal/domain"
+	"testing"
+	"time"
+)
+
+var (
+	adminActorID  = uuid.New()
+	moderatedUser = &domain.User{ID: uuid.New(), Username: "member", Email: "member@example.com", Role: domain.RoleUser}
+)
+
+func setupAdminRouter(t *testing.T) (*mocks.MockAdminService, *mocks.SwapRequestService, *gin.Engine) {
+	mockAdminService, mockSwapRequestService, _, router := setupAdminRouterWithOutbox(t)
+
+	return mockAdminService, mockSwapRequestService, router
+}
+
+func setupAdminRouterWithOutbox(t *testing.T) (*mocks.MockAdminService, *mocks.SwapRequestService, *mocks.MockEmailOutboxService, *gin.Engine) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	mockAdminService := new(mocks.MockAdminService)
+	mockSwapRequestService := new(mocks.SwapRequestService)
+	mockEmailOutboxService := new(mocks.MockEmailOutboxService)
+	handler := handlers.NewAdminHandler(mockAdminService, mockSwapRequestService, mockEmailOutboxService)
+
+	router := gin.New()
+	router.Use(func(context *gin.Context) {
+		context.Set("userID", adminActorID.String())
+	})
+	router.GET("/admin/users", handler.ListUsers)
+	router.POST("/admin/users/:id/suspend", handler.SuspendUser)
+	router.POST("/admin/users/:id/unsuspend", handler.UnsuspendUser)
+	router.PATCH("/admin/users/:id/role", handler.SetRole)
+	router.POST("/admin/swap-requests/:id/cancel", handler.CancelSwapRequest)
+	router.GET("/admin/emails", handler.ListEmails)
+	router.GET("/admin/emails/:id", handler.GetEmail)
+	router.POST("/admin/emails/:id/replay", handler.ReplayEmail)
+
+	return mockAdminService, mockSwapRequestService, mockEmailOutboxService, router
+}
+
+func TestAdminHandler(t *testing.T) {
+	t.Run("ListUsers", func(t *testing.T) {
+		t.Run("success", func(t *testing.T) {
+			mockAdminService, _, router := setupAdminRouter(t)
+
+			role := domain.RoleModerator
+			suspended := false
+			mockAdminService.On("ListUsers", domain.UserListQuery{Role: &role, Suspended: &suspended, Limit: 20, Offset: 40}).
+				Return([]domain.User{{ID: uuid.New(), Username: "mod", Role: domain.RoleModerator}}, nil)
+
+			response := performRequest(t, router, http.MethodGet, "/admin/users?role=moderator&suspended=false&limit=20&offset=40", nil)
+			assert.Equal(t, http.StatusOK, response.Code)
+
+			var parsed handlers.AdminUserListResponse
+			assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &parsed))
+			assert.Len(t, parsed.Users, 1)
+			assert.Equal(t, "moderator", parsed.Users[0].Role)
+		})
+
+		t.Run("invalid parameters", func(t *testing.T) {
+			for _, url := range []string{"/admin/users?suspended=maybe", "/admin/users?limit=0", "/admin/users?offset=-1"} {
+				mockAdminService, _, router := setupAdminRouter(t)
+
+				response := performRequest(t, router, http.MethodGet, url, nil)
+				assert.Equal(t, http.StatusBadRequest, response.Code, url)
+				mockAdminService.AssertNotCalled(t, "ListUsers", mock.Anything)
+			}
+		})
+
+		t.Run("invalid role", func(t *testing.T) {
+			mockAdminService, _, router := setupAdminRouter(t)
+
+			mockAdminService.On("ListUsers", mock.Anything).Return(nil, domain.InvalidRoleErr)
+
+			response := performRequest(t, router, http.MethodGet, "/admin/users?role=root", nil)
+			assert.Equal(t, http.StatusBadRequest, response.Code)
+		})
+	})
+
+	t.Run("SuspendUser", func(t *testing.T) {
+		t.Run("success", func(t *testing.T) {
+			mockAdminService, _, router := setupAdminRouter(t)
+
+			suspendedAt := time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+			suspended := *moderatedUser
+			suspended.SuspendedAt = &suspendedAt
+			mockAdminService.On("SuspendUser", adminActorID, moderatedUser.ID).Return(&suspended, nil)
+
+			response := performRequest(t, router, http.MethodPost, "/admin/users/"+moderatedUser.ID.String()+"/suspend", nil)
+			assert.Equal(t, http.StatusOK, response.Code)
+
+			var parsed handlers.AdminUserSuccessResponse
+			assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &parsed))
+			assert.NotNil(t, parsed.User.SuspendedAt)
+		})
+
+		t.Run("errors", func(t *testing.T) {
+			tests := []struct {
+				err    error
+				status int
+			}{
+				{services.ModeratedUserNotFoundErr, http.StatusNotFound},
+				{services.CannotModerateSelfErr, http.StatusBadRequest},
+				{services.InsufficientRoleErr, http.StatusForbidden},
+				{errors.New("db down"), http.StatusInternalServerError},
+			}
+
+			for _, test := range tests {
+				mockAdminService, _, router := setupAdminRouter(t)
+
+				mockAdminService.On("SuspendUser", adminActorID, moderatedUser.ID).Return(nil, test.err)
+
+				response := performRequest(t, router, http.MethodPost, "/admin/users/"+moderatedUser.ID.String()+"/suspend", nil)
+				assert.Equal(t, test.status, response.Code, test.err.Error())
+			}
+		})
+
+		t.Run("invalid id", func(t *testing.T) {
+			_, _, router := setupAdminRouter(t)
+
+			response := performRequest(t, router, http.MethodPost, "/admin/users/not-a-uuid/suspend", nil)
+			assert.Equal(t, http.StatusBadRequest, response.Code)
+		})
+	})
+
+	t.Run("UnsuspendUser", func(t *testing.T) {
+		mockAdminService, _, router := setupAdminRouter(t)
+
+		mockAdminService.On("UnsuspendUser", adminActorID, moderatedUser.ID).Return(moderatedUser, nil)
+
+		response := performRequest(t, router, http.MethodPost, "/admin/users/"+moderatedUser.ID.String()+"/unsuspend", nil)
+		assert.Equal(t, http.StatusOK, response.Code)
+		mockAdminService.AssertExpectations(t)
+	})
+
+	t.Run("SetRole", func(t *testing.T) {
+		t.Run("success", func(t *testing.T) {
+			mockAdminService, _, router := setupAdminRouter(t)
+
+			promoted := *moderatedUser
+			promoted.Role = domain.RoleModerator
+			mockAdminService.On("SetRole", adminActorID, moderatedUser.ID, domain.RoleModerator).Return(&promoted, nil)
+
+			response := performRequest(t, router, http.MethodPatch, "/admin/users/"+moderatedUser.ID.String()+"/role", map[string]string{"role": "moderator"})
+			assert.Equal(t, http.StatusOK, response.Code)
+
+			var parsed handlers.AdminUserSuccessResponse
+			assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &parsed))
+			assert.Equal(t, "moderator", parsed.User.Role)
+		})
+
+		t.Run("missing role", func(t *testing.T) {
+			mockAdminService, _, router := setupAdminRouter(t)
+
+			response := performRequest(t, router, http.MethodPatch, "/admin/users/"+moderatedUser.ID.String()+"/role", map[string]string{})
+			assert.Equal(t, http.StatusBadRequest, response.Code)
+			mockAdminService.AssertNotCalled(t, "SetRole", mock.Anything, mock.Anything, mock.Anything)
+		})
+	})
+
+	t.Run("CancelSwapRequest", func(t *testing.T) {
+		t.Run("success", func(t *testing.T) {
+			_, mockSwapRequestService, router := setupAdminRouter(t)
+
+			requestID := uuid.New()
+			mockSwapRequestService.On("ForceCancel", requestID).Return(&domain.SwapRequest{ID: requestID, Status: domain.StatusCancelled}, nil)
+
+			response := performRequest(t, router, http.MethodPost, "/admin/swap-requests/"+requestID.String()+"/cancel", nil)
+			assert.Equal(t, http.StatusOK, response.Code)
+			assert.Contains(t, response.Body.String(), "cancelled")
+		})
+
+		t.Run("errors", func(t *testing.T) {
+			tests := []struct {
+				err    error
+				status int
+			}{
+				{services.SwapRequestNotFoundErr, http.StatusNotFound},
+				{&domain.StatusTransitionError{Err: domain.InvalidStatusTransitionErr}, http.StatusConflict},
+				{services.SwapRequestChangedErr, http.StatusConflict},
+			}
+
+			for _, test := range tests {
+				_, mockSwapRequestService, router := setupAdminRouter(t)
+
+				requestID := uuid.New()
+				mockSwapRequestService.On("ForceCancel", requestID).Return(nil, test.err)
+
+				response := performRequest(t, router, http.MethodPost, "/admin/swap-requests/"+requestID.String()+"/cancel", nil)
+				assert.Equal(t, test.status, response.Code, test.err.Error())
+			}
+		})
+	})
+
+	t.Run("ListEmails", func(t *testing.T) {
+		t.Run("success", func(t *testing.T) {
+			_, _, mockEmailOutboxService, router := setupAdminRouterWithOutbox(t)
+
+			dead := domain.OutboxDead
+			mockEmailOutboxService.On("ListMessages", domain.OutboxQuery{Status: &dead, Limit: 10}).Return([]domain.OutboxMessage{{
+				ID: uuid.New(),
+				EmailMessage: domain.EmailMessage{
+					Recipient: "member@example.com",
+					Template:  domain.EmailTemplatePasswordReset,
+					Data:      domain.EmailData{"ResetURL": "secret link"},
+				},
+				Status:    domain.OutboxDead,
+				Attempts:  8,
+				LastError: "mailbox unavailable",
+			}}, nil)
+
+			response := performRequest(t, router, http.MethodGet, "/admin/emails?status=dead&limit=10", nil)
+			assert.Equal(t, http.StatusOK, response.Code)
+			assert.NotContains(t, response.Body.String(), "secret link")
+
+			var parsed handlers.AdminEmailListResponse
+			assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &parsed))
+			assert.Len(t, parsed.Emails, 1)
+			assert.Equal(t, "dead", parsed.Emails[0].Status)
+			assert.Equal(t, "password_reset", parsed.Emails[0].Template)
+			assert.Equal(t, "mailbox unavailable", parsed.Emails[0].LastError)
+		})
+
+		t.Run("invalid status", func(t *testing.T) {
+			_, _, mockEmailOutboxService, router := setupAdminRouterWithOutbox(t)
+
+			mockEmailOutboxService.On("ListMessages", mock.Anything).Return(nil, domain.InvalidOutboxStatusErr)
+
+			response := performRequest(t, router, http.MethodGet, "/admin/emails?status=lost", nil)
+			assert.Equal(t, http.StatusBadRequest, response.Code)
+		})
+	})
+
+	t.Run("GetEmail", func(t *testing.T) {
+		_, _, mockEmailOutboxService, router := setupAdminRouterWithOutbox(t)
+
+		messageID := uuid.New()
+		mockEmailOutboxService.On("FindMessage", messageID).Return(nil, services.OutboxMessageNotFoundErr)
+
+		response := performRequest(t, router, http.MethodGet, "/admin/emails/"+messageID.String(), nil)
+		assert.Equal(t, http.StatusNotFound, response.Code)
+	})
+
+	t.Run("ReplayEmail", func(t *testing.T) {
+		t.Run("success", func(t *testing.T) {
+			_, _, mockEmailOutboxService, router := setupAdminRouterWithOutbox(t)
+
+			messageID := uuid.New()
+			mockEmailOutboxService.On("Replay", messageID).Return(&domain.OutboxMessage{ID: messageID, Status: domain.OutboxPending}, nil)
+
+			response := performRequest(t, router, http.MethodPost, "/admin/emails/"+messageID.String()+"/replay", nil)
+			assert.Equal(t, http.StatusOK, response.Code)
+
+			var parsed handlers.AdminEmailSuccessResponse
+			assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &parsed))
+			assert.Equal(t, "pending", parsed.Email.Status)
+		})
+
+		t.Run("not dead", func(t *testing.T) {
+			_, _, mockEmailOutboxService, router := setupAdminRouterWithOutbox(t)
+
+			messageID := uuid.New()
+			mockEmailOutboxService.On("Replay", messageID).Return(nil, services.OutboxMessageNotDeadErr)
+
+			response := performRequest(t, router, http.MethodPost, "/admin/emails/"+messageID.String()+"/replay", nil)
+			assert.Equal(t, http.StatusConflict, response.Code)
+		})
+	})
+}
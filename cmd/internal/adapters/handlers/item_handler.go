@@ -1,13 +1,17 @@
 package handlers
 
 import (
+	"errors"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"mime/multipart"
 	"net/http"
-	"path/filepath"
+	"strconv"
+	"strings"
 	"swapp-go/cmd/internal/adapters/handlers/responses"
 	"swapp-go/cmd/internal/application/services"
 	"swapp-go/cmd/internal/domain"
+	"time"
 )
 
 type ItemHandler struct {
@@ -18,12 +22,43 @@ func NewItemHandler(itemServiceInterface services.ItemServiceInterface) *ItemHan
 	return &ItemHandler{itemServiceInterface}
 }
 
+// pictureFormKeys are the multipart fields that may carry item pictures; "picture"
+// is kept for clients that upload a single file.
+var pictureFormKeys = []string{"pictures", "picture"}
+
 type ItemResponse struct {
-	ItemID      string `json:"item_id"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	PictureURL  string `json:"picture"`
-	UserID      string `json:"user_id"`
+	ItemID      string                `json:"item_id"`
+	Name        string                `json:"name"`
+	Description string                `json:"description"`
+	PictureURL  string                `json:"picture"`
+	Pictures    []ItemPictureResponse `json:"pictures"`
+	UserID      string                `json:"user_id"`
+	Offered     bool                  `json:"offered"`
+	Category    string                `json:"category,omitempty"`
+	Condition   string                `json:"condition,omitempty"`
+	Tags        []string              `json:"tags"`
+	CreatedAt   time.Time             `json:"created_at"`
+}
+
+type ItemPictureResponse struct {
+	PictureID string                            `json:"picture_id"`
+	URL       string                            `json:"url"`
+	Width     int                               `json:"width,omitempty"`
+	Height    int                               `json:"height,omitempty"`
+	Position  int                               `json:"position"`
+	IsPrimary bool                              `json:"is_primary"`
+	Variants  map[string]PictureVariantResponse `json:"variants,omitempty"`
+}
+
+type PictureVariantResponse struct {
+	URL    string `json:"url"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+type ReorderPicturesRequest struct {
+	PictureIDs       []uuid.UUID `json:"picture_ids" binding:"required"`
+	PrimaryPictureID uuid.UUID   `json:"primary_picture_id"`
 }
 
 type ItemSuccessResponse struct {
@@ -31,6 +66,31 @@ type ItemSuccessResponse struct {
 	Item    *ItemResponse `json:"item"`
 }
 
+type CategoryResponse struct {
+	Slug       string `json:"slug"`
+	Name       string `json:"name"`
+	ParentSlug string `json:"parent_slug,omitempty"`
+}
+
+type CategoryListResponse struct {
+	Message    string             `json:"message"`
+	Categories []CategoryResponse `json:"categories"`
+}
+
+// itemAttributes holds the optional classification fields of the item forms.
+type itemAttributes struct {
+	categorySlug string
+	condition    domain.ItemCondition
+	tags         []string
+	hasTags      bool
+}
+
+type ItemListResponse struct {
+	Message    string         `json:"message"`
+	Items      []ItemResponse `json:"items"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+}
+
 func (handler *ItemHandler) Create(context *gin.Context) {
 	userID := context.GetString("userID")
 	parsedUserID, err := uuid.Parse(userID)
@@ -42,19 +102,32 @@ func (handler *ItemHandler) Create(context *gin.Context) {
 	name := context.PostForm("name")
 	description := context.PostForm("description")
 
-	pictureURL, err := saveUploadedPicture(context, "picture")
+	attributes, err := parseItemAttributes(context)
+	if err != nil {
+		responses.BadRequest(context, "Invalid item attributes", err)
+		return
+	}
+
+	uploads, closeUploads, err := openUploadedPictures(context)
 	if err != nil {
-		responses.InternalServerError(context, "Failed to save uploaded picture", err)
+		responses.BadRequest(context, "Invalid picture upload", err)
+		return
 	}
+	defer closeUploads()
 
 	item := &domain.Item{
-		Name:        name,
-		Description: description,
-		PictureURL:  pictureURL,
-		UserID:      parsedUserID,
+		Name:         name,
+		Description:  description,
+		UserID:       parsedUserID,
+		CategorySlug: attributes.categorySlug,
+		Condition:    attributes.condition,
+		Tags:         attributes.tags,
 	}
 
-	if err = handler.itemService.Create(item); err != nil {
+	if err = handler.itemService.Create(item, uploads); err != nil {
+		if respondWithPictureError(context, err) {
+			return
+		}
 		responses.BadRequest(context, "Item creation failed", err)
 		return
 	}
@@ -79,17 +152,42 @@ func (handler *ItemHandler) Update(context *gin.Context) {
 		updateData["description"] = description
 	}
 
-	if url, err := saveUploadedPicture(context, "picture"); err == nil {
-		updateData["picture"] = url
+	attributes, err := parseItemAttributes(context)
+	if err != nil {
+		responses.BadRequest(context, "Invalid item attributes", err)
+		return
+	}
+	if attributes.categorySlug != "" {
+		updateData["category_slug"] = attributes.categorySlug
+	}
+	if attributes.condition != "" {
+		updateData["condition"] = string(attributes.condition)
+	}
+	if attributes.hasTags {
+		updateData["tags"] = attributes.tags
+	}
+
+	uploads, closeUploads, err := openUploadedPictures(context)
+	if err != nil {
+		responses.BadRequest(context, "Invalid picture upload", err)
+		return
 	}
+	defer closeUploads()
 
-	if len(updateData) == 0 {
+	if len(updateData) == 0 && len(uploads) == 0 {
 		responses.BadRequest(context, "No valid fields provided for update", nil)
 		return
 	}
 
-	updatedItem, err := handler.itemService.Update(item.ID, updateData)
+	updatedItem, err := handler.itemService.Update(item.ID, updateData, uploads)
 	if err != nil {
+		if errors.Is(err, domain.UnknownCategoryErr) {
+			responses.BadRequest(context, "Unknown category", err)
+			return
+		}
+		if respondWithPictureError(context, err) {
+			return
+		}
 		responses.InternalServerError(context, "Failed to update item", err)
 		return
 	}
@@ -111,6 +209,60 @@ func (handler *ItemHandler) Delete(context *gin.Context) {
 	context.JSON(http.StatusOK, gin.H{"message": "Item deleted successfully!"})
 }
 
+func (handler *ItemHandler) ReorderPictures(context *gin.Context) {
+	item, ok := handler.verifyItemOwnership(context)
+	if !ok {
+		return
+	}
+
+	var requestInput ReorderPicturesRequest
+	if err := context.ShouldBindJSON(&requestInput); err != nil {
+		responses.BadRequest(context, "Invalid request body", err)
+		return
+	}
+
+	updatedItem, err := handler.itemService.ReorderPictures(item.ID, requestInput.PictureIDs, requestInput.PrimaryPictureID)
+	if err != nil {
+		if errors.Is(err, domain.InvalidPictureOrderErr) {
+			responses.BadRequest(context, "Invalid picture order", err)
+			return
+		}
+		if errors.Is(err, domain.ItemPictureNotFoundErr) {
+			responses.NotFound(context, "Picture not found", err)
+			return
+		}
+		responses.InternalServerError(context, "Failed to reorder pictures", err)
+		return
+	}
+
+	respondWithItem(context, http.StatusOK, "Pictures reordered successfully!", updatedItem)
+}
+
+func (handler *ItemHandler) DeletePicture(context *gin.Context) {
+	item, ok := handler.verifyItemOwnership(context)
+	if !ok {
+		return
+	}
+
+	pictureID, err := uuid.Parse(context.Param("pictureId"))
+	if err != nil {
+		responses.BadRequest(context, "Invalid picture ID", err)
+		return
+	}
+
+	_, err = handler.itemService.DeletePicture(item.ID, pictureID)
+	if err != nil {
+		if errors.Is(err, domain.ItemPictureNotFoundErr) {
+			responses.NotFound(context, "Picture not found", err)
+			return
+		}
+		responses.InternalServerError(context, "Failed to delete picture", err)
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"message": "Picture deleted successfully!"})
+}
+
 func (handler *ItemHandler) FindByID(context *gin.Context) {
 	itemID := context.Param("id")
 
@@ -126,32 +278,234 @@ func (handler *ItemHandler) FindByID(context *gin.Context) {
 		return
 	}
 
-	response := &ItemResponse{
-		ItemID:      item.ID.String(),
-		Name:        item.Name,
-		Description: item.Description,
-		PictureURL:  item.PictureURL,
-		UserID:      item.UserID.String(),
+	context.JSON(http.StatusOK, toItemResponse(item))
+}
+
+func (handler *ItemHandler) ListCategories(context *gin.Context) {
+	categories, err := handler.itemService.ListCategories()
+	if err != nil {
+		responses.InternalServerError(context, "Failed to fetch categories", err)
+		return
+	}
+
+	response := CategoryListResponse{
+		Message:    "Categories fetched successfully",
+		Categories: make([]CategoryResponse, 0, len(categories)),
+	}
+	for _, category := range categories {
+		response.Categories = append(response.Categories, CategoryResponse{
+			Slug:       category.Slug,
+			Name:       category.Name,
+			ParentSlug: category.ParentSlug,
+		})
 	}
 
 	context.JSON(http.StatusOK, response)
 }
 
+// Search browses items. Supported query parameters: q, owner_id, offered,
+// created_after and created_before (RFC 3339), category, condition and tags
+// (comma separated), sort, cursor and limit.
+func (handler *ItemHandler) Search(context *gin.Context) {
+	query, err := parseItemSearchQuery(context)
+	if err != nil {
+		responses.BadRequest(context, "Invalid search parameters", err)
+		return
+	}
+
+	result, err := handler.itemService.Search(query)
+	if err != nil {
+		if errors.Is(err, domain.InvalidItemSortErr) ||
+			errors.Is(err, domain.InvalidSearchCursorErr) ||
+			errors.Is(err, domain.InvalidItemConditionErr) ||
+			errors.Is(err, domain.InvalidItemTagErr) ||
+			errors.Is(err, domain.TooManyItemTagsErr) {
+			responses.BadRequest(context, "Invalid search parameters", err)
+			return
+		}
+		responses.InternalServerError(context, "Failed to search items", err)
+		return
+	}
+
+	items := make([]ItemResponse, 0, len(result.Items))
+	for _, item := range result.Items {
+		items = append(items, *toItemResponse(&item))
+	}
+
+	context.JSON(http.StatusOK, ItemListResponse{
+		Message:    "Items fetched successfully",
+		Items:      items,
+		NextCursor: result.NextCursor,
+	})
+}
+
+func parseItemSearchQuery(context *gin.Context) (domain.ItemSearchQuery, error) {
+	query := domain.ItemSearchQuery{
+		Keywords:     context.Query("q"),
+		Sort:         domain.ItemSort(context.Query("sort")),
+		Cursor:       context.Query("cursor"),
+		CategorySlug: context.Query("category"),
+		Tags:         splitList(context.QueryArray("tags")),
+	}
+
+	for _, condition := range splitList(context.QueryArray("condition")) {
+		query.Conditions = append(query.Conditions, domain.ItemCondition(condition))
+	}
+
+	if raw := context.Query("owner_id"); raw != "" {
+		ownerID, err := uuid.Parse(raw)
+		if err != nil {
+			return query, err
+		}
+		query.OwnerID = &ownerID
+	}
+
+	if raw := context.Query("offered"); raw != "" {
+		offered, err := strconv.ParseBool(raw)
+		if err != nil {
+			return query, err
+		}
+		query.Offered = &offered
+	}
+
+	if raw := context.Query("created_after"); raw != "" {
+		createdAfter, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return query, err
+		}
+		query.CreatedAfter = &createdAfter
+	}
+
+	if raw := context.Query("created_before"); raw != "" {
+		createdBefore, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return query, err
+		}
+		query.CreatedBefore = &createdBefore
+	}
+
+	if raw := context.Query("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 1 {
+			return query, errors.New("limit must be a positive integer")
+		}
+		query.Limit = limit
+	}
+
+	return query, nil
+}
+
+func parseItemAttributes(context *gin.Context) (itemAttributes, error) {
+	attributes := itemAttributes{
+		categorySlug: strings.TrimSpace(context.PostForm("category")),
+		condition:    domain.ItemCondition(strings.TrimSpace(context.PostForm("condition"))),
+	}
+
+	if attributes.condition != "" && !attributes.condition.IsValid() {
+		return attributes, domain.InvalidItemConditionErr
+	}
+
+	rawTags, hasTags := context.GetPostFormArray("tags")
+	if hasTags {
+		tags, err := domain.NormalizeTags(splitList(rawTags))
+		if err != nil {
+			return attributes, err
+		}
+		attributes.tags = tags
+		attributes.hasTags = true
+	}
+
+	return attributes, nil
+}
+
+// splitList flattens repeated and comma-separated values into a single list.
+func splitList(values []string) []string {
+	var result []string
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				result = append(result, part)
+			}
+		}
+	}
+
+	return result
+}
+
 func respondWithItem(context *gin.Context, status int, message string, item *domain.Item) {
 	response := ItemSuccessResponse{
 		Message: message,
-		Item: &ItemResponse{
-			ItemID:      item.ID.String(),
-			Name:        item.Name,
-			Description: item.Description,
-			PictureURL:  item.PictureURL,
-			UserID:      item.UserID.String(),
-		},
+		Item:    toItemResponse(item),
 	}
 
 	context.JSON(status, response)
 }
 
+func toItemResponse(item *domain.Item) *ItemResponse {
+	tags := item.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	pictures := make([]ItemPictureResponse, 0, len(item.Pictures))
+	for _, picture := range item.Pictures {
+		var variants map[string]PictureVariantResponse
+		if len(picture.Variants) > 0 {
+			variants = make(map[string]PictureVariantResponse, len(picture.Variants))
+		}
+		for _, variant := range picture.Variants {
+			variants[variant.Name] = PictureVariantResponse{
+				URL:    variant.URL,
+				Width:  variant.Width,
+				Height: variant.Height,
+			}
+		}
+
+		pictures = append(pictures, ItemPictureResponse{
+			PictureID: picture.ID.String(),
+			URL:       picture.URL,
+			Width:     picture.Width,
+			Height:    picture.Height,
+			Position:  picture.Position,
+			IsPrimary: picture.IsPrimary,
+			Variants:  variants,
+		})
+	}
+
+	return &ItemResponse{
+		ItemID:      item.ID.String(),
+		Name:        item.Name,
+		Description: item.Description,
+		PictureURL:  item.PictureURL,
+		Pictures:    pictures,
+		UserID:      item.UserID.String(),
+		Offered:     item.Offered,
+		Category:    item.CategorySlug,
+		Condition:   string(item.Condition),
+		Tags:        tags,
+		CreatedAt:   item.CreatedAt,
+	}
+}
+
+// respondWithPictureError writes the response for errors caused by the uploaded
+// pictures and reports whether err was one of them.
+func respondWithPictureError(context *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, domain.TooManyItemPicturesErr):
+		responses.BadRequest(context, "Too many pictures", err)
+	case errors.Is(err, domain.ImageTooLargeErr):
+		responses.PayloadTooLarge(context, "Picture is too large", err)
+	case errors.Is(err, domain.UnsupportedImageTypeErr):
+		responses.UnsupportedMediaType(context, "Unsupported picture type", err)
+	case errors.Is(err, domain.ImageDimensionsErr), errors.Is(err, domain.InvalidImageErr):
+		responses.UnprocessableEntity(context, "Invalid picture", err)
+	default:
+		return false
+	}
+
+	return true
+}
+
 func (handler *ItemHandler) verifyItemOwnership(context *gin.Context) (*domain.Item, bool) {
 	itemID := context.Param("id")
 	userID := context.GetString("userID")
@@ -189,17 +543,42 @@ func (handler *ItemHandler) verifyItemOwnership(context *gin.Context) (*domain.I
 	return item, true
 }
 
-func saveUploadedPicture(context *gin.Context, formKey string) (string, error) {
-	file, err := context.FormFile(formKey)
+// openUploadedPictures opens every file sent under pictureFormKeys, in upload
+// order. The returned function closes them again.
+func openUploadedPictures(context *gin.Context) ([]domain.PictureUpload, func(), error) {
+	var files []multipart.File
+	closeFiles := func() {
+		for _, file := range files {
+			file.Close()
+		}
+	}
+
+	form, err := context.MultipartForm()
 	if err != nil {
-		return "", err
+		if errors.Is(err, http.ErrNotMultipart) {
+			return nil, closeFiles, nil
+		}
+		return nil, nil, err
 	}
 
-	filename := uuid.New().String() + "_" + filepath.Base(file.Filename)
-	savePath := filepath.Join("uploads", filename)
-	if err = context.SaveUploadedFile(file, savePath); err != nil {
-		return "", err
+	var uploads []domain.PictureUpload
+	for _, key := range pictureFormKeys {
+		for _, header := range form.File[key] {
+			file, err := header.Open()
+			if err != nil {
+				closeFiles()
+				return nil, nil, err
+			}
+			files = append(files, file)
+
+			uploads = append(uploads, domain.PictureUpload{
+				Filename:    header.Filename,
+				ContentType: header.Header.Get("Content-Type"),
+				Size:        header.Size,
+				Content:     file,
+			})
+		}
 	}
 
-	return "/uploads/" + filename, nil
+	return uploads, closeFiles, nil
 }
@@ -11,6 +11,7 @@ import (
 	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"swapp-go/cmd/internal/adapters/handlers"
 	"swapp-go/cmd/internal/adapters/handlers/mocks"
 	"swapp-go/cmd/internal/domain"
@@ -52,7 +53,7 @@ func TestItemHandler(t *testing.T) {
 			handler := handlers.NewItemHandler(mockService)
 			userID := uuid.New()
 
-			mockService.On("Create", mock.AnythingOfType("*domain.Item")).Return(nil)
+			mockService.On("Create", mock.AnythingOfType("*domain.Item"), mock.AnythingOfType("[]domain.PictureUpload")).Return(nil)
 
 			bodyBuffer := &bytes.Buffer{}
 			formWriter := multipart.NewWriter(bodyBuffer)
@@ -75,7 +76,119 @@ func TestItemHandler(t *testing.T) {
 
 			assert.Equal(t, http.StatusCreated, responseRecorder.Code)
 			assert.Contains(t, responseRecorder.Body.String(), "Item created successfully!")
-			mockService.AssertCalled(t, "Create", mock.AnythingOfType("*domain.Item"))
+			mockService.AssertCalled(t, "Create", mock.AnythingOfType("*domain.Item"), mock.AnythingOfType("[]domain.PictureUpload"))
+		})
+
+		t.Run("with_attributes", func(t *testing.T) {
+			mockService := new(mocks.MockItemService)
+			handler := handlers.NewItemHandler(mockService)
+
+			mockService.On("Create", mock.MatchedBy(func(item *domain.Item) bool {
+				return item.CategorySlug == "bikes" &&
+					item.Condition == domain.ConditionLikeNew &&
+					len(item.Tags) == 3 && item.Tags[0] == "road" && item.Tags[2] == "carbon"
+			}), mock.Anything).Return(nil)
+
+			bodyBuffer := &bytes.Buffer{}
+			formWriter := multipart.NewWriter(bodyBuffer)
+			writeFormField(t, formWriter, "name", "Road bike")
+			writeFormField(t, formWriter, "category", "bikes")
+			writeFormField(t, formWriter, "condition", "like-new")
+			writeFormField(t, formWriter, "tags", "Road, 54cm")
+			writeFormField(t, formWriter, "tags", "carbon")
+
+			fileWriter, _ := formWriter.CreateFormFile("picture", "image.jpg")
+			writeFile(t, fileWriter, []byte("fake image content"))
+			closeWriter(t, formWriter)
+
+			request := httptest.NewRequest(http.MethodPost, "/items/create", bodyBuffer)
+			request.Header.Set("Content-Type", formWriter.FormDataContentType())
+
+			responseRecorder := httptest.NewRecorder()
+			context, _ := gin.CreateTestContext(responseRecorder)
+			context.Request = request
+			context.Set("userID", uuid.New().String())
+
+			handler.Create(context)
+
+			assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+			mockService.AssertExpectations(t)
+		})
+
+		t.Run("multiple_pictures", func(t *testing.T) {
+			mockService := new(mocks.MockItemService)
+			handler := handlers.NewItemHandler(mockService)
+
+			mockService.On("Create", mock.AnythingOfType("*domain.Item"), mock.MatchedBy(func(uploads []domain.PictureUpload) bool {
+				if len(uploads) != 3 {
+					return false
+				}
+
+				content, _ := io.ReadAll(uploads[1].Content)
+				return uploads[0].Filename == "front.jpg" &&
+					uploads[1].Filename == "back.jpg" &&
+					uploads[2].Filename == "legacy.jpg" &&
+					string(content) == "back content" &&
+					uploads[1].Size == int64(len("back content"))
+			})).Return(nil)
+
+			bodyBuffer := &bytes.Buffer{}
+			formWriter := multipart.NewWriter(bodyBuffer)
+			writeFormField(t, formWriter, "name", "Camera")
+			for _, name := range []string{"front", "back"} {
+				fileWriter, _ := formWriter.CreateFormFile("pictures", name+".jpg")
+				writeFile(t, fileWriter, []byte(name+" content"))
+			}
+			fileWriter, _ := formWriter.CreateFormFile("picture", "legacy.jpg")
+			writeFile(t, fileWriter, []byte("fake image content"))
+			closeWriter(t, formWriter)
+
+			request := httptest.NewRequest(http.MethodPost, "/items/create", bodyBuffer)
+			request.Header.Set("Content-Type", formWriter.FormDataContentType())
+
+			responseRecorder := httptest.NewRecorder()
+			context, _ := gin.CreateTestContext(responseRecorder)
+			context.Request = request
+			context.Set("userID", uuid.New().String())
+
+			handler.Create(context)
+
+			assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+			mockService.AssertExpectations(t)
+		})
+
+		t.Run("invalid_attributes", func(t *testing.T) {
+			invalidForms := []map[string]string{
+				{"condition": "mint"},
+				{"tags": "<script>"},
+				{"tags": "a,b,c,d,e,f,g,h,i,j,k"},
+			}
+
+			for _, fields := range invalidForms {
+				mockService := new(mocks.MockItemService)
+				handler := handlers.NewItemHandler(mockService)
+
+				bodyBuffer := &bytes.Buffer{}
+				formWriter := multipart.NewWriter(bodyBuffer)
+				writeFormField(t, formWriter, "name", "Item")
+				for key, value := range fields {
+					writeFormField(t, formWriter, key, value)
+				}
+				closeWriter(t, formWriter)
+
+				request := httptest.NewRequest(http.MethodPost, "/items/create", bodyBuffer)
+				request.Header.Set("Content-Type", formWriter.FormDataContentType())
+
+				responseRecorder := httptest.NewRecorder()
+				context, _ := gin.CreateTestContext(responseRecorder)
+				context.Request = request
+				context.Set("userID", uuid.New().String())
+
+				handler.Create(context)
+
+				assert.Equal(t, http.StatusBadRequest, responseRecorder.Code, fields)
+				mockService.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+			}
 		})
 
 		t.Run("invalid_user_id", func(t *testing.T) {
@@ -101,6 +214,46 @@ func TestItemHandler(t *testing.T) {
 			assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
 			assert.Contains(t, responseRecorder.Body.String(), "Invalid user ID")
 		})
+
+		t.Run("rejected_pictures", func(t *testing.T) {
+			cases := []struct {
+				err    error
+				status int
+			}{
+				{domain.ImageTooLargeErr, http.StatusRequestEntityTooLarge},
+				{domain.UnsupportedImageTypeErr, http.StatusUnsupportedMediaType},
+				{domain.ImageDimensionsErr, http.StatusUnprocessableEntity},
+				{domain.InvalidImageErr, http.StatusUnprocessableEntity},
+				{domain.TooManyItemPicturesErr, http.StatusBadRequest},
+			}
+
+			for _, testCase := range cases {
+				mockService := new(mocks.MockItemService)
+				handler := handlers.NewItemHandler(mockService)
+
+				mockService.On("Create", mock.AnythingOfType("*domain.Item"), mock.AnythingOfType("[]domain.PictureUpload")).
+					Return(testCase.err)
+
+				bodyBuffer := &bytes.Buffer{}
+				formWriter := multipart.NewWriter(bodyBuffer)
+				writeFormField(t, formWriter, "name", "Item")
+				fileWriter, _ := formWriter.CreateFormFile("pictures", "image.jpg")
+				writeFile(t, fileWriter, []byte("fake image content"))
+				closeWriter(t, formWriter)
+
+				request := httptest.NewRequest(http.MethodPost, "/items/create", bodyBuffer)
+				request.Header.Set("Content-Type", formWriter.FormDataContentType())
+
+				responseRecorder := httptest.NewRecorder()
+				context, _ := gin.CreateTestContext(responseRecorder)
+				context.Request = request
+				context.Set("userID", uuid.New().String())
+
+				handler.Create(context)
+
+				assert.Equal(t, testCase.status, responseRecorder.Code, testCase.err.Error())
+			}
+		})
 	})
 
 	t.Run("Update", func(t *testing.T) {
@@ -121,7 +274,9 @@ func TestItemHandler(t *testing.T) {
 			}
 
 			mockService.On("FindByID", itemID).Return(existingItem, nil)
-			mockService.On("Update", itemID, mock.AnythingOfType("map[string]interface {}")).Return(updatedItem, nil)
+			mockService.On("Update", itemID, mock.AnythingOfType("map[string]interface {}"), mock.MatchedBy(func(uploads []domain.PictureUpload) bool {
+				return len(uploads) == 1 && uploads[0].Filename == "new.jpg"
+			})).Return(updatedItem, nil)
 
 			bodyBuffer := &bytes.Buffer{}
 			formWriter := multipart.NewWriter(bodyBuffer)
@@ -146,6 +301,40 @@ func TestItemHandler(t *testing.T) {
 			assert.Equal(t, http.StatusOK, responseRecorder.Code)
 			assert.Contains(t, responseRecorder.Body.String(), "Item updated successfully")
 		})
+
+		t.Run("pictures_only", func(t *testing.T) {
+			mockService := new(mocks.MockItemService)
+			handler := handlers.NewItemHandler(mockService)
+
+			itemID := uuid.New()
+			userID := uuid.New()
+			existingItem := &domain.Item{ID: itemID, UserID: userID}
+
+			mockService.On("FindByID", itemID).Return(existingItem, nil)
+			mockService.On("Update", itemID, map[string]interface{}{}, mock.AnythingOfType("[]domain.PictureUpload")).
+				Return(nil, domain.TooManyItemPicturesErr)
+
+			bodyBuffer := &bytes.Buffer{}
+			formWriter := multipart.NewWriter(bodyBuffer)
+			fileWriter, _ := formWriter.CreateFormFile("pictures", "new.jpg")
+			writeFile(t, fileWriter, []byte("new image content"))
+			closeWriter(t, formWriter)
+
+			request := httptest.NewRequest(http.MethodPut, "/items/update/"+itemID.String(), bodyBuffer)
+			request.Header.Set("Content-Type", formWriter.FormDataContentType())
+
+			responseRecorder := httptest.NewRecorder()
+			context, _ := gin.CreateTestContext(responseRecorder)
+			context.Request = request
+			context.Params = gin.Params{{Key: "id", Value: itemID.String()}}
+			context.Set("userID", userID.String())
+
+			handler.Update(context)
+
+			assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+			assert.Contains(t, responseRecorder.Body.String(), "Too many pictures")
+			mockService.AssertExpectations(t)
+		})
 	})
 
 	t.Run("Delete", func(t *testing.T) {
@@ -228,6 +417,41 @@ func TestItemHandler(t *testing.T) {
 			assert.Contains(t, responseRecorder.Body.String(), itemID.String())
 		})
 
+		t.Run("picture_variants", func(t *testing.T) {
+			mockService := new(mocks.MockItemService)
+			handler := handlers.NewItemHandler(mockService)
+
+			itemID := uuid.New()
+			mockItem := &domain.Item{
+				ID:     itemID,
+				UserID: uuid.New(),
+				Pictures: []domain.ItemPicture{{
+					ID:        uuid.New(),
+					URL:       "/uploads/items/front.jpg",
+					Width:     1600,
+					Height:    1200,
+					IsPrimary: true,
+					Variants: []domain.PictureVariant{
+						{Name: "thumbnail", URL: "/uploads/items/front_thumbnail.jpg", Width: 240, Height: 180},
+					},
+				}},
+			}
+
+			mockService.On("FindByID", itemID).Return(mockItem, nil)
+
+			request := httptest.NewRequest(http.MethodGet, "/items/"+itemID.String(), nil)
+			responseRecorder := httptest.NewRecorder()
+			context, _ := gin.CreateTestContext(responseRecorder)
+			context.Request = request
+			context.Params = gin.Params{{Key: "id", Value: itemID.String()}}
+
+			handler.FindByID(context)
+
+			assert.Equal(t, http.StatusOK, responseRecorder.Code)
+			assert.Contains(t, responseRecorder.Body.String(), `"width":1600`)
+			assert.Contains(t, responseRecorder.Body.String(), `"thumbnail":{"url":"/uploads/items/front_thumbnail.jpg","width":240,"height":180}`)
+		})
+
 		t.Run("not_found", func(t *testing.T) {
 			mockService := new(mocks.MockItemService)
 			handler := handlers.NewItemHandler(mockService)
@@ -246,4 +470,235 @@ func TestItemHandler(t *testing.T) {
 			assert.Equal(t, http.StatusNotFound, responseRecorder.Code)
 		})
 	})
+	t.Run("Search", func(t *testing.T) {
+		performSearch := func(handler *handlers.ItemHandler, rawQuery string) *httptest.ResponseRecorder {
+			request := httptest.NewRequest(http.MethodGet, "/items?"+rawQuery, nil)
+			responseRecorder := httptest.NewRecorder()
+			context, _ := gin.CreateTestContext(responseRecorder)
+			context.Request = request
+
+			handler.Search(context)
+
+			return responseRecorder
+		}
+
+		t.Run("success", func(t *testing.T) {
+			mockService := new(mocks.MockItemService)
+			handler := handlers.NewItemHandler(mockService)
+
+			ownerID := uuid.New()
+			item := domain.Item{ID: uuid.New(), Name: "Bike", UserID: ownerID}
+
+			mockService.On("Search", mock.MatchedBy(func(query domain.ItemSearchQuery) bool {
+				return query.Keywords == "bike" &&
+					*query.OwnerID == ownerID &&
+					!*query.Offered &&
+					query.Sort == domain.ItemSortNameAsc &&
+					query.Limit == 5 &&
+					query.Cursor == "abc" &&
+					query.CategorySlug == "bikes" &&
+					len(query.Conditions) == 2 && query.Conditions[1] == domain.ConditionUsed &&
+					len(query.Tags) == 1 && query.Tags[0] == "red"
+			})).Return(&domain.ItemSearchResult{Items: []domain.Item{item}, NextCursor: "next"}, nil)
+
+			responseRecorder := performSearch(handler, "q=bike&owner_id="+ownerID.String()+"&offered=false&sort=name_asc&limit=5&cursor=abc&category=bikes&condition=new,used&tags=red")
+
+			assert.Equal(t, http.StatusOK, responseRecorder.Code)
+			assert.Contains(t, responseRecorder.Body.String(), item.ID.String())
+			assert.Contains(t, responseRecorder.Body.String(), `"next_cursor":"next"`)
+			mockService.AssertExpectations(t)
+		})
+
+		t.Run("invalid_parameters", func(t *testing.T) {
+			mockService := new(mocks.MockItemService)
+			handler := handlers.NewItemHandler(mockService)
+
+			for _, rawQuery := range []string{"owner_id=nope", "offered=maybe", "created_after=yesterday", "limit=0"} {
+				responseRecorder := performSearch(handler, rawQuery)
+				assert.Equal(t, http.StatusBadRequest, responseRecorder.Code, rawQuery)
+			}
+
+			mockService.AssertNotCalled(t, "Search", mock.Anything)
+		})
+
+		t.Run("invalid_cursor", func(t *testing.T) {
+			mockService := new(mocks.MockItemService)
+			handler := handlers.NewItemHandler(mockService)
+
+			mockService.On("Search", mock.Anything).Return(nil, domain.InvalidSearchCursorErr)
+
+			responseRecorder := performSearch(handler, "cursor=broken")
+			assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+		})
+
+		t.Run("invalid_condition", func(t *testing.T) {
+			mockService := new(mocks.MockItemService)
+			handler := handlers.NewItemHandler(mockService)
+
+			mockService.On("Search", mock.Anything).Return(nil, domain.InvalidItemConditionErr)
+
+			responseRecorder := performSearch(handler, "condition=mint")
+			assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+		})
+
+		t.Run("service_error", func(t *testing.T) {
+			mockService := new(mocks.MockItemService)
+			handler := handlers.NewItemHandler(mockService)
+
+			mockService.On("Search", mock.Anything).Return(nil, errors.New("db error"))
+
+			responseRecorder := performSearch(handler, "")
+			assert.Equal(t, http.StatusInternalServerError, responseRecorder.Code)
+		})
+	})
+
+	t.Run("ListCategories", func(t *testing.T) {
+		mockService := new(mocks.MockItemService)
+		handler := handlers.NewItemHandler(mockService)
+
+		mockService.On("ListCategories").Return([]domain.Category{
+			{Slug: "sports-outdoors", Name: "Sports & Outdoors"},
+			{Slug: "bikes", Name: "Bikes", ParentSlug: "sports-outdoors"},
+		}, nil)
+
+		responseRecorder := httptest.NewRecorder()
+		context, _ := gin.CreateTestContext(responseRecorder)
+		context.Request = httptest.NewRequest(http.MethodGet, "/categories", nil)
+
+		handler.ListCategories(context)
+
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+		assert.Contains(t, responseRecorder.Body.String(), `"parent_slug":"sports-outdoors"`)
+	})
+
+	t.Run("ReorderPictures", func(t *testing.T) {
+		performReorder := func(handler *handlers.ItemHandler, itemID, userID uuid.UUID, body string) *httptest.ResponseRecorder {
+			request := httptest.NewRequest(http.MethodPut, "/items/"+itemID.String()+"/pictures/order", strings.NewReader(body))
+			request.Header.Set("Content-Type", "application/json")
+
+			responseRecorder := httptest.NewRecorder()
+			context, _ := gin.CreateTestContext(responseRecorder)
+			context.Request = request
+			context.Params = gin.Params{{Key: "id", Value: itemID.String()}}
+			context.Set("userID", userID.String())
+
+			handler.ReorderPictures(context)
+
+			return responseRecorder
+		}
+
+		t.Run("success", func(t *testing.T) {
+			mockService := new(mocks.MockItemService)
+			handler := handlers.NewItemHandler(mockService)
+
+			itemID, userID := uuid.New(), uuid.New()
+			first, second := uuid.New(), uuid.New()
+
+			updatedItem := &domain.Item{
+				ID:         itemID,
+				UserID:     userID,
+				PictureURL: "/uploads/second.jpg",
+				Pictures: []domain.ItemPicture{
+					{ID: second, URL: "/uploads/second.jpg", Position: 0, IsPrimary: true},
+					{ID: first, URL: "/uploads/first.jpg", Position: 1},
+				},
+			}
+
+			mockService.On("FindByID", itemID).Return(&domain.Item{ID: itemID, UserID: userID}, nil)
+			mockService.On("ReorderPictures", itemID, []uuid.UUID{second, first}, second).Return(updatedItem, nil)
+
+			body := `{"picture_ids":["` + second.String() + `","` + first.String() + `"],"primary_picture_id":"` + second.String() + `"}`
+			responseRecorder := performReorder(handler, itemID, userID, body)
+
+			assert.Equal(t, http.StatusOK, responseRecorder.Code)
+			assert.Contains(t, responseRecorder.Body.String(), `"picture_id":"`+second.String()+`","url":"/uploads/second.jpg","position":0,"is_primary":true`)
+			mockService.AssertExpectations(t)
+		})
+
+		t.Run("invalid_order", func(t *testing.T) {
+			mockService := new(mocks.MockItemService)
+			handler := handlers.NewItemHandler(mockService)
+
+			itemID, userID := uuid.New(), uuid.New()
+			pictureID := uuid.New()
+
+			mockService.On("FindByID", itemID).Return(&domain.Item{ID: itemID, UserID: userID}, nil)
+			mockService.On("ReorderPictures", itemID, []uuid.UUID{pictureID}, uuid.Nil).Return(nil, domain.InvalidPictureOrderErr)
+
+			responseRecorder := performReorder(handler, itemID, userID, `{"picture_ids":["`+pictureID.String()+`"]}`)
+
+			assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+		})
+
+		t.Run("missing_picture_ids", func(t *testing.T) {
+			mockService := new(mocks.MockItemService)
+			handler := handlers.NewItemHandler(mockService)
+
+			itemID, userID := uuid.New(), uuid.New()
+			mockService.On("FindByID", itemID).Return(&domain.Item{ID: itemID, UserID: userID}, nil)
+
+			responseRecorder := performReorder(handler, itemID, userID, `{}`)
+
+			assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+			mockService.AssertNotCalled(t, "ReorderPictures", mock.Anything, mock.Anything, mock.Anything)
+		})
+	})
+
+	t.Run("DeletePicture", func(t *testing.T) {
+		performDeletePicture := func(handler *handlers.ItemHandler, itemID, userID uuid.UUID, pictureID string) *httptest.ResponseRecorder {
+			request := httptest.NewRequest(http.MethodDelete, "/items/"+itemID.String()+"/pictures/"+pictureID, nil)
+
+			responseRecorder := httptest.NewRecorder()
+			context, _ := gin.CreateTestContext(responseRecorder)
+			context.Request = request
+			context.Params = gin.Params{{Key: "id", Value: itemID.String()}, {Key: "pictureId", Value: pictureID}}
+			context.Set("userID", userID.String())
+
+			handler.DeletePicture(context)
+
+			return responseRecorder
+		}
+
+		t.Run("success", func(t *testing.T) {
+			mockService := new(mocks.MockItemService)
+			handler := handlers.NewItemHandler(mockService)
+
+			itemID, userID, pictureID := uuid.New(), uuid.New(), uuid.New()
+
+			mockService.On("FindByID", itemID).Return(&domain.Item{ID: itemID, UserID: userID}, nil)
+			mockService.On("DeletePicture", itemID, pictureID).Return(&domain.ItemPicture{ID: pictureID, URL: "/uploads/missing.jpg"}, nil)
+
+			responseRecorder := performDeletePicture(handler, itemID, userID, pictureID.String())
+
+			assert.Equal(t, http.StatusOK, responseRecorder.Code)
+			assert.Contains(t, responseRecorder.Body.String(), "Picture deleted successfully!")
+		})
+
+		t.Run("not_found", func(t *testing.T) {
+			mockService := new(mocks.MockItemService)
+			handler := handlers.NewItemHandler(mockService)
+
+			itemID, userID, pictureID := uuid.New(), uuid.New(), uuid.New()
+
+			mockService.On("FindByID", itemID).Return(&domain.Item{ID: itemID, UserID: userID}, nil)
+			mockService.On("DeletePicture", itemID, pictureID).Return(nil, domain.ItemPictureNotFoundErr)
+
+			responseRecorder := performDeletePicture(handler, itemID, userID, pictureID.String())
+
+			assert.Equal(t, http.StatusNotFound, responseRecorder.Code)
+		})
+
+		t.Run("invalid_picture_id", func(t *testing.T) {
+			mockService := new(mocks.MockItemService)
+			handler := handlers.NewItemHandler(mockService)
+
+			itemID, userID := uuid.New(), uuid.New()
+			mockService.On("FindByID", itemID).Return(&domain.Item{ID: itemID, UserID: userID}, nil)
+
+			responseRecorder := performDeletePicture(handler, itemID, userID, "not-a-uuid")
+
+			assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+			mockService.AssertNotCalled(t, "DeletePicture", mock.Anything, mock.Anything)
+		})
+	})
 }
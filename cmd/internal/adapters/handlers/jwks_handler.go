@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"swapp-go/cmd/internal/application/ports"
+)
+
+type JWKSHandler struct {
+	verifier ports.AccessTokenVerifier
+}
+
+func NewJWKSHandler(verifier ports.AccessTokenVerifier) *JWKSHandler {
+	return &JWKSHandler{verifier: verifier}
+}
+
+type JSONWebKeyResponse struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+}
+
+type JSONWebKeySetResponse struct {
+	Keys []JSONWebKeyResponse `json:"keys"`
+}
+
+// JWKS publishes the public keys that access tokens may be signed with, so
+// that other services can verify them without sharing a secret.
+func (handler *JWKSHandler) JWKS(context *gin.Context) {
+	publicKeys := handler.verifier.PublicKeys()
+
+	keys := make([]JSONWebKeyResponse, 0, len(publicKeys))
+	for _, key := range publicKeys {
+		keys = append(keys, JSONWebKeyResponse{
+			KeyType:   key.KeyType,
+			KeyID:     key.KeyID,
+			Use:       "sig",
+			Algorithm: key.Algorithm,
+			N:         key.N,
+			E:         key.E,
+			Curve:     key.Curve,
+			X:         key.X,
+		})
+	}
+
+	context.Header("Cache-Control", "public, max-age=300")
+	context.JSON(http.StatusOK, JSONWebKeySetResponse{Keys: keys})
+}
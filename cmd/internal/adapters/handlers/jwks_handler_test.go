@@ -0,0 +1,43 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"swapp-go/cmd/internal/adapters/handlers"
+	"swapp-go/cmd/internal/adapters/handlers/mocks"
+	"swapp-go/cmd/internal/domain"
+	"testing"
+)
+
+func TestJWKSHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockVerifier := new(mocks.MockAccessTokenVerifier)
+	mockVerifier.On("PublicKeys").Return([]domain.JSONWebKey{
+		{KeyType: "OKP", KeyID: "2025-01", Algorithm: "EdDSA", Curve: "Ed25519", X: "11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"},
+		{KeyType: "RSA", KeyID: "2025-02", Algorithm: "RS256", N: "sXchDaQebHnPiGvyDOAT4saGEUetSyo9MKLOoWFsueri", E: "AQAB"},
+	})
+
+	router := gin.New()
+	router.GET("/.well-known/jwks.json", handlers.NewJWKSHandler(mockVerifier).JWKS)
+
+	response := performRequest(t, router, http.MethodGet, "/.well-known/jwks.json", nil)
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Contains(t, response.Header().Get("Cache-Control"), "max-age")
+
+	var jwks map[string][]map[string]string
+	assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &jwks))
+	assert.Len(t, jwks["keys"], 2)
+	assert.Equal(t, map[string]string{
+		"kty": "OKP",
+		"kid": "2025-01",
+		"use": "sig",
+		"alg": "EdDSA",
+		"crv": "Ed25519",
+		"x":   "11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo",
+	}, jwks["keys"][0])
+	assert.Equal(t, "AQAB", jwks["keys"][1]["e"])
+	assert.NotContains(t, jwks["keys"][1], "crv")
+}
@@ -0,0 +1,39 @@
+package mocks
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"swapp-go/cmd/internal/domain"
+)
+
+type MockAdminService struct {
+	mock.Mock
+}
+
+func (m *MockAdminService) ListUsers(query domain.UserListQuery) ([]domain.User, error) {
+	args := m.Called(query)
+	users, _ := args.Get(0).([]domain.User)
+
+	return users, args.Error(1)
+}
+
+func (m *MockAdminService) SuspendUser(actorID, userID uuid.UUID) (*domain.User, error) {
+	args := m.Called(actorID, userID)
+	user, _ := args.Get(0).(*domain.User)
+
+	return user, args.Error(1)
+}
+
+func (m *MockAdminService) UnsuspendUser(actorID, userID uuid.UUID) (*domain.User, error) {
+	args := m.Called(actorID, userID)
+	user, _ := args.Get(0).(*domain.User)
+
+	return user, args.Error(1)
+}
+
+func (m *MockAdminService) SetRole(actorID, userID uuid.UUID, role domain.Role) (*domain.User, error) {
+	args := m.Called(actorID, userID, role)
+	user, _ := args.Get(0).(*domain.User)
+
+	return user, args.Error(1)
+}
@@ -0,0 +1,32 @@
+package mocks
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"swapp-go/cmd/internal/domain"
+)
+
+type MockEmailOutboxService struct {
+	mock.Mock
+}
+
+func (m *MockEmailOutboxService) ListMessages(query domain.OutboxQuery) ([]domain.OutboxMessage, error) {
+	args := m.Called(query)
+	messages, _ := args.Get(0).([]domain.OutboxMessage)
+
+	return messages, args.Error(1)
+}
+
+func (m *MockEmailOutboxService) FindMessage(id uuid.UUID) (*domain.OutboxMessage, error) {
+	args := m.Called(id)
+	message, _ := args.Get(0).(*domain.OutboxMessage)
+
+	return message, args.Error(1)
+}
+
+func (m *MockEmailOutboxService) Replay(id uuid.UUID) (*domain.OutboxMessage, error) {
+	args := m.Called(id)
+	message, _ := args.Get(0).(*domain.OutboxMessage)
+
+	return message, args.Error(1)
+}
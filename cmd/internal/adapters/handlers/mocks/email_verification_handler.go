@@ -0,0 +1,26 @@
+package mocks
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"swapp-go/cmd/internal/domain"
+)
+
+type MockEmailVerificationService struct {
+	mock.Mock
+}
+
+func (m *MockEmailVerificationService) SendVerification(user *domain.User) error {
+	return m.Called(user).Error(0)
+}
+
+func (m *MockEmailVerificationService) Resend(userID uuid.UUID) error {
+	return m.Called(userID).Error(0)
+}
+
+func (m *MockEmailVerificationService) Verify(token string) (*domain.User, error) {
+	args := m.Called(token)
+	user, _ := args.Get(0).(*domain.User)
+
+	return user, args.Error(1)
+}
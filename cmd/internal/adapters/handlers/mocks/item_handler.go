@@ -10,12 +10,17 @@ type MockItemService struct {
 	mock.Mock
 }
 
-func (m *MockItemService) Create(item *domain.Item) error {
-	return m.Called(item).Error(0)
+func (m *MockItemService) Create(item *domain.Item, uploads []domain.PictureUpload) error {
+	return m.Called(item, uploads).Error(0)
 }
 
-func (m *MockItemService) Update(id uuid.UUID, fields map[string]interface{}) (*domain.Item, error) {
-	args := m.Called(id, fields)
+func (m *MockItemService) Update(id uuid.UUID, fields map[string]interface{}, uploads []domain.PictureUpload) (*domain.Item, error) {
+	args := m.Called(id, fields, uploads)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
 	return args.Get(0).(*domain.Item), args.Error(1)
 }
 
@@ -23,6 +28,26 @@ func (m *MockItemService) Delete(id uuid.UUID) error {
 	return m.Called(id).Error(0)
 }
 
+func (m *MockItemService) ReorderPictures(itemID uuid.UUID, pictureIDs []uuid.UUID, primaryID uuid.UUID) (*domain.Item, error) {
+	args := m.Called(itemID, pictureIDs, primaryID)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*domain.Item), args.Error(1)
+}
+
+func (m *MockItemService) DeletePicture(itemID uuid.UUID, pictureID uuid.UUID) (*domain.ItemPicture, error) {
+	args := m.Called(itemID, pictureID)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*domain.ItemPicture), args.Error(1)
+}
+
 func (m *MockItemService) FindByID(id uuid.UUID) (*domain.Item, error) {
 	args := m.Called(id)
 
@@ -32,3 +57,23 @@ func (m *MockItemService) FindByID(id uuid.UUID) (*domain.Item, error) {
 
 	return args.Get(0).(*domain.Item), args.Error(1)
 }
+
+func (m *MockItemService) Search(query domain.ItemSearchQuery) (*domain.ItemSearchResult, error) {
+	args := m.Called(query)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*domain.ItemSearchResult), args.Error(1)
+}
+
+func (m *MockItemService) ListCategories() ([]domain.Category, error) {
+	args := m.Called()
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]domain.Category), args.Error(1)
+}
@@ -0,0 +1,23 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+	"swapp-go/cmd/internal/domain"
+)
+
+type MockAccessTokenVerifier struct {
+	mock.Mock
+}
+
+func (m *MockAccessTokenVerifier) Verify(token string) (*domain.AccessTokenClaims, error) {
+	args := m.Called(token)
+	claims, _ := args.Get(0).(*domain.AccessTokenClaims)
+
+	return claims, args.Error(1)
+}
+
+func (m *MockAccessTokenVerifier) PublicKeys() []domain.JSONWebKey {
+	keys, _ := m.Called().Get(0).([]domain.JSONWebKey)
+
+	return keys
+}
@@ -0,0 +1,61 @@
+package mocks
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"swapp-go/cmd/internal/domain"
+)
+
+type MockNotificationPreferenceService struct {
+	mock.Mock
+}
+
+func (m *MockNotificationPreferenceService) GetPreferences(userID uuid.UUID) (domain.NotificationPreferences, error) {
+	args := m.Called(userID)
+	preferences, _ := args.Get(0).(domain.NotificationPreferences)
+
+	return preferences, args.Error(1)
+}
+
+func (m *MockNotificationPreferenceService) UpdatePreferences(
+	userID uuid.UUID,
+	changes domain.NotificationPreferences,
+) (domain.NotificationPreferences, error) {
+	args := m.Called(userID, changes)
+	preferences, _ := args.Get(0).(domain.NotificationPreferences)
+
+	return preferences, args.Error(1)
+}
+
+func (m *MockNotificationPreferenceService) Unsubscribe(token string) (domain.NotificationEvent, error) {
+	args := m.Called(token)
+
+	return args.Get(0).(domain.NotificationEvent), args.Error(1)
+}
+
+type MockNotificationService struct {
+	mock.Mock
+}
+
+func (m *MockNotificationService) List(userID uuid.UUID, query domain.NotificationQuery) ([]domain.Notification, error) {
+	args := m.Called(userID, query)
+	notifications, _ := args.Get(0).([]domain.Notification)
+
+	return notifications, args.Error(1)
+}
+
+func (m *MockNotificationService) CountUnread(userID uuid.UUID) (int64, error) {
+	args := m.Called(userID)
+
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockNotificationService) MarkRead(id, userID uuid.UUID) error {
+	return m.Called(id, userID).Error(0)
+}
+
+func (m *MockNotificationService) MarkAllRead(userID uuid.UUID) (int64, error) {
+	args := m.Called(userID)
+
+	return args.Get(0).(int64), args.Error(1)
+}
@@ -0,0 +1,40 @@
+package mocks
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"swapp-go/cmd/internal/domain"
+)
+
+type MockSessionService struct {
+	mock.Mock
+}
+
+func (m *MockSessionService) Start(user *domain.User, client domain.SessionClient) (*domain.AuthTokens, error) {
+	args := m.Called(user, client)
+	tokens, _ := args.Get(0).(*domain.AuthTokens)
+
+	return tokens, args.Error(1)
+}
+
+func (m *MockSessionService) Refresh(refreshToken string, client domain.SessionClient) (*domain.AuthTokens, error) {
+	args := m.Called(refreshToken, client)
+	tokens, _ := args.Get(0).(*domain.AuthTokens)
+
+	return tokens, args.Error(1)
+}
+
+func (m *MockSessionService) Revoke(userID uuid.UUID, sessionID uuid.UUID) error {
+	return m.Called(userID, sessionID).Error(0)
+}
+
+func (m *MockSessionService) ListActive(userID uuid.UUID) ([]domain.Session, error) {
+	args := m.Called(userID)
+	sessions, _ := args.Get(0).([]domain.Session)
+
+	return sessions, args.Error(1)
+}
+
+func (m *MockSessionService) ValidateSession(sessionID uuid.UUID) error {
+	return m.Called(sessionID).Error(0)
+}
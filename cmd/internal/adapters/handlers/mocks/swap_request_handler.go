@@ -51,11 +51,57 @@ func (m *SwapRequestService) ListByStatus(status domain.SwapRequestStatus) ([]do
 	return result.([]domain.SwapRequest), args.Error(1)
 }
 
-func (m *SwapRequestService) UpdateStatus(id uuid.UUID, status domain.SwapRequestStatus) error {
-	args := m.Called(id, status)
+func (m *SwapRequestService) UpdateStatus(id, userID uuid.UUID, status domain.SwapRequestStatus) error {
+	args := m.Called(id, userID, status)
 	return args.Error(0)
 }
 
+func (m *SwapRequestService) ConfirmHandover(id, userID uuid.UUID) (*domain.SwapRequest, error) {
+	args := m.Called(id, userID)
+	result := args.Get(0)
+	if result == nil {
+		return nil, args.Error(1)
+	}
+	return result.(*domain.SwapRequest), args.Error(1)
+}
+
+func (m *SwapRequestService) CounterOffer(id, userID uuid.UUID, offer domain.CounterOffer) (*domain.SwapRequest, error) {
+	args := m.Called(id, userID, offer)
+	result := args.Get(0)
+	if result == nil {
+		return nil, args.Error(1)
+	}
+	return result.(*domain.SwapRequest), args.Error(1)
+}
+
+func (m *SwapRequestService) ListThread(id, userID uuid.UUID) ([]domain.SwapRequest, error) {
+	args := m.Called(id, userID)
+	result := args.Get(0)
+	if result == nil {
+		return nil, args.Error(1)
+	}
+	return result.([]domain.SwapRequest), args.Error(1)
+}
+
+func (m *SwapRequestService) ForceCancel(id uuid.UUID) (*domain.SwapRequest, error) {
+	args := m.Called(id)
+	result := args.Get(0)
+	if result == nil {
+		return nil, args.Error(1)
+	}
+	return result.(*domain.SwapRequest), args.Error(1)
+}
+
+func (m *SwapRequestService) ExpireOverdue() (int, error) {
+	args := m.Called()
+	return args.Int(0), args.Error(1)
+}
+
+func (m *SwapRequestService) RemindExpiring() (int, error) {
+	args := m.Called()
+	return args.Int(0), args.Error(1)
+}
+
 func (m *SwapRequestService) Delete(id uuid.UUID) error {
 	args := m.Called(id)
 	return args.Error(0)
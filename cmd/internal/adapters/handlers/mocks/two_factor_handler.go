@@ -0,0 +1,50 @@
+package mocks
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"swapp-go/cmd/internal/domain"
+)
+
+type MockTwoFactorService struct {
+	mock.Mock
+}
+
+func (m *MockTwoFactorService) Enroll(userID uuid.UUID) (*domain.TwoFactorEnrollment, error) {
+	args := m.Called(userID)
+	enrollment, _ := args.Get(0).(*domain.TwoFactorEnrollment)
+
+	return enrollment, args.Error(1)
+}
+
+func (m *MockTwoFactorService) Confirm(userID uuid.UUID, code string, ipAddress string) ([]string, error) {
+	args := m.Called(userID, code, ipAddress)
+	codes, _ := args.Get(0).([]string)
+
+	return codes, args.Error(1)
+}
+
+func (m *MockTwoFactorService) Disable(userID uuid.UUID, code string, ipAddress string) error {
+	return m.Called(userID, code, ipAddress).Error(0)
+}
+
+func (m *MockTwoFactorService) RegenerateRecoveryCodes(userID uuid.UUID, code string, ipAddress string) ([]string, error) {
+	args := m.Called(userID, code, ipAddress)
+	codes, _ := args.Get(0).([]string)
+
+	return codes, args.Error(1)
+}
+
+func (m *MockTwoFactorService) Challenge(user *domain.User) (*domain.LoginChallenge, error) {
+	args := m.Called(user)
+	challenge, _ := args.Get(0).(*domain.LoginChallenge)
+
+	return challenge, args.Error(1)
+}
+
+func (m *MockTwoFactorService) CompleteLogin(challengeToken string, code string, ipAddress string) (*domain.User, error) {
+	args := m.Called(challengeToken, code, ipAddress)
+	user, _ := args.Get(0).(*domain.User)
+
+	return user, args.Error(1)
+}
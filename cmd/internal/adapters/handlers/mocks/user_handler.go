@@ -58,10 +58,13 @@ func (m *MockUserService) FindByEmail(email string) (*domain.User, error) {
 	return nil, args.Error(1)
 }
 
-func (m *MockUserService) Authenticate(username, password string) (string, *domain.User, error) {
-	args := m.Called(username, password)
-	token, _ := args.Get(0).(string)
-	user, _ := args.Get(1).(*domain.User)
+func (m *MockUserService) ChangePassword(userID, sessionID uuid.UUID, currentPassword, newPassword, ipAddress string) error {
+	return m.Called(userID, sessionID, currentPassword, newPassword, ipAddress).Error(0)
+}
+
+func (m *MockUserService) Authenticate(username, password, ipAddress string) (*domain.User, error) {
+	args := m.Called(username, password, ipAddress)
+	user, _ := args.Get(0).(*domain.User)
 
-	return token, user, args.Error(2)
+	return user, args.Error(1)
 }
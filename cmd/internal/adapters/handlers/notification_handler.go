@@ -0,0 +1,279 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"html/template"
+	"net/http"
+	"strconv"
+	"swapp-go/cmd/internal/adapters/handlers/responses"
+	"swapp-go/cmd/internal/application/services"
+	"swapp-go/cmd/internal/domain"
+	"time"
+)
+
+type NotificationHandler struct {
+	notificationService services.NotificationServiceInterface
+	preferenceService   services.NotificationPreferenceServiceInterface
+}
+
+func NewNotificationHandler(
+	notificationServiceInterface services.NotificationServiceInterface,
+	preferenceServiceInterface services.NotificationPreferenceServiceInterface,
+) *NotificationHandler {
+	return &NotificationHandler{
+		notificationService: notificationServiceInterface,
+		preferenceService:   preferenceServiceInterface,
+	}
+}
+
+type NotificationResponse struct {
+	ID              uuid.UUID  `json:"id"`
+	Type            string     `json:"type"`
+	SwapRequestID   uuid.UUID  `json:"swap_request_id"`
+	ReferenceNumber string     `json:"reference_number"`
+	Read            bool       `json:"read"`
+	ReadAt          *time.Time `json:"read_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+type NotificationListResponse struct {
+	Message       string                 `json:"message"`
+	Notifications []NotificationResponse `json:"notifications"`
+	UnreadCount   int64                  `json:"unread_count"`
+}
+
+type MarkAllNotificationsReadResponse struct {
+	Message string `json:"message"`
+	Marked  int64  `json:"marked"`
+}
+
+// UpdateNotificationPreferencesRequest maps events to whether the user wants
+// emails about them. Events left out keep their current setting.
+type UpdateNotificationPreferencesRequest struct {
+	Preferences map[domain.NotificationEvent]bool `json:"preferences" binding:"required"`
+}
+
+type NotificationPreferencesResponse struct {
+	Preferences map[domain.NotificationEvent]bool `json:"preferences"`
+}
+
+type UnsubscribeResponse struct {
+	Message string                   `json:"message"`
+	Event   domain.NotificationEvent `json:"event"`
+}
+
+// List pages through the user's notifications, newest first, together with
+// the number of unread ones. Supported query parameters: unread, limit and
+// offset.
+func (handler *NotificationHandler) List(context *gin.Context) {
+	userID, err := getUserIDFromContext(context)
+	if err != nil {
+		responses.Unauthorized(context, "Unauthorized", err)
+		return
+	}
+
+	query, err := parseNotificationQuery(context)
+	if err != nil {
+		responses.BadRequest(context, "Invalid query parameters", err)
+		return
+	}
+
+	notifications, err := handler.notificationService.List(userID, query)
+	if err != nil {
+		responses.InternalServerError(context, "Failed to list notifications", err)
+		return
+	}
+
+	unreadCount, err := handler.notificationService.CountUnread(userID)
+	if err != nil {
+		responses.InternalServerError(context, "Failed to list notifications", err)
+		return
+	}
+
+	response := make([]NotificationResponse, 0, len(notifications))
+	for _, notification := range notifications {
+		response = append(response, toNotificationResponse(&notification))
+	}
+
+	context.JSON(http.StatusOK, NotificationListResponse{
+		Message:       "Notifications fetched successfully",
+		Notifications: response,
+		UnreadCount:   unreadCount,
+	})
+}
+
+func (handler *NotificationHandler) MarkRead(context *gin.Context) {
+	userID, err := getUserIDFromContext(context)
+	if err != nil {
+		responses.Unauthorized(context, "Unauthorized", err)
+		return
+	}
+
+	notificationID, err := uuid.Parse(context.Param("id"))
+	if err != nil {
+		responses.BadRequest(context, "Invalid ID format", err)
+		return
+	}
+
+	if err = handler.notificationService.MarkRead(notificationID, userID); err != nil {
+		if errors.Is(err, services.NotificationNotFoundErr) {
+			responses.NotFound(context, "Notification not found", err)
+			return
+		}
+		responses.InternalServerError(context, "Failed to mark notification as read", err)
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"message": "Notification marked as read!"})
+}
+
+func (handler *NotificationHandler) MarkAllRead(context *gin.Context) {
+	userID, err := getUserIDFromContext(context)
+	if err != nil {
+		responses.Unauthorized(context, "Unauthorized", err)
+		return
+	}
+
+	marked, err := handler.notificationService.MarkAllRead(userID)
+	if err != nil {
+		responses.InternalServerError(context, "Failed to mark notifications as read", err)
+		return
+	}
+
+	context.JSON(http.StatusOK, MarkAllNotificationsReadResponse{
+		Message: "Notifications marked as read!",
+		Marked:  marked,
+	})
+}
+
+func (handler *NotificationHandler) GetPreferences(context *gin.Context) {
+	userID, err := getUserIDFromContext(context)
+	if err != nil {
+		responses.Unauthorized(context, "Unauthorized", err)
+		return
+	}
+
+	preferences, err := handler.preferenceService.GetPreferences(userID)
+	if err != nil {
+		responses.InternalServerError(context, "Failed to load notification preferences", err)
+		return
+	}
+
+	context.JSON(http.StatusOK, NotificationPreferencesResponse{Preferences: preferences})
+}
+
+func (handler *NotificationHandler) UpdatePreferences(context *gin.Context) {
+	userID, err := getUserIDFromContext(context)
+	if err != nil {
+		responses.Unauthorized(context, "Unauthorized", err)
+		return
+	}
+
+	var request UpdateNotificationPreferencesRequest
+	if err = context.ShouldBindJSON(&request); err != nil {
+		responses.BadRequest(context, "Invalid request", err)
+		return
+	}
+
+	preferences, err := handler.preferenceService.UpdatePreferences(userID, request.Preferences)
+	if err != nil {
+		if errors.Is(err, domain.InvalidNotificationEventErr) {
+			responses.BadRequest(context, "Unknown notification event", err)
+			return
+		}
+		responses.InternalServerError(context, "Failed to update notification preferences", err)
+		return
+	}
+
+	context.JSON(http.StatusOK, NotificationPreferencesResponse{Preferences: preferences})
+}
+
+// unsubscribePage asks for confirmation before unsubscribing, so that link
+// scanners and prefetching mail clients opening the link change nothing.
+var unsubscribePage = template.Must(template.New("unsubscribe").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Unsubscribe</title></head>
+<body>
+<p>Do you want to stop receiving these emails?</p>
+<form method="post" action="?token={{.}}">
+<button type="submit">Unsubscribe</button>
+</form>
+</body>
+</html>
+`))
+
+// ConfirmUnsubscribe serves unsubscribe links opened in a browser with a page
+// that POSTs the token back to Unsubscribe.
+func (handler *NotificationHandler) ConfirmUnsubscribe(context *gin.Context) {
+	token := context.Query("token")
+	if token == "" {
+		responses.BadRequest(context, "Missing unsubscribe token", nil)
+		return
+	}
+
+	var page bytes.Buffer
+	if err := unsubscribePage.Execute(&page, token); err != nil {
+		responses.InternalServerError(context, "Failed to render unsubscribe page", err)
+		return
+	}
+
+	context.Data(http.StatusOK, "text/html; charset=utf-8", page.Bytes())
+}
+
+// Unsubscribe handles one-click unsubscribe POSTs, from mail clients as
+// described by RFC 8058 and from the ConfirmUnsubscribe page. It takes the
+// token from the query string so that both use the URL from the email.
+func (handler *NotificationHandler) Unsubscribe(context *gin.Context) {
+	token := context.Query("token")
+	if token == "" {
+		responses.BadRequest(context, "Missing unsubscribe token", nil)
+		return
+	}
+
+	event, err := handler.preferenceService.Unsubscribe(token)
+	if err != nil {
+		if errors.Is(err, services.InvalidUnsubscribeTokenErr) {
+			responses.BadRequest(context, "Invalid unsubscribe link", err)
+			return
+		}
+		responses.InternalServerError(context, "Failed to unsubscribe", err)
+		return
+	}
+
+	context.JSON(http.StatusOK, UnsubscribeResponse{
+		Message: "You will no longer receive these emails",
+		Event:   event,
+	})
+}
+
+func parseNotificationQuery(context *gin.Context) (domain.NotificationQuery, error) {
+	var query domain.NotificationQuery
+
+	if raw := context.Query("unread"); raw != "" {
+		unreadOnly, err := strconv.ParseBool(raw)
+		if err != nil {
+			return query, err
+		}
+		query.UnreadOnly = unreadOnly
+	}
+
+	var err error
+	query.Limit, query.Offset, err = parsePage(context)
+
+	return query, err
+}
+
+func toNotificationResponse(notification *domain.Notification) NotificationResponse {
+	return NotificationResponse{
+		ID:              notification.ID,
+		Type:            string(notification.Type),
+		SwapRequestID:   notification.SwapRequestID,
+		ReferenceNumber: notification.ReferenceNumber,
+		Read:            notification.IsRead(),
+		ReadAt:          notification.ReadAt,
+		CreatedAt:       notification.CreatedAt,
+	}
+}
@@ -0,0 +1,264 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"swapp-go/cmd/internal/adapters/handlers"
+	"swapp-go/cmd/internal/adapters/handlers/mocks"
+	"swapp-go/cmd/internal/application/services"
+	"swapp-go/cmd/internal/domain"
+	"testing"
+	"time"
+)
+
+var notificationUserID = uuid.New()
+
+func setupNotificationRouter(t *testing.T) (
+	*mocks.MockNotificationService,
+	*mocks.MockNotificationPreferenceService,
+	*gin.Engine,
+) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	mockInbox := new(mocks.MockNotificationService)
+	mockService := new(mocks.MockNotificationPreferenceService)
+	handler := handlers.NewNotificationHandler(mockInbox, mockService)
+
+	authenticated := func(context *gin.Context) {
+		context.Set("userID", notificationUserID.String())
+	}
+
+	router := gin.New()
+	router.GET("/users/notification-preferences", authenticated, handler.GetPreferences)
+	router.PUT("/users/notification-preferences", authenticated, handler.UpdatePreferences)
+	router.GET("/notifications/unsubscribe", handler.ConfirmUnsubscribe)
+	router.POST("/notifications/unsubscribe", handler.Unsubscribe)
+	router.GET("/notifications", authenticated, handler.List)
+	router.POST("/notifications/read-all", authenticated, handler.MarkAllRead)
+	router.POST("/notifications/:id/read", authenticated, handler.MarkRead)
+
+	return mockInbox, mockService, router
+}
+
+func TestNotificationHandler(t *testing.T) {
+	t.Run("List", func(t *testing.T) {
+		t.Run("success", func(t *testing.T) {
+			mockInbox, _, router := setupNotificationRouter(t)
+
+			readAt := time.Date(2025, time.March, 1, 13, 0, 0, 0, time.UTC)
+			notification := domain.Notification{
+				ID:              uuid.New(),
+				UserID:          notificationUserID,
+				Type:            domain.NotificationType(domain.EmailTemplateSwapRequestAccepted),
+				SwapRequestID:   uuid.New(),
+				ReferenceNumber: "SW-1234",
+				ReadAt:          &readAt,
+				CreatedAt:       readAt.Add(-time.Hour),
+			}
+			expectedQuery := domain.NotificationQuery{UnreadOnly: true, Limit: 10, Offset: 20}
+			mockInbox.On("List", notificationUserID, expectedQuery).Return([]domain.Notification{notification}, nil)
+			mockInbox.On("CountUnread", notificationUserID).Return(int64(4), nil)
+
+			response := performRequest(t, router, http.MethodGet, "/notifications?unread=true&limit=10&offset=20", nil)
+			assert.Equal(t, http.StatusOK, response.Code)
+
+			var parsed handlers.NotificationListResponse
+			assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &parsed))
+			assert.Equal(t, int64(4), parsed.UnreadCount)
+			if assert.Len(t, parsed.Notifications, 1) {
+				assert.Equal(t, notification.ID, parsed.Notifications[0].ID)
+				assert.Equal(t, "swap_request_accepted", parsed.Notifications[0].Type)
+				assert.Equal(t, notification.SwapRequestID, parsed.Notifications[0].SwapRequestID)
+				assert.Equal(t, "SW-1234", parsed.Notifications[0].ReferenceNumber)
+				assert.True(t, parsed.Notifications[0].Read)
+			}
+		})
+
+		t.Run("empty_inbox", func(t *testing.T) {
+			mockInbox, _, router := setupNotificationRouter(t)
+
+			mockInbox.On("List", notificationUserID, domain.NotificationQuery{}).Return([]domain.Notification{}, nil)
+			mockInbox.On("CountUnread", notificationUserID).Return(int64(0), nil)
+
+			response := performRequest(t, router, http.MethodGet, "/notifications", nil)
+			assert.Equal(t, http.StatusOK, response.Code)
+			assert.Contains(t, response.Body.String(), `"notifications":[]`)
+		})
+
+		t.Run("invalid_unread_filter", func(t *testing.T) {
+			mockInbox, _, router := setupNotificationRouter(t)
+
+			response := performRequest(t, router, http.MethodGet, "/notifications?unread=maybe", nil)
+			assert.Equal(t, http.StatusBadRequest, response.Code)
+			mockInbox.AssertNotCalled(t, "List", mock.Anything, mock.Anything)
+		})
+	})
+
+	t.Run("MarkRead", func(t *testing.T) {
+		notificationID := uuid.New()
+
+		t.Run("success", func(t *testing.T) {
+			mockInbox, _, router := setupNotificationRouter(t)
+
+			mockInbox.On("MarkRead", notificationID, notificationUserID).Return(nil)
+
+			response := performRequest(t, router, http.MethodPost, "/notifications/"+notificationID.String()+"/read", nil)
+			assert.Equal(t, http.StatusOK, response.Code)
+			mockInbox.AssertExpectations(t)
+		})
+
+		t.Run("not_found", func(t *testing.T) {
+			mockInbox, _, router := setupNotificationRouter(t)
+
+			mockInbox.On("MarkRead", notificationID, notificationUserID).Return(services.NotificationNotFoundErr)
+
+			response := performRequest(t, router, http.MethodPost, "/notifications/"+notificationID.String()+"/read", nil)
+			assert.Equal(t, http.StatusNotFound, response.Code)
+		})
+
+		t.Run("invalid_id", func(t *testing.T) {
+			mockInbox, _, router := setupNotificationRouter(t)
+
+			response := performRequest(t, router, http.MethodPost, "/notifications/not-a-uuid/read", nil)
+			assert.Equal(t, http.StatusBadRequest, response.Code)
+			mockInbox.AssertNotCalled(t, "MarkRead", mock.Anything, mock.Anything)
+		})
+	})
+
+	t.Run("MarkAllRead", func(t *testing.T) {
+		mockInbox, _, router := setupNotificationRouter(t)
+
+		mockInbox.On("MarkAllRead", notificationUserID).Return(int64(3), nil)
+
+		response := performRequest(t, router, http.MethodPost, "/notifications/read-all", nil)
+		assert.Equal(t, http.StatusOK, response.Code)
+
+		var parsed handlers.MarkAllNotificationsReadResponse
+		assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &parsed))
+		assert.Equal(t, int64(3), parsed.Marked)
+	})
+
+	t.Run("GetPreferences", func(t *testing.T) {
+		_, mockService, router := setupNotificationRouter(t)
+
+		mockService.On("GetPreferences", notificationUserID).Return(domain.NotificationPreferences{
+			domain.NotifyNewRequest: true,
+			domain.NotifyAccepted:   false,
+		}, nil)
+
+		response := performRequest(t, router, http.MethodGet, "/users/notification-preferences", nil)
+		assert.Equal(t, http.StatusOK, response.Code)
+
+		var parsed handlers.NotificationPreferencesResponse
+		assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &parsed))
+		assert.True(t, parsed.Preferences[domain.NotifyNewRequest])
+		assert.False(t, parsed.Preferences[domain.NotifyAccepted])
+	})
+
+	t.Run("UpdatePreferences", func(t *testing.T) {
+		t.Run("success", func(t *testing.T) {
+			_, mockService, router := setupNotificationRouter(t)
+
+			changes := domain.NotificationPreferences{domain.NotifyRejected: false}
+			mockService.On("UpdatePreferences", notificationUserID, changes).Return(domain.NotificationPreferences{
+				domain.NotifyRejected: false,
+			}, nil)
+
+			response := performRequest(t, router, http.MethodPut, "/users/notification-preferences", map[string]interface{}{
+				"preferences": map[string]bool{"rejected": false},
+			})
+			assert.Equal(t, http.StatusOK, response.Code)
+			mockService.AssertExpectations(t)
+		})
+
+		t.Run("unknown_event", func(t *testing.T) {
+			_, mockService, router := setupNotificationRouter(t)
+
+			mockService.On("UpdatePreferences", notificationUserID, mock.Anything).
+				Return(nil, domain.InvalidNotificationEventErr)
+
+			response := performRequest(t, router, http.MethodPut, "/users/notification-preferences", map[string]interface{}{
+				"preferences": map[string]bool{"newsletter": false},
+			})
+			assert.Equal(t, http.StatusBadRequest, response.Code)
+		})
+
+		t.Run("missing_preferences", func(t *testing.T) {
+			_, mockService, router := setupNotificationRouter(t)
+
+			response := performRequest(t, router, http.MethodPut, "/users/notification-preferences", map[string]interface{}{})
+			assert.Equal(t, http.StatusBadRequest, response.Code)
+			mockService.AssertNotCalled(t, "UpdatePreferences", mock.Anything, mock.Anything)
+		})
+
+		t.Run("service_error", func(t *testing.T) {
+			_, mockService, router := setupNotificationRouter(t)
+
+			mockService.On("UpdatePreferences", notificationUserID, mock.Anything).
+				Return(nil, errors.New("database down"))
+
+			response := performRequest(t, router, http.MethodPut, "/users/notification-preferences", map[string]interface{}{
+				"preferences": map[string]bool{"accepted": true},
+			})
+			assert.Equal(t, http.StatusInternalServerError, response.Code)
+		})
+	})
+
+	t.Run("Unsubscribe", func(t *testing.T) {
+		t.Run("one_click_post", func(t *testing.T) {
+			_, mockService, router := setupNotificationRouter(t)
+
+			mockService.On("Unsubscribe", "signed-token").Return(domain.NotifyExpiringSoon, nil)
+
+			response := performRequest(t, router, http.MethodPost, "/notifications/unsubscribe?token=signed-token", nil)
+			assert.Equal(t, http.StatusOK, response.Code)
+
+			var parsed handlers.UnsubscribeResponse
+			assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &parsed))
+			assert.Equal(t, domain.NotifyExpiringSoon, parsed.Event)
+		})
+
+		t.Run("browser_get_asks_for_confirmation", func(t *testing.T) {
+			_, mockService, router := setupNotificationRouter(t)
+
+			response := performRequest(t, router, http.MethodGet, "/notifications/unsubscribe?token=signed-token", nil)
+			assert.Equal(t, http.StatusOK, response.Code)
+			assert.Contains(t, response.Header().Get("Content-Type"), "text/html")
+			assert.Contains(t, response.Body.String(), `<form method="post" action="?token=signed-token">`)
+			mockService.AssertNotCalled(t, "Unsubscribe", mock.Anything)
+		})
+
+		t.Run("confirmation_page_escapes_token", func(t *testing.T) {
+			_, _, router := setupNotificationRouter(t)
+
+			response := performRequest(t, router, http.MethodGet, `/notifications/unsubscribe?token="><script>`, nil)
+			assert.Equal(t, http.StatusOK, response.Code)
+			assert.NotContains(t, response.Body.String(), "<script>")
+		})
+
+		t.Run("invalid_token", func(t *testing.T) {
+			_, mockService, router := setupNotificationRouter(t)
+
+			mockService.On("Unsubscribe", "forged").Return(domain.NotificationEvent(""), services.InvalidUnsubscribeTokenErr)
+
+			response := performRequest(t, router, http.MethodPost, "/notifications/unsubscribe?token=forged", nil)
+			assert.Equal(t, http.StatusBadRequest, response.Code)
+		})
+
+		t.Run("missing_token", func(t *testing.T) {
+			for _, method := range []string{http.MethodGet, http.MethodPost} {
+				_, mockService, router := setupNotificationRouter(t)
+
+				response := performRequest(t, router, method, "/notifications/unsubscribe", nil)
+				assert.Equal(t, http.StatusBadRequest, response.Code, method)
+				mockService.AssertNotCalled(t, "Unsubscribe", mock.Anything)
+			}
+		})
+	})
+}
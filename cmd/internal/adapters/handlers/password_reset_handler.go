@@ -1,17 +1,19 @@
 package handlers
 
 import (
+	"errors"
 	"github.com/gin-gonic/gin"
 	"log"
 	"net/http"
 	"swapp-go/cmd/internal/application/services"
-	"swapp-go/cmd/internal/utils"
-	"time"
 )
 
+// resetRequestedMessage is the answer to every reset request, so that it does
+// not reveal which email addresses have an account.
+const resetRequestedMessage = "If an account exists for this email, a password reset link has been sent."
+
 type PasswordResetHandler struct {
-	ResetService *services.PasswordResetService
-	UserService  *services.UserService
+	resetService services.PasswordResetServiceInterface
 }
 
 type PasswordResetRequest struct {
@@ -20,14 +22,11 @@ type PasswordResetRequest struct {
 
 type ResetPasswordRequest struct {
 	Token       string `json:"token" binding:"required"`
-	NewPassword string `json:"new_password" binding:"required,min=8"`
+	NewPassword string `json:"new_password" binding:"required"`
 }
 
-func NewPasswordResetHandler(resetService *services.PasswordResetService, userService *services.UserService) *PasswordResetHandler {
-	return &PasswordResetHandler{
-		ResetService: resetService,
-		UserService:  userService,
-	}
+func NewPasswordResetHandler(resetService services.PasswordResetServiceInterface) *PasswordResetHandler {
+	return &PasswordResetHandler{resetService: resetService}
 }
 
 func (handler *PasswordResetHandler) RequestReset(context *gin.Context) {
@@ -38,22 +37,11 @@ func (handler *PasswordResetHandler) RequestReset(context *gin.Context) {
 		return
 	}
 
-	user, err := handler.UserService.FindByEmail(request.Email)
-	if err != nil || user == nil {
-		context.JSON(http.StatusOK, gin.H{"message": "User not found, no reset token was created."})
-		return
-	}
-
-	token, err := handler.ResetService.GenerateAndSaveToken(user.ID)
-	if err != nil {
-		context.JSON(http.StatusInternalServerError, gin.H{"error": "Could not generate reset token"})
-		return
+	if err := handler.resetService.RequestReset(request.Email); err != nil {
+		log.Printf("Warning: failed to send password reset email: %v", err)
 	}
 
-	context.JSON(http.StatusOK, gin.H{
-		"message": "Reset token generated",
-		"token":   token,
-	})
+	context.JSON(http.StatusOK, gin.H{"message": resetRequestedMessage})
 }
 
 func (handler *PasswordResetHandler) ResetPassword(context *gin.Context) {
@@ -64,39 +52,17 @@ func (handler *PasswordResetHandler) ResetPassword(context *gin.Context) {
 		return
 	}
 
-	resetToken, err := handler.ResetService.ValidateToken(request.Token)
-	if err != nil || resetToken == nil {
-		context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid or expired token"})
-		return
-	}
-
-	if resetToken.ExpiresAt.Before(time.Now()) {
-		context.JSON(http.StatusBadRequest, gin.H{"error": "Token expired"})
-		return
-	}
-
-	user, err := handler.UserService.FindByID(resetToken.UserID)
-	if err != nil || user == nil {
-		context.JSON(http.StatusBadRequest, gin.H{"error": "User not found"})
-		return
-	}
-
-	hashedPassword, err := utils.HashPassword(request.NewPassword)
-	if err != nil {
-		context.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to hash new password"})
+	if err := handler.resetService.ResetPassword(request.Token, request.NewPassword); err != nil {
+		if respondWithWeakPassword(context, err) {
+			return
+		}
+		if errors.Is(err, services.InvalidResetTokenErr) {
+			context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid or expired token"})
+			return
+		}
+		context.JSON(http.StatusInternalServerError, gin.H{"error": "Could not reset password"})
 		return
 	}
 
-	_, err = handler.UserService.Update(user.ID, map[string]interface{}{"password": hashedPassword})
-	if err != nil {
-		context.JSON(http.StatusInternalServerError, gin.H{"error": "Could not update password"})
-		return
-	}
-
-	err = handler.ResetService.DeleteToken(request.Token)
-	if err != nil {
-		log.Printf("Warning: failed to delete password reset token: %v", err)
-	}
-
 	context.JSON(http.StatusOK, gin.H{"message": "Password reset successfully!"})
 }
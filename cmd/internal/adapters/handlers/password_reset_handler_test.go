@@ -5,9 +5,11 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"regexp"
 	"swapp-go/cmd/internal/adapters/handlers"
 	gormRepo "swapp-go/cmd/internal/adapters/persistence/gorm"
 	"swapp-go/cmd/internal/adapters/persistence/models"
+	appMocks "swapp-go/cmd/internal/application/mocks"
 	"swapp-go/cmd/internal/application/services"
 	"swapp-go/cmd/internal/domain"
 	"swapp-go/cmd/internal/utils"
@@ -15,129 +17,224 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
 
-func setupPasswordResetTestEnv(t *testing.T) (*gin.Engine, *gorm.DB, *domain.User, string) {
+const testResetURL = "https://swapp.example/reset-password"
+
+var resetLinkPattern = regexp.MustCompile(regexp.QuoteMeta(testResetURL) + `\?token=(\S+)`)
+
+type passwordResetTestEnv struct {
+	router       *gin.Engine
+	db           *gorm.DB
+	user         *domain.User
+	clock        *appMocks.Clock
+	emailService *appMocks.MockEmailService
+}
+
+func setupPasswordResetTestEnv(t *testing.T) *passwordResetTestEnv {
 	gin.SetMode(gin.TestMode)
 	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
 	assert.NoError(t, err)
 
-	err = db.AutoMigrate(&models.UserModel{}, &models.PasswordResetModel{})
+	err = db.AutoMigrate(&models.UserModel{}, &models.PasswordResetModel{}, &models.SessionModel{})
 	if err != nil {
-		return nil, nil, nil, ""
+		t.Fatalf("failed to migrate: %v", err)
 	}
 
 	userRepo := gormRepo.NewUserGormRepository(db)
 	resetRepo := gormRepo.NewPasswordResetGormRepository(db)
-	userService := services.NewUserService(userRepo)
-	resetService := services.NewPasswordResetService(resetRepo)
+	sessionRepo := gormRepo.NewSessionGormRepository(db)
+	emailService := new(appMocks.MockEmailService)
+	clock := &appMocks.Clock{Current: time.Now().UTC()}
+	passwordService, err := services.NewPasswordService(
+		domain.PasswordPolicy{MinLength: 8, MinCharacterClasses: 2},
+		appMocks.PasswordBlocklist{"Password1234"},
+		bcrypt.MinCost,
+	)
+	assert.NoError(t, err)
+	userService := services.NewUserService(userRepo, sessionRepo, passwordService, new(appMocks.MockLoginThrottleService), clock)
+	resetService := services.NewPasswordResetService(resetRepo, userRepo, sessionRepo, passwordService, emailService, clock, testResetURL, time.Hour)
 
-	handler := handlers.NewPasswordResetHandler(resetService, userService)
+	handler := handlers.NewPasswordResetHandler(resetService)
 	router := gin.Default()
 	router.POST("/request-reset", handler.RequestReset)
 	router.POST("/reset-password", handler.ResetPassword)
 
-	encryptedPassword, _ := utils.HashPassword("originalPassword")
 	user := &domain.User{
 		Username: "reset_user",
 		Email:    "reset@example.com",
-		Password: encryptedPassword,
+		Password: "originalPassword",
 	}
 	err = userService.RegisterUser(user)
 	assert.NoError(t, err)
 
-	return router, db, user, "originalPassword"
+	return &passwordResetTestEnv{router: router, db: db, user: user, clock: clock, emailService: emailService}
+}
+
+func (env *passwordResetTestEnv) post(path string, payload map[string]string) *httptest.ResponseRecorder {
+	jsonValue, _ := json.Marshal(payload)
+	request, _ := http.NewRequest("POST", path, bytes.NewBuffer(jsonValue))
+	request.Header.Set("Content-Type", "application/json")
+
+	response := httptest.NewRecorder()
+	env.router.ServeHTTP(response, request)
+
+	return response
+}
+
+// requestToken asks for a reset and returns the token from the mailed link.
+func (env *passwordResetTestEnv) requestToken(t *testing.T) string {
+	t.Helper()
+
+	var sent *domain.EmailMessage
+	env.emailService.On("SendEmail", mock.AnythingOfType("*domain.EmailMessage")).
+		Run(func(args mock.Arguments) { sent = args.Get(0).(*domain.EmailMessage) }).
+		Return(nil).Once()
+
+	response := env.post("/request-reset", map[string]string{"email": env.user.Email})
+	assert.Equal(t, http.StatusOK, response.Code)
+
+	if sent == nil {
+		t.Fatalf("no reset email was sent")
+	}
+	resetURL, _ := sent.Data["ResetURL"].(string)
+	link := resetLinkPattern.FindStringSubmatch(resetURL)
+	if link == nil {
+		t.Fatalf("reset email has no link: %q", resetURL)
+	}
+
+	return link[1]
 }
 
 func TestPasswordResetHandler(t *testing.T) {
 	t.Run("RequestReset", func(t *testing.T) {
-		t.Run("success", func(t *testing.T) {
-			router, _, user, _ := setupPasswordResetTestEnv(t)
+		t.Run("token_is_only_sent_by_email", func(t *testing.T) {
+			env := setupPasswordResetTestEnv(t)
 
-			payload := map[string]string{"email": user.Email}
-			jsonValue, _ := json.Marshal(payload)
-			request, _ := http.NewRequest("POST", "/request-reset", bytes.NewBuffer(jsonValue))
-			request.Header.Set("Content-Type", "application/json")
+			var sent *domain.EmailMessage
+			env.emailService.On("SendEmail", mock.AnythingOfType("*domain.EmailMessage")).
+				Run(func(args mock.Arguments) { sent = args.Get(0).(*domain.EmailMessage) }).
+				Return(nil).Once()
 
-			resp := httptest.NewRecorder()
-			router.ServeHTTP(resp, request)
+			resp := env.post("/request-reset", map[string]string{"email": env.user.Email})
 
 			assert.Equal(t, http.StatusOK, resp.Code)
-			assert.Contains(t, resp.Body.String(), "token")
+			assert.NotContains(t, resp.Body.String(), "token")
+			assert.Equal(t, env.user.Email, sent.Recipient)
+			assert.Regexp(t, resetLinkPattern, sent.Data["ResetURL"])
+		})
+
+		t.Run("uniform_response_for_unknown_email", func(t *testing.T) {
+			env := setupPasswordResetTestEnv(t)
+			env.emailService.On("SendEmail", mock.AnythingOfType("*domain.EmailMessage")).Return(nil)
+
+			known := env.post("/request-reset", map[string]string{"email": env.user.Email})
+			unknown := env.post("/request-reset", map[string]string{"email": "nobody@example.com"})
+
+			assert.Equal(t, known.Code, unknown.Code)
+			assert.Equal(t, known.Body.String(), unknown.Body.String())
+			env.emailService.AssertNumberOfCalls(t, "SendEmail", 1)
 		})
 	})
 
 	t.Run("ResetPassword", func(t *testing.T) {
 		t.Run("success", func(t *testing.T) {
-			router, db, user, _ := setupPasswordResetTestEnv(t)
-			resetRepo := gormRepo.NewPasswordResetGormRepository(db)
-			resetService := services.NewPasswordResetService(resetRepo)
+			env := setupPasswordResetTestEnv(t)
+			token := env.requestToken(t)
+
+			sessionRepo := gormRepo.NewSessionGormRepository(env.db)
+			session := &domain.Session{
+				UserID:           env.user.ID,
+				RefreshTokenHash: "hash",
+				CreatedAt:        env.clock.Now(),
+				LastUsedAt:       env.clock.Now(),
+				ExpiresAt:        env.clock.Now().Add(time.Hour),
+			}
+			assert.NoError(t, sessionRepo.Create(session))
+
+			response := env.post("/reset-password", map[string]string{
+				"token":        token,
+				"new_password": "newSecurePass123",
+			})
+
+			assert.Equal(t, http.StatusOK, response.Code)
+			assert.Contains(t, response.Body.String(), "Password reset successfully")
+
+			user, err := gormRepo.NewUserGormRepository(env.db).FindByID(env.user.ID)
+			assert.NoError(t, err)
+			assert.True(t, utils.CheckPasswordHash("newSecurePass123", user.Password))
 
-			token, err := resetService.GenerateAndSaveToken(user.ID)
+			sessions, err := sessionRepo.ListActiveByUser(env.user.ID, env.clock.Now())
 			assert.NoError(t, err)
+			assert.Empty(t, sessions, "resetting the password signs the user out everywhere")
 
-			payload := map[string]string{
+			reused := env.post("/reset-password", map[string]string{
 				"token":        token,
-				"new_password": "newSecurePass123",
-			}
-			jsonValue, _ := json.Marshal(payload)
-			request, _ := http.NewRequest("POST", "/reset-password", bytes.NewBuffer(jsonValue))
-			request.Header.Set("Content-Type", "application/json")
+				"new_password": "anotherPass123",
+			})
+			assert.Equal(t, http.StatusBadRequest, reused.Code)
+		})
 
-			response := httptest.NewRecorder()
-			router.ServeHTTP(response, request)
+		t.Run("weak_password_keeps_token", func(t *testing.T) {
+			env := setupPasswordResetTestEnv(t)
+			token := env.requestToken(t)
 
+			weak := env.post("/reset-password", map[string]string{
+				"token":        token,
+				"new_password": "Password1234",
+			})
+			assert.Equal(t, http.StatusBadRequest, weak.Code)
+			assert.Contains(t, weak.Body.String(), "violations")
+
+			response := env.post("/reset-password", map[string]string{
+				"token":        token,
+				"new_password": "newSecurePass123",
+			})
 			assert.Equal(t, http.StatusOK, response.Code)
-			assert.Contains(t, response.Body.String(), "Password reset successfully")
 		})
 
 		t.Run("invalid_token", func(t *testing.T) {
-			router, _, _, _ := setupPasswordResetTestEnv(t)
+			env := setupPasswordResetTestEnv(t)
 
-			payload := map[string]string{
+			response := env.post("/reset-password", map[string]string{
 				"token":        "invalid_token",
 				"new_password": "NewPassword123",
-			}
-			jsonValue, _ := json.Marshal(payload)
-			request, _ := http.NewRequest("POST", "/reset-password", bytes.NewBuffer(jsonValue))
-			request.Header.Set("Content-Type", "application/json")
-
-			response := httptest.NewRecorder()
-			router.ServeHTTP(response, request)
+			})
 
 			assert.Equal(t, http.StatusBadRequest, response.Code)
 			assert.Contains(t, response.Body.String(), "Invalid or expired token")
 		})
 
-		t.Run("expired_token", func(t *testing.T) {
-			router, db, user, _ := setupPasswordResetTestEnv(t)
-			resetRepo := gormRepo.NewPasswordResetGormRepository(db)
-
-			expired := &domain.PasswordReset{
-				Token:     uuid.NewString(),
-				UserID:    user.ID,
-				ExpiresAt: time.Now().Add(-1 * time.Hour),
-			}
-			err := resetRepo.Save(expired)
-			assert.NoError(t, err)
+		t.Run("superseded_token", func(t *testing.T) {
+			env := setupPasswordResetTestEnv(t)
+			first := env.requestToken(t)
+			env.requestToken(t)
 
-			payload := map[string]string{
-				"token":        expired.Token,
+			response := env.post("/reset-password", map[string]string{
+				"token":        first,
 				"new_password": "NewPassword123",
-			}
-			jsonValue, _ := json.Marshal(payload)
-			request, _ := http.NewRequest("POST", "/reset-password", bytes.NewBuffer(jsonValue))
-			request.Header.Set("Content-Type", "application/json")
+			})
 
-			response := httptest.NewRecorder()
-			router.ServeHTTP(response, request)
+			assert.Equal(t, http.StatusBadRequest, response.Code)
+		})
+
+		t.Run("expired_token", func(t *testing.T) {
+			env := setupPasswordResetTestEnv(t)
+			token := env.requestToken(t)
+			env.clock.Advance(2 * time.Hour)
+
+			response := env.post("/reset-password", map[string]string{
+				"token":        token,
+				"new_password": "NewPassword123",
+			})
 
 			assert.Equal(t, http.StatusBadRequest, response.Code)
-			assert.Contains(t, response.Body.String(), "Token expired")
+			assert.Contains(t, response.Body.String(), "Invalid or expired token")
 		})
 	})
 }
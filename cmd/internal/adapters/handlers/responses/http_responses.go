@@ -23,6 +23,10 @@ func Unauthorized(context *gin.Context, message string, err error) {
 	HTTPStatus(context, http.StatusUnauthorized, message, err)
 }
 
+func Forbidden(context *gin.Context, message string, err error) {
+	HTTPStatus(context, http.StatusForbidden, message, err)
+}
+
 func NotFound(context *gin.Context, message string, err error) {
 	HTTPStatus(context, http.StatusNotFound, message, err)
 }
@@ -31,6 +35,22 @@ func Conflict(context *gin.Context, message string, err error) {
 	HTTPStatus(context, http.StatusConflict, message, err)
 }
 
+func PayloadTooLarge(context *gin.Context, message string, err error) {
+	HTTPStatus(context, http.StatusRequestEntityTooLarge, message, err)
+}
+
+func UnsupportedMediaType(context *gin.Context, message string, err error) {
+	HTTPStatus(context, http.StatusUnsupportedMediaType, message, err)
+}
+
+func UnprocessableEntity(context *gin.Context, message string, err error) {
+	HTTPStatus(context, http.StatusUnprocessableEntity, message, err)
+}
+
+func TooManyRequests(context *gin.Context, message string, err error) {
+	HTTPStatus(context, http.StatusTooManyRequests, message, err)
+}
+
 func InternalServerError(context *gin.Context, message string, err error) {
 	HTTPStatus(context, http.StatusInternalServerError, message, err)
 }
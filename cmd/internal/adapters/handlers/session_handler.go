@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"swapp-go/cmd/internal/adapters/handlers/responses"
+	"swapp-go/cmd/internal/application/services"
+	"swapp-go/cmd/internal/domain"
+	"time"
+)
+
+type SessionHandler struct {
+	sessionService services.SessionServiceInterface
+}
+
+func NewSessionHandler(sessionServiceInterface services.SessionServiceInterface) *SessionHandler {
+	return &SessionHandler{sessionService: sessionServiceInterface}
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+type AuthTokensResponse struct {
+	SessionID             string    `json:"session_id"`
+	Token                 string    `json:"token"`
+	TokenExpiresAt        time.Time `json:"token_expires_at"`
+	RefreshToken          string    `json:"refresh_token"`
+	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at"`
+}
+
+type SessionResponse struct {
+	SessionID  string    `json:"session_id"`
+	UserAgent  string    `json:"user_agent"`
+	IPAddress  string    `json:"ip_address"`
+	Current    bool      `json:"current"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+type SessionListResponse struct {
+	Message  string            `json:"message"`
+	Sessions []SessionResponse `json:"sessions"`
+}
+
+func (handler *SessionHandler) Refresh(context *gin.Context) {
+	var request RefreshTokenRequest
+	if err := context.ShouldBindJSON(&request); err != nil {
+		responses.BadRequest(context, "Invalid request", err)
+		return
+	}
+
+	tokens, err := handler.sessionService.Refresh(request.RefreshToken, sessionClient(context))
+	if err != nil {
+		if errors.Is(err, services.InvalidRefreshTokenErr) || errors.Is(err, services.RefreshTokenReusedErr) {
+			responses.Unauthorized(context, "Invalid refresh token", err)
+			return
+		}
+		responses.InternalServerError(context, "Failed to refresh session", err)
+		return
+	}
+
+	context.JSON(http.StatusOK, toAuthTokensResponse(tokens))
+}
+
+func (handler *SessionHandler) Logout(context *gin.Context) {
+	userID, sessionID, ok := currentSession(context)
+	if !ok {
+		return
+	}
+
+	if err := handler.sessionService.Revoke(userID, sessionID); err != nil {
+		responses.InternalServerError(context, "Failed to log out", err)
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"message": "Logged out successfully!"})
+}
+
+func (handler *SessionHandler) ListSessions(context *gin.Context) {
+	userID, currentSessionID, ok := currentSession(context)
+	if !ok {
+		return
+	}
+
+	sessions, err := handler.sessionService.ListActive(userID)
+	if err != nil {
+		responses.InternalServerError(context, "Failed to list sessions", err)
+		return
+	}
+
+	sessionResponses := make([]SessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		sessionResponses = append(sessionResponses, SessionResponse{
+			SessionID:  session.ID.String(),
+			UserAgent:  session.UserAgent,
+			IPAddress:  session.IPAddress,
+			Current:    session.ID == currentSessionID,
+			CreatedAt:  session.CreatedAt,
+			LastUsedAt: session.LastUsedAt,
+			ExpiresAt:  session.ExpiresAt,
+		})
+	}
+
+	context.JSON(http.StatusOK, SessionListResponse{
+		Message:  "Sessions retrieved successfully!",
+		Sessions: sessionResponses,
+	})
+}
+
+func (handler *SessionHandler) RevokeSession(context *gin.Context) {
+	userID, _, ok := currentSession(context)
+	if !ok {
+		return
+	}
+
+	sessionID, err := uuid.Parse(context.Param("id"))
+	if err != nil {
+		responses.BadRequest(context, "Invalid session ID", err)
+		return
+	}
+
+	if err = handler.sessionService.Revoke(userID, sessionID); err != nil {
+		if errors.Is(err, services.SessionNotFoundErr) {
+			responses.NotFound(context, "Session not found", err)
+			return
+		}
+		responses.InternalServerError(context, "Failed to revoke session", err)
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"message": "Session revoked successfully!"})
+}
+
+// currentSession reads the user and session set by the auth middleware.
+func currentSession(context *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	userID, err := uuid.Parse(context.GetString("userID"))
+	if err != nil {
+		responses.BadRequest(context, "Invalid user ID", err)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	sessionID, err := uuid.Parse(context.GetString("sessionID"))
+	if err != nil {
+		responses.BadRequest(context, "Invalid session ID", err)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return userID, sessionID, true
+}
+
+func sessionClient(context *gin.Context) domain.SessionClient {
+	return domain.SessionClient{
+		UserAgent: context.Request.UserAgent(),
+		IPAddress: context.ClientIP(),
+	}
+}
+
+func toAuthTokensResponse(tokens *domain.AuthTokens) AuthTokensResponse {
+	return AuthTokensResponse{
+		SessionID:             tokens.SessionID.String(),
+		Token:                 tokens.AccessToken,
+		TokenExpiresAt:        tokens.AccessTokenExpiresAt,
+		RefreshToken:          tokens.RefreshToken,
+		RefreshTokenExpiresAt: tokens.RefreshTokenExpiresAt,
+	}
+}
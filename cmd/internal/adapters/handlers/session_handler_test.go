@@ -0,0 +1,153 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"swapp-go/cmd/internal/adapters/handlers"
+	"swapp-go/cmd/internal/adapters/handlers/mocks"
+	"swapp-go/cmd/internal/application/services"
+	"swapp-go/cmd/internal/domain"
+	"testing"
+	"time"
+)
+
+var (
+	sessionUserID    = uuid.New()
+	currentSessionID = uuid.New()
+)
+
+func setupSessionRouter(t *testing.T) (*mocks.MockSessionService, *gin.Engine) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	mockService := new(mocks.MockSessionService)
+	handler := handlers.NewSessionHandler(mockService)
+
+	authenticated := func(context *gin.Context) {
+		context.Set("userID", sessionUserID.String())
+		context.Set("sessionID", currentSessionID.String())
+	}
+
+	router := gin.New()
+	router.POST("/auth/refresh", handler.Refresh)
+	router.POST("/auth/logout", authenticated, handler.Logout)
+	router.GET("/users/sessions", authenticated, handler.ListSessions)
+	router.DELETE("/users/sessions/:id", authenticated, handler.RevokeSession)
+
+	return mockService, router
+}
+
+func TestSessionHandler(t *testing.T) {
+	t.Run("Refresh", func(t *testing.T) {
+		t.Run("success", func(t *testing.T) {
+			mockService, router := setupSessionRouter(t)
+
+			tokens := &domain.AuthTokens{
+				SessionID:             currentSessionID,
+				AccessToken:           "new-access-token",
+				AccessTokenExpiresAt:  time.Now().Add(15 * time.Minute),
+				RefreshToken:          "new-refresh-token",
+				RefreshTokenExpiresAt: time.Now().Add(time.Hour),
+			}
+			mockService.On("Refresh", "old-refresh-token", mock.AnythingOfType("domain.SessionClient")).Return(tokens, nil)
+
+			response := performRequest(t, router, http.MethodPost, "/auth/refresh", map[string]string{"refresh_token": "old-refresh-token"})
+			assert.Equal(t, http.StatusOK, response.Code)
+
+			var parsed handlers.AuthTokensResponse
+			assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &parsed))
+			assert.Equal(t, "new-access-token", parsed.Token)
+			assert.Equal(t, "new-refresh-token", parsed.RefreshToken)
+			assert.Equal(t, currentSessionID.String(), parsed.SessionID)
+		})
+
+		t.Run("rejected_tokens", func(t *testing.T) {
+			for _, err := range []error{services.InvalidRefreshTokenErr, services.RefreshTokenReusedErr} {
+				mockService, router := setupSessionRouter(t)
+				mockService.On("Refresh", "stale", mock.Anything).Return(nil, err)
+
+				response := performRequest(t, router, http.MethodPost, "/auth/refresh", map[string]string{"refresh_token": "stale"})
+				assert.Equal(t, http.StatusUnauthorized, response.Code, err.Error())
+			}
+		})
+
+		t.Run("missing_token", func(t *testing.T) {
+			mockService, router := setupSessionRouter(t)
+
+			response := performRequest(t, router, http.MethodPost, "/auth/refresh", map[string]string{})
+			assert.Equal(t, http.StatusBadRequest, response.Code)
+			mockService.AssertNotCalled(t, "Refresh", mock.Anything, mock.Anything)
+		})
+	})
+
+	t.Run("Logout", func(t *testing.T) {
+		mockService, router := setupSessionRouter(t)
+		mockService.On("Revoke", sessionUserID, currentSessionID).Return(nil)
+
+		response := performRequest(t, router, http.MethodPost, "/auth/logout", nil)
+		assert.Equal(t, http.StatusOK, response.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("ListSessions", func(t *testing.T) {
+		mockService, router := setupSessionRouter(t)
+		otherSessionID := uuid.New()
+		mockService.On("ListActive", sessionUserID).Return([]domain.Session{
+			{ID: currentSessionID, UserAgent: "laptop"},
+			{ID: otherSessionID, UserAgent: "phone"},
+		}, nil)
+
+		response := performRequest(t, router, http.MethodGet, "/users/sessions", nil)
+		assert.Equal(t, http.StatusOK, response.Code)
+
+		var parsed handlers.SessionListResponse
+		assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &parsed))
+		assert.Len(t, parsed.Sessions, 2)
+		assert.True(t, parsed.Sessions[0].Current)
+		assert.False(t, parsed.Sessions[1].Current)
+		assert.Equal(t, "phone", parsed.Sessions[1].UserAgent)
+	})
+
+	t.Run("RevokeSession", func(t *testing.T) {
+		t.Run("success", func(t *testing.T) {
+			mockService, router := setupSessionRouter(t)
+			sessionID := uuid.New()
+			mockService.On("Revoke", sessionUserID, sessionID).Return(nil)
+
+			response := performRequest(t, router, http.MethodDelete, "/users/sessions/"+sessionID.String(), nil)
+			assert.Equal(t, http.StatusOK, response.Code)
+			mockService.AssertExpectations(t)
+		})
+
+		t.Run("not_found", func(t *testing.T) {
+			mockService, router := setupSessionRouter(t)
+			sessionID := uuid.New()
+			mockService.On("Revoke", sessionUserID, sessionID).Return(services.SessionNotFoundErr)
+
+			response := performRequest(t, router, http.MethodDelete, "/users/sessions/"+sessionID.String(), nil)
+			assert.Equal(t, http.StatusNotFound, response.Code)
+		})
+
+		t.Run("invalid_id", func(t *testing.T) {
+			mockService, router := setupSessionRouter(t)
+
+			response := performRequest(t, router, http.MethodDelete, "/users/sessions/not-a-uuid", nil)
+			assert.Equal(t, http.StatusBadRequest, response.Code)
+			mockService.AssertNotCalled(t, "Revoke", mock.Anything, mock.Anything)
+		})
+
+		t.Run("service_error", func(t *testing.T) {
+			mockService, router := setupSessionRouter(t)
+			sessionID := uuid.New()
+			mockService.On("Revoke", sessionUserID, sessionID).Return(errors.New("db error"))
+
+			response := performRequest(t, router, http.MethodDelete, "/users/sessions/"+sessionID.String(), nil)
+			assert.Equal(t, http.StatusInternalServerError, response.Code)
+		})
+	})
+}
@@ -7,10 +7,12 @@ import (
 	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"log"
 	"net/http"
 	"swapp-go/cmd/internal/adapters/handlers/responses"
 	"swapp-go/cmd/internal/application/services"
 	"swapp-go/cmd/internal/domain"
+	"time"
 )
 
 type SwapRequestHandler struct {
@@ -21,10 +23,20 @@ func NewSwapRequestHandler(swapRequestService services.SwapRequestServiceInterfa
 	return &SwapRequestHandler{swapRequestService}
 }
 
+// SwapRequestRequest accepts either single items or bundles; both forms may be combined.
 type SwapRequestRequest struct {
-	OfferedItemID   uuid.UUID `json:"offered_item_id" binding:"required"`
-	RequestedItemID uuid.UUID `json:"requested_item_id" binding:"required"`
-	RecipientID     uuid.UUID `json:"recipient_id" binding:"required"`
+	OfferedItemID    uuid.UUID   `json:"offered_item_id"`
+	RequestedItemID  uuid.UUID   `json:"requested_item_id"`
+	OfferedItemIDs   []uuid.UUID `json:"offered_item_ids"`
+	RequestedItemIDs []uuid.UUID `json:"requested_item_ids"`
+	RecipientID      uuid.UUID   `json:"recipient_id" binding:"required"`
+}
+
+type CounterOfferRequest struct {
+	OfferedItemID    uuid.UUID   `json:"offered_item_id"`
+	RequestedItemID  uuid.UUID   `json:"requested_item_id"`
+	OfferedItemIDs   []uuid.UUID `json:"offered_item_ids"`
+	RequestedItemIDs []uuid.UUID `json:"requested_item_ids"`
 }
 
 type SwapRequestResponse struct {
@@ -35,6 +47,15 @@ type SwapRequestResponse struct {
 	RequestedItemID string `json:"requested_item_id"`
 	SenderID        string `json:"sender_id"`
 	RecipientID     string `json:"recipient_id"`
+
+	OfferedItemIDs   []string `json:"offered_item_ids"`
+	RequestedItemIDs []string `json:"requested_item_ids"`
+
+	SenderConfirmed    bool       `json:"sender_confirmed"`
+	RecipientConfirmed bool       `json:"recipient_confirmed"`
+	ThreadID           string     `json:"thread_id"`
+	ParentID           *string    `json:"parent_id,omitempty"`
+	ExpiresAt          *time.Time `json:"expires_at,omitempty"`
 }
 
 type SwapRequestSuccessResponse struct {
@@ -47,13 +68,6 @@ type SwapRequestListResponse struct {
 	SwapRequests []SwapRequestResponse `json:"swapRequests"`
 }
 
-var validStatuses = map[domain.SwapRequestStatus]bool{
-	domain.StatusPending:   true,
-	domain.StatusAccepted:  true,
-	domain.StatusRejected:  true,
-	domain.StatusCancelled: true,
-}
-
 func (handler *SwapRequestHandler) Create(context *gin.Context) {
 	var requestInput SwapRequestRequest
 
@@ -74,21 +88,19 @@ func (handler *SwapRequestHandler) Create(context *gin.Context) {
 	}
 
 	swapRequest := &domain.SwapRequest{
-		Status:          domain.StatusPending,
-		ReferenceNumber: referenceNumber,
-		OfferedItemID:   requestInput.OfferedItemID,
-		RequestedItemID: requestInput.RequestedItemID,
-		SenderID:        userID,
-		RecipientID:     requestInput.RecipientID,
+		Status:           domain.StatusPending,
+		ReferenceNumber:  referenceNumber,
+		OfferedItemID:    requestInput.OfferedItemID,
+		RequestedItemID:  requestInput.RequestedItemID,
+		OfferedItemIDs:   requestInput.OfferedItemIDs,
+		RequestedItemIDs: requestInput.RequestedItemIDs,
+		SenderID:         userID,
+		RecipientID:      requestInput.RecipientID,
 	}
 
 	err = handler.swapRequestService.Create(swapRequest)
 	if err != nil {
-		if errors.Is(err, services.ItemAlreadyOfferedErr) {
-			responses.Conflict(context, "Item is already offered in another swap request", err)
-			return
-		}
-		responses.InternalServerError(context, "Failed to create swap request", err)
+		respondWithSwapRequestError(context, "Failed to create swap request", err)
 		return
 	}
 
@@ -174,7 +186,7 @@ func (handler *SwapRequestHandler) Delete(context *gin.Context) {
 	}
 
 	if err = handler.swapRequestService.Delete(requestID); err != nil {
-		responses.InternalServerError(context, "Failed to delete request", err)
+		respondWithSwapRequestError(context, "Failed to delete request", err)
 		return
 	}
 
@@ -202,33 +214,111 @@ func (handler *SwapRequestHandler) UpdateStatus(context *gin.Context) {
 		return
 	}
 
-	if !validStatuses[body.Status] {
+	if !body.Status.IsValid() {
 		responses.BadRequest(context, "Invalid status value", nil)
 		return
 	}
 
-	swapRequest, err := handler.swapRequestService.FindByID(requestID)
+	if err = handler.swapRequestService.UpdateStatus(requestID, userID, body.Status); err != nil {
+		respondWithSwapRequestError(context, "Failed to update status", err)
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"message": "Status updated successfully!"})
+}
+
+func (handler *SwapRequestHandler) ConfirmHandover(context *gin.Context) {
+	userID, err := getUserIDFromContext(context)
 	if err != nil {
-		responses.NotFound(context, "Swap request not found", err)
+		responses.Unauthorized(context, "Unauthorized", nil)
 		return
 	}
 
-	if body.Status == domain.StatusCancelled && swapRequest.SenderID != userID {
-		responses.Unauthorized(context, "Only the sender can cancel this request", nil)
+	requestID, err := uuid.Parse(context.Param("id"))
+	if err != nil {
+		responses.BadRequest(context, "Invalid ID format", err)
 		return
 	}
 
-	if (body.Status == domain.StatusAccepted || body.Status == domain.StatusRejected) && swapRequest.RecipientID != userID {
-		responses.Unauthorized(context, "Only the recipient can accept or reject a request", nil)
+	swapRequest, err := handler.swapRequestService.ConfirmHandover(requestID, userID)
+	if err != nil {
+		respondWithSwapRequestError(context, "Failed to confirm handover", err)
 		return
 	}
 
-	if err = handler.swapRequestService.UpdateStatus(requestID, body.Status); err != nil {
-		responses.InternalServerError(context, "Failed to update status", err)
+	message := "Handover confirmed, waiting for the other party"
+	if swapRequest.Status == domain.StatusCompleted {
+		message = "Swap completed successfully!"
+	}
+
+	respondWithSwapRequest(context, http.StatusOK, message, swapRequest)
+}
+
+func (handler *SwapRequestHandler) CounterOffer(context *gin.Context) {
+	userID, err := getUserIDFromContext(context)
+	if err != nil {
+		responses.Unauthorized(context, "Unauthorized", nil)
 		return
 	}
 
-	context.JSON(http.StatusOK, gin.H{"message": "Status updated successfully!"})
+	requestID, err := uuid.Parse(context.Param("id"))
+	if err != nil {
+		responses.BadRequest(context, "Invalid ID format", err)
+		return
+	}
+
+	var requestInput CounterOfferRequest
+	if err = context.ShouldBindJSON(&requestInput); err != nil {
+		responses.BadRequest(context, "Invalid request body", err)
+		return
+	}
+
+	offeredItemIDs := appendItemID(requestInput.OfferedItemIDs, requestInput.OfferedItemID)
+	requestedItemIDs := appendItemID(requestInput.RequestedItemIDs, requestInput.RequestedItemID)
+
+	if len(offeredItemIDs) == 0 && len(requestedItemIDs) == 0 {
+		responses.BadRequest(context, "Counter offer must propose different items", nil)
+		return
+	}
+
+	referenceNumber, err := generateReferenceNumber()
+	if err != nil {
+		log.Printf("Failed to generate reference number for counter offer: %v", err)
+	}
+
+	counter, err := handler.swapRequestService.CounterOffer(requestID, userID, domain.CounterOffer{
+		ReferenceNumber:  referenceNumber,
+		OfferedItemIDs:   offeredItemIDs,
+		RequestedItemIDs: requestedItemIDs,
+	})
+	if err != nil {
+		respondWithSwapRequestError(context, "Failed to create counter offer", err)
+		return
+	}
+
+	respondWithSwapRequest(context, http.StatusCreated, "Counter offer created successfully!", counter)
+}
+
+func (handler *SwapRequestHandler) ListThread(context *gin.Context) {
+	userID, err := getUserIDFromContext(context)
+	if err != nil {
+		responses.Unauthorized(context, "Unauthorized", nil)
+		return
+	}
+
+	requestID, err := uuid.Parse(context.Param("id"))
+	if err != nil {
+		responses.BadRequest(context, "Invalid ID format", err)
+		return
+	}
+
+	thread, err := handler.swapRequestService.ListThread(requestID, userID)
+	if err != nil {
+		respondWithSwapRequestError(context, "Failed to fetch negotiation history", err)
+		return
+	}
+
+	respondWithSwapRequestList(context, http.StatusOK, "Negotiation history fetched successfully", thread)
 }
 
 func (handler *SwapRequestHandler) ListByUser(context *gin.Context) {
@@ -262,7 +352,7 @@ func (handler *SwapRequestHandler) ListByStatus(context *gin.Context) {
 
 	status := domain.SwapRequestStatus(statusParam)
 
-	if !validStatuses[status] {
+	if !status.IsValid() {
 		responses.BadRequest(context, "Invalid status parameter", nil)
 		return
 	}
@@ -293,36 +383,65 @@ func getUserIDFromContext(context *gin.Context) (uuid.UUID, error) {
 	return uuid.Parse(rawUserID.(string))
 }
 
+func respondWithSwapRequestError(context *gin.Context, message string, err error) {
+	switch {
+	case errors.Is(err, services.SwapRequestNotFoundErr):
+		responses.NotFound(context, "Swap request not found", err)
+	case errors.Is(err, services.OfferedItemNotFoundErr), errors.Is(err, services.RequestedItemNotFoundErr):
+		responses.NotFound(context, "Item not found", err)
+	case errors.Is(err, domain.EmailNotVerifiedErr):
+		responses.Forbidden(context, "Verify your email address before sending swap requests", err)
+	case errors.Is(err, services.OfferedItemNotOwnedErr):
+		responses.Forbidden(context, "You can only offer your own items", err)
+	case errors.Is(err, services.RequestedItemNotOwnedErr):
+		responses.UnprocessableEntity(context, "Requested item does not belong to the recipient", err)
+	case errors.Is(err, services.SelfSwapErr):
+		responses.BadRequest(context, "You cannot send a swap request to yourself", err)
+	case errors.Is(err, services.RequestedItemSwappedErr):
+		responses.Conflict(context, "Requested item is already part of an accepted swap", err)
+	case errors.Is(err, services.ItemAlreadyOfferedErr):
+		responses.Conflict(context, "Item is already offered in another swap request", err)
+	case errors.Is(err, domain.MissingSwapItemsErr):
+		responses.BadRequest(context, "Both sides of the swap must include at least one item", err)
+	case errors.Is(err, domain.TooManySwapItemsErr):
+		responses.BadRequest(context, fmt.Sprintf("A swap may include at most %d items per side", domain.MaxSwapBundleItems), err)
+	case errors.Is(err, domain.OverlappingSwapItemsErr):
+		responses.BadRequest(context, "An item cannot be both offered and requested", err)
+	case errors.Is(err, domain.CounterOfferUnchangedErr):
+		responses.BadRequest(context, "Counter offer must propose different items", err)
+	case errors.Is(err, domain.CounterOfferRequiredErr):
+		responses.BadRequest(context, "Use a counter offer to counter a swap request", err)
+	case errors.Is(err, domain.NotSwapRequestParticipantErr):
+		responses.Forbidden(context, "You are not part of this swap request", err)
+	case errors.Is(err, domain.TransitionNotPermittedErr):
+		responses.Forbidden(context, "You are not allowed to perform this status change", err)
+	case errors.Is(err, domain.InvalidStatusTransitionErr):
+		responses.Conflict(context, "Status change not allowed from the current status", err)
+	case errors.Is(err, domain.SwapRequestExpiredErr):
+		responses.Conflict(context, "This swap request has expired", err)
+	case errors.Is(err, services.SwapRequestChangedErr):
+		responses.Conflict(context, "Swap request was updated by someone else, try again", err)
+	case errors.Is(err, domain.HandoverAlreadyConfirmedErr):
+		responses.Conflict(context, "You have already confirmed this handover", err)
+	case errors.Is(err, domain.InvalidStatusErr):
+		responses.BadRequest(context, "Invalid status value", err)
+	default:
+		responses.InternalServerError(context, message, err)
+	}
+}
+
 func respondWithSwapRequest(context *gin.Context, status int, message string, swapRequest *domain.SwapRequest) {
-	response := SwapRequestSuccessResponse{
-		Message: message,
-		SwapRequest: &SwapRequestResponse{
-			ID:              swapRequest.ID.String(),
-			Status:          string(swapRequest.Status),
-			ReferenceNumber: swapRequest.ReferenceNumber,
-			OfferedItemID:   swapRequest.OfferedItemID.String(),
-			RequestedItemID: swapRequest.RequestedItemID.String(),
-			SenderID:        swapRequest.SenderID.String(),
-			RecipientID:     swapRequest.RecipientID.String(),
-		},
-	}
-
-	context.JSON(status, response)
+	context.JSON(status, SwapRequestSuccessResponse{
+		Message:     message,
+		SwapRequest: toSwapRequestResponse(swapRequest),
+	})
 }
 
 func respondWithSwapRequestList(context *gin.Context, status int, message string, swapRequests []domain.SwapRequest) {
 	var responseList []SwapRequestResponse
 
 	for _, request := range swapRequests {
-		responseList = append(responseList, SwapRequestResponse{
-			ID:              request.ID.String(),
-			Status:          string(request.Status),
-			ReferenceNumber: request.ReferenceNumber,
-			OfferedItemID:   request.OfferedItemID.String(),
-			RequestedItemID: request.RequestedItemID.String(),
-			SenderID:        request.SenderID.String(),
-			RecipientID:     request.RecipientID.String(),
-		})
+		responseList = append(responseList, *toSwapRequestResponse(&request))
 	}
 
 	context.JSON(status, SwapRequestListResponse{
@@ -331,6 +450,49 @@ func respondWithSwapRequestList(context *gin.Context, status int, message string
 	})
 }
 
+func toSwapRequestResponse(swapRequest *domain.SwapRequest) *SwapRequestResponse {
+	response := &SwapRequestResponse{
+		ID:                 swapRequest.ID.String(),
+		Status:             string(swapRequest.Status),
+		ReferenceNumber:    swapRequest.ReferenceNumber,
+		OfferedItemID:      swapRequest.OfferedItemID.String(),
+		RequestedItemID:    swapRequest.RequestedItemID.String(),
+		SenderID:           swapRequest.SenderID.String(),
+		RecipientID:        swapRequest.RecipientID.String(),
+		SenderConfirmed:    swapRequest.SenderConfirmed,
+		RecipientConfirmed: swapRequest.RecipientConfirmed,
+		ThreadID:           swapRequest.ThreadID.String(),
+		OfferedItemIDs:     itemIDStrings(swapRequest.AllOfferedItemIDs()),
+		RequestedItemIDs:   itemIDStrings(swapRequest.AllRequestedItemIDs()),
+		ExpiresAt:          swapRequest.ExpiresAt,
+	}
+
+	if swapRequest.ParentID != nil {
+		parentID := swapRequest.ParentID.String()
+		response.ParentID = &parentID
+	}
+
+	return response
+}
+
+// appendItemID adds a single item sent with the legacy field to a bundle, if set.
+func appendItemID(itemIDs []uuid.UUID, itemID uuid.UUID) []uuid.UUID {
+	if itemID == uuid.Nil {
+		return itemIDs
+	}
+
+	return append(itemIDs, itemID)
+}
+
+func itemIDStrings(itemIDs []uuid.UUID) []string {
+	result := make([]string, 0, len(itemIDs))
+	for _, itemID := range itemIDs {
+		result = append(result, itemID.String())
+	}
+
+	return result
+}
+
 func generateReferenceNumber() (string, error) {
 	bytes := make([]byte, 12)
 
@@ -46,6 +46,9 @@ func setupRouterAndHandler(mockService *mocks.SwapRequestService) *gin.Engine {
 	router.GET("/swap-requests/list-by-status/:status", handler.ListByStatus)
 	router.PATCH("/swap-requests/update-status/:id", handler.UpdateStatus)
 	router.DELETE("/swap-requests/delete/:id", handler.Delete)
+	router.POST("/swap-requests/:id/confirm", handler.ConfirmHandover)
+	router.POST("/swap-requests/:id/counter", handler.CounterOffer)
+	router.GET("/swap-requests/:id/thread", handler.ListThread)
 
 	return router
 }
@@ -134,6 +137,85 @@ func TestSwapRequestHandler_Create(t *testing.T) {
 		router.ServeHTTP(resp, req)
 		assert.Equal(t, http.StatusInternalServerError, resp.Code)
 	})
+
+	t.Run("validation errors", func(t *testing.T) {
+		cases := map[error]int{
+			services.SelfSwapErr:              http.StatusBadRequest,
+			services.OfferedItemNotFoundErr:   http.StatusNotFound,
+			services.RequestedItemNotFoundErr: http.StatusNotFound,
+			services.OfferedItemNotOwnedErr:   http.StatusForbidden,
+			services.RequestedItemNotOwnedErr: http.StatusUnprocessableEntity,
+			services.RequestedItemSwappedErr:  http.StatusConflict,
+			domain.EmailNotVerifiedErr:        http.StatusForbidden,
+		}
+
+		for serviceErr, expectedCode := range cases {
+			router, mockService := newTestRouter()
+
+			reqBody := handlers.SwapRequestRequest{
+				OfferedItemID:   testOfferedItemID,
+				RequestedItemID: testRequestedItemID,
+				RecipientID:     testRecipientID,
+			}
+			jsonBody, _ := json.Marshal(reqBody)
+
+			mockService.On("Create", mock.AnythingOfType("*domain.SwapRequest")).Return(serviceErr)
+
+			req := httptest.NewRequest(http.MethodPost, "/swap-requests/create", bytes.NewBuffer(jsonBody))
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+			assert.Equal(t, expectedCode, resp.Code, serviceErr.Error())
+		}
+	})
+
+	t.Run("bundle is passed to the service", func(t *testing.T) {
+		router, mockService := newTestRouter()
+
+		secondOfferedItemID := uuid.New()
+		reqBody := handlers.SwapRequestRequest{
+			OfferedItemIDs:   []uuid.UUID{testOfferedItemID, secondOfferedItemID},
+			RequestedItemIDs: []uuid.UUID{testRequestedItemID},
+			RecipientID:      testRecipientID,
+		}
+		jsonBody, _ := json.Marshal(reqBody)
+
+		mockService.On("Create", mock.MatchedBy(func(request *domain.SwapRequest) bool {
+			return len(request.OfferedItemIDs) == 2 && request.OfferedItemIDs[1] == secondOfferedItemID
+		})).Return(nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/swap-requests/create", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		resp := httptest.NewRecorder()
+
+		router.ServeHTTP(resp, req)
+		assert.Equal(t, http.StatusCreated, resp.Code)
+
+		var body handlers.SwapRequestSuccessResponse
+		assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &body))
+		assert.Len(t, body.SwapRequest.OfferedItemIDs, 2)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("invalid bundle", func(t *testing.T) {
+		router, mockService := newTestRouter()
+
+		reqBody := handlers.SwapRequestRequest{
+			OfferedItemID: testOfferedItemID,
+			RecipientID:   testRecipientID,
+		}
+		jsonBody, _ := json.Marshal(reqBody)
+
+		mockService.On("Create", mock.AnythingOfType("*domain.SwapRequest")).Return(domain.MissingSwapItemsErr)
+
+		req := httptest.NewRequest(http.MethodPost, "/swap-requests/create", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		resp := httptest.NewRecorder()
+
+		router.ServeHTTP(resp, req)
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+	})
 }
 
 func TestSwapRequestHandler_FindByID(t *testing.T) {
@@ -367,6 +449,20 @@ func TestSwapRequestHandler_Delete(t *testing.T) {
 		assert.Equal(t, http.StatusNotFound, resp.Code)
 	})
 
+	t.Run("already answered", func(t *testing.T) {
+		router, mockService := newTestRouter()
+
+		answered := &domain.SwapRequest{ID: testSwapRequestID, SenderID: testUserID, Status: domain.StatusAccepted}
+		mockService.On("FindByID", testSwapRequestID).Return(swapRequest, nil)
+		mockService.On("Delete", testSwapRequestID).Return(answered.Withdraw())
+
+		req := httptest.NewRequest(http.MethodDelete, "/swap-requests/delete/"+testSwapRequestID.String(), nil)
+		resp := httptest.NewRecorder()
+
+		router.ServeHTTP(resp, req)
+		assert.Equal(t, http.StatusConflict, resp.Code)
+	})
+
 	t.Run("delete error", func(t *testing.T) {
 		router, mockService := newTestRouter()
 
@@ -384,22 +480,8 @@ func TestSwapRequestHandler_Delete(t *testing.T) {
 func TestSwapRequestHandler_UpdateStatus(t *testing.T) {
 	swapID := uuid.New()
 
-	t.Run("success_cancel_by_sender", func(t *testing.T) {
-		router, mockService := newTestRouter()
-
-		swap := &domain.SwapRequest{
-			ID:              swapID,
-			SenderID:        testUserID,
-			RecipientID:     uuid.New(),
-			Status:          domain.StatusPending,
-			OfferedItemID:   uuid.New(),
-			RequestedItemID: uuid.New(),
-		}
-
-		mockService.On("FindByID", swapID).Return(swap, nil)
-		mockService.On("UpdateStatus", swapID, domain.StatusCancelled).Return(nil)
-
-		body := map[string]string{"status": "cancelled"}
+	performUpdateStatus := func(router *gin.Engine, status string) *httptest.ResponseRecorder {
+		body := map[string]string{"status": status}
 		jsonBody, _ := json.Marshal(body)
 
 		req := httptest.NewRequest(http.MethodPatch, "/swap-requests/update-status/"+swapID.String(), bytes.NewBuffer(jsonBody))
@@ -407,84 +489,93 @@ func TestSwapRequestHandler_UpdateStatus(t *testing.T) {
 		resp := httptest.NewRecorder()
 
 		router.ServeHTTP(resp, req)
+
+		return resp
+	}
+
+	t.Run("success_cancel_by_sender", func(t *testing.T) {
+		router, mockService := newTestRouter()
+
+		mockService.On("UpdateStatus", swapID, testUserID, domain.StatusCancelled).Return(nil)
+
+		resp := performUpdateStatus(router, "cancelled")
 		assert.Equal(t, http.StatusOK, resp.Code)
+		mockService.AssertExpectations(t)
 	})
 
 	t.Run("success_accept_by_recipient", func(t *testing.T) {
 		router, mockService := newTestRouter()
 
-		swap := &domain.SwapRequest{
-			ID:              swapID,
-			SenderID:        uuid.New(),
-			RecipientID:     testUserID,
-			Status:          domain.StatusPending,
-			OfferedItemID:   uuid.New(),
-			RequestedItemID: uuid.New(),
-		}
+		mockService.On("UpdateStatus", swapID, testUserID, domain.StatusAccepted).Return(nil)
 
-		mockService.On("FindByID", swapID).Return(swap, nil)
-		mockService.On("UpdateStatus", swapID, domain.StatusAccepted).Return(nil)
+		resp := performUpdateStatus(router, "accepted")
+		assert.Equal(t, http.StatusOK, resp.Code)
+		mockService.AssertExpectations(t)
+	})
 
-		body := map[string]string{"status": "accepted"}
-		jsonBody, _ := json.Marshal(body)
+	t.Run("success_reject_by_recipient", func(t *testing.T) {
+		router, mockService := newTestRouter()
 
-		req := httptest.NewRequest(http.MethodPatch, "/swap-requests/update-status/"+swapID.String(), bytes.NewBuffer(jsonBody))
-		req.Header.Set("Content-Type", "application/json")
-		resp := httptest.NewRecorder()
+		mockService.On("UpdateStatus", swapID, testUserID, domain.StatusRejected).Return(nil)
 
-		router.ServeHTTP(resp, req)
+		resp := performUpdateStatus(router, "rejected")
 		assert.Equal(t, http.StatusOK, resp.Code)
+		mockService.AssertExpectations(t)
 	})
 
-	t.Run("success_reject_by_recipient", func(t *testing.T) {
+	t.Run("unauthorized_user", func(t *testing.T) {
 		router, mockService := newTestRouter()
 
-		swap := &domain.SwapRequest{
-			ID:              swapID,
-			SenderID:        uuid.New(),
-			RecipientID:     testUserID,
-			Status:          domain.StatusPending,
-			OfferedItemID:   uuid.New(),
-			RequestedItemID: uuid.New(),
-		}
+		mockService.
+			On("UpdateStatus", swapID, testUserID, domain.StatusAccepted).
+			Return(domain.NotSwapRequestParticipantErr)
 
-		mockService.On("FindByID", swapID).Return(swap, nil)
-		mockService.On("UpdateStatus", swapID, domain.StatusRejected).Return(nil)
+		resp := performUpdateStatus(router, "accepted")
+		assert.Equal(t, http.StatusForbidden, resp.Code)
+	})
 
-		body := map[string]string{"status": "rejected"}
-		jsonBody, _ := json.Marshal(body)
+	t.Run("transition_not_permitted_for_party", func(t *testing.T) {
+		router, mockService := newTestRouter()
 
-		req := httptest.NewRequest(http.MethodPatch, "/swap-requests/update-status/"+swapID.String(), bytes.NewBuffer(jsonBody))
-		req.Header.Set("Content-Type", "application/json")
-		resp := httptest.NewRecorder()
+		mockService.
+			On("UpdateStatus", swapID, testUserID, domain.StatusAccepted).
+			Return(domain.ValidateTransition(domain.StatusPending, domain.StatusAccepted, domain.PartySender))
 
-		router.ServeHTTP(resp, req)
-		assert.Equal(t, http.StatusOK, resp.Code)
+		resp := performUpdateStatus(router, "accepted")
+		assert.Equal(t, http.StatusForbidden, resp.Code)
 	})
 
-	t.Run("unauthorized_user", func(t *testing.T) {
+	t.Run("transition_not_allowed_from_current_status", func(t *testing.T) {
 		router, mockService := newTestRouter()
 
-		swap := &domain.SwapRequest{
-			ID:              swapID,
-			SenderID:        uuid.New(),
-			RecipientID:     uuid.New(),
-			Status:          domain.StatusPending,
-			OfferedItemID:   uuid.New(),
-			RequestedItemID: uuid.New(),
-		}
+		mockService.
+			On("UpdateStatus", swapID, testUserID, domain.StatusPending).
+			Return(domain.ValidateTransition(domain.StatusAccepted, domain.StatusPending, domain.PartyRecipient))
 
-		mockService.On("FindByID", swapID).Return(swap, nil)
+		resp := performUpdateStatus(router, "pending")
+		assert.Equal(t, http.StatusConflict, resp.Code)
+	})
 
-		body := map[string]string{"status": "accepted"}
-		jsonBody, _ := json.Marshal(body)
+	t.Run("expired", func(t *testing.T) {
+		router, mockService := newTestRouter()
 
-		req := httptest.NewRequest(http.MethodPatch, "/swap-requests/update-status/"+swapID.String(), bytes.NewBuffer(jsonBody))
-		req.Header.Set("Content-Type", "application/json")
-		resp := httptest.NewRecorder()
+		mockService.
+			On("UpdateStatus", swapID, testUserID, domain.StatusAccepted).
+			Return(domain.SwapRequestExpiredErr)
 
-		router.ServeHTTP(resp, req)
-		assert.Equal(t, http.StatusUnauthorized, resp.Code)
+		resp := performUpdateStatus(router, "accepted")
+		assert.Equal(t, http.StatusConflict, resp.Code)
+	})
+
+	t.Run("not_found", func(t *testing.T) {
+		router, mockService := newTestRouter()
+
+		mockService.
+			On("UpdateStatus", swapID, testUserID, domain.StatusAccepted).
+			Return(services.SwapRequestNotFoundErr)
+
+		resp := performUpdateStatus(router, "accepted")
+		assert.Equal(t, http.StatusNotFound, resp.Code)
 	})
 
 	t.Run("invalid_JSON_payload", func(t *testing.T) {
@@ -498,56 +589,219 @@ func TestSwapRequestHandler_UpdateStatus(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, resp.Code)
 	})
 
-	t.Run("invalid_status_transition", func(t *testing.T) {
+	t.Run("invalid_status_value", func(t *testing.T) {
+		router, mockService := newTestRouter()
+
+		resp := performUpdateStatus(router, "not-a-valid-status")
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+		mockService.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("update_status_service_error", func(t *testing.T) {
+		router, mockService := newTestRouter()
+
+		mockService.
+			On("UpdateStatus", swapID, testUserID, domain.StatusAccepted).
+			Return(errors.New("DB error"))
+
+		resp := performUpdateStatus(router, "accepted")
+		assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	})
+}
+
+func TestSwapRequestHandler_ConfirmHandover(t *testing.T) {
+	swapID := uuid.New()
+
+	performConfirm := func(router *gin.Engine) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/swap-requests/"+swapID.String()+"/confirm", nil)
+		resp := httptest.NewRecorder()
+
+		router.ServeHTTP(resp, req)
+
+		return resp
+	}
+
+	t.Run("waiting_for_other_party", func(t *testing.T) {
 		router, mockService := newTestRouter()
 
-		swap := &domain.SwapRequest{
+		mockService.On("ConfirmHandover", swapID, testUserID).Return(&domain.SwapRequest{
 			ID:              swapID,
+			Status:          domain.StatusAccepted,
 			SenderID:        testUserID,
-			RecipientID:     uuid.New(),
-			Status:          domain.StatusPending,
-			OfferedItemID:   uuid.New(),
-			RequestedItemID: uuid.New(),
-		}
+			RecipientID:     testRecipientID,
+			SenderConfirmed: true,
+		}, nil)
 
-		mockService.On("FindByID", swapID).Return(swap, nil)
+		resp := performConfirm(router)
+		assert.Equal(t, http.StatusOK, resp.Code)
 
-		body := map[string]string{"status": "not-a-valid-status"}
-		jsonBody, _ := json.Marshal(body)
+		var body handlers.SwapRequestSuccessResponse
+		assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &body))
+		assert.True(t, body.SwapRequest.SenderConfirmed)
+		assert.Equal(t, string(domain.StatusAccepted), body.SwapRequest.Status)
+	})
 
-		req := httptest.NewRequest(http.MethodPatch, "/swap-requests/update-status/"+swapID.String(), bytes.NewBuffer(jsonBody))
+	t.Run("completed", func(t *testing.T) {
+		router, mockService := newTestRouter()
+
+		mockService.On("ConfirmHandover", swapID, testUserID).Return(&domain.SwapRequest{
+			ID:                 swapID,
+			Status:             domain.StatusCompleted,
+			SenderID:           testUserID,
+			RecipientID:        testRecipientID,
+			SenderConfirmed:    true,
+			RecipientConfirmed: true,
+		}, nil)
+
+		resp := performConfirm(router)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Contains(t, resp.Body.String(), "Swap completed successfully!")
+	})
+
+	t.Run("already_confirmed", func(t *testing.T) {
+		router, mockService := newTestRouter()
+
+		mockService.On("ConfirmHandover", swapID, testUserID).Return(nil, domain.HandoverAlreadyConfirmedErr)
+
+		resp := performConfirm(router)
+		assert.Equal(t, http.StatusConflict, resp.Code)
+	})
+
+	t.Run("not_accepted", func(t *testing.T) {
+		router, mockService := newTestRouter()
+
+		mockService.On("ConfirmHandover", swapID, testUserID).
+			Return(nil, (&domain.SwapRequest{Status: domain.StatusPending}).ConfirmHandover(domain.PartySender))
+
+		resp := performConfirm(router)
+		assert.Equal(t, http.StatusConflict, resp.Code)
+	})
+
+	t.Run("not_found", func(t *testing.T) {
+		router, mockService := newTestRouter()
+
+		mockService.On("ConfirmHandover", swapID, testUserID).Return(nil, services.SwapRequestNotFoundErr)
+
+		resp := performConfirm(router)
+		assert.Equal(t, http.StatusNotFound, resp.Code)
+	})
+}
+
+func TestSwapRequestHandler_CounterOffer(t *testing.T) {
+	swapID := uuid.New()
+
+	performCounter := func(router *gin.Engine, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/swap-requests/"+swapID.String()+"/counter", bytes.NewBufferString(body))
 		req.Header.Set("Content-Type", "application/json")
 		resp := httptest.NewRecorder()
 
 		router.ServeHTTP(resp, req)
+
+		return resp
+	}
+
+	t.Run("success", func(t *testing.T) {
+		router, mockService := newTestRouter()
+
+		mockService.On("CounterOffer", swapID, testUserID, mock.MatchedBy(func(offer domain.CounterOffer) bool {
+			return len(offer.RequestedItemIDs) == 1 && offer.RequestedItemIDs[0] == testRequestedItemID && offer.ReferenceNumber != ""
+		})).Return(&domain.SwapRequest{
+			ID:              uuid.New(),
+			Status:          domain.StatusPending,
+			SenderID:        testUserID,
+			RecipientID:     testRecipientID,
+			RequestedItemID: testRequestedItemID,
+			ThreadID:        swapID,
+			ParentID:        &swapID,
+		}, nil)
+
+		resp := performCounter(router, `{"requested_item_id":"`+testRequestedItemID.String()+`"}`)
+		assert.Equal(t, http.StatusCreated, resp.Code)
+
+		var body handlers.SwapRequestSuccessResponse
+		assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &body))
+		assert.Equal(t, swapID.String(), *body.SwapRequest.ParentID)
+		assert.Equal(t, swapID.String(), body.SwapRequest.ThreadID)
+	})
+
+	t.Run("no items proposed", func(t *testing.T) {
+		router, mockService := newTestRouter()
+
+		resp := performCounter(router, `{}`)
 		assert.Equal(t, http.StatusBadRequest, resp.Code)
+		mockService.AssertNotCalled(t, "CounterOffer", mock.Anything, mock.Anything, mock.Anything)
 	})
 
-	t.Run("update_status_service_error", func(t *testing.T) {
+	t.Run("email not verified", func(t *testing.T) {
 		router, mockService := newTestRouter()
 
-		swap := &domain.SwapRequest{
-			ID:              swapID,
-			SenderID:        uuid.New(),
-			RecipientID:     testUserID,
-			Status:          domain.StatusPending,
-			OfferedItemID:   uuid.New(),
-			RequestedItemID: uuid.New(),
-		}
+		mockService.On("CounterOffer", swapID, testUserID, mock.Anything).Return(nil, domain.EmailNotVerifiedErr)
 
-		mockService.On("FindByID", swapID).Return(swap, nil)
-		mockService.
-			On("UpdateStatus", swapID, domain.StatusAccepted).
-			Return(errors.New("DB error"))
+		resp := performCounter(router, `{"offered_item_id":"`+testOfferedItemID.String()+`"}`)
+		assert.Equal(t, http.StatusForbidden, resp.Code)
+	})
 
-		body := map[string]string{"status": "accepted"}
-		jsonBody, _ := json.Marshal(body)
+	t.Run("not the recipient", func(t *testing.T) {
+		router, mockService := newTestRouter()
 
-		req := httptest.NewRequest(http.MethodPatch, "/swap-requests/update-status/"+swapID.String(), bytes.NewBuffer(jsonBody))
-		req.Header.Set("Content-Type", "application/json")
+		mockService.On("CounterOffer", swapID, testUserID, mock.Anything).
+			Return(nil, domain.ValidateTransition(domain.StatusPending, domain.StatusCountered, domain.PartySender))
+
+		resp := performCounter(router, `{"offered_item_id":"`+testOfferedItemID.String()+`"}`)
+		assert.Equal(t, http.StatusForbidden, resp.Code)
+	})
+
+	t.Run("already answered", func(t *testing.T) {
+		router, mockService := newTestRouter()
+
+		mockService.On("CounterOffer", swapID, testUserID, mock.Anything).
+			Return(nil, domain.ValidateTransition(domain.StatusRejected, domain.StatusCountered, domain.PartyRecipient))
+
+		resp := performCounter(router, `{"offered_item_id":"`+testOfferedItemID.String()+`"}`)
+		assert.Equal(t, http.StatusConflict, resp.Code)
+	})
+
+	t.Run("requested item not owned", func(t *testing.T) {
+		router, mockService := newTestRouter()
+
+		mockService.On("CounterOffer", swapID, testUserID, mock.Anything).Return(nil, services.RequestedItemNotOwnedErr)
+
+		resp := performCounter(router, `{"requested_item_id":"`+testRequestedItemID.String()+`"}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+	})
+}
+
+func TestSwapRequestHandler_ListThread(t *testing.T) {
+	swapID := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		router, mockService := newTestRouter()
+
+		mockService.On("ListThread", swapID, testUserID).Return([]domain.SwapRequest{
+			{ID: swapID, Status: domain.StatusCountered, ThreadID: swapID},
+			{ID: uuid.New(), Status: domain.StatusPending, ThreadID: swapID, ParentID: &swapID},
+		}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/swap-requests/"+swapID.String()+"/thread", nil)
 		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusOK, resp.Code)
+
+		var body handlers.SwapRequestListResponse
+		assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &body))
+		assert.Len(t, body.SwapRequests, 2)
+	})
+
+	t.Run("not a participant", func(t *testing.T) {
+		router, mockService := newTestRouter()
+
+		mockService.On("ListThread", swapID, testUserID).Return(nil, domain.NotSwapRequestParticipantErr)
 
+		req := httptest.NewRequest(http.MethodGet, "/swap-requests/"+swapID.String()+"/thread", nil)
+		resp := httptest.NewRecorder()
 		router.ServeHTTP(resp, req)
-		assert.Equal(t, http.StatusInternalServerError, resp.Code)
+
+		assert.Equal(t, http.StatusForbidden, resp.Code)
 	})
 }
@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"swapp-go/cmd/internal/adapters/handlers/responses"
+	"swapp-go/cmd/internal/application/services"
+)
+
+type TwoFactorHandler struct {
+	twoFactorService services.TwoFactorServiceInterface
+}
+
+func NewTwoFactorHandler(twoFactorServiceInterface services.TwoFactorServiceInterface) *TwoFactorHandler {
+	return &TwoFactorHandler{twoFactorService: twoFactorServiceInterface}
+}
+
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type TwoFactorEnrollmentResponse struct {
+	Message         string `json:"message"`
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+type RecoveryCodesResponse struct {
+	Message       string   `json:"message"`
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+func (handler *TwoFactorHandler) Enroll(context *gin.Context) {
+	userID, err := getUserIDFromContext(context)
+	if err != nil {
+		responses.Unauthorized(context, "Unauthorized", err)
+		return
+	}
+
+	enrollment, err := handler.twoFactorService.Enroll(userID)
+	if err != nil {
+		respondWithTwoFactorError(context, "Failed to set up two-factor authentication", err)
+		return
+	}
+
+	context.JSON(http.StatusOK, TwoFactorEnrollmentResponse{
+		Message:         "Scan the provisioning URI and confirm with a code to enable two-factor authentication",
+		Secret:          enrollment.Secret,
+		ProvisioningURI: enrollment.ProvisioningURI,
+	})
+}
+
+func (handler *TwoFactorHandler) Confirm(context *gin.Context) {
+	userID, request, ok := bindTwoFactorCodeRequest(context)
+	if !ok {
+		return
+	}
+
+	codes, err := handler.twoFactorService.Confirm(userID, request.Code, context.ClientIP())
+	if err != nil {
+		respondWithTwoFactorError(context, "Failed to enable two-factor authentication", err)
+		return
+	}
+
+	context.JSON(http.StatusOK, RecoveryCodesResponse{
+		Message:       "Two-factor authentication enabled successfully!",
+		RecoveryCodes: codes,
+	})
+}
+
+func (handler *TwoFactorHandler) Disable(context *gin.Context) {
+	userID, request, ok := bindTwoFactorCodeRequest(context)
+	if !ok {
+		return
+	}
+
+	if err := handler.twoFactorService.Disable(userID, request.Code, context.ClientIP()); err != nil {
+		respondWithTwoFactorError(context, "Failed to disable two-factor authentication", err)
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"message": "Two-factor authentication disabled successfully!"})
+}
+
+func (handler *TwoFactorHandler) RegenerateRecoveryCodes(context *gin.Context) {
+	userID, request, ok := bindTwoFactorCodeRequest(context)
+	if !ok {
+		return
+	}
+
+	codes, err := handler.twoFactorService.RegenerateRecoveryCodes(userID, request.Code, context.ClientIP())
+	if err != nil {
+		respondWithTwoFactorError(context, "Failed to regenerate recovery codes", err)
+		return
+	}
+
+	context.JSON(http.StatusOK, RecoveryCodesResponse{
+		Message:       "Recovery codes regenerated successfully!",
+		RecoveryCodes: codes,
+	})
+}
+
+// bindTwoFactorCodeRequest reads the current user and the code they entered.
+func bindTwoFactorCodeRequest(context *gin.Context) (uuid.UUID, *TwoFactorCodeRequest, bool) {
+	userID, err := getUserIDFromContext(context)
+	if err != nil {
+		responses.Unauthorized(context, "Unauthorized", err)
+		return uuid.Nil, nil, false
+	}
+
+	var request TwoFactorCodeRequest
+	if err = context.ShouldBindJSON(&request); err != nil {
+		responses.BadRequest(context, "Invalid request", err)
+		return uuid.Nil, nil, false
+	}
+
+	return userID, &request, true
+}
+
+func respondWithTwoFactorError(context *gin.Context, message string, err error) {
+	var throttledErr *services.LoginThrottledError
+
+	switch {
+	case errors.As(err, &throttledErr):
+		respondThrottled(context, throttledErr, "Too many invalid codes")
+	case errors.Is(err, services.TwoFactorNotEnrolledErr):
+		responses.Conflict(context, "Two-factor authentication is not set up", err)
+	case errors.Is(err, services.TwoFactorAlreadyEnabledErr):
+		responses.Conflict(context, "Two-factor authentication is already enabled", err)
+	case errors.Is(err, services.InvalidTwoFactorCodeErr):
+		responses.BadRequest(context, "Invalid code", err)
+	default:
+		responses.InternalServerError(context, message, err)
+	}
+}
@@ -0,0 +1,147 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"swapp-go/cmd/internal/adapters/handlers"
+	"swapp-go/cmd/internal/adapters/handlers/mocks"
+	"swapp-go/cmd/internal/application/services"
+	"swapp-go/cmd/internal/domain"
+	"testing"
+	"time"
+)
+
+var twoFactorUserID = uuid.New()
+
+func setupTwoFactorRouter(t *testing.T) (*mocks.MockTwoFactorService, *gin.Engine) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	mockService := new(mocks.MockTwoFactorService)
+	handler := handlers.NewTwoFactorHandler(mockService)
+
+	authenticated := func(context *gin.Context) {
+		context.Set("userID", twoFactorUserID.String())
+	}
+
+	router := gin.New()
+	router.POST("/users/two-factor/enroll", authenticated, handler.Enroll)
+	router.POST("/users/two-factor/confirm", authenticated, handler.Confirm)
+	router.POST("/users/two-factor/disable", authenticated, handler.Disable)
+	router.POST("/users/two-factor/recovery-codes", authenticated, handler.RegenerateRecoveryCodes)
+
+	return mockService, router
+}
+
+func TestTwoFactorHandler(t *testing.T) {
+	t.Run("Enroll", func(t *testing.T) {
+		t.Run("success", func(t *testing.T) {
+			mockService, router := setupTwoFactorRouter(t)
+
+			mockService.On("Enroll", twoFactorUserID).Return(&domain.TwoFactorEnrollment{
+				Secret:          "JBSWY3DPEHPK3PXP",
+				ProvisioningURI: "otpauth://totp/Swapp:user?secret=JBSWY3DPEHPK3PXP",
+			}, nil)
+
+			response := performRequest(t, router, http.MethodPost, "/users/two-factor/enroll", nil)
+			assert.Equal(t, http.StatusOK, response.Code)
+
+			var parsed handlers.TwoFactorEnrollmentResponse
+			assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &parsed))
+			assert.Equal(t, "JBSWY3DPEHPK3PXP", parsed.Secret)
+			assert.Equal(t, "otpauth://totp/Swapp:user?secret=JBSWY3DPEHPK3PXP", parsed.ProvisioningURI)
+		})
+
+		t.Run("already_enabled", func(t *testing.T) {
+			mockService, router := setupTwoFactorRouter(t)
+
+			mockService.On("Enroll", twoFactorUserID).Return(nil, services.TwoFactorAlreadyEnabledErr)
+
+			response := performRequest(t, router, http.MethodPost, "/users/two-factor/enroll", nil)
+			assert.Equal(t, http.StatusConflict, response.Code)
+		})
+	})
+
+	t.Run("Confirm", func(t *testing.T) {
+		t.Run("success", func(t *testing.T) {
+			mockService, router := setupTwoFactorRouter(t)
+
+			mockService.On("Confirm", twoFactorUserID, "123456", mock.AnythingOfType("string")).Return([]string{"AAAA-BBBB-CCCC-DDDD"}, nil)
+
+			response := performRequest(t, router, http.MethodPost, "/users/two-factor/confirm", map[string]string{"code": "123456"})
+			assert.Equal(t, http.StatusOK, response.Code)
+
+			var parsed handlers.RecoveryCodesResponse
+			assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &parsed))
+			assert.Equal(t, []string{"AAAA-BBBB-CCCC-DDDD"}, parsed.RecoveryCodes)
+		})
+
+		t.Run("errors", func(t *testing.T) {
+			tests := []struct {
+				err    error
+				status int
+			}{
+				{services.InvalidTwoFactorCodeErr, http.StatusBadRequest},
+				{services.TwoFactorNotEnrolledErr, http.StatusConflict},
+				{services.TwoFactorAlreadyEnabledErr, http.StatusConflict},
+				{&services.LoginThrottledError{RetryAt: time.Now().Add(time.Minute)}, http.StatusTooManyRequests},
+				{errors.New("db down"), http.StatusInternalServerError},
+			}
+
+			for _, test := range tests {
+				mockService, router := setupTwoFactorRouter(t)
+
+				mockService.On("Confirm", twoFactorUserID, "123456", mock.AnythingOfType("string")).Return(nil, test.err)
+
+				response := performRequest(t, router, http.MethodPost, "/users/two-factor/confirm", map[string]string{"code": "123456"})
+				assert.Equal(t, test.status, response.Code, test.err.Error())
+			}
+		})
+
+		t.Run("missing_code", func(t *testing.T) {
+			_, router := setupTwoFactorRouter(t)
+
+			response := performRequest(t, router, http.MethodPost, "/users/two-factor/confirm", map[string]string{})
+			assert.Equal(t, http.StatusBadRequest, response.Code)
+		})
+	})
+
+	t.Run("Disable", func(t *testing.T) {
+		t.Run("success", func(t *testing.T) {
+			mockService, router := setupTwoFactorRouter(t)
+
+			mockService.On("Disable", twoFactorUserID, "123456", mock.AnythingOfType("string")).Return(nil)
+
+			response := performRequest(t, router, http.MethodPost, "/users/two-factor/disable", map[string]string{"code": "123456"})
+			assert.Equal(t, http.StatusOK, response.Code)
+			mockService.AssertExpectations(t)
+		})
+
+		t.Run("invalid_code", func(t *testing.T) {
+			mockService, router := setupTwoFactorRouter(t)
+
+			mockService.On("Disable", twoFactorUserID, "000000", mock.AnythingOfType("string")).Return(services.InvalidTwoFactorCodeErr)
+
+			response := performRequest(t, router, http.MethodPost, "/users/two-factor/disable", map[string]string{"code": "000000"})
+			assert.Equal(t, http.StatusBadRequest, response.Code)
+		})
+	})
+
+	t.Run("RegenerateRecoveryCodes", func(t *testing.T) {
+		mockService, router := setupTwoFactorRouter(t)
+
+		mockService.On("RegenerateRecoveryCodes", twoFactorUserID, "123456", mock.AnythingOfType("string")).Return([]string{"EEEE-FFFF-GGGG-HHHH"}, nil)
+
+		response := performRequest(t, router, http.MethodPost, "/users/two-factor/recovery-codes", map[string]string{"code": "123456"})
+		assert.Equal(t, http.StatusOK, response.Code)
+
+		var parsed handlers.RecoveryCodesResponse
+		assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &parsed))
+		assert.Equal(t, []string{"EEEE-FFFF-GGGG-HHHH"}, parsed.RecoveryCodes)
+	})
+}
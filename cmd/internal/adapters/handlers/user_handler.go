@@ -1,21 +1,39 @@
 package handlers
 
 import (
+	"errors"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/nyaruka/phonenumbers"
+	"log"
+	"math"
 	"net/http"
+	"strconv"
 	"swapp-go/cmd/internal/adapters/handlers/responses"
 	"swapp-go/cmd/internal/application/services"
 	"swapp-go/cmd/internal/domain"
+	"time"
 )
 
 type UserHandler struct {
-	userService services.UserServiceInterface
+	userService         services.UserServiceInterface
+	sessionService      services.SessionServiceInterface
+	verificationService services.EmailVerificationServiceInterface
+	twoFactorService    services.TwoFactorServiceInterface
 }
 
-func NewUserHandler(userServiceInterface services.UserServiceInterface) *UserHandler {
-	return &UserHandler{userServiceInterface}
+func NewUserHandler(
+	userServiceInterface services.UserServiceInterface,
+	sessionServiceInterface services.SessionServiceInterface,
+	verificationServiceInterface services.EmailVerificationServiceInterface,
+	twoFactorServiceInterface services.TwoFactorServiceInterface,
+) *UserHandler {
+	return &UserHandler{
+		userService:         userServiceInterface,
+		sessionService:      sessionServiceInterface,
+		verificationService: verificationServiceInterface,
+		twoFactorService:    twoFactorServiceInterface,
+	}
 }
 
 type RegisterUserRequest struct {
@@ -31,6 +49,23 @@ type LoginUserRequest struct {
 	Password string `json:"password" binding:"required"`
 }
 
+type TwoFactorLoginRequest struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+	Code           string `json:"code" binding:"required"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required"`
+}
+
+// WeakPasswordResponse tells which rules of the password policy a chosen
+// password breaks.
+type WeakPasswordResponse struct {
+	Error      string   `json:"error"`
+	Violations []string `json:"violations"`
+}
+
 type UpdateUserRequest struct {
 	Username *string `json:"username,omitempty"`
 	Email    *string `json:"email,omitempty" binding:"omitempty,email"`
@@ -38,12 +73,17 @@ type UpdateUserRequest struct {
 	Address  *string `json:"address,omitempty"`
 }
 
+type VerifyEmailRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
 type UserResponse struct {
-	UserID   string  `json:"user_id"`
-	Username string  `json:"username"`
-	Email    string  `json:"email"`
-	Phone    *string `json:"phone,omitempty"`
-	Address  *string `json:"address,omitempty"`
+	UserID        string  `json:"user_id"`
+	Username      string  `json:"username"`
+	Email         string  `json:"email"`
+	EmailVerified bool    `json:"email_verified"`
+	Phone         *string `json:"phone,omitempty"`
+	Address       *string `json:"address,omitempty"`
 }
 
 type UserSuccessResponse struct {
@@ -52,12 +92,22 @@ type UserSuccessResponse struct {
 }
 
 type LoginUserResponse struct {
-	UserID   string  `json:"user_id"`
-	Username string  `json:"username"`
-	Email    string  `json:"email"`
-	Phone    *string `json:"phone"`
-	Address  *string `json:"address"`
-	Token    string  `json:"token"`
+	UserID        string  `json:"user_id"`
+	Username      string  `json:"username"`
+	Email         string  `json:"email"`
+	EmailVerified bool    `json:"email_verified"`
+	Phone         *string `json:"phone"`
+	Address       *string `json:"address"`
+	Role          string  `json:"role"`
+	AuthTokensResponse
+}
+
+// LoginChallengeResponse is returned by a login with a correct password when
+// the user still has to provide a second factor.
+type LoginChallengeResponse struct {
+	TwoFactorRequired  bool      `json:"two_factor_required"`
+	ChallengeToken     string    `json:"challenge_token"`
+	ChallengeExpiresAt time.Time `json:"challenge_expires_at"`
 }
 
 func (handler *UserHandler) RegisterUser(context *gin.Context) {
@@ -77,10 +127,15 @@ func (handler *UserHandler) RegisterUser(context *gin.Context) {
 	}
 
 	if err := handler.userService.RegisterUser(user); err != nil {
+		if respondWithWeakPassword(context, err) {
+			return
+		}
 		responses.BadRequest(context, "Invalid request", err)
 		return
 	}
 
+	handler.sendVerification(user)
+
 	respondWithUser(context, http.StatusCreated, "User created successfully!", user)
 }
 
@@ -129,9 +184,50 @@ func (handler *UserHandler) Update(context *gin.Context) {
 		return
 	}
 
+	if request.Email != nil && !updatedUser.IsEmailVerified() {
+		handler.sendVerification(updatedUser)
+	}
+
 	respondWithUser(context, http.StatusOK, "User updated successfully!", updatedUser)
 }
 
+// ChangePassword sets a new password for the signed-in user and signs out
+// their other sessions.
+func (handler *UserHandler) ChangePassword(context *gin.Context) {
+	userID, sessionID, ok := currentSession(context)
+	if !ok {
+		return
+	}
+
+	var request ChangePasswordRequest
+	if err := context.ShouldBindJSON(&request); err != nil {
+		responses.BadRequest(context, "Invalid request", err)
+		return
+	}
+
+	err := handler.userService.ChangePassword(userID, sessionID, request.CurrentPassword, request.NewPassword, context.ClientIP())
+	if respondWithWeakPassword(context, err) {
+		return
+	}
+	if err != nil {
+		var throttledErr *services.LoginThrottledError
+
+		switch {
+		case errors.As(err, &throttledErr):
+			respondThrottled(context, throttledErr, "Too many failed password checks")
+		case errors.Is(err, services.IncorrectPasswordErr):
+			responses.Forbidden(context, "Current password is incorrect", err)
+		case errors.Is(err, services.PasswordUnchangedErr):
+			responses.BadRequest(context, "New password must differ from the current one", err)
+		default:
+			responses.InternalServerError(context, "Failed to change password", err)
+		}
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"message": "Password changed successfully!"})
+}
+
 func (handler *UserHandler) Delete(context *gin.Context) {
 	userID := context.GetString("userID")
 
@@ -164,15 +260,7 @@ func (handler *UserHandler) FindByID(context *gin.Context) {
 		return
 	}
 
-	response := &UserResponse{
-		UserID:   user.ID.String(),
-		Username: user.Username,
-		Email:    user.Email,
-		Phone:    user.Phone,
-		Address:  user.Address,
-	}
-
-	context.JSON(http.StatusOK, response)
+	context.JSON(http.StatusOK, toUserResponse(user))
 }
 
 func (handler *UserHandler) LoginUser(context *gin.Context) {
@@ -183,35 +271,179 @@ func (handler *UserHandler) LoginUser(context *gin.Context) {
 		return
 	}
 
-	token, user, err := handler.userService.Authenticate(request.Username, request.Password)
+	user, err := handler.userService.Authenticate(request.Username, request.Password, context.ClientIP())
 	if err != nil {
-		responses.Unauthorized(context, "Unauthorized", err)
+		respondWithLoginError(context, err)
+		return
+	}
+
+	challenge, err := handler.twoFactorService.Challenge(user)
+	if err != nil {
+		responses.InternalServerError(context, "Failed to log in", err)
+		return
+	}
+
+	if challenge != nil {
+		context.JSON(http.StatusOK, LoginChallengeResponse{
+			TwoFactorRequired:  true,
+			ChallengeToken:     challenge.Token,
+			ChallengeExpiresAt: challenge.ExpiresAt,
+		})
+		return
+	}
+
+	handler.startSession(context, user)
+}
+
+func (handler *UserHandler) LoginWithTwoFactor(context *gin.Context) {
+	var request TwoFactorLoginRequest
+
+	if err := context.ShouldBindJSON(&request); err != nil {
+		responses.BadRequest(context, "Invalid request", err)
+		return
+	}
+
+	user, err := handler.twoFactorService.CompleteLogin(request.ChallengeToken, request.Code, context.ClientIP())
+	if err != nil {
+		respondWithLoginError(context, err)
+		return
+	}
+
+	handler.startSession(context, user)
+}
+
+func (handler *UserHandler) startSession(context *gin.Context, user *domain.User) {
+	tokens, err := handler.sessionService.Start(user, sessionClient(context))
+	if errors.Is(err, domain.AccountSuspendedErr) {
+		responses.Forbidden(context, "Your account is suspended", err)
+		return
+	}
+	if err != nil {
+		responses.InternalServerError(context, "Failed to start session", err)
 		return
 	}
 
 	response := &LoginUserResponse{
-		UserID:   user.ID.String(),
-		Username: user.Username,
-		Email:    user.Email,
-		Phone:    user.Phone,
-		Address:  user.Address,
-		Token:    token,
+		UserID:             user.ID.String(),
+		Username:           user.Username,
+		Email:              user.Email,
+		EmailVerified:      user.IsEmailVerified(),
+		Phone:              user.Phone,
+		Address:            user.Address,
+		Role:               string(user.Role),
+		AuthTokensResponse: toAuthTokensResponse(tokens),
 	}
 
 	context.JSON(http.StatusOK, response)
 }
 
+func (handler *UserHandler) VerifyEmail(context *gin.Context) {
+	var request VerifyEmailRequest
+	if err := context.ShouldBindJSON(&request); err != nil {
+		responses.BadRequest(context, "Invalid request", err)
+		return
+	}
+
+	user, err := handler.verificationService.Verify(request.Token)
+	if err != nil {
+		if errors.Is(err, services.InvalidVerificationTokenErr) {
+			responses.BadRequest(context, "Invalid or expired verification token", err)
+			return
+		}
+		responses.InternalServerError(context, "Failed to verify email address", err)
+		return
+	}
+
+	respondWithUser(context, http.StatusOK, "Email address verified successfully!", user)
+}
+
+func (handler *UserHandler) ResendVerification(context *gin.Context) {
+	userID, err := getUserIDFromContext(context)
+	if err != nil {
+		responses.Unauthorized(context, "Unauthorized", err)
+		return
+	}
+
+	if err = handler.verificationService.Resend(userID); err != nil {
+		switch {
+		case errors.Is(err, services.EmailAlreadyVerifiedErr):
+			responses.Conflict(context, "Email address is already verified", err)
+		case errors.Is(err, services.VerificationThrottledErr):
+			responses.TooManyRequests(context, "Please wait before requesting another verification email", err)
+		default:
+			responses.InternalServerError(context, "Failed to send verification email", err)
+		}
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"message": "Verification email sent"})
+}
+
+// sendVerification mails a verification link without failing the request; the
+// user can ask for a new link if this one does not arrive.
+func (handler *UserHandler) sendVerification(user *domain.User) {
+	if err := handler.verificationService.SendVerification(user); err != nil {
+		log.Printf("Failed to send verification email to user %s: %v", user.ID, err)
+	}
+}
+
+// respondWithLoginError tells throttled clients when to come back; every
+// rejected credential gets the same answer.
+func respondWithLoginError(context *gin.Context, err error) {
+	var throttledErr *services.LoginThrottledError
+
+	switch {
+	case errors.As(err, &throttledErr):
+		respondThrottled(context, throttledErr, "Too many failed login attempts")
+	case errors.Is(err, services.InvalidCredentialsErr),
+		errors.Is(err, services.InvalidLoginChallengeErr),
+		errors.Is(err, services.InvalidTwoFactorCodeErr):
+		responses.Unauthorized(context, "Unauthorized", err)
+	case errors.Is(err, domain.AccountSuspendedErr):
+		responses.Forbidden(context, "Your account is suspended", err)
+	default:
+		responses.InternalServerError(context, "Failed to log in", err)
+	}
+}
+
+func respondThrottled(context *gin.Context, err *services.LoginThrottledError, message string) {
+	retryAfter := int(math.Ceil(time.Until(err.RetryAt).Seconds()))
+	context.Header("Retry-After", strconv.Itoa(max(retryAfter, 1)))
+	responses.TooManyRequests(context, message, err)
+}
+
+// respondWithWeakPassword answers with the broken password rules if err is a
+// password policy error, and reports whether it did.
+func respondWithWeakPassword(context *gin.Context, err error) bool {
+	var policyErr *domain.PasswordPolicyError
+	if !errors.As(err, &policyErr) {
+		return false
+	}
+
+	context.JSON(http.StatusBadRequest, WeakPasswordResponse{
+		Error:      "Password does not meet the password policy",
+		Violations: policyErr.Violations,
+	})
+
+	return true
+}
+
 func respondWithUser(context *gin.Context, status int, message string, user *domain.User) {
 	response := UserSuccessResponse{
 		Message: message,
-		User: &UserResponse{
-			UserID:   user.ID.String(),
-			Username: user.Username,
-			Email:    user.Email,
-			Phone:    user.Phone,
-			Address:  user.Address,
-		},
+		User:    toUserResponse(user),
 	}
 
 	context.JSON(status, response)
 }
+
+func toUserResponse(user *domain.User) *UserResponse {
+	return &UserResponse{
+		UserID:        user.ID.String(),
+		Username:      user.Username,
+		Email:         user.Email,
+		EmailVerified: user.IsEmailVerified(),
+		Phone:         user.Phone,
+		Address:       user.Address,
+	}
+}
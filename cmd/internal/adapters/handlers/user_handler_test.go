@@ -12,9 +12,11 @@ import (
 	"net/http/httptest"
 	"swapp-go/cmd/internal/adapters/handlers"
 	"swapp-go/cmd/internal/adapters/handlers/mocks"
+	"swapp-go/cmd/internal/application/services"
 	"swapp-go/cmd/internal/domain"
 	"swapp-go/cmd/internal/validators"
 	"testing"
+	"time"
 )
 
 type mapStrStr map[string]string
@@ -42,12 +44,13 @@ const (
 )
 
 var (
-	username  = "test_user"
-	email     = "test@example.com"
-	password  = "password123"
-	phone     = "+447712345678"
-	address   = "1, Main Street"
-	testToken = "test-token"
+	username         = "test_user"
+	email            = "test@example.com"
+	password         = "password123"
+	phone            = "+447712345678"
+	address          = "1, Main Street"
+	testToken        = "test-token"
+	testRefreshToken = "test-refresh-token"
 
 	domainUser = domain.User{
 		Username: username,
@@ -76,6 +79,8 @@ var (
 	}
 )
 
+var currentPasswordSessionID = uuid.New()
+
 // Test Helpers
 func setupRouter(handler *handlers.UserHandler) *gin.Engine {
 	gin.SetMode(gin.TestMode)
@@ -85,6 +90,12 @@ func setupRouter(handler *handlers.UserHandler) *gin.Engine {
 	router := gin.Default()
 	router.POST("/users/register", handler.RegisterUser)
 	router.POST("/users/login", handler.LoginUser)
+	router.POST("/users/login/two-factor", handler.LoginWithTwoFactor)
+	router.POST("/users/verify-email", handler.VerifyEmail)
+	router.POST("/users/verify-email/resend", func(context *gin.Context) {
+		context.Set("userID", uuid.Nil.String())
+		handler.ResendVerification(context)
+	})
 	router.PATCH("/users/update", func(context *gin.Context) {
 		context.Set("userID", uuid.Nil.String())
 		handler.Update(context)
@@ -93,6 +104,11 @@ func setupRouter(handler *handlers.UserHandler) *gin.Engine {
 		context.Set("userID", uuid.Nil.String())
 		handler.Delete(context)
 	})
+	router.POST("/users/password", func(context *gin.Context) {
+		context.Set("userID", uuid.Nil.String())
+		context.Set("sessionID", currentPasswordSessionID.String())
+		handler.ChangePassword(context)
+	})
 
 	return router
 }
@@ -100,11 +116,45 @@ func setupRouter(handler *handlers.UserHandler) *gin.Engine {
 func setupTest(t *testing.T) (*mocks.MockUserService, *gin.Engine) {
 	t.Helper()
 
+	mockService, _, router := setupTestWithSessions(t)
+
+	return mockService, router
+}
+
+func setupTestWithSessions(t *testing.T) (*mocks.MockUserService, *mocks.MockSessionService, *gin.Engine) {
+	t.Helper()
+
+	mockService, mockSessionService, _, mockTwoFactorService, router := setupUserHandlerTest(t)
+	mockTwoFactorService.On("Challenge", mock.Anything).Return(nil, nil).Maybe()
+
+	return mockService, mockSessionService, router
+}
+
+func setupTestWithVerification(t *testing.T) (*mocks.MockUserService, *mocks.MockEmailVerificationService, *gin.Engine) {
+	t.Helper()
+
+	mockService, _, mockVerificationService, _, router := setupUserHandlerTest(t)
+
+	return mockService, mockVerificationService, router
+}
+
+func setupUserHandlerTest(t *testing.T) (
+	*mocks.MockUserService,
+	*mocks.MockSessionService,
+	*mocks.MockEmailVerificationService,
+	*mocks.MockTwoFactorService,
+	*gin.Engine,
+) {
+	t.Helper()
+
 	mockService := new(mocks.MockUserService)
-	handler := handlers.NewUserHandler(mockService)
+	mockSessionService := new(mocks.MockSessionService)
+	mockVerificationService := new(mocks.MockEmailVerificationService)
+	mockTwoFactorService := new(mocks.MockTwoFactorService)
+	handler := handlers.NewUserHandler(mockService, mockSessionService, mockVerificationService, mockTwoFactorService)
 	router := setupRouter(handler)
 
-	return mockService, router
+	return mockService, mockSessionService, mockVerificationService, mockTwoFactorService, router
 }
 
 func performRequest(t *testing.T, router *gin.Engine, method, url string, body interface{}) *httptest.ResponseRecorder {
@@ -164,11 +214,14 @@ func parseLoginResponse(t *testing.T, response *httptest.ResponseRecorder) *hand
 func TestUserHandler(t *testing.T) {
 	t.Run("RegisterUser", func(t *testing.T) {
 		t.Run("success", func(t *testing.T) {
-			mockService, router := setupTest(t)
+			mockService, mockVerificationService, router := setupTestWithVerification(t)
 
 			mockService.
 				On("RegisterUser", mock.AnythingOfType("*domain.User")).
 				Return(nil)
+			mockVerificationService.
+				On("SendVerification", mock.MatchedBy(func(user *domain.User) bool { return user.Email == email })).
+				Return(nil)
 
 			userPayload := map[string]string{
 				"username": username,
@@ -182,6 +235,23 @@ func TestUserHandler(t *testing.T) {
 			assert.Equal(t, http.StatusCreated, response.Code)
 
 			mockService.AssertExpectations(t)
+			mockVerificationService.AssertExpectations(t)
+		})
+
+		t.Run("verification_email_failure", func(t *testing.T) {
+			mockService, mockVerificationService, router := setupTestWithVerification(t)
+
+			mockService.
+				On("RegisterUser", mock.AnythingOfType("*domain.User")).
+				Return(nil)
+			mockVerificationService.
+				On("SendVerification", mock.AnythingOfType("*domain.User")).
+				Return(errors.New("smtp down"))
+
+			response := performRequest(t, router, http.MethodPost, "/users/register", domainUser)
+			assert.Equal(t, http.StatusCreated, response.Code)
+
+			mockVerificationService.AssertExpectations(t)
 		})
 
 		t.Run("username_exists", func(t *testing.T) {
@@ -218,6 +288,24 @@ func TestUserHandler(t *testing.T) {
 			mockService.AssertExpectations(t)
 		})
 
+		t.Run("weak_password", func(t *testing.T) {
+			mockService, router := setupTest(t)
+
+			mockService.On("RegisterUser", mock.AnythingOfType("*domain.User")).
+				Return(&domain.PasswordPolicyError{Violations: []string{"must be at least 8 characters long"}})
+
+			response := performRequest(t, router, http.MethodPost, "/users/register", mapStrStr{
+				"username": username,
+				"email":    email,
+				"password": "short",
+			})
+			assert.Equal(t, http.StatusBadRequest, response.Code)
+
+			var parsed handlers.WeakPasswordResponse
+			assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &parsed))
+			assert.Equal(t, []string{"must be at least 8 characters long"}, parsed.Violations)
+		})
+
 		t.Run("invalid_json", func(t *testing.T) {
 			_, router := setupTest(t)
 
@@ -232,7 +320,7 @@ func TestUserHandler(t *testing.T) {
 
 	t.Run("LoginUser", func(t *testing.T) {
 		t.Run("success", func(t *testing.T) {
-			mockService, router := setupTest(t)
+			mockService, mockSessionService, router := setupTestWithSessions(t)
 
 			userID := uuid.New()
 			testUser := &domain.User{
@@ -245,8 +333,15 @@ func TestUserHandler(t *testing.T) {
 			}
 
 			mockService.
-				On("Authenticate", username, password).
-				Return(testToken, testUser, nil)
+				On("Authenticate", username, password, mock.AnythingOfType("string")).
+				Return(testUser, nil)
+			mockSessionService.
+				On("Start", testUser, mock.AnythingOfType("domain.SessionClient")).
+				Return(&domain.AuthTokens{
+					SessionID:    uuid.New(),
+					AccessToken:  testToken,
+					RefreshToken: testRefreshToken,
+				}, nil)
 
 			response := performRequest(t, router, http.MethodPost, "/users/login", loginPayload(username, password))
 			assert.Equal(t, http.StatusOK, response.Code)
@@ -258,27 +353,44 @@ func TestUserHandler(t *testing.T) {
 			assert.Equal(t, testUser.Phone, loginResp.Phone)
 			assert.Equal(t, testUser.Address, loginResp.Address)
 			assert.Equal(t, testToken, loginResp.Token)
+			assert.Equal(t, testRefreshToken, loginResp.RefreshToken)
 
 			mockService.AssertExpectations(t)
+			mockSessionService.AssertExpectations(t)
 		})
 
 		t.Run("invalid_credentials", func(t *testing.T) {
 			mockService, router := setupTest(t)
 
 			mockService.
-				On("Authenticate", username, password).
-				Return("", nil, errors.New("invalid credentials"))
+				On("Authenticate", username, password, mock.AnythingOfType("string")).
+				Return(nil, services.InvalidCredentialsErr)
 
 			response := performRequest(t, router, http.MethodPost, "/users/login", loginPayload(username, password))
 			assert.Equal(t, http.StatusUnauthorized, response.Code)
 
 			errResp := parseErrorResponse(t, response)
 			assert.Equal(t, "Unauthorized", errResp.Error)
-			assert.Equal(t, "invalid credentials", errResp.Details)
+			assert.Equal(t, "invalid username or password", errResp.Details)
 
 			mockService.AssertExpectations(t)
 		})
 
+		t.Run("throttled", func(t *testing.T) {
+			mockService, router := setupTest(t)
+
+			mockService.
+				On("Authenticate", username, password, mock.AnythingOfType("string")).
+				Return(nil, &services.LoginThrottledError{RetryAt: time.Now().Add(90 * time.Second)})
+
+			response := performRequest(t, router, http.MethodPost, "/users/login", loginPayload(username, password))
+			assert.Equal(t, http.StatusTooManyRequests, response.Code)
+			assert.Contains(t, []string{"89", "90"}, response.Header().Get("Retry-After"))
+
+			errResp := parseErrorResponse(t, response)
+			assert.Equal(t, "Too many failed login attempts", errResp.Error)
+		})
+
 		t.Run("invalid_json", func(t *testing.T) {
 			_, router := setupTest(t)
 
@@ -291,9 +403,81 @@ func TestUserHandler(t *testing.T) {
 		})
 	})
 
+	t.Run("LoginUserWithTwoFactor", func(t *testing.T) {
+		testUser := &domain.User{ID: uuid.New(), Username: username, Email: email, Password: password}
+		challengeExpiresAt := time.Date(2025, 1, 1, 12, 5, 0, 0, time.UTC)
+
+		t.Run("password_returns_challenge", func(t *testing.T) {
+			mockService, mockSessionService, _, mockTwoFactorService, router := setupUserHandlerTest(t)
+
+			mockService.On("Authenticate", username, password, mock.AnythingOfType("string")).Return(testUser, nil)
+			mockTwoFactorService.On("Challenge", testUser).
+				Return(&domain.LoginChallenge{Token: "challenge-token", ExpiresAt: challengeExpiresAt}, nil)
+
+			response := performRequest(t, router, http.MethodPost, "/users/login", loginPayload(username, password))
+			assert.Equal(t, http.StatusOK, response.Code)
+
+			var challengeResp handlers.LoginChallengeResponse
+			assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &challengeResp))
+			assert.True(t, challengeResp.TwoFactorRequired)
+			assert.Equal(t, "challenge-token", challengeResp.ChallengeToken)
+			assert.Equal(t, challengeExpiresAt, challengeResp.ChallengeExpiresAt)
+			assert.NotContains(t, response.Body.String(), `"token"`, "no session is started yet")
+
+			mockTwoFactorService.AssertExpectations(t)
+			mockSessionService.AssertNotCalled(t, "Start", mock.Anything, mock.Anything)
+		})
+
+		t.Run("code_starts_session", func(t *testing.T) {
+			_, mockSessionService, _, mockTwoFactorService, router := setupUserHandlerTest(t)
+
+			mockTwoFactorService.On("CompleteLogin", "challenge-token", "123456", mock.AnythingOfType("string")).Return(testUser, nil)
+			mockSessionService.On("Start", testUser, mock.AnythingOfType("domain.SessionClient")).
+				Return(&domain.AuthTokens{AccessToken: testToken, RefreshToken: testRefreshToken}, nil)
+
+			response := performRequest(t, router, http.MethodPost, "/users/login/two-factor", mapStrStr{
+				"challenge_token": "challenge-token",
+				"code":            "123456",
+			})
+			assert.Equal(t, http.StatusOK, response.Code)
+
+			loginResp := parseLoginResponse(t, response)
+			assert.Equal(t, testUser.ID.String(), loginResp.UserID)
+			assert.Equal(t, testToken, loginResp.Token)
+			assert.Equal(t, testRefreshToken, loginResp.RefreshToken)
+
+			mockTwoFactorService.AssertExpectations(t)
+			mockSessionService.AssertExpectations(t)
+		})
+
+		t.Run("rejected_code", func(t *testing.T) {
+			for _, serviceErr := range []error{services.InvalidTwoFactorCodeErr, services.InvalidLoginChallengeErr} {
+				_, mockSessionService, _, mockTwoFactorService, router := setupUserHandlerTest(t)
+
+				mockTwoFactorService.On("CompleteLogin", "challenge-token", "000000", mock.AnythingOfType("string")).Return(nil, serviceErr)
+
+				response := performRequest(t, router, http.MethodPost, "/users/login/two-factor", mapStrStr{
+					"challenge_token": "challenge-token",
+					"code":            "000000",
+				})
+				assert.Equal(t, http.StatusUnauthorized, response.Code)
+				mockSessionService.AssertNotCalled(t, "Start", mock.Anything, mock.Anything)
+			}
+		})
+
+		t.Run("missing_code", func(t *testing.T) {
+			_, router := setupTest(t)
+
+			response := performRequest(t, router, http.MethodPost, "/users/login/two-factor", mapStrStr{
+				"challenge_token": "challenge-token",
+			})
+			assert.Equal(t, http.StatusBadRequest, response.Code)
+		})
+	})
+
 	t.Run("UpdateUser", func(t *testing.T) {
 		t.Run("success", func(t *testing.T) {
-			mockService, router := setupTest(t)
+			mockService, mockVerificationService, router := setupTestWithVerification(t)
 
 			mockService.
 				On("Update", uuid.Nil, mock.MatchedBy(func(fields map[string]interface{}) bool {
@@ -303,6 +487,7 @@ func TestUserHandler(t *testing.T) {
 						fields["address"] == updatedAddress
 				})).
 				Return(updatedUser, nil)
+			mockVerificationService.On("SendVerification", updatedUser).Return(nil)
 
 			response := performRequest(t, router, http.MethodPatch, "/users/update", updatePayload)
 			assert.Equal(t, http.StatusOK, response.Code)
@@ -313,11 +498,30 @@ func TestUserHandler(t *testing.T) {
 			assert.Equal(t, updateUserSuccessMsg, parsed.Message)
 
 			mockService.AssertExpectations(t)
+			mockVerificationService.AssertExpectations(t)
+		})
+
+		t.Run("without_email_change", func(t *testing.T) {
+			mockService, mockVerificationService, router := setupTestWithVerification(t)
+
+			mockService.
+				On("Update", uuid.Nil, map[string]interface{}{"address": updatedAddress}).
+				Return(updatedUser, nil)
+
+			response := performRequest(t, router, http.MethodPatch, "/users/update", map[string]string{"address": updatedAddress})
+			assert.Equal(t, http.StatusOK, response.Code)
+
+			mockVerificationService.AssertNotCalled(t, "SendVerification", mock.Anything)
 		})
 
 		t.Run("failure", func(t *testing.T) {
 			mockService := new(mocks.MockUserService)
-			handler := handlers.NewUserHandler(mockService)
+			handler := handlers.NewUserHandler(
+				mockService,
+				new(mocks.MockSessionService),
+				new(mocks.MockEmailVerificationService),
+				new(mocks.MockTwoFactorService),
+			)
 
 			router := gin.Default()
 			router.PATCH("/users/update", func(c *gin.Context) {
@@ -402,4 +606,115 @@ func TestUserHandler(t *testing.T) {
 			mockService.AssertExpectations(t)
 		})
 	})
+	t.Run("ChangePassword", func(t *testing.T) {
+		request := mapStrStr{"current_password": password, "new_password": "blue-Lantern"}
+
+		t.Run("success", func(t *testing.T) {
+			mockService, router := setupTest(t)
+
+			mockService.On("ChangePassword", uuid.Nil, currentPasswordSessionID, password, "blue-Lantern", mock.AnythingOfType("string")).
+				Return(nil)
+
+			response := performRequest(t, router, http.MethodPost, "/users/password", request)
+			assert.Equal(t, http.StatusOK, response.Code)
+			mockService.AssertExpectations(t)
+		})
+
+		t.Run("errors", func(t *testing.T) {
+			tests := []struct {
+				err    error
+				status int
+			}{
+				{&domain.PasswordPolicyError{Violations: []string{"is too common"}}, http.StatusBadRequest},
+				{services.IncorrectPasswordErr, http.StatusForbidden},
+				{services.PasswordUnchangedErr, http.StatusBadRequest},
+				{&services.LoginThrottledError{RetryAt: time.Now().Add(time.Minute)}, http.StatusTooManyRequests},
+				{errors.New("db down"), http.StatusInternalServerError},
+			}
+
+			for _, test := range tests {
+				mockService, router := setupTest(t)
+
+				mockService.On("ChangePassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(test.err)
+
+				response := performRequest(t, router, http.MethodPost, "/users/password", request)
+				assert.Equal(t, test.status, response.Code, test.err.Error())
+			}
+		})
+
+		t.Run("missing_fields", func(t *testing.T) {
+			mockService, router := setupTest(t)
+
+			response := performRequest(t, router, http.MethodPost, "/users/password", mapStrStr{"new_password": "blue-Lantern"})
+			assert.Equal(t, http.StatusBadRequest, response.Code)
+			mockService.AssertNotCalled(t, "ChangePassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	})
+
+	t.Run("VerifyEmail", func(t *testing.T) {
+		t.Run("success", func(t *testing.T) {
+			_, mockVerificationService, router := setupTestWithVerification(t)
+
+			verifiedAt := time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+			mockVerificationService.
+				On("Verify", "signed-token").
+				Return(&domain.User{ID: uuid.New(), Username: username, Email: email, EmailVerifiedAt: &verifiedAt}, nil)
+
+			response := performRequest(t, router, http.MethodPost, "/users/verify-email", map[string]string{"token": "signed-token"})
+			assert.Equal(t, http.StatusOK, response.Code)
+
+			var parsed handlers.UserSuccessResponse
+			err := json.Unmarshal(response.Body.Bytes(), &parsed)
+			assert.NoError(t, err)
+			assert.True(t, parsed.User.EmailVerified)
+		})
+
+		t.Run("invalid_token", func(t *testing.T) {
+			_, mockVerificationService, router := setupTestWithVerification(t)
+
+			mockVerificationService.
+				On("Verify", "forged").
+				Return(nil, services.InvalidVerificationTokenErr)
+
+			response := performRequest(t, router, http.MethodPost, "/users/verify-email", map[string]string{"token": "forged"})
+			assert.Equal(t, http.StatusBadRequest, response.Code)
+
+			errResp := parseErrorResponse(t, response)
+			assert.Equal(t, "Invalid or expired verification token", errResp.Error)
+		})
+
+		t.Run("missing_token", func(t *testing.T) {
+			_, _, router := setupTestWithVerification(t)
+
+			response := performRequest(t, router, http.MethodPost, "/users/verify-email", map[string]string{})
+			assert.Equal(t, http.StatusBadRequest, response.Code)
+		})
+	})
+
+	t.Run("ResendVerification", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			err    error
+			status int
+		}{
+			{"success", nil, http.StatusOK},
+			{"already_verified", services.EmailAlreadyVerifiedErr, http.StatusConflict},
+			{"throttled", services.VerificationThrottledErr, http.StatusTooManyRequests},
+			{"failure", errors.New("smtp down"), http.StatusInternalServerError},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				_, mockVerificationService, router := setupTestWithVerification(t)
+
+				mockVerificationService.On("Resend", uuid.Nil).Return(test.err)
+
+				response := performRequest(t, router, http.MethodPost, "/users/verify-email/resend", nil)
+				assert.Equal(t, test.status, response.Code)
+
+				mockVerificationService.AssertExpectations(t)
+			})
+		}
+	})
 }
@@ -0,0 +1,16 @@
+package clock
+
+import (
+	"swapp-go/cmd/internal/application/ports"
+	"time"
+)
+
+type SystemClock struct{}
+
+func NewSystemClock() ports.Clock {
+	return SystemClock{}
+}
+
+func (SystemClock) Now() time.Time {
+	return time.Now().UTC()
+}
@@ -0,0 +1,75 @@
+package email
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+
+	"swapp-go/cmd/internal/application/ports"
+	"swapp-go/cmd/internal/domain"
+)
+
+// fromLine matches body lines that an mbox reader would take for the start of
+// the next message, including ones already quoted with ">".
+var fromLine = regexp.MustCompile(`(?m)^(>*From )`)
+
+// FileEmailService appends every email to an mbox file, so that development
+// mail can be read with any mail client instead of being sent.
+type FileEmailService struct {
+	mutex    sync.Mutex
+	path     string
+	sender   string
+	renderer ports.EmailRenderer
+}
+
+func NewFileEmailService(path, sender string, renderer ports.EmailRenderer) ports.EmailService {
+	return &FileEmailService{path: path, sender: sender, renderer: renderer}
+}
+
+func (s *FileEmailService) SendEmail(message *domain.EmailMessage) error {
+	rendered, err := s.renderer.Render(message)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	msg, err := BuildMessage(s.sender, message.Recipient, rendered, now)
+	if err != nil {
+		return err
+	}
+
+	// mbox uses bare line feeds and quotes body lines starting with "From "
+	// (the mboxrd variant, which can be reversed exactly).
+	msg = bytes.ReplaceAll(msg, []byte("\r\n"), []byte("\n"))
+	msg = fromLine.ReplaceAll(msg, []byte(">$1"))
+
+	var entry bytes.Buffer
+	entry.WriteString("From " + s.sender + " " + now.UTC().Format(time.ANSIC) + "\n")
+	entry.Write(msg)
+	if !bytes.HasSuffix(msg, []byte("\n")) {
+		entry.WriteString("\n")
+	}
+	entry.WriteString("\n")
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if err = os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+
+	if _, err = file.Write(entry.Bytes()); err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
@@ -0,0 +1,50 @@
+package email_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"regexp"
+	"swapp-go/cmd/internal/adapters/infrastructure/email"
+	"swapp-go/cmd/internal/domain"
+	"testing"
+)
+
+// stubRenderer renders every message to the same email.
+type stubRenderer domain.RenderedEmail
+
+func (renderer stubRenderer) Render(*domain.EmailMessage) (*domain.RenderedEmail, error) {
+	rendered := domain.RenderedEmail(renderer)
+	return &rendered, nil
+}
+
+func TestFileEmailService(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mail", "dev.mbox")
+	service := email.NewFileEmailService(path, "noreply@swapp.example.com", stubRenderer{
+		Subject: "Swap accepted",
+		Text:    "Hi alice,\n\nFrom now on the bike is yours.\n>From the archive.\n",
+		HTML:    "<p>Hi alice,</p>",
+	})
+
+	for _, recipient := range []string{"alice@example.com", "bob@example.com"} {
+		err := service.SendEmail(&domain.EmailMessage{
+			Recipient: recipient,
+			Template:  domain.EmailTemplateSwapRequestAccepted,
+		})
+		assert.NoError(t, err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read mbox: %v", err)
+	}
+	mbox := string(content)
+
+	separators := regexp.MustCompile(`(?m)^From noreply@swapp\.example\.com `).FindAllStringIndex(mbox, -1)
+	assert.Len(t, separators, 2, "one separator line per message")
+	assert.Contains(t, mbox, "To: alice@example.com\n")
+	assert.Contains(t, mbox, "To: bob@example.com\n")
+	assert.NotContains(t, mbox, "\r\n")
+	assert.Regexp(t, `(?m)^>From now on`, mbox, "body lines starting with From are quoted")
+	assert.Regexp(t, `(?m)^>>From the archive`, mbox, "quoted lines are quoted again")
+}
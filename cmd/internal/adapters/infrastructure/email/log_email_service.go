@@ -0,0 +1,48 @@
+package email
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"swapp-go/cmd/internal/application/ports"
+	"swapp-go/cmd/internal/domain"
+)
+
+// LogEmailService prints the plain-text version of every email instead of
+// sending it. It is the quickest way to follow links from emails during
+// development.
+type LogEmailService struct {
+	mutex    sync.Mutex
+	out      io.Writer
+	sender   string
+	renderer ports.EmailRenderer
+}
+
+func NewLogEmailService(out io.Writer, sender string, renderer ports.EmailRenderer) ports.EmailService {
+	return &LogEmailService{out: out, sender: sender, renderer: renderer}
+}
+
+func (s *LogEmailService) SendEmail(message *domain.EmailMessage) error {
+	rendered, err := s.renderer.Render(message)
+	if err != nil {
+		return err
+	}
+
+	var entry strings.Builder
+	entry.WriteString("----- email -----\n")
+	fmt.Fprintf(&entry, "From: %s\n", s.sender)
+	fmt.Fprintf(&entry, "To: %s\n", message.Recipient)
+	fmt.Fprintf(&entry, "Subject: %s\n", rendered.Subject)
+	fmt.Fprintf(&entry, "Template: %s\n\n", message.Template)
+	entry.WriteString(rendered.Text)
+	entry.WriteString("-----------------\n")
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	_, err = io.WriteString(s.out, entry.String())
+
+	return err
+}
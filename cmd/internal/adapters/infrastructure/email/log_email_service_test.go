@@ -0,0 +1,26 @@
+package email_test
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"swapp-go/cmd/internal/adapters/infrastructure/email"
+	"swapp-go/cmd/internal/domain"
+	"testing"
+)
+
+func TestLogEmailService(t *testing.T) {
+	var out bytes.Buffer
+	service := email.NewLogEmailService(&out, "noreply@swapp.example.com", newTestRenderer(t))
+
+	err := service.SendEmail(&domain.EmailMessage{
+		Recipient: "alice@example.com",
+		Template:  domain.EmailTemplatePasswordReset,
+		Data:      testEmailData(),
+	})
+	assert.NoError(t, err)
+
+	assert.Contains(t, out.String(), "To: alice@example.com\n")
+	assert.Contains(t, out.String(), "Template: password_reset\n")
+	assert.Contains(t, out.String(), "https://swapp.example.com/reset?token=abc")
+	assert.NotContains(t, out.String(), "<p>", "only the plain-text version is printed")
+}
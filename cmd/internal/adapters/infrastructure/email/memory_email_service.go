@@ -0,0 +1,100 @@
+package email
+
+import (
+	"sync"
+	"time"
+
+	"swapp-go/cmd/internal/application/ports"
+	"swapp-go/cmd/internal/domain"
+)
+
+// MemoryEmailService keeps every email in memory instead of sending it. It is
+// meant for tests, which can query what was sent, and for local runs.
+type MemoryEmailService struct {
+	mutex    sync.RWMutex
+	sender   string
+	renderer ports.EmailRenderer
+	sent     []SentEmail
+}
+
+// SentEmail is an email captured by MemoryEmailService, with both the message
+// that was sent and what it rendered to.
+type SentEmail struct {
+	From     string
+	Message  domain.EmailMessage
+	Rendered domain.RenderedEmail
+	SentAt   time.Time
+}
+
+func NewMemoryEmailService(sender string, renderer ports.EmailRenderer) *MemoryEmailService {
+	return &MemoryEmailService{sender: sender, renderer: renderer}
+}
+
+// SendEmail renders the email before capturing it, so that broken templates
+// fail here as they would with a real adapter.
+func (s *MemoryEmailService) SendEmail(message *domain.EmailMessage) error {
+	rendered, err := s.renderer.Render(message)
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.sent = append(s.sent, SentEmail{
+		From:     s.sender,
+		Message:  *message,
+		Rendered: *rendered,
+		SentAt:   time.Now(),
+	})
+
+	return nil
+}
+
+// Sent lists all captured emails, oldest first.
+func (s *MemoryEmailService) Sent() []SentEmail {
+	return s.Filter(func(SentEmail) bool { return true })
+}
+
+// SentTo lists the emails captured for recipient, oldest first.
+func (s *MemoryEmailService) SentTo(recipient string) []SentEmail {
+	return s.Filter(func(email SentEmail) bool { return email.Message.Recipient == recipient })
+}
+
+// WithTemplate lists the emails rendered from template, oldest first.
+func (s *MemoryEmailService) WithTemplate(template domain.EmailTemplate) []SentEmail {
+	return s.Filter(func(email SentEmail) bool { return email.Message.Template == template })
+}
+
+// Last returns the most recent email captured for recipient, if any.
+func (s *MemoryEmailService) Last(recipient string) (SentEmail, bool) {
+	emails := s.SentTo(recipient)
+	if len(emails) == 0 {
+		return SentEmail{}, false
+	}
+
+	return emails[len(emails)-1], true
+}
+
+// Filter lists the captured emails that match, oldest first.
+func (s *MemoryEmailService) Filter(match func(SentEmail) bool) []SentEmail {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	emails := make([]SentEmail, 0, len(s.sent))
+	for _, email := range s.sent {
+		if match(email) {
+			emails = append(emails, email)
+		}
+	}
+
+	return emails
+}
+
+// Reset forgets all captured emails.
+func (s *MemoryEmailService) Reset() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.sent = nil
+}
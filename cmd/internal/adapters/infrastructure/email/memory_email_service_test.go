@@ -0,0 +1,64 @@
+package email_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"swapp-go/cmd/internal/adapters/infrastructure/email"
+	"swapp-go/cmd/internal/domain"
+	"testing"
+)
+
+func TestMemoryEmailService(t *testing.T) {
+	service := email.NewMemoryEmailService("noreply@swapp.example.com", newTestRenderer(t))
+
+	send := func(recipient string, template domain.EmailTemplate) {
+		t.Helper()
+		assert.NoError(t, service.SendEmail(&domain.EmailMessage{
+			Recipient: recipient,
+			Template:  template,
+			Data:      testEmailData(),
+		}))
+	}
+
+	send("alice@example.com", domain.EmailTemplateEmailVerification)
+	send("bob@example.com", domain.EmailTemplateSwapRequestCreated)
+	send("alice@example.com", domain.EmailTemplatePasswordReset)
+
+	t.Run("Sent", func(t *testing.T) {
+		sent := service.Sent()
+		assert.Len(t, sent, 3)
+		assert.Equal(t, "noreply@swapp.example.com", sent[0].From)
+		assert.Equal(t, domain.EmailTemplateEmailVerification, sent[0].Message.Template)
+		assert.NotEmpty(t, sent[0].Rendered.Subject)
+		assert.Contains(t, sent[0].Rendered.Text, "https://swapp.example.com/verify?token=abc")
+	})
+
+	t.Run("SentTo and Last", func(t *testing.T) {
+		assert.Len(t, service.SentTo("alice@example.com"), 2)
+		assert.Empty(t, service.SentTo("carol@example.com"))
+
+		last, ok := service.Last("alice@example.com")
+		assert.True(t, ok)
+		assert.Equal(t, domain.EmailTemplatePasswordReset, last.Message.Template)
+		assert.Equal(t, "https://swapp.example.com/reset?token=abc", last.Message.Data["ResetURL"])
+
+		_, ok = service.Last("carol@example.com")
+		assert.False(t, ok)
+	})
+
+	t.Run("WithTemplate", func(t *testing.T) {
+		sent := service.WithTemplate(domain.EmailTemplateSwapRequestCreated)
+		assert.Len(t, sent, 1)
+		assert.Equal(t, "bob@example.com", sent[0].Message.Recipient)
+	})
+
+	t.Run("rendering errors are not captured", func(t *testing.T) {
+		err := service.SendEmail(&domain.EmailMessage{Recipient: "alice@example.com", Template: "unknown"})
+		assert.Error(t, err)
+		assert.Len(t, service.Sent(), 3)
+	})
+
+	t.Run("Reset", func(t *testing.T) {
+		service.Reset()
+		assert.Empty(t, service.Sent())
+	})
+}
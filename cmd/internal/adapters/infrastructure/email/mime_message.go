@@ -0,0 +1,74 @@
+package email
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/textproto"
+	"swapp-go/cmd/internal/domain"
+	"time"
+)
+
+type mailHeader struct{ name, value string }
+
+// BuildMessage encodes email as a multipart/alternative MIME message with a
+// plain-text part followed by the preferred HTML part. Emails with an
+// unsubscribe link get one-click List-Unsubscribe headers.
+func BuildMessage(from, to string, email *domain.RenderedEmail, date time.Time) ([]byte, error) {
+	var body bytes.Buffer
+	parts := multipart.NewWriter(&body)
+
+	if err := writePart(parts, "text/plain; charset=\"utf-8\"", email.Text); err != nil {
+		return nil, err
+	}
+	if err := writePart(parts, "text/html; charset=\"utf-8\"", email.HTML); err != nil {
+		return nil, err
+	}
+	if err := parts.Close(); err != nil {
+		return nil, err
+	}
+
+	var message bytes.Buffer
+	headers := []mailHeader{
+		{"From", from},
+		{"To", to},
+		{"Subject", mime.QEncoding.Encode("utf-8", email.Subject)},
+		{"Date", date.Format(time.RFC1123Z)},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", fmt.Sprintf("multipart/alternative; boundary=%q", parts.Boundary())},
+	}
+	// List-Unsubscribe-Post lets mail clients unsubscribe with a single POST to
+	// the link (RFC 8058) instead of opening it in a browser.
+	if email.UnsubscribeURL != "" {
+		headers = append(headers,
+			mailHeader{"List-Unsubscribe", "<" + email.UnsubscribeURL + ">"},
+			mailHeader{"List-Unsubscribe-Post", "List-Unsubscribe=One-Click"},
+		)
+	}
+	for _, header := range headers {
+		fmt.Fprintf(&message, "%s: %s\r\n", header.name, header.value)
+	}
+	message.WriteString("\r\n")
+	message.Write(body.Bytes())
+
+	return message.Bytes(), nil
+}
+
+func writePart(parts *multipart.Writer, contentType string, content string) error {
+	part, err := parts.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {contentType},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
+	if err != nil {
+		return err
+	}
+
+	encoder := quotedprintable.NewWriter(part)
+	if _, err = encoder.Write([]byte(content)); err != nil {
+		return err
+	}
+
+	return encoder.Close()
+}
@@ -0,0 +1,103 @@
+package email_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
+	"swapp-go/cmd/internal/adapters/infrastructure/email"
+	"swapp-go/cmd/internal/domain"
+	"testing"
+	"time"
+)
+
+func TestBuildMessage(t *testing.T) {
+	date := time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+	rendered := &domain.RenderedEmail{
+		Subject: "Swap accepted — reference SW-1234",
+		Text:    "Hi alice,\n\nYour swap was accepted.\n",
+		HTML:    `<p style="margin:0">Hi alice,</p>`,
+	}
+
+	raw, err := email.BuildMessage("noreply@swapp.example.com", "alice@example.com", rendered, date)
+	if err != nil {
+		t.Fatalf("failed to build message: %v", err)
+	}
+
+	message, err := mail.ReadMessage(strings.NewReader(string(raw)))
+	if err != nil {
+		t.Fatalf("failed to parse message: %v", err)
+	}
+
+	assert.Equal(t, "noreply@swapp.example.com", message.Header.Get("From"))
+	assert.Equal(t, "alice@example.com", message.Header.Get("To"))
+	assert.Empty(t, message.Header.Get("List-Unsubscribe"), "only emails with an unsubscribe link get the header")
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(message.Header.Get("Subject"))
+	if err != nil {
+		t.Fatalf("failed to decode subject: %v", err)
+	}
+	assert.Equal(t, rendered.Subject, subject)
+
+	sentAt, err := message.Header.Date()
+	if err != nil {
+		t.Fatalf("failed to parse date: %v", err)
+	}
+	assert.True(t, date.Equal(sentAt))
+
+	mediaType, params, err := mime.ParseMediaType(message.Header.Get("Content-Type"))
+	if err != nil {
+		t.Fatalf("failed to parse content type: %v", err)
+	}
+	assert.Equal(t, "multipart/alternative", mediaType)
+
+	// multipart.Reader decodes quoted-printable parts transparently.
+	parts := multipart.NewReader(message.Body, params["boundary"])
+
+	var contentTypes, bodies []string
+	for {
+		part, err := parts.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("failed to read part: %v", err)
+		}
+
+		body, err := io.ReadAll(part)
+		if err != nil {
+			t.Fatalf("failed to read part body: %v", err)
+		}
+
+		contentTypes = append(contentTypes, part.Header.Get("Content-Type"))
+		bodies = append(bodies, string(body))
+	}
+
+	assert.Equal(t, []string{`text/plain; charset="utf-8"`, `text/html; charset="utf-8"`}, contentTypes)
+	// Quoted-printable text lines end in CRLF on the wire.
+	assert.Equal(t, []string{strings.ReplaceAll(rendered.Text, "\n", "\r\n"), rendered.HTML}, bodies)
+}
+
+func TestBuildMessage_ListUnsubscribe(t *testing.T) {
+	rendered := &domain.RenderedEmail{
+		Subject:        "Swap accepted",
+		Text:           "Hi alice,\n",
+		HTML:           "<p>Hi alice,</p>",
+		UnsubscribeURL: "https://api.example.com/notifications/unsubscribe?token=abc",
+	}
+
+	raw, err := email.BuildMessage("noreply@swapp.example.com", "alice@example.com", rendered, time.Now())
+	if err != nil {
+		t.Fatalf("failed to build message: %v", err)
+	}
+
+	message, err := mail.ReadMessage(strings.NewReader(string(raw)))
+	if err != nil {
+		t.Fatalf("failed to parse message: %v", err)
+	}
+
+	assert.Equal(t, "<https://api.example.com/notifications/unsubscribe?token=abc>", message.Header.Get("List-Unsubscribe"))
+	assert.Equal(t, "List-Unsubscribe=One-Click", message.Header.Get("List-Unsubscribe-Post"))
+}
@@ -2,9 +2,10 @@ package email
 
 import (
 	"crypto/tls"
-	"fmt"
+	"net"
 	"net/smtp"
-	"strings"
+	"strconv"
+	"time"
 
 	"swapp-go/cmd/internal/application/ports"
 	"swapp-go/cmd/internal/config"
@@ -12,45 +13,45 @@ import (
 )
 
 type SmtpEmailService struct {
-	config config.EmailConfig
+	config   config.EmailConfig
+	renderer ports.EmailRenderer
 }
 
-func NewSmtpEmailService(config config.EmailConfig) ports.EmailService {
-	return &SmtpEmailService{config: config}
+func NewSmtpEmailService(config config.EmailConfig, renderer ports.EmailRenderer) ports.EmailService {
+	return &SmtpEmailService{config: config, renderer: renderer}
 }
 
 func (s *SmtpEmailService) SendEmail(message *domain.EmailMessage) error {
 	from := s.config.Sender
 	to := message.Recipient
 
-	msg := buildMessage(from, to, message.Subject, message.Body)
-
-	auth := smtp.PlainAuth(
-		"",
-		s.config.Username,
-		s.config.Password,
-		s.config.SMTPHost,
-	)
-
-	addr := fmt.Sprintf("%s:%d", s.config.SMTPHost, s.config.SMTPPort)
+	rendered, err := s.renderer.Render(message)
+	if err != nil {
+		return err
+	}
 
-	tlsConfig := &tls.Config{
-		InsecureSkipVerify: false,
-		ServerName:         s.config.SMTPHost,
+	msg, err := BuildMessage(from, to, rendered, time.Now())
+	if err != nil {
+		return err
 	}
 
-	client, err := smtp.Dial(addr)
+	client, err := s.dial()
 	if err != nil {
 		return err
 	}
 	defer client.Close()
-	
-	if err = client.StartTLS(tlsConfig); err != nil {
-		return err
+
+	if s.config.SMTPSecurity == config.SMTPSecurityStartTLS {
+		if err = client.StartTLS(s.tlsConfig()); err != nil {
+			return err
+		}
 	}
 
-	if err = client.Auth(auth); err != nil {
-		return err
+	if s.config.Username != "" {
+		auth := smtp.PlainAuth("", s.config.Username, s.config.Password, s.config.SMTPHost)
+		if err = client.Auth(auth); err != nil {
+			return err
+		}
 	}
 
 	if err = client.Mail(from); err != nil {
@@ -66,7 +67,7 @@ func (s *SmtpEmailService) SendEmail(message *domain.EmailMessage) error {
 		return err
 	}
 
-	_, err = data.Write([]byte(msg))
+	_, err = data.Write(msg)
 	if err != nil {
 		return err
 	}
@@ -79,21 +80,40 @@ func (s *SmtpEmailService) SendEmail(message *domain.EmailMessage) error {
 	return client.Quit()
 }
 
-func buildMessage(from, to, subject, body string) string {
-	headers := make(map[string]string)
-	headers["From"] = from
-	headers["To"] = to
-	headers["Subject"] = subject
-	headers["MIME-Version"] = "1.0"
-	headers["Content-Type"] = "text/plain; charset=\"utf-8\""
+// dial connects to the server, over TLS from the start when implicit TLS is
+// configured. The timeout covers the whole conversation, so a stalled server
+// cannot hold up the dispatcher.
+func (s *SmtpEmailService) dial() (*smtp.Client, error) {
+	addr := net.JoinHostPort(s.config.SMTPHost, strconv.Itoa(s.config.SMTPPort))
+	dialer := &net.Dialer{Timeout: s.config.SMTPTimeout}
+
+	var conn net.Conn
+	var err error
+	if s.config.SMTPSecurity == config.SMTPSecurityTLS {
+		conn, err = tls.DialWithDialer(dialer, "tcp", addr, s.tlsConfig())
+	} else {
+		conn, err = dialer.Dial("tcp", addr)
+	}
+	if err != nil {
+		return nil, err
+	}
 
-	var msg strings.Builder
+	if s.config.SMTPTimeout > 0 {
+		if err = conn.SetDeadline(time.Now().Add(s.config.SMTPTimeout)); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
 
-	for k, v := range headers {
-		msg.WriteString(fmt.Sprintf("%s: %s\r\n", k, v))
+	client, err := smtp.NewClient(conn, s.config.SMTPHost)
+	if err != nil {
+		conn.Close()
+		return nil, err
 	}
 
-	msg.WriteString("\r\n" + body)
+	return client, nil
+}
 
-	return msg.String()
+func (s *SmtpEmailService) tlsConfig() *tls.Config {
+	return &tls.Config{ServerName: s.config.SMTPHost}
 }
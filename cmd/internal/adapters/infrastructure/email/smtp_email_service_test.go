@@ -0,0 +1,154 @@
+package email_test
+
+import (
+	"bufio"
+	"github.com/stretchr/testify/assert"
+	"net"
+	"net/textproto"
+	"strings"
+	"swapp-go/cmd/internal/adapters/infrastructure/email"
+	"swapp-go/cmd/internal/config"
+	"swapp-go/cmd/internal/domain"
+	"testing"
+	"time"
+)
+
+// fakeRelay accepts one SMTP conversation without TLS or authentication and
+// reports the commands and message data it received.
+func fakeRelay(t *testing.T, stall bool) (int, <-chan []string) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	received := make(chan []string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		if stall {
+			time.Sleep(time.Second)
+			return
+		}
+
+		reader := textproto.NewReader(bufio.NewReader(conn))
+		writer := textproto.NewWriter(bufio.NewWriter(conn))
+		var commands []string
+
+		writer.PrintfLine("220 relay.test ESMTP")
+		for {
+			line, err := reader.ReadLine()
+			if err != nil {
+				break
+			}
+			commands = append(commands, line)
+
+			verb := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+			switch verb {
+			case "EHLO":
+				writer.PrintfLine("250 relay.test")
+			case "DATA":
+				writer.PrintfLine("354 go ahead")
+				data, _ := reader.ReadDotLines()
+				commands = append(commands, strings.Join(data, "\n"))
+				writer.PrintfLine("250 queued")
+			case "QUIT":
+				writer.PrintfLine("221 bye")
+				received <- commands
+				return
+			default:
+				writer.PrintfLine("250 ok")
+			}
+		}
+		received <- commands
+	}()
+
+	return listener.Addr().(*net.TCPAddr).Port, received
+}
+
+func TestSmtpEmailService(t *testing.T) {
+	message := &domain.EmailMessage{
+		Recipient: "alice@example.com",
+		Template:  domain.EmailTemplatePasswordReset,
+		Data:      testEmailData(),
+	}
+
+	t.Run("relay without TLS or authentication", func(t *testing.T) {
+		port, received := fakeRelay(t, false)
+
+		service := email.NewSmtpEmailService(config.EmailConfig{
+			Sender:       "noreply@swapp.example.com",
+			SMTPHost:     "127.0.0.1",
+			SMTPPort:     port,
+			SMTPSecurity: config.SMTPSecurityNone,
+			SMTPTimeout:  5 * time.Second,
+		}, newTestRenderer(t))
+
+		assert.NoError(t, service.SendEmail(message))
+
+		commands := <-received
+		assert.Contains(t, commands, "MAIL FROM:<noreply@swapp.example.com>")
+		assert.Contains(t, commands, "RCPT TO:<alice@example.com>")
+		for _, command := range commands {
+			assert.False(t, strings.HasPrefix(command, "AUTH"), "no credentials are sent")
+			assert.False(t, strings.HasPrefix(command, "STARTTLS"), "no TLS upgrade is attempted")
+		}
+		assert.Contains(t, strings.Join(commands, "\n"), "Content-Type: multipart/alternative")
+	})
+
+	t.Run("STARTTLS is required unless disabled", func(t *testing.T) {
+		port, _ := fakeRelay(t, false)
+
+		service := email.NewSmtpEmailService(config.EmailConfig{
+			Sender:       "noreply@swapp.example.com",
+			SMTPHost:     "127.0.0.1",
+			SMTPPort:     port,
+			SMTPSecurity: config.SMTPSecurityStartTLS,
+			SMTPTimeout:  5 * time.Second,
+		}, newTestRenderer(t))
+
+		assert.Error(t, service.SendEmail(message))
+	})
+
+	t.Run("a stalled server times out", func(t *testing.T) {
+		port, _ := fakeRelay(t, true)
+
+		service := email.NewSmtpEmailService(config.EmailConfig{
+			Sender:       "noreply@swapp.example.com",
+			SMTPHost:     "127.0.0.1",
+			SMTPPort:     port,
+			SMTPSecurity: config.SMTPSecurityNone,
+			SMTPTimeout:  50 * time.Millisecond,
+		}, newTestRenderer(t))
+
+		started := time.Now()
+		err := service.SendEmail(message)
+
+		var netErr net.Error
+		if assert.ErrorAs(t, err, &netErr) {
+			assert.True(t, netErr.Timeout())
+		}
+		assert.Less(t, time.Since(started), 500*time.Millisecond)
+	})
+
+	t.Run("implicit TLS does not fall back to plain SMTP", func(t *testing.T) {
+		port, _ := fakeRelay(t, false)
+
+		service := email.NewSmtpEmailService(config.EmailConfig{
+			Sender:       "noreply@swapp.example.com",
+			SMTPHost:     "127.0.0.1",
+			SMTPPort:     port,
+			SMTPSecurity: config.SMTPSecurityTLS,
+			SMTPTimeout:  time.Second,
+		}, newTestRenderer(t))
+
+		// The plain-text relay cannot complete a TLS handshake.
+		assert.Error(t, service.SendEmail(message))
+	})
+}
@@ -0,0 +1,128 @@
+package email
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"net/url"
+	"strings"
+	"swapp-go/cmd/internal/application/ports"
+	"swapp-go/cmd/internal/domain"
+	texttemplate "text/template"
+)
+
+// templateFiles holds one <template>.txt and one <template>.html file per
+// domain.EmailTemplate. The text file defines "subject" and "text", the HTML
+// file defines "content", which layout.html wraps.
+//
+//go:embed templates
+var templateFiles embed.FS
+
+type TemplateRenderer struct {
+	text map[domain.EmailTemplate]*texttemplate.Template
+	html map[domain.EmailTemplate]*htmltemplate.Template
+}
+
+// NewTemplateRenderer parses every email template. Deep links are built on
+// appURL; pictures with a relative URL are served from assetURL.
+func NewTemplateRenderer(appURL string, assetURL string) (ports.EmailRenderer, error) {
+	funcs := map[string]any{
+		"link":  linkFunc(appURL),
+		"asset": assetFunc(assetURL),
+	}
+
+	renderer := &TemplateRenderer{
+		text: make(map[domain.EmailTemplate]*texttemplate.Template, len(domain.EmailTemplates)),
+		html: make(map[domain.EmailTemplate]*htmltemplate.Template, len(domain.EmailTemplates)),
+	}
+
+	for _, id := range domain.EmailTemplates {
+		text, err := texttemplate.New(string(id)).
+			Funcs(funcs).
+			Option("missingkey=error").
+			ParseFS(templateFiles, "templates/partials.txt", "templates/"+string(id)+".txt")
+		if err != nil {
+			return nil, fmt.Errorf("error parsing text template %s: %w", id, err)
+		}
+
+		html, err := htmltemplate.New(string(id)).
+			Funcs(funcs).
+			Option("missingkey=error").
+			ParseFS(templateFiles, "templates/layout.html", "templates/"+string(id)+".html")
+		if err != nil {
+			return nil, fmt.Errorf("error parsing HTML template %s: %w", id, err)
+		}
+
+		renderer.text[id] = text
+		renderer.html[id] = html
+	}
+
+	return renderer, nil
+}
+
+func (renderer *TemplateRenderer) Render(message *domain.EmailMessage) (*domain.RenderedEmail, error) {
+	text, ok := renderer.text[message.Template]
+	if !ok {
+		return nil, fmt.Errorf("unknown email template %q", message.Template)
+	}
+	html := renderer.html[message.Template]
+
+	// Every template may show an unsubscribe link, so the key must be present
+	// even for emails that have none.
+	unsubscribeURL, _ := message.Data[domain.UnsubscribeURLKey].(string)
+	data := make(domain.EmailData, len(message.Data)+1)
+	for key, value := range message.Data {
+		data[key] = value
+	}
+	data[domain.UnsubscribeURLKey] = unsubscribeURL
+
+	var subject, textBody, htmlBody bytes.Buffer
+
+	if err := text.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return nil, fmt.Errorf("error rendering subject of %s: %w", message.Template, err)
+	}
+	if err := text.ExecuteTemplate(&textBody, "text", data); err != nil {
+		return nil, fmt.Errorf("error rendering text of %s: %w", message.Template, err)
+	}
+	if err := html.ExecuteTemplate(&htmlBody, "layout", data); err != nil {
+		return nil, fmt.Errorf("error rendering HTML of %s: %w", message.Template, err)
+	}
+
+	return &domain.RenderedEmail{
+		Subject:        strings.Join(strings.Fields(subject.String()), " "),
+		Text:           strings.TrimSpace(textBody.String()) + "\n",
+		HTML:           htmlBody.String(),
+		UnsubscribeURL: unsubscribeURL,
+	}, nil
+}
+
+// linkFunc returns the template function that builds a deep link into the app
+// from escaped path segments.
+func linkFunc(appURL string) func(segments ...any) string {
+	base := strings.TrimRight(appURL, "/")
+
+	return func(segments ...any) string {
+		var link strings.Builder
+		link.WriteString(base)
+		for _, segment := range segments {
+			link.WriteString("/" + url.PathEscape(fmt.Sprint(segment)))
+		}
+
+		return link.String()
+	}
+}
+
+// assetFunc returns the template function that makes a picture URL absolute,
+// since mail clients cannot resolve relative ones.
+func assetFunc(assetURL string) func(pictureURL string) string {
+	base := strings.TrimRight(assetURL, "/")
+
+	return func(pictureURL string) string {
+		if strings.HasPrefix(pictureURL, "http://") || strings.HasPrefix(pictureURL, "https://") {
+			return pictureURL
+		}
+
+		return base + "/" + strings.TrimLeft(pictureURL, "/")
+	}
+}
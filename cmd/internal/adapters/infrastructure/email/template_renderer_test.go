@@ -0,0 +1,127 @@
+package email_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"swapp-go/cmd/internal/adapters/infrastructure/email"
+	"swapp-go/cmd/internal/application/ports"
+	"swapp-go/cmd/internal/domain"
+	"testing"
+)
+
+func newTestRenderer(t *testing.T) ports.EmailRenderer {
+	t.Helper()
+
+	renderer, err := email.NewTemplateRenderer("https://swapp.example.com/", "https://cdn.example.com")
+	if err != nil {
+		t.Fatalf("failed to parse templates: %v", err)
+	}
+
+	return renderer
+}
+
+func testEmailData() domain.EmailData {
+	return domain.EmailData{
+		"Username":        "alice",
+		"Counterpart":     "bob",
+		"IsSender":        true,
+		"Reference":       "SW-1234",
+		"SwapRequestID":   "3f0c1b7e-0000-4000-8000-000000000001",
+		"VerificationURL": "https://swapp.example.com/verify?token=abc",
+		"ResetURL":        "https://swapp.example.com/reset?token=abc",
+		"LockedUntil":     "2025-03-01 12:15 UTC",
+		"ExpiresAt":       "Sat, 01 Mar 2025 12:00:00 UTC",
+		"OfferedItems": []any{
+			map[string]any{"Name": "Road bike", "PictureURL": "uploads/bike.jpg"},
+		},
+		"RequestedItems": []any{
+			map[string]any{"Name": "Guitar & amp", "PictureURL": ""},
+		},
+	}
+}
+
+func TestTemplateRenderer_Render(t *testing.T) {
+	renderer := newTestRenderer(t)
+
+	t.Run("every template renders", func(t *testing.T) {
+		for _, template := range domain.EmailTemplates {
+			rendered, err := renderer.Render(&domain.EmailMessage{
+				Recipient: "alice@example.com",
+				Template:  template,
+				Data:      testEmailData(),
+			})
+			if err != nil {
+				t.Fatalf("failed to render %s: %v", template, err)
+			}
+
+			assert.NotEmpty(t, rendered.Subject, template)
+			assert.NotContains(t, rendered.Subject, "\n", template)
+			assert.Contains(t, rendered.Text, "alice", template)
+			assert.Contains(t, rendered.HTML, "alice", template)
+		}
+	})
+
+	t.Run("swap email shows items, pictures and a deep link", func(t *testing.T) {
+		rendered, err := renderer.Render(&domain.EmailMessage{
+			Template: domain.EmailTemplateSwapRequestCreated,
+			Data:     testEmailData(),
+		})
+		if err != nil {
+			t.Fatalf("failed to render: %v", err)
+		}
+
+		link := "https://swapp.example.com/swap-requests/3f0c1b7e-0000-4000-8000-000000000001"
+
+		assert.Equal(t, "New swap request from bob (reference SW-1234)", rendered.Subject)
+		assert.Contains(t, rendered.Text, "Road bike")
+		assert.Contains(t, rendered.Text, "Guitar & amp")
+		assert.Contains(t, rendered.Text, link)
+		assert.Contains(t, rendered.HTML, "Road bike")
+		assert.Contains(t, rendered.HTML, "Guitar &amp; amp")
+		assert.Contains(t, rendered.HTML, `src="https://cdn.example.com/uploads/bike.jpg"`)
+		assert.Contains(t, rendered.HTML, `href="`+link+`"`)
+	})
+
+	t.Run("unsubscribe link", func(t *testing.T) {
+		data := testEmailData()
+		data[domain.UnsubscribeURLKey] = "https://api.example.com/notifications/unsubscribe?token=abc"
+
+		rendered, err := renderer.Render(&domain.EmailMessage{
+			Template: domain.EmailTemplateSwapRequestAccepted,
+			Data:     data,
+		})
+		if err != nil {
+			t.Fatalf("failed to render: %v", err)
+		}
+
+		assert.Equal(t, "https://api.example.com/notifications/unsubscribe?token=abc", rendered.UnsubscribeURL)
+		assert.Contains(t, rendered.Text, "https://api.example.com/notifications/unsubscribe?token=abc")
+		assert.Contains(t, rendered.HTML, `href="https://api.example.com/notifications/unsubscribe?token=abc"`)
+
+		withoutLink, err := renderer.Render(&domain.EmailMessage{
+			Template: domain.EmailTemplateSwapRequestAccepted,
+			Data:     testEmailData(),
+		})
+		if err != nil {
+			t.Fatalf("failed to render: %v", err)
+		}
+
+		assert.Empty(t, withoutLink.UnsubscribeURL)
+		assert.NotContains(t, withoutLink.HTML, "Unsubscribe")
+	})
+
+	t.Run("missing data", func(t *testing.T) {
+		_, err := renderer.Render(&domain.EmailMessage{
+			Template: domain.EmailTemplatePasswordReset,
+			Data:     domain.EmailData{"Username": "alice"},
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown template", func(t *testing.T) {
+		_, err := renderer.Render(&domain.EmailMessage{
+			Template: domain.EmailTemplate("unknown"),
+			Data:     testEmailData(),
+		})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,124 @@
+package imaging
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"io"
+	"net/http"
+	"swapp-go/cmd/internal/application/ports"
+	"swapp-go/cmd/internal/config"
+	"swapp-go/cmd/internal/domain"
+
+	_ "image/gif"
+	_ "image/png"
+)
+
+// sniffedTypes are the content types, as detected from the file's leading
+// bytes, that uploads may have. The client's own content type is ignored.
+var sniffedTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+}
+
+// ImageProcessor normalizes uploads with the standard library codecs. Every
+// picture is re-encoded as a JPEG, which drops EXIF, GPS and any other
+// metadata of the upload once its orientation has been applied.
+type ImageProcessor struct {
+	config config.UploadConfig
+}
+
+func NewImageProcessor(uploadConfig config.UploadConfig) ports.ImageProcessor {
+	return &ImageProcessor{config: uploadConfig}
+}
+
+func (processor *ImageProcessor) Process(upload domain.PictureUpload) (*domain.ProcessedImage, error) {
+	if upload.Size > processor.config.MaxBytes {
+		return nil, domain.ImageTooLargeErr
+	}
+
+	data, err := io.ReadAll(io.LimitReader(upload.Content, processor.config.MaxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > processor.config.MaxBytes {
+		return nil, domain.ImageTooLargeErr
+	}
+
+	contentType := http.DetectContentType(data)
+	if !sniffedTypes[contentType] {
+		return nil, domain.UnsupportedImageTypeErr
+	}
+
+	// Check the dimensions from the header so that oversized images are
+	// rejected before their pixels are allocated.
+	imageConfig, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, domain.InvalidImageErr
+	}
+	if !processor.acceptsDimensions(imageConfig.Width, imageConfig.Height) {
+		return nil, domain.ImageDimensionsErr
+	}
+
+	decoded, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, domain.InvalidImageErr
+	}
+
+	picture := flatten(decoded)
+	if contentType == "image/jpeg" {
+		picture = applyOrientation(picture, jpegOrientation(data))
+	}
+
+	picture = fit(picture, processor.config.PictureDimension)
+
+	processed := &domain.ProcessedImage{ContentType: "image/jpeg", Extension: ".jpg"}
+	if processed.Picture, err = processor.encode("", picture); err != nil {
+		return nil, err
+	}
+
+	for _, variant := range processor.config.Variants {
+		encoded, err := processor.encode(variant.Name, fit(picture, variant.MaxDimension))
+		if err != nil {
+			return nil, err
+		}
+		processed.Variants = append(processed.Variants, encoded)
+	}
+
+	return processed, nil
+}
+
+func (processor *ImageProcessor) acceptsDimensions(width, height int) bool {
+	return width >= processor.config.MinDimension && height >= processor.config.MinDimension &&
+		width <= processor.config.MaxDimension && height <= processor.config.MaxDimension &&
+		width*height <= processor.config.MaxPixels
+}
+
+func (processor *ImageProcessor) encode(name string, picture *image.RGBA) (domain.EncodedImage, error) {
+	var buffer bytes.Buffer
+	if err := jpeg.Encode(&buffer, picture, &jpeg.Options{Quality: processor.config.JPEGQuality}); err != nil {
+		return domain.EncodedImage{}, err
+	}
+
+	return domain.EncodedImage{
+		Name:    name,
+		Width:   picture.Bounds().Dx(),
+		Height:  picture.Bounds().Dy(),
+		Content: buffer.Bytes(),
+	}, nil
+}
+
+// flatten copies the image into an RGBA buffer anchored at the origin,
+// compositing any transparency onto white since JPEG has no alpha channel.
+func flatten(source image.Image) *image.RGBA {
+	bounds := source.Bounds()
+	flattened := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+
+	draw.Draw(flattened, flattened.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(flattened, flattened.Bounds(), source, bounds.Min, draw.Over)
+
+	return flattened
+}
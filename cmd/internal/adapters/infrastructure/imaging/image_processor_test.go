@@ -0,0 +1,222 @@
+package imaging_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"github.com/stretchr/testify/assert"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"swapp-go/cmd/internal/adapters/infrastructure/imaging"
+	"swapp-go/cmd/internal/config"
+	"swapp-go/cmd/internal/domain"
+	"testing"
+)
+
+func testUploadConfig() config.UploadConfig {
+	return config.UploadConfig{
+		MaxBytes:         1 << 20,
+		MinDimension:     16,
+		MaxDimension:     1000,
+		MaxPixels:        500_000,
+		PictureDimension: 200,
+		JPEGQuality:      90,
+		Variants: []config.ImageVariantConfig{
+			{Name: "medium", MaxDimension: 100},
+			{Name: "thumbnail", MaxDimension: 32},
+		},
+	}
+}
+
+// halvesImage returns an image whose left half is red and right half is blue.
+func halvesImage(width, height int) *image.RGBA {
+	picture := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if x < width/2 {
+				picture.Set(x, y, color.RGBA{R: 255, A: 255})
+			} else {
+				picture.Set(x, y, color.RGBA{B: 255, A: 255})
+			}
+		}
+	}
+
+	return picture
+}
+
+func encodeJPEG(t *testing.T, picture image.Image) []byte {
+	t.Helper()
+
+	var buffer bytes.Buffer
+	if err := jpeg.Encode(&buffer, picture, &jpeg.Options{Quality: 95}); err != nil {
+		t.Fatalf("failed to encode jpeg: %v", err)
+	}
+
+	return buffer.Bytes()
+}
+
+// withExif inserts an APP1 segment holding an orientation tag and a GPS IFD
+// pointer right after the start of image marker.
+func withExif(data []byte, orientation uint16) []byte {
+	var tiff bytes.Buffer
+	tiff.WriteString("MM")
+	binary.Write(&tiff, binary.BigEndian, uint16(42))
+	binary.Write(&tiff, binary.BigEndian, uint32(8))
+	binary.Write(&tiff, binary.BigEndian, uint16(2))
+	// Orientation, SHORT, one value.
+	binary.Write(&tiff, binary.BigEndian, []uint16{0x0112, 3})
+	binary.Write(&tiff, binary.BigEndian, uint32(1))
+	binary.Write(&tiff, binary.BigEndian, []uint16{orientation, 0})
+	// GPS IFD pointer, LONG, one value.
+	binary.Write(&tiff, binary.BigEndian, []uint16{0x8825, 4})
+	binary.Write(&tiff, binary.BigEndian, uint32(1))
+	binary.Write(&tiff, binary.BigEndian, uint32(38))
+	binary.Write(&tiff, binary.BigEndian, uint32(0))
+	tiff.WriteString("GPS 52.3676N 4.9041E")
+
+	payload := append([]byte("Exif\x00\x00"), tiff.Bytes()...)
+
+	var result bytes.Buffer
+	result.Write(data[:2])
+	result.Write([]byte{0xFF, 0xE1})
+	binary.Write(&result, binary.BigEndian, uint16(len(payload)+2))
+	result.Write(payload)
+	result.Write(data[2:])
+
+	return result.Bytes()
+}
+
+func upload(data []byte) domain.PictureUpload {
+	return domain.PictureUpload{
+		Filename:    "photo.jpg",
+		ContentType: "image/jpeg",
+		Size:        int64(len(data)),
+		Content:     bytes.NewReader(data),
+	}
+}
+
+func decode(t *testing.T, content []byte) image.Image {
+	t.Helper()
+
+	decoded, format, err := image.Decode(bytes.NewReader(content))
+	if err != nil {
+		t.Fatalf("failed to decode output: %v", err)
+	}
+	assert.Equal(t, "jpeg", format)
+
+	return decoded
+}
+
+func isRed(c color.Color) bool {
+	r, g, b, _ := c.RGBA()
+	return r > 0xC000 && g < 0x4000 && b < 0x4000
+}
+
+func isBlue(c color.Color) bool {
+	r, g, b, _ := c.RGBA()
+	return b > 0xC000 && r < 0x4000 && g < 0x4000
+}
+
+func TestImageProcessor(t *testing.T) {
+	processor := imaging.NewImageProcessor(testUploadConfig())
+
+	t.Run("applies orientation and strips metadata", func(t *testing.T) {
+		data := withExif(encodeJPEG(t, halvesImage(120, 60)), 6)
+
+		processed, err := processor.Process(upload(data))
+		assert.NoError(t, err)
+		assert.Equal(t, "image/jpeg", processed.ContentType)
+		assert.Equal(t, ".jpg", processed.Extension)
+
+		assert.Equal(t, 60, processed.Picture.Width)
+		assert.Equal(t, 120, processed.Picture.Height)
+		assert.NotContains(t, string(processed.Picture.Content), "Exif")
+		assert.NotContains(t, string(processed.Picture.Content), "GPS")
+
+		// Rotated clockwise, the red left half ends up on top.
+		picture := decode(t, processed.Picture.Content)
+		assert.True(t, isRed(picture.At(30, 10)))
+		assert.True(t, isBlue(picture.At(30, 110)))
+	})
+
+	t.Run("scales the picture and its variants down", func(t *testing.T) {
+		processed, err := processor.Process(upload(encodeJPEG(t, halvesImage(400, 100))))
+		assert.NoError(t, err)
+
+		assert.Equal(t, 200, processed.Picture.Width)
+		assert.Equal(t, 50, processed.Picture.Height)
+
+		assert.Len(t, processed.Variants, 2)
+		assert.Equal(t, "medium", processed.Variants[0].Name)
+		assert.Equal(t, 100, processed.Variants[0].Width)
+		assert.Equal(t, 25, processed.Variants[0].Height)
+		assert.Equal(t, "thumbnail", processed.Variants[1].Name)
+		assert.Equal(t, 32, processed.Variants[1].Width)
+		assert.Equal(t, 8, processed.Variants[1].Height)
+
+		thumbnail := decode(t, processed.Variants[1].Content)
+		assert.Equal(t, image.Rect(0, 0, 32, 8), thumbnail.Bounds())
+		assert.True(t, isRed(thumbnail.At(4, 4)))
+		assert.True(t, isBlue(thumbnail.At(28, 4)))
+	})
+
+	t.Run("converts png and flattens transparency onto white", func(t *testing.T) {
+		transparent := image.NewNRGBA(image.Rect(0, 0, 40, 40))
+
+		var buffer bytes.Buffer
+		assert.NoError(t, png.Encode(&buffer, transparent))
+
+		processed, err := processor.Process(upload(buffer.Bytes()))
+		assert.NoError(t, err)
+
+		r, g, b, _ := decode(t, processed.Picture.Content).At(20, 20).RGBA()
+		assert.Greater(t, r, uint32(0xF000))
+		assert.Greater(t, g, uint32(0xF000))
+		assert.Greater(t, b, uint32(0xF000))
+	})
+
+	t.Run("rejects files that are too large", func(t *testing.T) {
+		data := encodeJPEG(t, halvesImage(40, 40))
+
+		smallConfig := testUploadConfig()
+		smallConfig.MaxBytes = int64(len(data) - 1)
+		smallProcessor := imaging.NewImageProcessor(smallConfig)
+
+		_, err := smallProcessor.Process(upload(data))
+		assert.ErrorIs(t, err, domain.ImageTooLargeErr)
+
+		// The declared size is not trusted.
+		lying := upload(data)
+		lying.Size = 10
+		_, err = smallProcessor.Process(lying)
+		assert.ErrorIs(t, err, domain.ImageTooLargeErr)
+	})
+
+	t.Run("rejects unsupported content whatever its name", func(t *testing.T) {
+		for _, data := range [][]byte{
+			[]byte("MZ\x90\x00\x03\x00\x00\x00 executable"),
+			[]byte("%PDF-1.4 document"),
+			[]byte("<html><script>alert(1)</script></html>"),
+		} {
+			_, err := processor.Process(upload(data))
+			assert.ErrorIs(t, err, domain.UnsupportedImageTypeErr)
+		}
+	})
+
+	t.Run("rejects corrupt images", func(t *testing.T) {
+		_, err := processor.Process(upload([]byte("\xFF\xD8\xFF\xE0 not really a jpeg")))
+		assert.ErrorIs(t, err, domain.InvalidImageErr)
+	})
+
+	t.Run("rejects out of bounds dimensions", func(t *testing.T) {
+		for _, picture := range []image.Image{
+			halvesImage(8, 100),
+			halvesImage(1200, 100),
+			halvesImage(900, 900),
+		} {
+			_, err := processor.Process(upload(encodeJPEG(t, picture)))
+			assert.ErrorIs(t, err, domain.ImageDimensionsErr)
+		}
+	})
+}
@@ -0,0 +1,95 @@
+package imaging
+
+import (
+	"image"
+	"math"
+)
+
+// contribution is the share of a source row or column in a destination pixel.
+type contribution struct {
+	index  int
+	weight float64
+}
+
+// fit scales the image down so that neither side exceeds maxDimension, keeping
+// its aspect ratio. Images that already fit are returned as they are.
+func fit(source *image.RGBA, maxDimension int) *image.RGBA {
+	width, height := source.Bounds().Dx(), source.Bounds().Dy()
+	if width <= maxDimension && height <= maxDimension {
+		return source
+	}
+
+	scale := float64(maxDimension) / float64(max(width, height))
+	targetWidth := max(1, int(math.Round(float64(width)*scale)))
+	targetHeight := max(1, int(math.Round(float64(height)*scale)))
+
+	return resize(source, targetWidth, targetHeight)
+}
+
+// resize scales the image down with a box filter: every destination pixel is
+// the area-weighted average of the source pixels it covers. Rows are resampled
+// one at a time so that memory stays proportional to the destination width.
+func resize(source *image.RGBA, width, height int) *image.RGBA {
+	columns := boxContributions(source.Bounds().Dx(), width)
+	rows := boxContributions(source.Bounds().Dy(), height)
+
+	destination := image.NewRGBA(image.Rect(0, 0, width, height))
+	resampledRow := make([]float64, width*4)
+	accumulated := make([]float64, width*4)
+
+	for y, rowContributions := range rows {
+		clear(accumulated)
+
+		for _, row := range rowContributions {
+			resampleRow(source, row.index, columns, resampledRow)
+			for i, value := range resampledRow {
+				accumulated[i] += value * row.weight
+			}
+		}
+
+		offset := destination.PixOffset(0, y)
+		for i, value := range accumulated {
+			destination.Pix[offset+i] = uint8(math.Min(255, math.Round(value)))
+		}
+	}
+
+	return destination
+}
+
+func resampleRow(source *image.RGBA, y int, columns [][]contribution, row []float64) {
+	rowOffset := source.PixOffset(0, y)
+
+	for x, columnContributions := range columns {
+		var red, green, blue, alpha float64
+		for _, column := range columnContributions {
+			pixel := source.Pix[rowOffset+column.index*4:]
+			red += float64(pixel[0]) * column.weight
+			green += float64(pixel[1]) * column.weight
+			blue += float64(pixel[2]) * column.weight
+			alpha += float64(pixel[3]) * column.weight
+		}
+
+		row[x*4], row[x*4+1], row[x*4+2], row[x*4+3] = red, green, blue, alpha
+	}
+}
+
+// boxContributions maps each of the target positions to the source positions
+// it covers, weighted by coverage so that the weights of a target sum to one.
+func boxContributions(sourceSize, targetSize int) [][]contribution {
+	scale := float64(sourceSize) / float64(targetSize)
+	contributions := make([][]contribution, targetSize)
+
+	for target := range contributions {
+		start := float64(target) * scale
+		end := math.Min(float64(sourceSize), start+scale)
+
+		for index := int(start); float64(index) < end; index++ {
+			coverage := math.Min(end, float64(index+1)) - math.Max(start, float64(index))
+			if coverage > 0 {
+				contributions[target] = append(contributions[target], contribution{index: index, weight: coverage / scale})
+			}
+		}
+	}
+
+	return contributions
+}
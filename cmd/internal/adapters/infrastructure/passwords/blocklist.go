@@ -0,0 +1,62 @@
+package passwords
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// commonPasswords are among the most used passwords in public breach corpora.
+//
+//go:embed common_passwords.txt
+var commonPasswords string
+
+// Blocklist is a set of forbidden passwords, compared case-insensitively.
+type Blocklist struct {
+	passwords map[string]struct{}
+}
+
+// NewBlocklist returns the built-in list of common passwords, extended with
+// the passwords in extraFile, one per line, when it is set.
+func NewBlocklist(extraFile string) (*Blocklist, error) {
+	blocklist := &Blocklist{passwords: make(map[string]struct{})}
+
+	if err := blocklist.add(strings.NewReader(commonPasswords)); err != nil {
+		return nil, err
+	}
+
+	if extraFile == "" {
+		return blocklist, nil
+	}
+
+	file, err := os.Open(extraFile)
+	if err != nil {
+		return nil, fmt.Errorf("error opening password blocklist: %w", err)
+	}
+	defer file.Close()
+
+	if err = blocklist.add(file); err != nil {
+		return nil, fmt.Errorf("error reading password blocklist %s: %w", extraFile, err)
+	}
+
+	return blocklist, nil
+}
+
+func (blocklist *Blocklist) Contains(password string) bool {
+	_, ok := blocklist.passwords[strings.ToLower(password)]
+	return ok
+}
+
+func (blocklist *Blocklist) add(reader io.Reader) error {
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		if password := strings.TrimSpace(scanner.Text()); password != "" {
+			blocklist.passwords[strings.ToLower(password)] = struct{}{}
+		}
+	}
+
+	return scanner.Err()
+}
@@ -0,0 +1,37 @@
+package passwords_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"swapp-go/cmd/internal/adapters/infrastructure/passwords"
+	"testing"
+)
+
+func TestBlocklist(t *testing.T) {
+	t.Run("built-in list", func(t *testing.T) {
+		blocklist, err := passwords.NewBlocklist("")
+		assert.NoError(t, err)
+
+		assert.True(t, blocklist.Contains("password123"))
+		assert.True(t, blocklist.Contains("PassWord123"))
+		assert.False(t, blocklist.Contains("correct horse battery staple"))
+	})
+
+	t.Run("extra file", func(t *testing.T) {
+		extraFile := filepath.Join(t.TempDir(), "breached.txt")
+		assert.NoError(t, os.WriteFile(extraFile, []byte("Tr0ub4dor&3\n\n  hunter3  \n"), 0o600))
+
+		blocklist, err := passwords.NewBlocklist(extraFile)
+		assert.NoError(t, err)
+
+		assert.True(t, blocklist.Contains("tr0ub4dor&3"))
+		assert.True(t, blocklist.Contains("hunter3"))
+		assert.True(t, blocklist.Contains("qwerty"))
+	})
+
+	t.Run("missing extra file", func(t *testing.T) {
+		_, err := passwords.NewBlocklist(filepath.Join(t.TempDir(), "missing.txt"))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,28 @@
+package storage
+
+import (
+	"errors"
+	"strings"
+)
+
+var InvalidKeyErr = errors.New("invalid blob key")
+
+// validateKey rejects keys that are empty, absolute or could escape the store's
+// root through "." or ".." segments.
+func validateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return InvalidKeyErr
+	}
+
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return InvalidKeyErr
+		}
+	}
+
+	return nil
+}
+
+func joinURL(base, key string) string {
+	return strings.TrimSuffix(base, "/") + "/" + key
+}
@@ -0,0 +1,73 @@
+package storage
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"swapp-go/cmd/internal/application/ports"
+)
+
+// LocalBlobStore keeps blobs as files under a directory that the API serves
+// itself. It suits single-instance deployments and development.
+type LocalBlobStore struct {
+	dir       string
+	publicURL string
+}
+
+func NewLocalBlobStore(dir, publicURL string) ports.BlobStore {
+	return &LocalBlobStore{dir: dir, publicURL: publicURL}
+}
+
+// Put writes to a temporary file first so that readers never see a partial blob.
+func (store *LocalBlobStore) Put(key string, content io.Reader, _ int64, _ string) error {
+	path, err := store.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	tempFile, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tempFile.Name())
+
+	if _, err = io.Copy(tempFile, content); err != nil {
+		tempFile.Close()
+		return err
+	}
+	if err = tempFile.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tempFile.Name(), path)
+}
+
+// Delete removes the blob. Deleting a missing blob is not an error.
+func (store *LocalBlobStore) Delete(key string) error {
+	path, err := store.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err = os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+func (store *LocalBlobStore) URL(key string) string {
+	return joinURL(store.publicURL, key)
+}
+
+func (store *LocalBlobStore) path(key string) (string, error) {
+	if err := validateKey(key); err != nil {
+		return "", err
+	}
+
+	return filepath.Join(store.dir, filepath.FromSlash(key)), nil
+}
@@ -0,0 +1,62 @@
+package storage_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"strings"
+	"swapp-go/cmd/internal/adapters/infrastructure/storage"
+	"testing"
+)
+
+func TestLocalBlobStore(t *testing.T) {
+	dir := t.TempDir()
+	store := storage.NewLocalBlobStore(dir, "/uploads")
+
+	t.Run("Put and Delete", func(t *testing.T) {
+		err := store.Put("items/1/photo.jpg", strings.NewReader("image bytes"), 11, "image/jpeg")
+		assert.NoError(t, err)
+
+		content, err := os.ReadFile(filepath.Join(dir, "items", "1", "photo.jpg"))
+		assert.NoError(t, err)
+		assert.Equal(t, "image bytes", string(content))
+
+		entries, err := os.ReadDir(filepath.Join(dir, "items", "1"))
+		assert.NoError(t, err)
+		assert.Len(t, entries, 1)
+
+		assert.NoError(t, store.Delete("items/1/photo.jpg"))
+		_, err = os.Stat(filepath.Join(dir, "items", "1", "photo.jpg"))
+		assert.True(t, os.IsNotExist(err))
+
+		assert.NoError(t, store.Delete("items/1/photo.jpg"))
+	})
+
+	t.Run("URL", func(t *testing.T) {
+		assert.Equal(t, "/uploads/items/1/photo.jpg", store.URL("items/1/photo.jpg"))
+	})
+
+	t.Run("invalid keys", func(t *testing.T) {
+		for _, key := range []string{"", "/etc/passwd", "../outside.jpg", "items/../../outside.jpg", "items//photo.jpg", `items\photo.jpg`} {
+			err := store.Put(key, strings.NewReader("data"), 4, "")
+			assert.ErrorIs(t, err, storage.InvalidKeyErr, key)
+		}
+	})
+}
+
+func TestMemoryBlobStore(t *testing.T) {
+	store := storage.NewMemoryBlobStore("https://files.test")
+
+	assert.NoError(t, store.Put("items/1/photo.jpg", strings.NewReader("image bytes"), 11, "image/jpeg"))
+
+	blob, ok := store.Get("items/1/photo.jpg")
+	assert.True(t, ok)
+	assert.Equal(t, "image bytes", string(blob.Content))
+	assert.Equal(t, "image/jpeg", blob.ContentType)
+	assert.Equal(t, []string{"items/1/photo.jpg"}, store.Keys())
+	assert.Equal(t, "https://files.test/items/1/photo.jpg", store.URL("items/1/photo.jpg"))
+
+	assert.NoError(t, store.Delete("items/1/photo.jpg"))
+	_, ok = store.Get("items/1/photo.jpg")
+	assert.False(t, ok)
+}
@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"io"
+	"sync"
+)
+
+// MemoryBlobStore keeps blobs in memory. It is meant for tests and local runs
+// where uploads do not need to survive a restart.
+type MemoryBlobStore struct {
+	mutex     sync.RWMutex
+	publicURL string
+	blobs     map[string]MemoryBlob
+}
+
+type MemoryBlob struct {
+	Content     []byte
+	ContentType string
+}
+
+func NewMemoryBlobStore(publicURL string) *MemoryBlobStore {
+	return &MemoryBlobStore{publicURL: publicURL, blobs: make(map[string]MemoryBlob)}
+}
+
+func (store *MemoryBlobStore) Put(key string, content io.Reader, _ int64, contentType string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return err
+	}
+
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	store.blobs[key] = MemoryBlob{Content: data, ContentType: contentType}
+
+	return nil
+}
+
+func (store *MemoryBlobStore) Delete(key string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	delete(store.blobs, key)
+
+	return nil
+}
+
+func (store *MemoryBlobStore) URL(key string) string {
+	return joinURL(store.publicURL, key)
+}
+
+// Get returns the blob stored under key, if any.
+func (store *MemoryBlobStore) Get(key string) (MemoryBlob, bool) {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	blob, ok := store.blobs[key]
+
+	return blob, ok
+}
+
+// Keys lists the keys of all stored blobs.
+func (store *MemoryBlobStore) Keys() []string {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	keys := make([]string, 0, len(store.blobs))
+	for key := range store.blobs {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
@@ -0,0 +1,120 @@
+package storage
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"swapp-go/cmd/internal/application/ports"
+	"swapp-go/cmd/internal/config"
+	"time"
+)
+
+const s3RequestTimeout = 30 * time.Second
+
+// S3BlobStore keeps blobs in a bucket of an S3-compatible object store such as
+// AWS S3 or MinIO, so that every replica of the API sees the same files.
+type S3BlobStore struct {
+	config    config.S3Config
+	publicURL string
+	client    *http.Client
+	clock     ports.Clock
+	signer    sigV4Signer
+}
+
+func NewS3BlobStore(s3Config config.S3Config, publicURL string, clock ports.Clock) ports.BlobStore {
+	return &S3BlobStore{
+		config:    s3Config,
+		publicURL: publicURL,
+		client:    &http.Client{Timeout: s3RequestTimeout},
+		clock:     clock,
+		signer: sigV4Signer{
+			accessKeyID:     s3Config.AccessKeyID,
+			secretAccessKey: s3Config.SecretAccessKey,
+			region:          s3Config.Region,
+			service:         "s3",
+		},
+	}
+}
+
+// Put uploads the blob in a single request. The content is buffered because
+// the signature covers a hash of the payload.
+func (store *S3BlobStore) Put(key string, content io.Reader, _ int64, contentType string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequest(http.MethodPut, store.objectURL(key), bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	if contentType != "" {
+		request.Header.Set("Content-Type", contentType)
+	}
+
+	return store.do(request, hashHex(data))
+}
+
+// Delete removes the object. Deleting a missing object is not an error.
+func (store *S3BlobStore) Delete(key string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+
+	request, err := http.NewRequest(http.MethodDelete, store.objectURL(key), nil)
+	if err != nil {
+		return err
+	}
+
+	return store.do(request, hashHex(nil))
+}
+
+func (store *S3BlobStore) URL(key string) string {
+	if store.publicURL != "" {
+		return joinURL(store.publicURL, uriEncode(key, false))
+	}
+
+	return store.objectURL(key)
+}
+
+func (store *S3BlobStore) objectURL(key string) string {
+	encodedKey := uriEncode(key, false)
+
+	if store.config.UsePathStyle {
+		return store.config.Endpoint + "/" + uriEncode(store.config.Bucket, true) + "/" + encodedKey
+	}
+
+	endpoint, err := url.Parse(store.config.Endpoint)
+	if err != nil || endpoint.Host == "" {
+		return store.config.Endpoint + "/" + uriEncode(store.config.Bucket, true) + "/" + encodedKey
+	}
+
+	return endpoint.Scheme + "://" + store.config.Bucket + "." + endpoint.Host + "/" + encodedKey
+}
+
+func (store *S3BlobStore) do(request *http.Request, payloadHash string) error {
+	store.signer.sign(request, payloadHash, store.clock.Now())
+
+	response, err := store.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 200 && response.StatusCode < 300 {
+		return nil
+	}
+	if request.Method == http.MethodDelete && response.StatusCode == http.StatusNotFound {
+		return nil
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(response.Body, 512))
+
+	return fmt.Errorf("s3 %s %s failed with status %d: %s", request.Method, request.URL.Path, response.StatusCode, bytes.TrimSpace(body))
+}
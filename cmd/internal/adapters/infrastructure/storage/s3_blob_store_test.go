@@ -0,0 +1,120 @@
+package storage_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"swapp-go/cmd/internal/adapters/infrastructure/storage"
+	"swapp-go/cmd/internal/application/mocks"
+	"swapp-go/cmd/internal/config"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeS3 is a minimal S3 stand-in that keeps objects in memory and checks the
+// shape of the signature headers of every request.
+type fakeS3 struct {
+	t       *testing.T
+	mutex   sync.Mutex
+	objects map[string]fakeS3Object
+	failPut bool
+}
+
+type fakeS3Object struct {
+	body        []byte
+	contentType string
+}
+
+func (fake *fakeS3) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	body, _ := io.ReadAll(request.Body)
+	sum := sha256.Sum256(body)
+
+	authorization := request.Header.Get("Authorization")
+	assert.True(fake.t, strings.HasPrefix(authorization, "AWS4-HMAC-SHA256 Credential=test-key/20250102/eu-west-1/s3/aws4_request, SignedHeaders="), authorization)
+	assert.Contains(fake.t, authorization, "host;")
+	assert.Contains(fake.t, authorization, "x-amz-content-sha256;x-amz-date")
+	assert.Equal(fake.t, "20250102T030405Z", request.Header.Get("X-Amz-Date"))
+	assert.Equal(fake.t, hex.EncodeToString(sum[:]), request.Header.Get("X-Amz-Content-Sha256"))
+
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+
+	switch request.Method {
+	case http.MethodPut:
+		if fake.failPut {
+			http.Error(writer, "<Error><Code>AccessDenied</Code></Error>", http.StatusForbidden)
+			return
+		}
+		fake.objects[request.URL.Path] = fakeS3Object{body: body, contentType: request.Header.Get("Content-Type")}
+		writer.WriteHeader(http.StatusOK)
+	case http.MethodDelete:
+		delete(fake.objects, request.URL.Path)
+		writer.WriteHeader(http.StatusNoContent)
+	default:
+		writer.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func TestS3BlobStore(t *testing.T) {
+	fake := &fakeS3{t: t, objects: make(map[string]fakeS3Object)}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	clock := &mocks.Clock{Current: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)}
+	s3Config := config.S3Config{
+		Endpoint:        server.URL,
+		Region:          "eu-west-1",
+		Bucket:          "swapp",
+		AccessKeyID:     "test-key",
+		SecretAccessKey: "test-secret",
+		UsePathStyle:    true,
+	}
+	store := storage.NewS3BlobStore(s3Config, "", clock)
+
+	t.Run("Put and Delete", func(t *testing.T) {
+		err := store.Put("items/1/photo.jpg", strings.NewReader("image bytes"), 11, "image/jpeg")
+		assert.NoError(t, err)
+
+		object, ok := fake.objects["/swapp/items/1/photo.jpg"]
+		assert.True(t, ok)
+		assert.Equal(t, "image bytes", string(object.body))
+		assert.Equal(t, "image/jpeg", object.contentType)
+
+		assert.NoError(t, store.Delete("items/1/photo.jpg"))
+		assert.Empty(t, fake.objects)
+
+		assert.NoError(t, store.Delete("items/1/photo.jpg"))
+	})
+
+	t.Run("URL", func(t *testing.T) {
+		assert.Equal(t, server.URL+"/swapp/items/1/photo.jpg", store.URL("items/1/photo.jpg"))
+
+		cdnStore := storage.NewS3BlobStore(s3Config, "https://cdn.example.com/", clock)
+		assert.Equal(t, "https://cdn.example.com/items/1/photo.jpg", cdnStore.URL("items/1/photo.jpg"))
+
+		virtualHostConfig := s3Config
+		virtualHostConfig.Endpoint = "https://s3.eu-west-1.amazonaws.com"
+		virtualHostConfig.UsePathStyle = false
+		virtualHostStore := storage.NewS3BlobStore(virtualHostConfig, "", clock)
+		assert.Equal(t, "https://swapp.s3.eu-west-1.amazonaws.com/items/1/photo.jpg", virtualHostStore.URL("items/1/photo.jpg"))
+	})
+
+	t.Run("error status", func(t *testing.T) {
+		fake.failPut = true
+		defer func() { fake.failPut = false }()
+
+		err := store.Put("items/1/photo.jpg", strings.NewReader("image bytes"), 11, "image/jpeg")
+		assert.ErrorContains(t, err, "403")
+		assert.ErrorContains(t, err, "AccessDenied")
+	})
+
+	t.Run("invalid key", func(t *testing.T) {
+		err := store.Put("../secrets", strings.NewReader(""), 0, "")
+		assert.ErrorIs(t, err, storage.InvalidKeyErr)
+	})
+}
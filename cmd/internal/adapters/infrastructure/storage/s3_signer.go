@@ -0,0 +1,155 @@
+package storage
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	sigV4Algorithm  = "AWS4-HMAC-SHA256"
+	amzDateFormat   = "20060102T150405Z"
+	scopeDateFormat = "20060102"
+)
+
+// sigV4Signer signs requests with AWS Signature Version 4, as described in
+// https://docs.aws.amazon.com/AmazonS3/latest/API/sig-v4-header-based-auth.html.
+type sigV4Signer struct {
+	accessKeyID     string
+	secretAccessKey string
+	region          string
+	service         string
+}
+
+// sign sets the X-Amz-Date, X-Amz-Content-Sha256 and Authorization headers.
+// payloadHash is the hex encoded SHA-256 of the request body.
+func (signer sigV4Signer) sign(request *http.Request, payloadHash string, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format(amzDateFormat)
+	scopeDate := now.Format(scopeDateFormat)
+
+	request.Header.Set("X-Amz-Date", amzDate)
+	request.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	signedHeaders, headerBlock := canonicalHeaders(request)
+	canonicalRequest := strings.Join([]string{
+		request.Method,
+		canonicalURI(request.URL),
+		canonicalQuery(request.URL),
+		headerBlock,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := strings.Join([]string{scopeDate, signer.region, signer.service, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{sigV4Algorithm, amzDate, scope, hashHex([]byte(canonicalRequest))}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+signer.secretAccessKey), scopeDate)
+	signingKey = hmacSHA256(signingKey, signer.region)
+	signingKey = hmacSHA256(signingKey, signer.service)
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	request.Header.Set("Authorization", fmt.Sprintf(
+		"%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		sigV4Algorithm, signer.accessKeyID, scope, signedHeaders, signature,
+	))
+}
+
+// canonicalHeaders returns the signed header list and the canonical header
+// block. Host, Content-Type, Content-MD5, Range and every X-Amz-* header are signed.
+func canonicalHeaders(request *http.Request) (string, string) {
+	host := request.Host
+	if host == "" {
+		host = request.URL.Host
+	}
+	values := map[string]string{"host": host}
+
+	for name, headerValues := range request.Header {
+		lowerName := strings.ToLower(name)
+		switch {
+		case lowerName == "content-type", lowerName == "content-md5", lowerName == "range",
+			strings.HasPrefix(lowerName, "x-amz-"):
+		default:
+			continue
+		}
+
+		trimmed := make([]string, 0, len(headerValues))
+		for _, value := range headerValues {
+			trimmed = append(trimmed, strings.Join(strings.Fields(value), " "))
+		}
+		values[lowerName] = strings.Join(trimmed, ",")
+	}
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var block strings.Builder
+	for _, name := range names {
+		block.WriteString(name + ":" + values[name] + "\n")
+	}
+
+	return strings.Join(names, ";"), block.String()
+}
+
+func canonicalURI(requestURL *url.URL) string {
+	if path := requestURL.EscapedPath(); path != "" {
+		return path
+	}
+
+	return "/"
+}
+
+func canonicalQuery(requestURL *url.URL) string {
+	query := requestURL.Query()
+	pairs := make([]string, 0, len(query))
+	for name, values := range query {
+		for _, value := range values {
+			pairs = append(pairs, uriEncode(name, true)+"="+uriEncode(value, true))
+		}
+	}
+	sort.Strings(pairs)
+
+	return strings.Join(pairs, "&")
+}
+
+// uriEncode percent-encodes everything except the unreserved characters of
+// RFC 3986 and, unless encodeSlash is set, the slash.
+func uriEncode(value string, encodeSlash bool) string {
+	var encoded strings.Builder
+	for i := 0; i < len(value); i++ {
+		character := value[i]
+		switch {
+		case 'A' <= character && character <= 'Z', 'a' <= character && character <= 'z',
+			'0' <= character && character <= '9',
+			character == '-', character == '_', character == '.', character == '~':
+			encoded.WriteByte(character)
+		case character == '/' && !encodeSlash:
+			encoded.WriteByte(character)
+		default:
+			fmt.Fprintf(&encoded, "%%%02X", character)
+		}
+	}
+
+	return encoded.String()
+}
+
+func hashHex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
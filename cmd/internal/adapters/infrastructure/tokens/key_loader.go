@@ -0,0 +1,112 @@
+package tokens
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"swapp-go/cmd/internal/application/ports"
+	"swapp-go/cmd/internal/config"
+)
+
+// ephemeralKeyID names the key generated when no keys are configured.
+const ephemeralKeyID = "ephemeral"
+
+// LoadKeyRing reads the keys of the configured directory, one "<kid>.pem" file
+// per key. Without a directory it generates a throwaway Ed25519 key, which is
+// only suitable for development since tokens do not survive a restart.
+func LoadKeyRing(jwtConfig config.JWTConfig, clock ports.Clock) (*KeyRing, error) {
+	if jwtConfig.KeysDir == "" {
+		log.Printf("JWT_KEYS_DIR is not set, signing tokens with an ephemeral key")
+
+		_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+
+		key, err := NewSigningKey(ephemeralKeyID, privateKey)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewKeyRing([]SigningKey{key}, ephemeralKeyID, jwtConfig.Issuer, clock)
+	}
+
+	paths, err := filepath.Glob(filepath.Join(jwtConfig.KeysDir, "*.pem"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	var keys []SigningKey
+	var privateKeyIDs []string
+	for _, path := range paths {
+		keyID := strings.TrimSuffix(filepath.Base(path), ".pem")
+
+		key, err := readKeyFile(keyID, path)
+		if err != nil {
+			return nil, fmt.Errorf("key %s: %w", keyID, err)
+		}
+
+		keys = append(keys, key)
+		if key.CanSign() {
+			privateKeyIDs = append(privateKeyIDs, keyID)
+		}
+	}
+
+	activeKeyID := jwtConfig.ActiveKeyID
+	if activeKeyID == "" {
+		if len(privateKeyIDs) != 1 {
+			return nil, fmt.Errorf("%w: set JWT_ACTIVE_KEY_ID to pick one of %d private keys", NoActiveKeyErr, len(privateKeyIDs))
+		}
+		activeKeyID = privateKeyIDs[0]
+	}
+
+	return NewKeyRing(keys, activeKeyID, jwtConfig.Issuer, clock)
+}
+
+func readKeyFile(keyID string, path string) (SigningKey, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return SigningKey{}, err
+	}
+
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return SigningKey{}, fmt.Errorf("no PEM data found")
+	}
+
+	switch block.Type {
+	case "PRIVATE KEY":
+		privateKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return SigningKey{}, err
+		}
+		signer, ok := privateKey.(crypto.Signer)
+		if !ok {
+			return SigningKey{}, UnsupportedKeyErr
+		}
+		return NewSigningKey(keyID, signer)
+	case "RSA PRIVATE KEY":
+		privateKey, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return SigningKey{}, err
+		}
+		return NewSigningKey(keyID, privateKey)
+	case "PUBLIC KEY":
+		publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return SigningKey{}, err
+		}
+		return NewVerificationKey(keyID, publicKey)
+	default:
+		return SigningKey{}, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+}
@@ -0,0 +1,116 @@
+package tokens_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"swapp-go/cmd/internal/adapters/infrastructure/tokens"
+	"swapp-go/cmd/internal/application/mocks"
+	"swapp-go/cmd/internal/config"
+	"testing"
+)
+
+func writePEM(t *testing.T, dir, name, blockType string, der []byte) {
+	t.Helper()
+
+	content := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+	if err := os.WriteFile(filepath.Join(dir, name), content, 0o600); err != nil {
+		t.Fatalf("failed to write %s: %v", name, err)
+	}
+}
+
+func writeEd25519Keys(t *testing.T, dir, keyID string) ed25519.PublicKey {
+	t.Helper()
+
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	assert.NoError(t, err)
+	writePEM(t, dir, keyID+".pem", "PRIVATE KEY", der)
+
+	return publicKey
+}
+
+func tokenKeyID(t *testing.T, token string) string {
+	t.Helper()
+
+	parsed, _, err := jwt.NewParser().ParseUnverified(token, jwt.MapClaims{})
+	assert.NoError(t, err)
+
+	keyID, _ := parsed.Header["kid"].(string)
+
+	return keyID
+}
+
+func TestLoadKeyRing(t *testing.T) {
+	clock := &mocks.Clock{Current: testNow}
+
+	t.Run("single_private_key", func(t *testing.T) {
+		dir := t.TempDir()
+		writeEd25519Keys(t, dir, "2025-01")
+
+		ring, err := tokens.LoadKeyRing(config.JWTConfig{KeysDir: dir, Issuer: "swapp"}, clock)
+		assert.NoError(t, err)
+
+		token, err := ring.Issue(testClaims())
+		assert.NoError(t, err)
+		assert.Equal(t, "2025-01", tokenKeyID(t, token))
+	})
+
+	t.Run("rotated_keys", func(t *testing.T) {
+		dir := t.TempDir()
+
+		retiredPublicKey := writeEd25519Keys(t, dir, "2025-01")
+		der, err := x509.MarshalPKIXPublicKey(retiredPublicKey)
+		assert.NoError(t, err)
+		assert.NoError(t, os.Remove(filepath.Join(dir, "2025-01.pem")))
+		writePEM(t, dir, "2025-01.pem", "PUBLIC KEY", der)
+
+		rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		assert.NoError(t, err)
+		writePEM(t, dir, "2025-02.pem", "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaKey))
+		writeEd25519Keys(t, dir, "2025-03")
+
+		_, err = tokens.LoadKeyRing(config.JWTConfig{KeysDir: dir, Issuer: "swapp"}, clock)
+		assert.ErrorIs(t, err, tokens.NoActiveKeyErr, "two private keys need an explicit active key")
+
+		ring, err := tokens.LoadKeyRing(config.JWTConfig{KeysDir: dir, ActiveKeyID: "2025-02", Issuer: "swapp"}, clock)
+		assert.NoError(t, err)
+		assert.Len(t, ring.PublicKeys(), 3)
+		assert.Equal(t, "2025-01", ring.PublicKeys()[0].KeyID)
+
+		token, err := ring.Issue(testClaims())
+		assert.NoError(t, err)
+		assert.Equal(t, "2025-02", tokenKeyID(t, token))
+		assert.Equal(t, "RS256", ring.PublicKeys()[1].Algorithm)
+
+		_, err = tokens.LoadKeyRing(config.JWTConfig{KeysDir: dir, ActiveKeyID: "2025-01", Issuer: "swapp"}, clock)
+		assert.ErrorIs(t, err, tokens.NoActiveKeyErr, "a public key cannot sign")
+	})
+
+	t.Run("invalid_key_file", func(t *testing.T) {
+		dir := t.TempDir()
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "broken.pem"), []byte("not a key"), 0o600))
+
+		_, err := tokens.LoadKeyRing(config.JWTConfig{KeysDir: dir, Issuer: "swapp"}, clock)
+		assert.ErrorContains(t, err, "broken")
+	})
+
+	t.Run("ephemeral_key", func(t *testing.T) {
+		ring, err := tokens.LoadKeyRing(config.JWTConfig{Issuer: "swapp"}, clock)
+		assert.NoError(t, err)
+
+		token, err := ring.Issue(testClaims())
+		assert.NoError(t, err)
+
+		_, err = ring.Verify(token)
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,268 @@
+package tokens
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"math/big"
+	"swapp-go/cmd/internal/application/ports"
+	"swapp-go/cmd/internal/domain"
+)
+
+// minRSAKeyBits is the smallest RSA modulus accepted for signing keys.
+const minRSAKeyBits = 2048
+
+var (
+	UnsupportedKeyErr = errors.New("unsupported key type, use an RSA or Ed25519 key")
+	WeakRSAKeyErr     = fmt.Errorf("RSA keys must be at least %d bits", minRSAKeyBits)
+	NoActiveKeyErr    = errors.New("no private key available to sign tokens")
+	DuplicateKeyIDErr = errors.New("duplicate key ID")
+)
+
+// SigningKey is one key of the ring. Keys without a private half can only
+// verify tokens.
+type SigningKey struct {
+	ID         string
+	method     jwt.SigningMethod
+	privateKey crypto.Signer
+	publicKey  crypto.PublicKey
+}
+
+// NewSigningKey wraps a private key, which signs with RS256 for RSA keys and
+// EdDSA for Ed25519 keys.
+func NewSigningKey(id string, privateKey crypto.Signer) (SigningKey, error) {
+	key, err := NewVerificationKey(id, privateKey.Public())
+	if err != nil {
+		return SigningKey{}, err
+	}
+
+	key.privateKey = privateKey
+
+	return key, nil
+}
+
+// NewVerificationKey wraps the public key of a retired signing key.
+func NewVerificationKey(id string, publicKey crypto.PublicKey) (SigningKey, error) {
+	switch typedKey := publicKey.(type) {
+	case *rsa.PublicKey:
+		if typedKey.N.BitLen() < minRSAKeyBits {
+			return SigningKey{}, WeakRSAKeyErr
+		}
+		return SigningKey{ID: id, method: jwt.SigningMethodRS256, publicKey: typedKey}, nil
+	case ed25519.PublicKey:
+		return SigningKey{ID: id, method: jwt.SigningMethodEdDSA, publicKey: typedKey}, nil
+	default:
+		return SigningKey{}, UnsupportedKeyErr
+	}
+}
+
+// CanSign reports whether the key holds its private half.
+func (key SigningKey) CanSign() bool {
+	return key.privateKey != nil
+}
+
+// KeyRing signs access tokens with its active key and verifies them with any
+// of its keys, found by the token's "kid" header. Rotating keys means adding
+// a new active key and keeping the previous one until its tokens expire.
+type KeyRing struct {
+	activeKey SigningKey
+	keys      map[string]SigningKey
+	keyIDs    []string
+	issuer    string
+	clock     ports.Clock
+}
+
+func NewKeyRing(keys []SigningKey, activeKeyID string, issuer string, clock ports.Clock) (*KeyRing, error) {
+	ring := &KeyRing{
+		keys:   make(map[string]SigningKey, len(keys)),
+		issuer: issuer,
+		clock:  clock,
+	}
+
+	for _, key := range keys {
+		if _, duplicate := ring.keys[key.ID]; duplicate {
+			return nil, fmt.Errorf("%w: %s", DuplicateKeyIDErr, key.ID)
+		}
+		ring.keys[key.ID] = key
+		ring.keyIDs = append(ring.keyIDs, key.ID)
+	}
+
+	activeKey, ok := ring.keys[activeKeyID]
+	if !ok || !activeKey.CanSign() {
+		return nil, NoActiveKeyErr
+	}
+	ring.activeKey = activeKey
+
+	return ring, nil
+}
+
+type accessTokenClaims struct {
+	Email     string `json:"email"`
+	Role      string `json:"role,omitempty"`
+	SessionID string `json:"sid"`
+	jwt.RegisteredClaims
+}
+
+func (ring *KeyRing) Issue(claims domain.AccessTokenClaims) (string, error) {
+	token := jwt.NewWithClaims(ring.activeKey.method, accessTokenClaims{
+		Email:     claims.Email,
+		Role:      string(claims.Role),
+		SessionID: claims.SessionID.String(),
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    ring.issuer,
+			Subject:   claims.UserID.String(),
+			IssuedAt:  jwt.NewNumericDate(claims.IssuedAt),
+			ExpiresAt: jwt.NewNumericDate(claims.ExpiresAt),
+		},
+	})
+	token.Header["kid"] = ring.activeKey.ID
+
+	return token.SignedString(ring.activeKey.privateKey)
+}
+
+func (ring *KeyRing) Verify(tokenString string) (*domain.AccessTokenClaims, error) {
+	parser := jwt.NewParser(
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg()}),
+		jwt.WithIssuer(ring.issuer),
+		jwt.WithExpirationRequired(),
+		jwt.WithTimeFunc(ring.clock.Now),
+	)
+
+	var claims accessTokenClaims
+	if _, err := parser.ParseWithClaims(tokenString, &claims, ring.verificationKey); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.InvalidAccessTokenErr, err)
+	}
+
+	userID, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid subject", domain.InvalidAccessTokenErr)
+	}
+
+	sessionID, err := uuid.Parse(claims.SessionID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid session", domain.InvalidAccessTokenErr)
+	}
+
+	// Tokens issued before roles existed carry none and belong to plain users.
+	role := domain.Role(claims.Role)
+	if role == "" {
+		role = domain.RoleUser
+	}
+	if !role.IsValid() {
+		return nil, fmt.Errorf("%w: invalid role", domain.InvalidAccessTokenErr)
+	}
+
+	result := &domain.AccessTokenClaims{
+		UserID:    userID,
+		Email:     claims.Email,
+		Role:      role,
+		SessionID: sessionID,
+		ExpiresAt: claims.ExpiresAt.Time,
+	}
+	if claims.IssuedAt != nil {
+		result.IssuedAt = claims.IssuedAt.Time
+	}
+
+	return result, nil
+}
+
+// verificationKey finds the key named by the token's "kid" header and makes
+// sure the token was signed with that key's algorithm.
+func (ring *KeyRing) verificationKey(token *jwt.Token) (interface{}, error) {
+	keyID, _ := token.Header["kid"].(string)
+
+	key, ok := ring.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("unknown key ID %q", keyID)
+	}
+
+	if token.Method.Alg() != key.method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method %s for key %q", token.Method.Alg(), keyID)
+	}
+
+	return key.publicKey, nil
+}
+
+// PublicKeys returns every key of the ring in JWK form, in the order they were added.
+func (ring *KeyRing) PublicKeys() []domain.JSONWebKey {
+	publicKeys := make([]domain.JSONWebKey, 0, len(ring.keyIDs))
+	for _, keyID := range ring.keyIDs {
+		key := ring.keys[keyID]
+		jwk := domain.JSONWebKey{KeyID: key.ID, Algorithm: key.method.Alg()}
+
+		switch publicKey := key.publicKey.(type) {
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.KeyType = "OKP"
+			jwk.Curve = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(publicKey)
+		}
+
+		publicKeys = append(publicKeys, jwk)
+	}
+
+	return publicKeys
+}
+
+type linkTokenClaims struct {
+	Value string `json:"val,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// SignLink signs a link token with the active key. The purpose is carried as
+// the token's audience, which access tokens never have, so neither kind of
+// token is accepted in place of the other.
+func (ring *KeyRing) SignLink(claims domain.LinkTokenClaims) (string, error) {
+	registered := jwt.RegisteredClaims{
+		Issuer:   ring.issuer,
+		Subject:  claims.UserID.String(),
+		Audience: jwt.ClaimStrings{claims.Purpose},
+		IssuedAt: jwt.NewNumericDate(ring.clock.Now()),
+	}
+	if !claims.ExpiresAt.IsZero() {
+		registered.ExpiresAt = jwt.NewNumericDate(claims.ExpiresAt)
+	}
+
+	token := jwt.NewWithClaims(ring.activeKey.method, linkTokenClaims{Value: claims.Value, RegisteredClaims: registered})
+	token.Header["kid"] = ring.activeKey.ID
+
+	return token.SignedString(ring.activeKey.privateKey)
+}
+
+func (ring *KeyRing) VerifyLink(purpose string, tokenString string) (*domain.LinkTokenClaims, error) {
+	parser := jwt.NewParser(
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg()}),
+		jwt.WithIssuer(ring.issuer),
+		jwt.WithAudience(purpose),
+		jwt.WithTimeFunc(ring.clock.Now),
+	)
+
+	var claims linkTokenClaims
+	if _, err := parser.ParseWithClaims(tokenString, &claims, ring.verificationKey); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.InvalidLinkTokenErr, err)
+	}
+
+	userID, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid subject", domain.InvalidLinkTokenErr)
+	}
+
+	result := &domain.LinkTokenClaims{
+		Purpose: purpose,
+		UserID:  userID,
+		Value:   claims.Value,
+	}
+	if claims.ExpiresAt != nil {
+		result.ExpiresAt = claims.ExpiresAt.Time
+	}
+
+	return result, nil
+}
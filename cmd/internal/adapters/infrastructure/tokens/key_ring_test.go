@@ -0,0 +1,292 @@
+package tokens_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"swapp-go/cmd/internal/adapters/infrastructure/tokens"
+	"swapp-go/cmd/internal/application/mocks"
+	"swapp-go/cmd/internal/domain"
+	"testing"
+	"time"
+)
+
+var testNow = time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+func newEd25519Key(t *testing.T, id string) (tokens.SigningKey, ed25519.PublicKey) {
+	t.Helper()
+
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate Ed25519 key: %v", err)
+	}
+
+	key, err := tokens.NewSigningKey(id, privateKey)
+	if err != nil {
+		t.Fatalf("failed to create signing key: %v", err)
+	}
+
+	return key, publicKey
+}
+
+func newRSAKey(t *testing.T, id string) (tokens.SigningKey, *rsa.PrivateKey) {
+	t.Helper()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate RSA key: %v", err)
+	}
+
+	key, err := tokens.NewSigningKey(id, privateKey)
+	if err != nil {
+		t.Fatalf("failed to create signing key: %v", err)
+	}
+
+	return key, privateKey
+}
+
+func testClaims() domain.AccessTokenClaims {
+	return domain.AccessTokenClaims{
+		UserID:    uuid.New(),
+		Email:     "test@example.com",
+		Role:      domain.RoleModerator,
+		SessionID: uuid.New(),
+		IssuedAt:  testNow,
+		ExpiresAt: testNow.Add(15 * time.Minute),
+	}
+}
+
+func TestKeyRing_IssueAndVerify(t *testing.T) {
+	edKey, _ := newEd25519Key(t, "ed-1")
+	rsaKey, _ := newRSAKey(t, "rsa-1")
+
+	for _, key := range []tokens.SigningKey{edKey, rsaKey} {
+		t.Run(key.ID, func(t *testing.T) {
+			clock := &mocks.Clock{Current: testNow}
+			ring, err := tokens.NewKeyRing([]tokens.SigningKey{key}, key.ID, "swapp", clock)
+			assert.NoError(t, err)
+
+			claims := testClaims()
+			token, err := ring.Issue(claims)
+			assert.NoError(t, err)
+
+			parsed, _, err := jwt.NewParser().ParseUnverified(token, jwt.MapClaims{})
+			assert.NoError(t, err)
+			assert.Equal(t, key.ID, parsed.Header["kid"])
+
+			verified, err := ring.Verify(token)
+			assert.NoError(t, err)
+			assert.Equal(t, claims.UserID, verified.UserID)
+			assert.Equal(t, claims.Email, verified.Email)
+			assert.Equal(t, claims.Role, verified.Role)
+			assert.Equal(t, claims.SessionID, verified.SessionID)
+			assert.True(t, claims.ExpiresAt.Equal(verified.ExpiresAt))
+
+			clock.Advance(16 * time.Minute)
+			_, err = ring.Verify(token)
+			assert.ErrorIs(t, err, domain.InvalidAccessTokenErr)
+		})
+	}
+}
+
+func TestKeyRing_Rotation(t *testing.T) {
+	clock := &mocks.Clock{Current: testNow}
+	oldKey, oldPublicKey := newEd25519Key(t, "2025-01")
+	newKey, _ := newEd25519Key(t, "2025-02")
+
+	oldRing, err := tokens.NewKeyRing([]tokens.SigningKey{oldKey}, oldKey.ID, "swapp", clock)
+	assert.NoError(t, err)
+	outstandingToken, err := oldRing.Issue(testClaims())
+	assert.NoError(t, err)
+
+	retiredKey, err := tokens.NewVerificationKey(oldKey.ID, oldPublicKey)
+	assert.NoError(t, err)
+	assert.False(t, retiredKey.CanSign())
+
+	rotatedRing, err := tokens.NewKeyRing([]tokens.SigningKey{retiredKey, newKey}, newKey.ID, "swapp", clock)
+	assert.NoError(t, err)
+
+	_, err = rotatedRing.Verify(outstandingToken)
+	assert.NoError(t, err, "tokens of the retired key stay valid until they expire")
+
+	newToken, err := rotatedRing.Issue(testClaims())
+	assert.NoError(t, err)
+	parsed, _, err := jwt.NewParser().ParseUnverified(newToken, jwt.MapClaims{})
+	assert.NoError(t, err)
+	assert.Equal(t, newKey.ID, parsed.Header["kid"])
+
+	_, err = oldRing.Verify(newToken)
+	assert.ErrorIs(t, err, domain.InvalidAccessTokenErr, "the old ring does not know the new key")
+}
+
+func TestKeyRing_RejectsForgedTokens(t *testing.T) {
+	clock := &mocks.Clock{Current: testNow}
+	key, publicKey := newEd25519Key(t, "ed-1")
+	ring, err := tokens.NewKeyRing([]tokens.SigningKey{key}, key.ID, "swapp", clock)
+	assert.NoError(t, err)
+
+	registeredClaims := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"iss": "swapp",
+			"sub": uuid.NewString(),
+			"sid": uuid.NewString(),
+			"exp": testNow.Add(time.Minute).Unix(),
+		}
+	}
+
+	// Signing with HMAC and the published public key as secret must not work.
+	hmacToken := jwt.NewWithClaims(jwt.SigningMethodHS256, registeredClaims())
+	hmacToken.Header["kid"] = key.ID
+	hmacTokenString, err := hmacToken.SignedString([]byte(publicKey))
+	assert.NoError(t, err)
+
+	unknownKey, _ := newEd25519Key(t, "ed-1")
+	unknownRing, err := tokens.NewKeyRing([]tokens.SigningKey{unknownKey}, unknownKey.ID, "swapp", clock)
+	assert.NoError(t, err)
+	unknownKeyToken, err := unknownRing.Issue(testClaims())
+	assert.NoError(t, err)
+
+	otherIssuerRing, err := tokens.NewKeyRing([]tokens.SigningKey{key}, key.ID, "someone-else", clock)
+	assert.NoError(t, err)
+	otherIssuerToken, err := otherIssuerRing.Issue(testClaims())
+	assert.NoError(t, err)
+
+	missingKeyID := jwt.NewWithClaims(jwt.SigningMethodEdDSA, registeredClaims())
+	_, privateKey, _ := ed25519.GenerateKey(rand.Reader)
+	missingKeyIDToken, err := missingKeyID.SignedString(privateKey)
+	assert.NoError(t, err)
+
+	unknownRoleClaims := testClaims()
+	unknownRoleClaims.Role = "root"
+	unknownRoleToken, err := ring.Issue(unknownRoleClaims)
+	assert.NoError(t, err)
+
+	noneToken, err := jwt.NewWithClaims(jwt.SigningMethodNone, registeredClaims()).SignedString(jwt.UnsafeAllowNoneSignatureType)
+	assert.NoError(t, err)
+
+	for name, token := range map[string]string{
+		"hmac":           hmacTokenString,
+		"wrong_key":      unknownKeyToken,
+		"other_issuer":   otherIssuerToken,
+		"missing_key_id": missingKeyIDToken,
+		"unknown_role":   unknownRoleToken,
+		"none":           noneToken,
+		"garbage":        "not-a-token",
+	} {
+		_, err = ring.Verify(token)
+		assert.ErrorIs(t, err, domain.InvalidAccessTokenErr, name)
+	}
+}
+
+func TestKeyRing_VerifyDefaultsMissingRole(t *testing.T) {
+	key, _ := newEd25519Key(t, "ed-1")
+	ring, err := tokens.NewKeyRing([]tokens.SigningKey{key}, key.ID, "swapp", &mocks.Clock{Current: testNow})
+	assert.NoError(t, err)
+
+	claims := testClaims()
+	claims.Role = ""
+	token, err := ring.Issue(claims)
+	assert.NoError(t, err)
+
+	verified, err := ring.Verify(token)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.RoleUser, verified.Role)
+}
+
+func TestKeyRing_LinkTokens(t *testing.T) {
+	clock := &mocks.Clock{Current: testNow}
+	key, _ := newEd25519Key(t, "ed-1")
+	ring, err := tokens.NewKeyRing([]tokens.SigningKey{key}, key.ID, "swapp", clock)
+	assert.NoError(t, err)
+
+	claims := domain.LinkTokenClaims{
+		Purpose:   domain.EmailVerificationPurpose,
+		UserID:    uuid.New(),
+		Value:     "test@example.com",
+		ExpiresAt: testNow.Add(time.Hour),
+	}
+
+	token, err := ring.SignLink(claims)
+	assert.NoError(t, err)
+
+	verified, err := ring.VerifyLink(domain.EmailVerificationPurpose, token)
+	assert.NoError(t, err)
+	assert.Equal(t, claims.UserID, verified.UserID)
+	assert.Equal(t, claims.Value, verified.Value)
+	assert.True(t, claims.ExpiresAt.Equal(verified.ExpiresAt))
+
+	_, err = ring.VerifyLink("unsubscribe", token)
+	assert.ErrorIs(t, err, domain.InvalidLinkTokenErr, "a token is only valid for its purpose")
+
+	_, err = ring.Verify(token)
+	assert.ErrorIs(t, err, domain.InvalidAccessTokenErr, "link tokens are not access tokens")
+
+	accessToken, err := ring.Issue(testClaims())
+	assert.NoError(t, err)
+	_, err = ring.VerifyLink(domain.EmailVerificationPurpose, accessToken)
+	assert.ErrorIs(t, err, domain.InvalidLinkTokenErr, "access tokens are not link tokens")
+
+	clock.Advance(2 * time.Hour)
+	_, err = ring.VerifyLink(domain.EmailVerificationPurpose, token)
+	assert.ErrorIs(t, err, domain.InvalidLinkTokenErr)
+
+	claims.ExpiresAt = time.Time{}
+	permanentToken, err := ring.SignLink(claims)
+	assert.NoError(t, err)
+	clock.Advance(365 * 24 * time.Hour)
+	verified, err = ring.VerifyLink(domain.EmailVerificationPurpose, permanentToken)
+	assert.NoError(t, err)
+	assert.True(t, verified.ExpiresAt.IsZero())
+}
+
+func TestKeyRing_PublicKeys(t *testing.T) {
+	edKey, edPublicKey := newEd25519Key(t, "ed-1")
+	rsaKey, rsaPrivateKey := newRSAKey(t, "rsa-1")
+
+	ring, err := tokens.NewKeyRing([]tokens.SigningKey{edKey, rsaKey}, rsaKey.ID, "swapp", &mocks.Clock{Current: testNow})
+	assert.NoError(t, err)
+
+	keys := ring.PublicKeys()
+	assert.Len(t, keys, 2)
+
+	assert.Equal(t, domain.JSONWebKey{
+		KeyType:   "OKP",
+		KeyID:     "ed-1",
+		Algorithm: "EdDSA",
+		Curve:     "Ed25519",
+		X:         base64.RawURLEncoding.EncodeToString(edPublicKey),
+	}, keys[0])
+
+	assert.Equal(t, "RSA", keys[1].KeyType)
+	assert.Equal(t, "RS256", keys[1].Algorithm)
+	assert.Equal(t, "AQAB", keys[1].E)
+	modulus, err := base64.RawURLEncoding.DecodeString(keys[1].N)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, new(big.Int).SetBytes(modulus).Cmp(rsaPrivateKey.N))
+}
+
+func TestNewKeyRing_Errors(t *testing.T) {
+	clock := &mocks.Clock{Current: testNow}
+	key, publicKey := newEd25519Key(t, "ed-1")
+	verificationKey, err := tokens.NewVerificationKey("retired", publicKey)
+	assert.NoError(t, err)
+
+	_, err = tokens.NewKeyRing([]tokens.SigningKey{key}, "missing", "swapp", clock)
+	assert.ErrorIs(t, err, tokens.NoActiveKeyErr)
+
+	_, err = tokens.NewKeyRing([]tokens.SigningKey{key, verificationKey}, "retired", "swapp", clock)
+	assert.ErrorIs(t, err, tokens.NoActiveKeyErr)
+
+	_, err = tokens.NewKeyRing([]tokens.SigningKey{key, key}, key.ID, "swapp", clock)
+	assert.ErrorIs(t, err, tokens.DuplicateKeyIDErr)
+
+	weakKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	assert.NoError(t, err)
+	_, err = tokens.NewSigningKey("weak", weakKey)
+	assert.ErrorIs(t, err, tokens.WeakRSAKeyErr)
+}
@@ -2,12 +2,19 @@ package middleware
 
 import (
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"net/http"
 	"strings"
+	"swapp-go/cmd/internal/application/ports"
 )
 
-func JwtAuthMiddleware(secret string) gin.HandlerFunc {
+// SessionValidator reports whether the session an access token belongs to is
+// still active.
+type SessionValidator interface {
+	ValidateSession(sessionID uuid.UUID) error
+}
+
+func JwtAuthMiddleware(verifier ports.AccessTokenVerifier, sessions SessionValidator) gin.HandlerFunc {
 	return func(context *gin.Context) {
 		authHeader := context.GetHeader("Authorization")
 		if authHeader == "" {
@@ -22,30 +29,21 @@ func JwtAuthMiddleware(secret string) gin.HandlerFunc {
 
 		tokenStr := strings.TrimPrefix(authHeader, "Bearer ")
 
-		token, err := jwt.Parse(tokenStr, func(token *jwt.Token) (interface{}, error) {
-			return []byte(secret), nil
-		})
-		if err != nil || !token.Valid {
+		claims, err := verifier.Verify(tokenStr)
+		if err != nil {
 			context.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
 			return
 		}
 
-		claims, ok := token.Claims.(jwt.MapClaims)
-		if !ok {
-			context.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid token claims"})
-			return
-		}
-
-		userID, ok := claims["sub"].(string)
-		if !ok {
-			context.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid token userID"})
+		if err = sessions.ValidateSession(claims.SessionID); err != nil {
+			context.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Session has been revoked"})
 			return
 		}
 
-		email, _ := claims["email"].(string)
-
-		context.Set("userID", userID)
-		context.Set("email", email)
+		context.Set("userID", claims.UserID.String())
+		context.Set("email", claims.Email)
+		context.Set("role", string(claims.Role))
+		context.Set("sessionID", claims.SessionID.String())
 
 		context.Next()
 	}
@@ -1,30 +1,52 @@
 package middleware_test
 
 import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"net/http"
 	"net/http/httptest"
-	"os"
+	"swapp-go/cmd/internal/adapters/handlers/mocks"
+	"swapp-go/cmd/internal/adapters/infrastructure/clock"
+	"swapp-go/cmd/internal/adapters/infrastructure/tokens"
 	"swapp-go/cmd/internal/adapters/middleware"
 	"testing"
 	"time"
 )
 
 // Test Helpers
-func init() {
-	_ = os.Setenv("JWT_SECRET", "test_jwt_secret")
-}
+const testKeyID = "test-key"
+
+var _, testPrivateKey, _ = ed25519.GenerateKey(rand.Reader)
+
+func newTestKeyRing(t *testing.T) *tokens.KeyRing {
+	t.Helper()
+
+	key, err := tokens.NewSigningKey(testKeyID, testPrivateKey)
+	assert.NoError(t, err)
+
+	keyRing, err := tokens.NewKeyRing([]tokens.SigningKey{key}, testKeyID, "swapp", clock.NewSystemClock())
+	assert.NoError(t, err)
 
-func jwtSecret() string {
-	return os.Getenv("JWT_SECRET")
+	return keyRing
 }
 
+var (
+	activeSessionID  = uuid.MustParse("0b6f2f0e-0f55-4a4e-9c43-2f7f8b1d6a10")
+	revokedSessionID = uuid.MustParse("5d1c3a7e-8e0f-4b8e-a4f4-3c6b9a2e7d21")
+)
+
 func generateClaims(expiration time.Time) jwt.MapClaims {
 	return jwt.MapClaims{
 		"email": "test@email.com",
+		"role":  "moderator",
 		"sub":   "6e9648ee-fd0b-4267-adcb-0c03b0176277",
+		"sid":   activeSessionID.String(),
+		"iss":   "swapp",
 		"exp":   expiration.Unix(),
 	}
 }
@@ -32,8 +54,9 @@ func generateClaims(expiration time.Time) jwt.MapClaims {
 func generateTestToken(t *testing.T, claims jwt.MapClaims) string {
 	t.Helper()
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	signedToken, err := token.SignedString([]byte(jwtSecret()))
+	token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, claims)
+	token.Header["kid"] = testKeyID
+	signedToken, err := token.SignedString(testPrivateKey)
 	assert.NoError(t, err)
 
 	return signedToken
@@ -126,8 +149,21 @@ func TestJwtAuthMiddleware_Scenarios(t *testing.T) {
 	validClaims := generateClaims(time.Now().Add(time.Hour))
 	expiredClaims := generateClaims(time.Now().Add(-time.Hour))
 
+	withoutSessionClaims := generateClaims(time.Now().Add(time.Hour))
+	delete(withoutSessionClaims, "sid")
+
+	revokedSessionClaims := generateClaims(time.Now().Add(time.Hour))
+	revokedSessionClaims["sid"] = revokedSessionID.String()
+
 	validToken := generateTestToken(t, validClaims)
 	expiredToken := generateTestToken(t, expiredClaims)
+	withoutSessionToken := generateTestToken(t, withoutSessionClaims)
+	revokedSessionToken := generateTestToken(t, revokedSessionClaims)
+
+	hmacToken := jwt.NewWithClaims(jwt.SigningMethodHS256, validClaims)
+	hmacToken.Header["kid"] = testKeyID
+	hmacTokenString, err := hmacToken.SignedString([]byte("test_jwt_secret"))
+	assert.NoError(t, err)
 
 	testCases := []struct {
 		name                 string
@@ -159,6 +195,24 @@ func TestJwtAuthMiddleware_Scenarios(t *testing.T) {
 			expectedStatusCode:   http.StatusUnauthorized,
 			expectedBodyContains: "Invalid or expired token",
 		},
+		{
+			name:                 "HMAC Signed Token",
+			authorizationHeader:  "Bearer " + hmacTokenString,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedBodyContains: "Invalid or expired token",
+		},
+		{
+			name:                 "Token Without Session",
+			authorizationHeader:  "Bearer " + withoutSessionToken,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedBodyContains: "Invalid or expired token",
+		},
+		{
+			name:                 "Revoked Session",
+			authorizationHeader:  "Bearer " + revokedSessionToken,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedBodyContains: "Session has been revoked",
+		},
 		{
 			name:                 "Valid Token",
 			authorizationHeader:  "Bearer " + validToken,
@@ -171,15 +225,21 @@ func TestJwtAuthMiddleware_Scenarios(t *testing.T) {
 		t.Run(testCase.name, func(t *testing.T) {
 			gin.SetMode(gin.TestMode)
 
+			sessionService := new(mocks.MockSessionService)
+			sessionService.On("ValidateSession", activeSessionID).Return(nil)
+			sessionService.On("ValidateSession", revokedSessionID).Return(errors.New("session has been revoked"))
+
 			router := gin.New()
-			router.Use(middleware.JwtAuthMiddleware(jwtSecret()))
+			router.Use(middleware.JwtAuthMiddleware(newTestKeyRing(t), sessionService))
 			router.GET("/protected", func(context *gin.Context) {
 				userID := context.GetString("userID")
 				email := context.GetString("email")
 
 				context.JSON(http.StatusOK, gin.H{
-					"userID": userID,
-					"email":  email,
+					"userID":    userID,
+					"email":     email,
+					"sessionID": context.GetString("sessionID"),
+					"role":      context.GetString("role"),
 				})
 			})
 
@@ -193,6 +253,9 @@ func TestJwtAuthMiddleware_Scenarios(t *testing.T) {
 
 			assert.Equal(t, testCase.expectedStatusCode, httpResponseRecorder.Code)
 			assert.Contains(t, httpResponseRecorder.Body.String(), testCase.expectedBodyContains)
+			if testCase.expectedStatusCode == http.StatusOK {
+				assert.Contains(t, httpResponseRecorder.Body.String(), `"role":"moderator"`)
+			}
 		})
 	}
 }
@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"swapp-go/cmd/internal/domain"
+)
+
+// RequireRole lets a request through only if the role JwtAuthMiddleware read
+// from the access token includes required. It must run after JwtAuthMiddleware.
+func RequireRole(required domain.Role) gin.HandlerFunc {
+	return func(context *gin.Context) {
+		role := domain.Role(context.GetString("role"))
+		if !role.Includes(required) {
+			context.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+			return
+		}
+
+		context.Next()
+	}
+}
@@ -0,0 +1,54 @@
+package middleware_test
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"swapp-go/cmd/internal/adapters/middleware"
+	"swapp-go/cmd/internal/domain"
+	"testing"
+)
+
+func TestRequireRole_Scenarios(t *testing.T) {
+	testCases := []struct {
+		name               string
+		role               string
+		required           domain.Role
+		expectedStatusCode int
+	}{
+		{"User On User Route", "user", domain.RoleUser, http.StatusOK},
+		{"User On Moderator Route", "user", domain.RoleModerator, http.StatusForbidden},
+		{"Moderator On Moderator Route", "moderator", domain.RoleModerator, http.StatusOK},
+		{"Moderator On Admin Route", "moderator", domain.RoleAdmin, http.StatusForbidden},
+		{"Admin On Moderator Route", "admin", domain.RoleModerator, http.StatusOK},
+		{"Missing Role", "", domain.RoleUser, http.StatusForbidden},
+		{"Unknown Role", "root", domain.RoleUser, http.StatusForbidden},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+
+			router := gin.New()
+			router.Use(func(context *gin.Context) {
+				if testCase.role != "" {
+					context.Set("role", testCase.role)
+				}
+			})
+			router.Use(middleware.RequireRole(testCase.required))
+			router.GET("/restricted", func(context *gin.Context) {
+				context.JSON(http.StatusOK, gin.H{"message": "OK"})
+			})
+
+			httpRequest, _ := http.NewRequest(http.MethodGet, "/restricted", nil)
+			httpResponseRecorder := httptest.NewRecorder()
+			router.ServeHTTP(httpResponseRecorder, httpRequest)
+
+			assert.Equal(t, testCase.expectedStatusCode, httpResponseRecorder.Code)
+			if testCase.expectedStatusCode == http.StatusForbidden {
+				assert.Contains(t, httpResponseRecorder.Body.String(), "Insufficient permissions")
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package gorm
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"swapp-go/cmd/internal/adapters/persistence/models"
+	"swapp-go/cmd/internal/domain"
+)
+
+type CategoryGormRepository struct {
+	db *gorm.DB
+}
+
+func NewCategoryGormRepository(db *gorm.DB) *CategoryGormRepository {
+	return &CategoryGormRepository{db}
+}
+
+func toDomainCategory(model *models.CategoryModel) *domain.Category {
+	category := &domain.Category{
+		Slug: model.Slug,
+		Name: model.Name,
+	}
+
+	if model.ParentSlug != nil {
+		category.ParentSlug = *model.ParentSlug
+	}
+
+	return category
+}
+
+func (categoryGorm *CategoryGormRepository) List() ([]domain.Category, error) {
+	var modelsList []models.CategoryModel
+	if err := categoryGorm.db.Order("slug ASC").Find(&modelsList).Error; err != nil {
+		return nil, err
+	}
+
+	var domainList []domain.Category
+	for _, m := range modelsList {
+		domainList = append(domainList, *toDomainCategory(&m))
+	}
+
+	return domainList, nil
+}
+
+func (categoryGorm *CategoryGormRepository) FindBySlug(slug string) (*domain.Category, error) {
+	var model models.CategoryModel
+	if err := categoryGorm.db.First(&model, "slug = ?", slug).Error; err != nil {
+		return nil, err
+	}
+	return toDomainCategory(&model), nil
+}
+
+// defaultCategories is the taxonomy seeded on startup. Parents must come before their children.
+var defaultCategories = []domain.Category{
+	{Slug: "electronics", Name: "Electronics"},
+	{Slug: "phones", Name: "Phones & Tablets", ParentSlug: "electronics"},
+	{Slug: "computers", Name: "Computers", ParentSlug: "electronics"},
+	{Slug: "cameras", Name: "Cameras", ParentSlug: "electronics"},
+	{Slug: "audio", Name: "Audio", ParentSlug: "electronics"},
+	{Slug: "home-garden", Name: "Home & Garden"},
+	{Slug: "furniture", Name: "Furniture", ParentSlug: "home-garden"},
+	{Slug: "kitchen", Name: "Kitchen", ParentSlug: "home-garden"},
+	{Slug: "garden", Name: "Garden", ParentSlug: "home-garden"},
+	{Slug: "clothing", Name: "Clothing & Accessories"},
+	{Slug: "womens-clothing", Name: "Women's Clothing", ParentSlug: "clothing"},
+	{Slug: "mens-clothing", Name: "Men's Clothing", ParentSlug: "clothing"},
+	{Slug: "kids-clothing", Name: "Kids' Clothing", ParentSlug: "clothing"},
+	{Slug: "shoes", Name: "Shoes", ParentSlug: "clothing"},
+	{Slug: "sports-outdoors", Name: "Sports & Outdoors"},
+	{Slug: "bikes", Name: "Bikes", ParentSlug: "sports-outdoors"},
+	{Slug: "fitness", Name: "Fitness", ParentSlug: "sports-outdoors"},
+	{Slug: "camping", Name: "Camping", ParentSlug: "sports-outdoors"},
+	{Slug: "books-media", Name: "Books & Media"},
+	{Slug: "books", Name: "Books", ParentSlug: "books-media"},
+	{Slug: "music", Name: "Music", ParentSlug: "books-media"},
+	{Slug: "films", Name: "Films", ParentSlug: "books-media"},
+	{Slug: "video-games", Name: "Video Games", ParentSlug: "books-media"},
+	{Slug: "toys-games", Name: "Toys & Games"},
+	{Slug: "other", Name: "Other"},
+}
+
+// SeedCategories inserts the default taxonomy, leaving existing categories untouched.
+func SeedCategories(db *gorm.DB) error {
+	seed := make([]models.CategoryModel, 0, len(defaultCategories))
+	for _, category := range defaultCategories {
+		model := models.CategoryModel{Slug: category.Slug, Name: category.Name}
+		if category.ParentSlug != "" {
+			parentSlug := category.ParentSlug
+			model.ParentSlug = &parentSlug
+		}
+		seed = append(seed, model)
+	}
+
+	return db.Clauses(clause.OnConflict{DoNothing: true}).Create(&seed).Error
+}
@@ -0,0 +1,42 @@
+package gorm_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"swapp-go/cmd/internal/adapters/persistence/gorm"
+	"swapp-go/cmd/internal/adapters/persistence/gorm/testutils"
+	"swapp-go/cmd/internal/adapters/persistence/models"
+	"testing"
+)
+
+func TestCategoryGormRepository(t *testing.T) {
+	db := testutils.SetupTestDB(t, &models.CategoryModel{})
+	repo := gorm.NewCategoryGormRepository(db)
+
+	assert.NoError(t, gorm.SeedCategories(db))
+
+	t.Run("seeding twice keeps a single copy", func(t *testing.T) {
+		first, err := repo.List()
+		assert.NoError(t, err)
+		assert.NotEmpty(t, first)
+
+		assert.NoError(t, gorm.SeedCategories(db))
+
+		second, err := repo.List()
+		assert.NoError(t, err)
+		assert.Len(t, second, len(first))
+	})
+
+	t.Run("FindBySlug", func(t *testing.T) {
+		category, err := repo.FindBySlug("bikes")
+		assert.NoError(t, err)
+		assert.Equal(t, "Bikes", category.Name)
+		assert.Equal(t, "sports-outdoors", category.ParentSlug)
+
+		root, err := repo.FindBySlug("electronics")
+		assert.NoError(t, err)
+		assert.Empty(t, root.ParentSlug)
+
+		_, err = repo.FindBySlug("spaceships")
+		assert.Error(t, err)
+	})
+}
@@ -3,6 +3,7 @@ package gorm
 import (
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"strings"
 	"swapp-go/cmd/internal/adapters/persistence/models"
 	"swapp-go/cmd/internal/domain"
 )
@@ -21,23 +22,155 @@ func toItemModel(item *domain.Item) *models.ItemModel {
 		id = uuid.New()
 	}
 
+	var categorySlug *string
+	if item.CategorySlug != "" {
+		categorySlug = &item.CategorySlug
+	}
+
 	return &models.ItemModel{
-		ID:          id,
-		Name:        item.Name,
-		Description: item.Description,
-		PictureURL:  item.PictureURL,
-		UserID:      item.UserID,
+		ID:           id,
+		Name:         item.Name,
+		Description:  item.Description,
+		PictureURL:   item.PictureURL,
+		UserID:       item.UserID,
+		CategorySlug: categorySlug,
+		Condition:    string(item.Condition),
+		Tags:         toItemTagModels(id, item.Tags),
+		Pictures:     toItemPictureModels(id, item.Pictures),
+	}
+}
+
+func toItemTagModels(itemID uuid.UUID, tags []string) []models.ItemTagModel {
+	var tagModels []models.ItemTagModel
+	for _, tag := range tags {
+		tagModels = append(tagModels, models.ItemTagModel{ItemID: itemID, Tag: tag})
+	}
+
+	return tagModels
+}
+
+func toItemPictureModels(itemID uuid.UUID, pictures []domain.ItemPicture) []models.ItemPictureModel {
+	var pictureModels []models.ItemPictureModel
+	for _, picture := range pictures {
+		id := picture.ID
+		if id == uuid.Nil {
+			id = uuid.New()
+		}
+
+		pictureModels = append(pictureModels, models.ItemPictureModel{
+			ID:         id,
+			ItemID:     itemID,
+			URL:        picture.URL,
+			StorageKey: picture.StorageKey,
+			Width:      picture.Width,
+			Height:     picture.Height,
+			Position:   picture.Position,
+			IsPrimary:  picture.IsPrimary,
+			Variants:   toItemPictureVariantModels(id, picture.Variants),
+			CreatedAt:  picture.CreatedAt,
+		})
+	}
+
+	return pictureModels
+}
+
+func toItemPictureVariantModels(pictureID uuid.UUID, variants []domain.PictureVariant) []models.ItemPictureVariantModel {
+	var variantModels []models.ItemPictureVariantModel
+	for _, variant := range variants {
+		variantModels = append(variantModels, models.ItemPictureVariantModel{
+			PictureID:  pictureID,
+			Name:       variant.Name,
+			URL:        variant.URL,
+			StorageKey: variant.StorageKey,
+			Width:      variant.Width,
+			Height:     variant.Height,
+		})
 	}
+
+	return variantModels
+}
+
+func toDomainItemPictures(pictureModels []models.ItemPictureModel) []domain.ItemPicture {
+	var pictures []domain.ItemPicture
+	for _, model := range pictureModels {
+		pictures = append(pictures, domain.ItemPicture{
+			ID:         model.ID,
+			ItemID:     model.ItemID,
+			URL:        model.URL,
+			StorageKey: model.StorageKey,
+			Width:      model.Width,
+			Height:     model.Height,
+			Variants:   toDomainPictureVariants(model.Variants),
+			Position:   model.Position,
+			IsPrimary:  model.IsPrimary,
+			CreatedAt:  model.CreatedAt,
+		})
+	}
+
+	return pictures
+}
+
+func toDomainPictureVariants(variantModels []models.ItemPictureVariantModel) []domain.PictureVariant {
+	var variants []domain.PictureVariant
+	for _, model := range variantModels {
+		variants = append(variants, domain.PictureVariant{
+			Name:       model.Name,
+			URL:        model.URL,
+			StorageKey: model.StorageKey,
+			Width:      model.Width,
+			Height:     model.Height,
+		})
+	}
+
+	return variants
 }
 
 func toDomainItem(model *models.ItemModel) *domain.Item {
-	return &domain.Item{
+	item := &domain.Item{
 		ID:          model.ID,
 		Name:        model.Name,
 		Description: model.Description,
 		PictureURL:  model.PictureURL,
 		UserID:      model.UserID,
+		Offered:     model.Offered,
+		Condition:   domain.ItemCondition(model.Condition),
+		CreatedAt:   model.CreatedAt,
+	}
+
+	if model.CategorySlug != nil {
+		item.CategorySlug = *model.CategorySlug
+	}
+
+	for _, tag := range model.Tags {
+		item.Tags = append(item.Tags, tag.Tag)
+	}
+
+	item.Pictures = toDomainItemPictures(model.Pictures)
+
+	return item
+}
+
+// withItemAssociations preloads the tags and the pictures, in display order and
+// with their variants, of each item.
+func withItemAssociations(db *gorm.DB) *gorm.DB {
+	return db.Preload("Tags").
+		Preload("Pictures", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position ASC")
+		}).
+		Preload("Pictures.Variants", func(db *gorm.DB) *gorm.DB {
+			return db.Order("name ASC")
+		})
+}
+
+// deleteItemPictures removes the pictures of an item together with their variants.
+func deleteItemPictures(tx *gorm.DB, itemID uuid.UUID) error {
+	if err := tx.
+		Where("picture_id IN (?)", tx.Model(&models.ItemPictureModel{}).Select("id").Where("item_id = ?", itemID)).
+		Delete(&models.ItemPictureVariantModel{}).Error; err != nil {
+		return err
 	}
+
+	return tx.Delete(&models.ItemPictureModel{}, "item_id = ?", itemID).Error
 }
 
 func (itemGorm *ItemGormRepository) Create(item *domain.Item) error {
@@ -48,17 +181,61 @@ func (itemGorm *ItemGormRepository) Create(item *domain.Item) error {
 	}
 
 	item.ID = model.ID
+	item.CreatedAt = model.CreatedAt
+	item.Pictures = toDomainItemPictures(model.Pictures)
 
 	return nil
 }
 
+// Update sets the given columns. A "tags" entry holding a []string replaces the
+// item's tags and a "pictures" entry holding a []domain.ItemPicture replaces its pictures.
 func (itemGorm *ItemGormRepository) Update(id uuid.UUID, fields map[string]interface{}) (*domain.Item, error) {
-	if err := itemGorm.db.Model(&models.ItemModel{}).Where("id = ?", id).Updates(fields).Error; err != nil {
+	columns := make(map[string]interface{}, len(fields))
+	tags, replaceTags := fields["tags"].([]string)
+	pictures, replacePictures := fields["pictures"].([]domain.ItemPicture)
+	for key, value := range fields {
+		if key != "tags" && key != "pictures" {
+			columns[key] = value
+		}
+	}
+
+	err := itemGorm.db.Transaction(func(tx *gorm.DB) error {
+		if len(columns) > 0 {
+			if err := tx.Model(&models.ItemModel{}).Where("id = ?", id).Updates(columns).Error; err != nil {
+				return err
+			}
+		}
+
+		if replaceTags {
+			if err := tx.Delete(&models.ItemTagModel{}, "item_id = ?", id).Error; err != nil {
+				return err
+			}
+			if tagModels := toItemTagModels(id, tags); len(tagModels) > 0 {
+				if err := tx.Create(&tagModels).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		if replacePictures {
+			if err := deleteItemPictures(tx, id); err != nil {
+				return err
+			}
+			if pictureModels := toItemPictureModels(id, pictures); len(pictureModels) > 0 {
+				if err := tx.Create(&pictureModels).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	var updatedItemModel models.ItemModel
-	if err := itemGorm.db.Where("id = ?", id).First(&updatedItemModel).Error; err != nil {
+	if err := withItemAssociations(itemGorm.db).Where("id = ?", id).First(&updatedItemModel).Error; err != nil {
 		return nil, err
 	}
 
@@ -66,13 +243,22 @@ func (itemGorm *ItemGormRepository) Update(id uuid.UUID, fields map[string]inter
 }
 
 func (itemGorm *ItemGormRepository) Delete(id uuid.UUID) error {
-	return itemGorm.db.Delete(&models.ItemModel{}, id).Error
+	return itemGorm.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&models.ItemTagModel{}, "item_id = ?", id).Error; err != nil {
+			return err
+		}
+		if err := deleteItemPictures(tx, id); err != nil {
+			return err
+		}
+
+		return tx.Delete(&models.ItemModel{}, id).Error
+	})
 }
 
 func (itemGorm *ItemGormRepository) FindByID(id uuid.UUID) (*domain.Item, error) {
 	var itemModel models.ItemModel
 
-	if err := itemGorm.db.First(&itemModel, id).Error; err != nil {
+	if err := withItemAssociations(itemGorm.db).First(&itemModel, id).Error; err != nil {
 		return nil, err
 	}
 
@@ -80,7 +266,7 @@ func (itemGorm *ItemGormRepository) FindByID(id uuid.UUID) (*domain.Item, error)
 }
 
 func (itemGorm *ItemGormRepository) TryMarkItemAsOffered(itemID uuid.UUID) (bool, error) {
-	result := itemGorm.db.Model(&domain.Item{}).
+	result := itemGorm.db.Model(&models.ItemModel{}).
 		Where("id = ? AND offered = ?", itemID, false).
 		Update("offered", true)
 
@@ -93,3 +279,80 @@ func (itemGorm *ItemGormRepository) TryMarkItemAsOffered(itemID uuid.UUID) (bool
 
 	return true, nil
 }
+
+func (itemGorm *ItemGormRepository) TransferOwnership(itemIDs []uuid.UUID, newOwnerID uuid.UUID) error {
+	return itemGorm.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.ItemModel{}).
+			Where("id IN ?", itemIDs).
+			Updates(map[string]interface{}{"user_id": newOwnerID, "offered": false})
+
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != int64(len(itemIDs)) {
+			return gorm.ErrRecordNotFound
+		}
+
+		return nil
+	})
+}
+
+// legacyUploadPrefix is the URL prefix of pictures that were written straight
+// to the uploads directory before the blob store existed. Their storage key is
+// the rest of the URL, relative to that directory.
+const legacyUploadPrefix = "/uploads/"
+
+// BackfillItemPictures gives every item that predates the item_pictures table
+// a primary picture row for its legacy picture_url, and fills in the storage
+// key of legacy uploads.
+func BackfillItemPictures(db *gorm.DB) error {
+	var legacyItems []models.ItemModel
+	if err := db.
+		Where("picture_url <> ''").
+		Where("NOT EXISTS (SELECT 1 FROM item_pictures WHERE item_pictures.item_id = items.id)").
+		Find(&legacyItems).Error; err != nil {
+		return err
+	}
+
+	if len(legacyItems) > 0 {
+		pictures := make([]models.ItemPictureModel, 0, len(legacyItems))
+		for _, item := range legacyItems {
+			pictures = append(pictures, models.ItemPictureModel{
+				ID:         uuid.New(),
+				ItemID:     item.ID,
+				URL:        item.PictureURL,
+				StorageKey: legacyStorageKey(item.PictureURL),
+				IsPrimary:  true,
+				CreatedAt:  item.CreatedAt,
+			})
+		}
+
+		if err := db.Create(&pictures).Error; err != nil {
+			return err
+		}
+	}
+
+	var unkeyedPictures []models.ItemPictureModel
+	if err := db.
+		Where("storage_key = '' OR storage_key IS NULL").
+		Where("url LIKE ?", legacyUploadPrefix+"%").
+		Find(&unkeyedPictures).Error; err != nil {
+		return err
+	}
+
+	for _, picture := range unkeyedPictures {
+		if err := db.Model(&picture).Update("storage_key", legacyStorageKey(picture.URL)).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func legacyStorageKey(url string) string {
+	if !strings.HasPrefix(url, legacyUploadPrefix) {
+		return ""
+	}
+
+	return strings.TrimPrefix(url, legacyUploadPrefix)
+}
@@ -20,7 +20,7 @@ func createTestItem(userID uuid.UUID) *domain.Item {
 }
 
 func TestCreateItem(t *testing.T) {
-	db := testutils.SetupTestDB(t, &models.ItemModel{})
+	db := testutils.SetupTestDB(t, &models.ItemModel{}, &models.ItemTagModel{}, &models.ItemPictureModel{}, &models.ItemPictureVariantModel{})
 	repo := gorm.NewItemGormRepository(db)
 
 	item := createTestItem(uuid.New())
@@ -37,7 +37,7 @@ func TestCreateItem(t *testing.T) {
 }
 
 func TestGetItemByID(t *testing.T) {
-	db := testutils.SetupTestDB(t, &models.ItemModel{})
+	db := testutils.SetupTestDB(t, &models.ItemModel{}, &models.ItemTagModel{}, &models.ItemPictureModel{}, &models.ItemPictureVariantModel{})
 	repo := gorm.NewItemGormRepository(db)
 
 	item := createTestItem(uuid.New())
@@ -50,7 +50,7 @@ func TestGetItemByID(t *testing.T) {
 }
 
 func TestUpdateItem(t *testing.T) {
-	db := testutils.SetupTestDB(t, &models.ItemModel{})
+	db := testutils.SetupTestDB(t, &models.ItemModel{}, &models.ItemTagModel{}, &models.ItemPictureModel{}, &models.ItemPictureVariantModel{})
 	repo := gorm.NewItemGormRepository(db)
 
 	item := createTestItem(uuid.New())
@@ -65,8 +65,147 @@ func TestUpdateItem(t *testing.T) {
 	assert.Equal(t, updatedName, updatedItem.Name)
 }
 
+func TestItemAttributes(t *testing.T) {
+	db := testutils.SetupTestDB(t, &models.ItemModel{}, &models.ItemTagModel{}, &models.ItemPictureModel{}, &models.ItemPictureVariantModel{})
+	repo := gorm.NewItemGormRepository(db)
+
+	item := createTestItem(uuid.New())
+	item.CategorySlug = "bikes"
+	item.Condition = domain.ConditionLikeNew
+	item.Tags = []string{"road", "carbon"}
+	assert.NoError(t, repo.Create(item))
+
+	result, err := repo.FindByID(item.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "bikes", result.CategorySlug)
+	assert.Equal(t, domain.ConditionLikeNew, result.Condition)
+	assert.ElementsMatch(t, []string{"road", "carbon"}, result.Tags)
+
+	updated, err := repo.Update(item.ID, map[string]interface{}{"tags": []string{"gravel"}, "condition": "used"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"gravel"}, updated.Tags)
+	assert.Equal(t, domain.ConditionUsed, updated.Condition)
+
+	updated, err = repo.Update(item.ID, map[string]interface{}{"name": "Renamed"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"gravel"}, updated.Tags)
+
+	assert.NoError(t, repo.Delete(item.ID))
+
+	var tagCount int64
+	assert.NoError(t, db.Model(&models.ItemTagModel{}).Where("item_id = ?", item.ID).Count(&tagCount).Error)
+	assert.Zero(t, tagCount)
+}
+
+func TestItemPictures(t *testing.T) {
+	db := testutils.SetupTestDB(t, &models.ItemModel{}, &models.ItemTagModel{}, &models.ItemPictureModel{}, &models.ItemPictureVariantModel{})
+	repo := gorm.NewItemGormRepository(db)
+
+	item := createTestItem(uuid.New())
+	assert.NoError(t, item.AddPictures([]domain.ItemPicture{
+		{URL: "/uploads/front.jpg", StorageKey: "front.jpg"},
+		{URL: "/uploads/back.jpg", StorageKey: "back.jpg"},
+	}))
+	assert.NoError(t, repo.Create(item))
+	assert.NotEqual(t, uuid.Nil, item.Pictures[0].ID)
+	assert.Equal(t, "front.jpg", item.Pictures[0].StorageKey)
+
+	result, err := repo.FindByID(item.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "/uploads/front.jpg", result.PictureURL)
+	assert.Len(t, result.Pictures, 2)
+	assert.Equal(t, "/uploads/front.jpg", result.Pictures[0].URL)
+	assert.True(t, result.Pictures[0].IsPrimary)
+	assert.Equal(t, "/uploads/back.jpg", result.Pictures[1].URL)
+	assert.Equal(t, 1, result.Pictures[1].Position)
+
+	assert.NoError(t, result.ReorderPictures([]uuid.UUID{result.Pictures[1].ID, result.Pictures[0].ID}, result.Pictures[1].ID))
+	updated, err := repo.Update(item.ID, map[string]interface{}{
+		"pictures":    result.Pictures,
+		"picture_url": result.PictureURL,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "/uploads/back.jpg", updated.PictureURL)
+	assert.Equal(t, "/uploads/back.jpg", updated.Pictures[0].URL)
+	assert.True(t, updated.Pictures[0].IsPrimary)
+	assert.False(t, updated.Pictures[1].IsPrimary)
+	assert.Equal(t, item.Pictures[1].ID, updated.Pictures[0].ID)
+
+	assert.NoError(t, repo.Delete(item.ID))
+
+	var pictureCount int64
+	assert.NoError(t, db.Model(&models.ItemPictureModel{}).Where("item_id = ?", item.ID).Count(&pictureCount).Error)
+	assert.Zero(t, pictureCount)
+}
+
+func TestItemPictureVariants(t *testing.T) {
+	db := testutils.SetupTestDB(t, &models.ItemModel{}, &models.ItemTagModel{}, &models.ItemPictureModel{}, &models.ItemPictureVariantModel{})
+	repo := gorm.NewItemGormRepository(db)
+
+	item := createTestItem(uuid.New())
+	assert.NoError(t, item.AddPictures([]domain.ItemPicture{{
+		ID:         uuid.New(),
+		URL:        "/uploads/items/front.jpg",
+		StorageKey: "items/front.jpg",
+		Width:      1600,
+		Height:     1200,
+		Variants: []domain.PictureVariant{
+			{Name: "thumbnail", URL: "/uploads/items/front_thumbnail.jpg", StorageKey: "items/front_thumbnail.jpg", Width: 240, Height: 180},
+			{Name: "medium", URL: "/uploads/items/front_medium.jpg", StorageKey: "items/front_medium.jpg", Width: 800, Height: 600},
+		},
+	}}))
+	assert.NoError(t, repo.Create(item))
+
+	result, err := repo.FindByID(item.ID)
+	assert.NoError(t, err)
+	assert.Len(t, result.Pictures, 1)
+	assert.Equal(t, 1600, result.Pictures[0].Width)
+	assert.Equal(t, 1200, result.Pictures[0].Height)
+	assert.Len(t, result.Pictures[0].Variants, 2)
+	assert.Equal(t, "medium", result.Pictures[0].Variants[0].Name)
+	assert.Equal(t, "items/front_medium.jpg", result.Pictures[0].Variants[0].StorageKey)
+	assert.Equal(t, 800, result.Pictures[0].Variants[0].Width)
+	assert.Equal(t, "thumbnail", result.Pictures[0].Variants[1].Name)
+
+	_, err = repo.Update(item.ID, map[string]interface{}{
+		"pictures":    []domain.ItemPicture{},
+		"picture_url": "",
+	})
+	assert.NoError(t, err)
+
+	var variantCount int64
+	assert.NoError(t, db.Model(&models.ItemPictureVariantModel{}).Count(&variantCount).Error)
+	assert.Zero(t, variantCount)
+}
+
+func TestBackfillItemPictures(t *testing.T) {
+	db := testutils.SetupTestDB(t, &models.ItemModel{}, &models.ItemTagModel{}, &models.ItemPictureModel{}, &models.ItemPictureVariantModel{})
+	repo := gorm.NewItemGormRepository(db)
+
+	legacy := createTestItem(uuid.New())
+	assert.NoError(t, repo.Create(legacy))
+
+	withoutPicture := createTestItem(uuid.New())
+	withoutPicture.PictureURL = ""
+	assert.NoError(t, repo.Create(withoutPicture))
+
+	assert.NoError(t, gorm.BackfillItemPictures(db))
+	assert.NoError(t, gorm.BackfillItemPictures(db))
+
+	result, err := repo.FindByID(legacy.ID)
+	assert.NoError(t, err)
+	assert.Len(t, result.Pictures, 1)
+	assert.Equal(t, "/uploads/test.jpg", result.Pictures[0].URL)
+	assert.Equal(t, "test.jpg", result.Pictures[0].StorageKey)
+	assert.True(t, result.Pictures[0].IsPrimary)
+
+	result, err = repo.FindByID(withoutPicture.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, result.Pictures)
+}
+
 func TestDeleteItem(t *testing.T) {
-	db := testutils.SetupTestDB(t, &models.ItemModel{})
+	db := testutils.SetupTestDB(t, &models.ItemModel{}, &models.ItemTagModel{}, &models.ItemPictureModel{}, &models.ItemPictureVariantModel{})
 	repo := gorm.NewItemGormRepository(db)
 
 	item := createTestItem(uuid.New())
@@ -79,3 +218,50 @@ func TestDeleteItem(t *testing.T) {
 	_, err = repo.FindByID(item.ID)
 	assert.Error(t, err)
 }
+
+func TestTransferOwnership(t *testing.T) {
+	db := testutils.SetupTestDB(t, &models.ItemModel{}, &models.ItemTagModel{}, &models.ItemPictureModel{}, &models.ItemPictureVariantModel{})
+	repo := gorm.NewItemGormRepository(db)
+
+	previousOwner, newOwner := uuid.New(), uuid.New()
+
+	first := createTestItem(previousOwner)
+	second := createTestItem(previousOwner)
+	untouched := createTestItem(previousOwner)
+	assert.NoError(t, repo.Create(first))
+	assert.NoError(t, repo.Create(second))
+	assert.NoError(t, repo.Create(untouched))
+
+	_, err := repo.TryMarkItemAsOffered(first.ID)
+	assert.NoError(t, err)
+
+	err = repo.TransferOwnership([]uuid.UUID{first.ID, second.ID}, newOwner)
+	assert.NoError(t, err)
+
+	for _, id := range []uuid.UUID{first.ID, second.ID} {
+		var model models.ItemModel
+		assert.NoError(t, db.First(&model, "id = ?", id).Error)
+		assert.Equal(t, newOwner, model.UserID)
+		assert.False(t, model.Offered)
+	}
+
+	result, err := repo.FindByID(untouched.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, previousOwner, result.UserID)
+}
+
+func TestTransferOwnership_MissingItem(t *testing.T) {
+	db := testutils.SetupTestDB(t, &models.ItemModel{}, &models.ItemTagModel{}, &models.ItemPictureModel{}, &models.ItemPictureVariantModel{})
+	repo := gorm.NewItemGormRepository(db)
+
+	owner := uuid.New()
+	item := createTestItem(owner)
+	assert.NoError(t, repo.Create(item))
+
+	err := repo.TransferOwnership([]uuid.UUID{item.ID, uuid.New()}, uuid.New())
+	assert.Error(t, err)
+
+	result, err := repo.FindByID(item.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, owner, result.UserID)
+}
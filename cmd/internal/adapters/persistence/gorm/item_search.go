@@ -0,0 +1,204 @@
+package gorm
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"strings"
+	"swapp-go/cmd/internal/adapters/persistence/models"
+	"swapp-go/cmd/internal/domain"
+	"time"
+)
+
+// itemSearchDocument is the tsvector the Postgres GIN index is built on; search
+// queries must use the exact same expression for the planner to pick the index.
+const itemSearchDocument = "to_tsvector('english', name || ' ' || description)"
+
+// EnsureItemSearchIndex creates the full-text index used by Search. It is a
+// no-op on databases other than Postgres, where Search falls back to LIKE.
+func EnsureItemSearchIndex(db *gorm.DB) error {
+	if db.Dialector.Name() != "postgres" {
+		return nil
+	}
+
+	return db.Exec("CREATE INDEX IF NOT EXISTS idx_items_search ON items USING GIN (" + itemSearchDocument + ")").Error
+}
+
+// itemSearchCursor is the position of the last item of a page: its sort key and ID.
+type itemSearchCursor struct {
+	Value string    `json:"v"`
+	ID    uuid.UUID `json:"id"`
+}
+
+func (itemGorm *ItemGormRepository) Search(query domain.ItemSearchQuery) (*domain.ItemSearchResult, error) {
+	if err := query.Normalize(); err != nil {
+		return nil, err
+	}
+
+	column, descending := itemSortOrder(query.Sort)
+
+	statement := itemGorm.db.Model(&models.ItemModel{})
+	statement = itemGorm.applyKeywords(statement, query.Keywords)
+
+	if query.OwnerID != nil {
+		statement = statement.Where("user_id = ?", *query.OwnerID)
+	}
+	if query.Offered != nil {
+		statement = statement.Where("offered = ?", *query.Offered)
+	}
+	if query.CreatedAfter != nil {
+		statement = statement.Where("created_at >= ?", *query.CreatedAfter)
+	}
+	if query.CreatedBefore != nil {
+		statement = statement.Where("created_at < ?", *query.CreatedBefore)
+	}
+	if query.CategorySlug != "" {
+		statement = statement.Where("category_slug IN (?)", itemGorm.categorySubtree(query.CategorySlug))
+	}
+	if len(query.Conditions) > 0 {
+		conditions := make([]string, 0, len(query.Conditions))
+		for _, condition := range query.Conditions {
+			conditions = append(conditions, string(condition))
+		}
+		statement = statement.Where("condition IN ?", conditions)
+	}
+	for _, tag := range query.Tags {
+		statement = statement.Where("EXISTS (SELECT 1 FROM item_tags WHERE item_tags.item_id = items.id AND item_tags.tag = ?)", tag)
+	}
+
+	if query.Cursor != "" {
+		cursor, err := decodeItemSearchCursor(query.Cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := itemCursorValue(column, cursor.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		operator := ">"
+		if descending {
+			operator = "<"
+		}
+		statement = statement.Where(
+			fmt.Sprintf("(%s %s ? OR (%s = ? AND id %s ?))", column, operator, column, operator),
+			value, value, cursor.ID,
+		)
+	}
+
+	direction := "ASC"
+	if descending {
+		direction = "DESC"
+	}
+
+	// One extra row tells us whether there is a next page.
+	var modelsList []models.ItemModel
+	if err := withItemAssociations(statement).
+		Order(fmt.Sprintf("%s %s, id %s", column, direction, direction)).
+		Limit(query.Limit + 1).
+		Find(&modelsList).Error; err != nil {
+		return nil, err
+	}
+
+	result := &domain.ItemSearchResult{Items: make([]domain.Item, 0, len(modelsList))}
+
+	if len(modelsList) > query.Limit {
+		modelsList = modelsList[:query.Limit]
+		result.NextCursor = encodeItemSearchCursor(column, &modelsList[len(modelsList)-1])
+	}
+
+	for _, m := range modelsList {
+		result.Items = append(result.Items, *toDomainItem(&m))
+	}
+
+	return result, nil
+}
+
+func (itemGorm *ItemGormRepository) applyKeywords(statement *gorm.DB, keywords string) *gorm.DB {
+	keywords = strings.TrimSpace(keywords)
+	if keywords == "" {
+		return statement
+	}
+
+	if itemGorm.db.Dialector.Name() == "postgres" {
+		return statement.Where(itemSearchDocument+" @@ plainto_tsquery('english', ?)", keywords)
+	}
+
+	for _, keyword := range strings.Fields(strings.ToLower(keywords)) {
+		pattern := "%" + escapeLikePattern(keyword) + "%"
+		statement = statement.Where(
+			`(LOWER(name) LIKE ? ESCAPE '\' OR LOWER(description) LIKE ? ESCAPE '\')`,
+			pattern, pattern,
+		)
+	}
+
+	return statement
+}
+
+// categorySubtree selects the slug of the given category and of all its descendants.
+func (itemGorm *ItemGormRepository) categorySubtree(slug string) *gorm.DB {
+	return itemGorm.db.Raw(`
+		WITH RECURSIVE subtree(slug) AS (
+			SELECT slug FROM categories WHERE slug = ?
+			UNION ALL
+			SELECT categories.slug FROM categories JOIN subtree ON categories.parent_slug = subtree.slug
+		)
+		SELECT slug FROM subtree`, slug)
+}
+
+func itemSortOrder(sort domain.ItemSort) (column string, descending bool) {
+	switch sort {
+	case domain.ItemSortOldest:
+		return "created_at", false
+	case domain.ItemSortNameAsc:
+		return "name", false
+	case domain.ItemSortNameDesc:
+		return "name", true
+	default:
+		return "created_at", true
+	}
+}
+
+func itemCursorValue(column, raw string) (interface{}, error) {
+	if column != "created_at" {
+		return raw, nil
+	}
+
+	createdAt, err := time.Parse(time.RFC3339Nano, raw)
+	if err != nil {
+		return nil, domain.InvalidSearchCursorErr
+	}
+
+	return createdAt, nil
+}
+
+func encodeItemSearchCursor(column string, model *models.ItemModel) string {
+	cursor := itemSearchCursor{Value: model.Name, ID: model.ID}
+	if column == "created_at" {
+		cursor.Value = model.CreatedAt.Format(time.RFC3339Nano)
+	}
+
+	encoded, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(encoded)
+}
+
+func decodeItemSearchCursor(raw string) (*itemSearchCursor, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, domain.InvalidSearchCursorErr
+	}
+
+	var cursor itemSearchCursor
+	if err = json.Unmarshal(decoded, &cursor); err != nil || cursor.ID == uuid.Nil {
+		return nil, domain.InvalidSearchCursorErr
+	}
+
+	return &cursor, nil
+}
+
+func escapeLikePattern(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
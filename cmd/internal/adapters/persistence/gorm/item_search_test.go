@@ -0,0 +1,168 @@
+package gorm_test
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"swapp-go/cmd/internal/adapters/persistence/gorm"
+	"swapp-go/cmd/internal/adapters/persistence/gorm/testutils"
+	"swapp-go/cmd/internal/adapters/persistence/models"
+	"swapp-go/cmd/internal/domain"
+	"testing"
+	"time"
+)
+
+func TestItemSearch(t *testing.T) {
+	db := testutils.SetupTestDB(t, &models.ItemModel{}, &models.ItemTagModel{}, &models.ItemPictureModel{}, &models.ItemPictureVariantModel{}, &models.CategoryModel{})
+	repo := gorm.NewItemGormRepository(db)
+	assert.NoError(t, gorm.SeedCategories(db))
+
+	ownerID, otherOwnerID := uuid.New(), uuid.New()
+	base := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	seed := []struct {
+		name        string
+		description string
+		owner       uuid.UUID
+		offered     bool
+		category    string
+		condition   domain.ItemCondition
+		tags        []string
+	}{
+		{"Mountain bike", "Red bike with 21 gears", ownerID, false, "bikes", domain.ConditionUsed, []string{"red", "mtb"}},
+		{"Road bike", "Lightweight carbon frame", otherOwnerID, true, "bikes", domain.ConditionLikeNew, []string{"carbon"}},
+		{"Guitar", "Acoustic guitar, barely used", ownerID, false, "music", domain.ConditionUsed, nil},
+		{"Bike helmet", "Fits 55-59cm", otherOwnerID, false, "sports-outdoors", domain.ConditionNew, []string{"red"}},
+		{"Camera", "Film camera, 100% working", ownerID, false, "cameras", domain.ConditionForParts, nil},
+	}
+
+	for i, entry := range seed {
+		category := entry.category
+		model := &models.ItemModel{
+			ID:           uuid.New(),
+			Name:         entry.name,
+			Description:  entry.description,
+			UserID:       entry.owner,
+			Offered:      entry.offered,
+			CategorySlug: &category,
+			Condition:    string(entry.condition),
+			CreatedAt:    base.Add(time.Duration(i) * time.Hour),
+		}
+		for _, tag := range entry.tags {
+			model.Tags = append(model.Tags, models.ItemTagModel{ItemID: model.ID, Tag: tag})
+		}
+		assert.NoError(t, db.Create(model).Error)
+	}
+
+	names := func(items []domain.Item) []string {
+		result := make([]string, 0, len(items))
+		for _, item := range items {
+			result = append(result, item.Name)
+		}
+		return result
+	}
+
+	t.Run("keywords match name and description", func(t *testing.T) {
+		result, err := repo.Search(domain.ItemSearchQuery{Keywords: "BIKE"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Bike helmet", "Road bike", "Mountain bike"}, names(result.Items))
+
+		result, err = repo.Search(domain.ItemSearchQuery{Keywords: "bike gears"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Mountain bike"}, names(result.Items))
+	})
+
+	t.Run("wildcards in keywords are literal", func(t *testing.T) {
+		result, err := repo.Search(domain.ItemSearchQuery{Keywords: "100%"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Camera"}, names(result.Items))
+
+		result, err = repo.Search(domain.ItemSearchQuery{Keywords: "_"})
+		assert.NoError(t, err)
+		assert.Empty(t, result.Items)
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		offered := false
+		createdAfter := base.Add(time.Hour)
+
+		result, err := repo.Search(domain.ItemSearchQuery{
+			OwnerID:      &ownerID,
+			Offered:      &offered,
+			CreatedAfter: &createdAfter,
+			Sort:         domain.ItemSortOldest,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Guitar", "Camera"}, names(result.Items))
+
+		createdBefore := base.Add(2 * time.Hour)
+		result, err = repo.Search(domain.ItemSearchQuery{CreatedBefore: &createdBefore, Sort: domain.ItemSortOldest})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Mountain bike", "Road bike"}, names(result.Items))
+	})
+
+	t.Run("category includes subcategories", func(t *testing.T) {
+		result, err := repo.Search(domain.ItemSearchQuery{CategorySlug: "sports-outdoors", Sort: domain.ItemSortOldest})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Mountain bike", "Road bike", "Bike helmet"}, names(result.Items))
+
+		result, err = repo.Search(domain.ItemSearchQuery{CategorySlug: "bikes", Sort: domain.ItemSortOldest})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Mountain bike", "Road bike"}, names(result.Items))
+	})
+
+	t.Run("conditions and tags", func(t *testing.T) {
+		result, err := repo.Search(domain.ItemSearchQuery{
+			Conditions: []domain.ItemCondition{domain.ConditionNew, domain.ConditionLikeNew},
+			Sort:       domain.ItemSortOldest,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Road bike", "Bike helmet"}, names(result.Items))
+
+		result, err = repo.Search(domain.ItemSearchQuery{Tags: []string{"Red"}, Sort: domain.ItemSortOldest})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Mountain bike", "Bike helmet"}, names(result.Items))
+		assert.ElementsMatch(t, []string{"red", "mtb"}, result.Items[0].Tags)
+
+		result, err = repo.Search(domain.ItemSearchQuery{Tags: []string{"red", "mtb"}})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Mountain bike"}, names(result.Items))
+	})
+
+	t.Run("cursor pagination walks every page once", func(t *testing.T) {
+		for _, sort := range []domain.ItemSort{domain.ItemSortNewest, domain.ItemSortOldest, domain.ItemSortNameAsc, domain.ItemSortNameDesc} {
+			var collected []string
+			cursor := ""
+
+			for page := 0; page < 5; page++ {
+				result, err := repo.Search(domain.ItemSearchQuery{Sort: sort, Limit: 2, Cursor: cursor})
+				assert.NoError(t, err)
+				collected = append(collected, names(result.Items)...)
+
+				cursor = result.NextCursor
+				if cursor == "" {
+					break
+				}
+			}
+
+			assert.Len(t, collected, len(seed), string(sort))
+			assert.Empty(t, cursor, string(sort))
+		}
+
+		result, err := repo.Search(domain.ItemSearchQuery{Sort: domain.ItemSortNameAsc, Limit: 2})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Bike helmet", "Camera"}, names(result.Items))
+
+		result, err = repo.Search(domain.ItemSearchQuery{Sort: domain.ItemSortNameAsc, Limit: 2, Cursor: result.NextCursor})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Guitar", "Mountain bike"}, names(result.Items))
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		_, err := repo.Search(domain.ItemSearchQuery{Cursor: "not-a-cursor"})
+		assert.ErrorIs(t, err, domain.InvalidSearchCursorErr)
+	})
+
+	t.Run("search index is skipped outside postgres", func(t *testing.T) {
+		assert.NoError(t, gorm.EnsureItemSearchIndex(db))
+	})
+}
@@ -0,0 +1,85 @@
+package gorm
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"swapp-go/cmd/internal/adapters/persistence/models"
+	"swapp-go/cmd/internal/application/ports"
+	"swapp-go/cmd/internal/domain"
+	"time"
+)
+
+type LoginAttemptGormStore struct {
+	db *gorm.DB
+}
+
+func NewLoginAttemptGormStore(db *gorm.DB) ports.LoginAttemptStore {
+	return &LoginAttemptGormStore{db: db}
+}
+
+func (store *LoginAttemptGormStore) Find(key string) (*domain.LoginAttempts, error) {
+	return findLoginAttempts(store.db, key)
+}
+
+// RecordFailure increments the counter in a single upsert, so that concurrent
+// failures are all counted.
+func (store *LoginAttemptGormStore) RecordFailure(key string, failedAt time.Time, windowStart time.Time) (*domain.LoginAttempts, error) {
+	var attempts *domain.LoginAttempts
+
+	err := store.db.Transaction(func(tx *gorm.DB) error {
+		model := &models.LoginAttemptModel{Key: key, Failures: 1, LastFailureAt: failedAt}
+
+		err := tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "key"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"failures": gorm.Expr(
+					"CASE WHEN login_attempts.last_failure_at < ? THEN 1 ELSE login_attempts.failures + 1 END",
+					windowStart,
+				),
+				"last_failure_at": failedAt,
+			}),
+		}).Create(model).Error
+		if err != nil {
+			return err
+		}
+
+		attempts, err = findLoginAttempts(tx, key)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return attempts, nil
+}
+
+func (store *LoginAttemptGormStore) Lock(key string, lockedUntil time.Time) error {
+	return store.db.Model(&models.LoginAttemptModel{}).
+		Where("key = ?", key).
+		Update("locked_until", lockedUntil).Error
+}
+
+func (store *LoginAttemptGormStore) Reset(key string) error {
+	return store.db.Delete(&models.LoginAttemptModel{}, "key = ?", key).Error
+}
+
+func findLoginAttempts(db *gorm.DB, key string) (*domain.LoginAttempts, error) {
+	var model models.LoginAttemptModel
+
+	err := db.First(&model, "key = ?", key).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.LoginAttempts{
+		Key:           model.Key,
+		Failures:      model.Failures,
+		LastFailureAt: model.LastFailureAt,
+		LockedUntil:   model.LockedUntil,
+	}, nil
+}
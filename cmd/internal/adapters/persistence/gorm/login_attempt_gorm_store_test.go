@@ -0,0 +1,69 @@
+package gorm_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	gormRepo "swapp-go/cmd/internal/adapters/persistence/gorm"
+	"swapp-go/cmd/internal/adapters/persistence/gorm/testutils"
+	"swapp-go/cmd/internal/adapters/persistence/models"
+	"testing"
+	"time"
+)
+
+func TestLoginAttemptStore(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	window := time.Hour
+
+	t.Run("RecordFailureAndReset", func(t *testing.T) {
+		db := testutils.SetupTestDB(t, &models.LoginAttemptModel{})
+		store := gormRepo.NewLoginAttemptGormStore(db)
+
+		attempts, err := store.Find("account:user")
+		assert.NoError(t, err)
+		assert.Nil(t, attempts)
+
+		for i := 1; i <= 3; i++ {
+			attempts, err = store.RecordFailure("account:user", now, now.Add(-window))
+			assert.NoError(t, err)
+			assert.Equal(t, i, attempts.Failures)
+		}
+
+		other, err := store.RecordFailure("ip:203.0.113.7", now, now.Add(-window))
+		assert.NoError(t, err)
+		assert.Equal(t, 1, other.Failures, "keys are counted apart")
+
+		assert.NoError(t, store.Reset("account:user"))
+		attempts, err = store.Find("account:user")
+		assert.NoError(t, err)
+		assert.Nil(t, attempts)
+	})
+
+	t.Run("Lock", func(t *testing.T) {
+		db := testutils.SetupTestDB(t, &models.LoginAttemptModel{})
+		store := gormRepo.NewLoginAttemptGormStore(db)
+
+		_, err := store.RecordFailure("account:user", now, now.Add(-window))
+		assert.NoError(t, err)
+		assert.NoError(t, store.Lock("account:user", now.Add(time.Minute)))
+
+		attempts, err := store.Find("account:user")
+		assert.NoError(t, err)
+		assert.True(t, attempts.IsLocked(now))
+		assert.False(t, attempts.IsLocked(now.Add(time.Minute)))
+	})
+
+	t.Run("OldFailuresAreForgotten", func(t *testing.T) {
+		db := testutils.SetupTestDB(t, &models.LoginAttemptModel{})
+		store := gormRepo.NewLoginAttemptGormStore(db)
+
+		for i := 0; i < 3; i++ {
+			_, err := store.RecordFailure("account:user", now, now.Add(-window))
+			assert.NoError(t, err)
+		}
+
+		later := now.Add(2 * window)
+		attempts, err := store.RecordFailure("account:user", later, later.Add(-window))
+		assert.NoError(t, err)
+		assert.Equal(t, 1, attempts.Failures)
+		assert.True(t, later.Equal(attempts.LastFailureAt))
+	})
+}
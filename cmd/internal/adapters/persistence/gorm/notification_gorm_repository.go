@@ -0,0 +1,120 @@
+package gorm
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"swapp-go/cmd/internal/adapters/persistence/models"
+	"swapp-go/cmd/internal/application/ports"
+	"swapp-go/cmd/internal/domain"
+	"time"
+)
+
+type NotificationGormRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationGormRepository(db *gorm.DB) ports.NotificationRepository {
+	return &NotificationGormRepository{db: db}
+}
+
+func toNotificationModel(notification *domain.Notification) *models.NotificationModel {
+	id := notification.ID
+	if id == uuid.Nil {
+		id = uuid.New()
+	}
+
+	return &models.NotificationModel{
+		ID:              id,
+		UserID:          notification.UserID,
+		Type:            string(notification.Type),
+		SwapRequestID:   notification.SwapRequestID,
+		ReferenceNumber: notification.ReferenceNumber,
+		ReadAt:          notification.ReadAt,
+		CreatedAt:       notification.CreatedAt,
+	}
+}
+
+func toDomainNotification(model *models.NotificationModel) *domain.Notification {
+	return &domain.Notification{
+		ID:              model.ID,
+		UserID:          model.UserID,
+		Type:            domain.NotificationType(model.Type),
+		SwapRequestID:   model.SwapRequestID,
+		ReferenceNumber: model.ReferenceNumber,
+		ReadAt:          model.ReadAt,
+		CreatedAt:       model.CreatedAt,
+	}
+}
+
+func (notificationGorm *NotificationGormRepository) Create(notification *domain.Notification) error {
+	model := toNotificationModel(notification)
+
+	if err := notificationGorm.db.Create(model).Error; err != nil {
+		return err
+	}
+
+	notification.ID = model.ID
+	notification.CreatedAt = model.CreatedAt
+
+	return nil
+}
+
+func (notificationGorm *NotificationGormRepository) List(
+	userID uuid.UUID,
+	query domain.NotificationQuery,
+) ([]domain.Notification, error) {
+	db := notificationGorm.db.Model(&models.NotificationModel{}).Where("user_id = ?", userID)
+
+	if query.UnreadOnly {
+		db = db.Where("read_at IS NULL")
+	}
+
+	var notificationModels []models.NotificationModel
+	if err := db.Order("created_at DESC, id ASC").Limit(query.Limit).Offset(query.Offset).Find(&notificationModels).Error; err != nil {
+		return nil, err
+	}
+
+	notifications := make([]domain.Notification, 0, len(notificationModels))
+	for i := range notificationModels {
+		notifications = append(notifications, *toDomainNotification(&notificationModels[i]))
+	}
+
+	return notifications, nil
+}
+
+func (notificationGorm *NotificationGormRepository) CountUnread(userID uuid.UUID) (int64, error) {
+	var count int64
+
+	err := notificationGorm.db.Model(&models.NotificationModel{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Count(&count).Error
+
+	return count, err
+}
+
+func (notificationGorm *NotificationGormRepository) MarkRead(id, userID uuid.UUID, readAt time.Time) (bool, error) {
+	result := notificationGorm.db.Model(&models.NotificationModel{}).
+		Where("id = ? AND user_id = ? AND read_at IS NULL", id, userID).
+		Update("read_at", readAt)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected > 0 {
+		return true, nil
+	}
+
+	var count int64
+	err := notificationGorm.db.Model(&models.NotificationModel{}).
+		Where("id = ? AND user_id = ?", id, userID).
+		Count(&count).Error
+
+	return count > 0, err
+}
+
+func (notificationGorm *NotificationGormRepository) MarkAllRead(userID uuid.UUID, readAt time.Time) (int64, error) {
+	result := notificationGorm.db.Model(&models.NotificationModel{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Update("read_at", readAt)
+
+	return result.RowsAffected, result.Error
+}
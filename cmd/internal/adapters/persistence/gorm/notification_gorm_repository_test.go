@@ -0,0 +1,126 @@
+package gorm_test
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	gormRepo "swapp-go/cmd/internal/adapters/persistence/gorm"
+	"swapp-go/cmd/internal/adapters/persistence/gorm/testutils"
+	"swapp-go/cmd/internal/adapters/persistence/models"
+	"swapp-go/cmd/internal/application/ports"
+	"swapp-go/cmd/internal/domain"
+	"testing"
+	"time"
+)
+
+func setupNotificationRepository(t *testing.T) ports.NotificationRepository {
+	t.Helper()
+
+	db := testutils.SetupTestDB(t, &models.NotificationModel{})
+
+	return gormRepo.NewNotificationGormRepository(db)
+}
+
+func createTestNotification(
+	t *testing.T,
+	repo ports.NotificationRepository,
+	userID uuid.UUID,
+	template domain.EmailTemplate,
+	createdAt time.Time,
+) *domain.Notification {
+	t.Helper()
+
+	swapRequest := &domain.SwapRequest{ID: uuid.New(), ReferenceNumber: "SW-1234"}
+	notification := domain.NewSwapNotification(userID, template, swapRequest, createdAt)
+	assert.NoError(t, repo.Create(notification))
+
+	return notification
+}
+
+func notificationTypes(notifications []domain.Notification) []domain.NotificationType {
+	types := make([]domain.NotificationType, 0, len(notifications))
+	for _, notification := range notifications {
+		types = append(types, notification.Type)
+	}
+	return types
+}
+
+func TestNotificationRepository(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	userID := uuid.New()
+
+	t.Run("CreateAndList", func(t *testing.T) {
+		repo := setupNotificationRepository(t)
+
+		created := createTestNotification(t, repo, userID, domain.EmailTemplateSwapRequestCreated, now.Add(-2*time.Minute))
+		createTestNotification(t, repo, userID, domain.EmailTemplateSwapRequestAccepted, now.Add(-time.Minute))
+		createTestNotification(t, repo, uuid.New(), domain.EmailTemplateSwapRequestRejected, now)
+
+		listed, err := repo.List(userID, domain.NotificationQuery{Limit: 10})
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.NotificationType{"swap_request_accepted", "swap_request_created"}, notificationTypes(listed))
+
+		oldest := listed[1]
+		assert.Equal(t, created.ID, oldest.ID)
+		assert.Equal(t, created.SwapRequestID, oldest.SwapRequestID)
+		assert.Equal(t, "SW-1234", oldest.ReferenceNumber)
+		assert.False(t, oldest.IsRead())
+
+		page, err := repo.List(userID, domain.NotificationQuery{Limit: 1, Offset: 1})
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.NotificationType{"swap_request_created"}, notificationTypes(page))
+	})
+
+	t.Run("MarkRead", func(t *testing.T) {
+		repo := setupNotificationRepository(t)
+
+		first := createTestNotification(t, repo, userID, domain.EmailTemplateSwapRequestCreated, now.Add(-time.Minute))
+		createTestNotification(t, repo, userID, domain.EmailTemplateSwapRequestCountered, now)
+
+		found, err := repo.MarkRead(first.ID, userID, now)
+		assert.NoError(t, err)
+		assert.True(t, found)
+
+		found, err = repo.MarkRead(first.ID, userID, now.Add(time.Hour))
+		assert.NoError(t, err)
+		assert.True(t, found, "marking a read notification again still finds it")
+
+		found, err = repo.MarkRead(first.ID, uuid.New(), now)
+		assert.NoError(t, err)
+		assert.False(t, found, "another user's notification is not found")
+
+		unread, err := repo.List(userID, domain.NotificationQuery{UnreadOnly: true, Limit: 10})
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.NotificationType{"swap_request_countered"}, notificationTypes(unread))
+
+		all, err := repo.List(userID, domain.NotificationQuery{Limit: 10})
+		assert.NoError(t, err)
+		if assert.Len(t, all, 2) && assert.True(t, all[1].IsRead()) {
+			assert.True(t, now.Equal(*all[1].ReadAt))
+		}
+
+		count, err := repo.CountUnread(userID)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("MarkAllRead", func(t *testing.T) {
+		repo := setupNotificationRepository(t)
+		otherUserID := uuid.New()
+
+		createTestNotification(t, repo, userID, domain.EmailTemplateSwapRequestCreated, now)
+		createTestNotification(t, repo, userID, domain.EmailTemplateSwapRequestExpiring, now)
+		createTestNotification(t, repo, otherUserID, domain.EmailTemplateSwapRequestCreated, now)
+
+		marked, err := repo.MarkAllRead(userID, now)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), marked)
+
+		count, err := repo.CountUnread(userID)
+		assert.NoError(t, err)
+		assert.Zero(t, count)
+
+		count, err = repo.CountUnread(otherUserID)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	})
+}
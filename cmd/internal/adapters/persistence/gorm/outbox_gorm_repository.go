@@ -0,0 +1,215 @@
+package gorm
+
+import (
+	"encoding/json"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"swapp-go/cmd/internal/adapters/persistence/models"
+	"swapp-go/cmd/internal/application/ports"
+	"swapp-go/cmd/internal/domain"
+	"time"
+)
+
+type OutboxGormRepository struct {
+	db *gorm.DB
+}
+
+func NewOutboxGormRepository(db *gorm.DB) ports.OutboxRepository {
+	return &OutboxGormRepository{db: db}
+}
+
+func toOutboxMessageModel(message *domain.OutboxMessage) (*models.OutboxMessageModel, error) {
+	id := message.ID
+	if id == uuid.Nil {
+		id = uuid.New()
+	}
+
+	data, err := json.Marshal(message.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.OutboxMessageModel{
+		ID:            id,
+		Recipient:     message.Recipient,
+		Template:      string(message.Template),
+		Data:          string(data),
+		Status:        string(message.Status),
+		Attempts:      message.Attempts,
+		NextAttemptAt: message.NextAttemptAt,
+		LastError:     message.LastError,
+		CreatedAt:     message.CreatedAt,
+		SentAt:        message.SentAt,
+	}, nil
+}
+
+func toDomainOutboxMessage(model *models.OutboxMessageModel) (*domain.OutboxMessage, error) {
+	var data domain.EmailData
+	if err := json.Unmarshal([]byte(model.Data), &data); err != nil {
+		return nil, err
+	}
+
+	return &domain.OutboxMessage{
+		ID: model.ID,
+		EmailMessage: domain.EmailMessage{
+			Recipient: model.Recipient,
+			Template:  domain.EmailTemplate(model.Template),
+			Data:      data,
+		},
+		Status:        domain.OutboxStatus(model.Status),
+		Attempts:      model.Attempts,
+		NextAttemptAt: model.NextAttemptAt,
+		LastError:     model.LastError,
+		CreatedAt:     model.CreatedAt,
+		SentAt:        model.SentAt,
+	}, nil
+}
+
+func (outboxGorm *OutboxGormRepository) Enqueue(message *domain.OutboxMessage) error {
+	model, err := toOutboxMessageModel(message)
+	if err != nil {
+		return err
+	}
+
+	if err = outboxGorm.db.Create(model).Error; err != nil {
+		return err
+	}
+
+	message.ID = model.ID
+	message.CreatedAt = model.CreatedAt
+
+	return nil
+}
+
+func (outboxGorm *OutboxGormRepository) FindByID(id uuid.UUID) (*domain.OutboxMessage, error) {
+	var model models.OutboxMessageModel
+
+	if err := outboxGorm.db.First(&model, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+
+	return toDomainOutboxMessage(&model)
+}
+
+func (outboxGorm *OutboxGormRepository) List(query domain.OutboxQuery) ([]domain.OutboxMessage, error) {
+	db := outboxGorm.db.Model(&models.OutboxMessageModel{})
+
+	if query.Status != nil {
+		db = db.Where("status = ?", string(*query.Status))
+	}
+
+	var messageModels []models.OutboxMessageModel
+	if err := db.Order("created_at DESC, id ASC").Limit(query.Limit).Offset(query.Offset).Find(&messageModels).Error; err != nil {
+		return nil, err
+	}
+
+	return toDomainOutboxMessages(messageModels)
+}
+
+func (outboxGorm *OutboxGormRepository) ListDue(now time.Time, limit int) ([]domain.OutboxMessage, error) {
+	var messageModels []models.OutboxMessageModel
+
+	err := outboxGorm.db.
+		Where("status = ? AND next_attempt_at <= ?", string(domain.OutboxPending), now).
+		Order("next_attempt_at ASC, id ASC").
+		Limit(limit).
+		Find(&messageModels).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return toDomainOutboxMessages(messageModels)
+}
+
+func (outboxGorm *OutboxGormRepository) TryClaim(id uuid.UUID, attempts int, leaseUntil time.Time) (bool, error) {
+	result := outboxGorm.db.Model(&models.OutboxMessageModel{}).
+		Where("id = ? AND status = ? AND attempts = ?", id, string(domain.OutboxPending), attempts).
+		Updates(map[string]interface{}{
+			"attempts":        attempts + 1,
+			"next_attempt_at": leaseUntil,
+		})
+
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// MarkSent also clears the template data, which is not needed any more and
+// may hold links that act as credentials.
+func (outboxGorm *OutboxGormRepository) MarkSent(id uuid.UUID, sentAt time.Time) error {
+	return outboxGorm.db.Model(&models.OutboxMessageModel{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":     string(domain.OutboxSent),
+			"sent_at":    sentAt,
+			"last_error": "",
+			"data":       "{}",
+		}).Error
+}
+
+func (outboxGorm *OutboxGormRepository) MarkFailed(id uuid.UUID, nextAttemptAt time.Time, lastError string) error {
+	return outboxGorm.db.Model(&models.OutboxMessageModel{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"next_attempt_at": nextAttemptAt,
+			"last_error":      lastError,
+		}).Error
+}
+
+// MarkDead keeps the template data so that the message can be replayed, but
+// drops the links in it that act as credentials.
+func (outboxGorm *OutboxGormRepository) MarkDead(id uuid.UUID, lastError string) error {
+	var model models.OutboxMessageModel
+	if err := outboxGorm.db.Select("data").First(&model, "id = ?", id).Error; err != nil {
+		return err
+	}
+
+	var data domain.EmailData
+	if err := json.Unmarshal([]byte(model.Data), &data); err != nil {
+		return err
+	}
+
+	redacted, err := json.Marshal(data.WithoutSecrets())
+	if err != nil {
+		return err
+	}
+
+	return outboxGorm.db.Model(&models.OutboxMessageModel{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":     string(domain.OutboxDead),
+			"last_error": lastError,
+			"data":       string(redacted),
+		}).Error
+}
+
+func (outboxGorm *OutboxGormRepository) TryRequeue(id uuid.UUID, nextAttemptAt time.Time) (bool, error) {
+	result := outboxGorm.db.Model(&models.OutboxMessageModel{}).
+		Where("id = ? AND status = ?", id, string(domain.OutboxDead)).
+		Updates(map[string]interface{}{
+			"status":          string(domain.OutboxPending),
+			"attempts":        0,
+			"next_attempt_at": nextAttemptAt,
+		})
+
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+func toDomainOutboxMessages(messageModels []models.OutboxMessageModel) ([]domain.OutboxMessage, error) {
+	messages := make([]domain.OutboxMessage, 0, len(messageModels))
+	for i := range messageModels {
+		message, err := toDomainOutboxMessage(&messageModels[i])
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, *message)
+	}
+
+	return messages, nil
+}
@@ -0,0 +1,193 @@
+package gorm_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	gormRepo "swapp-go/cmd/internal/adapters/persistence/gorm"
+	"swapp-go/cmd/internal/adapters/persistence/gorm/testutils"
+	"swapp-go/cmd/internal/adapters/persistence/models"
+	"swapp-go/cmd/internal/application/ports"
+	"swapp-go/cmd/internal/domain"
+	"testing"
+	"time"
+)
+
+func setupOutboxRepository(t *testing.T) ports.OutboxRepository {
+	t.Helper()
+
+	db := testutils.SetupTestDB(t, &models.OutboxMessageModel{})
+
+	return gormRepo.NewOutboxGormRepository(db)
+}
+
+func enqueueTestMessage(t *testing.T, repo ports.OutboxRepository, recipient string, now time.Time) *domain.OutboxMessage {
+	t.Helper()
+
+	message := domain.NewOutboxMessage(domain.EmailMessage{
+		Recipient: recipient,
+		Template:  domain.EmailTemplateSwapRequestCreated,
+		Data: domain.EmailData{
+			"Username":     "user",
+			"OfferedItems": []map[string]any{{"Name": "Bike"}},
+		},
+	}, now)
+	assert.NoError(t, repo.Enqueue(message))
+
+	return message
+}
+
+func TestOutboxRepository(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+
+	t.Run("EnqueueAndFind", func(t *testing.T) {
+		repo := setupOutboxRepository(t)
+
+		message := enqueueTestMessage(t, repo, "user@example.com", now)
+
+		found, err := repo.FindByID(message.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, "user@example.com", found.Recipient)
+		assert.Equal(t, domain.EmailTemplateSwapRequestCreated, found.Template)
+		assert.Equal(t, "user", found.Data["Username"])
+		assert.Equal(t, []any{map[string]any{"Name": "Bike"}}, found.Data["OfferedItems"])
+		assert.Equal(t, domain.OutboxPending, found.Status)
+		assert.Zero(t, found.Attempts)
+		assert.WithinDuration(t, now, found.NextAttemptAt, time.Second)
+		assert.Nil(t, found.SentAt)
+	})
+
+	t.Run("ListDue", func(t *testing.T) {
+		repo := setupOutboxRepository(t)
+
+		later := enqueueTestMessage(t, repo, "later@example.com", now.Add(time.Minute))
+		due := enqueueTestMessage(t, repo, "due@example.com", now.Add(-time.Minute))
+		sent := enqueueTestMessage(t, repo, "sent@example.com", now.Add(-2*time.Minute))
+		assert.NoError(t, repo.MarkSent(sent.ID, now))
+
+		messages, err := repo.ListDue(now, 10)
+		assert.NoError(t, err)
+		assert.Len(t, messages, 1)
+		assert.Equal(t, due.ID, messages[0].ID)
+		assert.NotEqual(t, later.ID, messages[0].ID)
+	})
+
+	t.Run("TryClaim", func(t *testing.T) {
+		repo := setupOutboxRepository(t)
+
+		message := enqueueTestMessage(t, repo, "user@example.com", now)
+		leaseUntil := now.Add(5 * time.Minute)
+
+		claimed, err := repo.TryClaim(message.ID, 0, leaseUntil)
+		assert.NoError(t, err)
+		assert.True(t, claimed)
+
+		claimed, err = repo.TryClaim(message.ID, 0, leaseUntil)
+		assert.NoError(t, err)
+		assert.False(t, claimed, "a claimed message cannot be claimed with a stale attempt count")
+
+		found, err := repo.FindByID(message.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, found.Attempts)
+		assert.WithinDuration(t, leaseUntil, found.NextAttemptAt, time.Second)
+
+		messages, err := repo.ListDue(now, 10)
+		assert.NoError(t, err)
+		assert.Empty(t, messages, "a leased message is not due")
+	})
+
+	t.Run("MarkFailedAndDead", func(t *testing.T) {
+		repo := setupOutboxRepository(t)
+
+		message := enqueueTestMessage(t, repo, "user@example.com", now)
+		retryAt := now.Add(time.Minute)
+
+		assert.NoError(t, repo.MarkFailed(message.ID, retryAt, "connection refused"))
+
+		found, err := repo.FindByID(message.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.OutboxPending, found.Status)
+		assert.Equal(t, "connection refused", found.LastError)
+		assert.WithinDuration(t, retryAt, found.NextAttemptAt, time.Second)
+
+		assert.NoError(t, repo.MarkDead(message.ID, "mailbox unavailable"))
+
+		found, err = repo.FindByID(message.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.OutboxDead, found.Status)
+		assert.Equal(t, "mailbox unavailable", found.LastError)
+	})
+
+	t.Run("MarkSentClearsData", func(t *testing.T) {
+		repo := setupOutboxRepository(t)
+
+		message := enqueueTestMessage(t, repo, "user@example.com", now)
+		assert.NoError(t, repo.MarkSent(message.ID, now))
+
+		found, err := repo.FindByID(message.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.OutboxSent, found.Status)
+		assert.Empty(t, found.Data)
+	})
+
+	t.Run("MarkDeadDropsSecretLinks", func(t *testing.T) {
+		repo := setupOutboxRepository(t)
+
+		message := domain.NewOutboxMessage(domain.EmailMessage{
+			Recipient: "user@example.com",
+			Template:  domain.EmailTemplatePasswordReset,
+			Data: domain.EmailData{
+				"Username":               "user",
+				"ResetURL":               "https://example.com/reset?token=secret",
+				domain.UnsubscribeURLKey: "https://example.com/unsubscribe?token=secret",
+			},
+		}, now)
+		assert.NoError(t, repo.Enqueue(message))
+		assert.NoError(t, repo.MarkDead(message.ID, "mailbox unavailable"))
+
+		found, err := repo.FindByID(message.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.EmailData{"Username": "user"}, found.Data)
+	})
+
+	t.Run("TryRequeue", func(t *testing.T) {
+		repo := setupOutboxRepository(t)
+
+		message := enqueueTestMessage(t, repo, "user@example.com", now)
+
+		requeued, err := repo.TryRequeue(message.ID, now)
+		assert.NoError(t, err)
+		assert.False(t, requeued, "only dead messages are requeued")
+
+		_, err = repo.TryClaim(message.ID, 0, now.Add(time.Minute))
+		assert.NoError(t, err)
+		assert.NoError(t, repo.MarkDead(message.ID, "mailbox unavailable"))
+
+		requeued, err = repo.TryRequeue(message.ID, now)
+		assert.NoError(t, err)
+		assert.True(t, requeued)
+
+		found, err := repo.FindByID(message.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.OutboxPending, found.Status)
+		assert.Zero(t, found.Attempts)
+		assert.Equal(t, "mailbox unavailable", found.LastError)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		repo := setupOutboxRepository(t)
+
+		older := enqueueTestMessage(t, repo, "older@example.com", now.Add(-time.Hour))
+		newer := enqueueTestMessage(t, repo, "newer@example.com", now)
+		assert.NoError(t, repo.MarkDead(older.ID, "mailbox unavailable"))
+
+		messages, err := repo.List(domain.OutboxQuery{Limit: 10})
+		assert.NoError(t, err)
+		assert.Len(t, messages, 2)
+		assert.Equal(t, newer.ID, messages[0].ID)
+
+		dead := domain.OutboxDead
+		messages, err = repo.List(domain.OutboxQuery{Status: &dead, Limit: 10})
+		assert.NoError(t, err)
+		assert.Len(t, messages, 1)
+		assert.Equal(t, older.ID, messages[0].ID)
+	})
+}
@@ -14,30 +14,55 @@ func NewPasswordResetGormRepository(db *gorm.DB) *PasswordResetGormRepository {
 	return &PasswordResetGormRepository{db: db}
 }
 
-func (r *PasswordResetGormRepository) Save(token *domain.PasswordReset) error {
+func (r *PasswordResetGormRepository) Replace(reset *domain.PasswordReset) error {
 	model := models.PasswordResetModel{
-		Token:     token.Token,
-		UserID:    token.UserID,
-		ExpiresAt: token.ExpiresAt,
+		TokenHash: reset.TokenHash,
+		UserID:    reset.UserID,
+		CreatedAt: reset.CreatedAt,
+		ExpiresAt: reset.ExpiresAt,
 	}
 
-	return r.db.Create(&model).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&models.PasswordResetModel{}, "user_id = ?", reset.UserID).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(&model).Error
+	})
 }
 
-func (r *PasswordResetGormRepository) GetByToken(token string) (*domain.PasswordReset, error) {
+func (r *PasswordResetGormRepository) GetByTokenHash(tokenHash string) (*domain.PasswordReset, error) {
 	var model models.PasswordResetModel
 
-	if err := r.db.First(&model, "token = ?", token).Error; err != nil {
+	if err := r.db.First(&model, "token_hash = ?", tokenHash).Error; err != nil {
 		return nil, err
 	}
 
 	return &domain.PasswordReset{
-		Token:     model.Token,
+		TokenHash: model.TokenHash,
 		UserID:    model.UserID,
+		CreatedAt: model.CreatedAt,
 		ExpiresAt: model.ExpiresAt,
 	}, nil
 }
 
-func (r *PasswordResetGormRepository) Delete(token string) error {
-	return r.db.Delete(&models.PasswordResetModel{}, "token = ?", token).Error
+func (r *PasswordResetGormRepository) TryDelete(tokenHash string) (bool, error) {
+	result := r.db.Delete(&models.PasswordResetModel{}, "token_hash = ?", tokenHash)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// DiscardLegacyPasswordResets drops the password_resets table if it still
+// stores plain text tokens. Those tokens can no longer be looked up, and the
+// table may hold several of them per user, which the new schema forbids.
+func DiscardLegacyPasswordResets(db *gorm.DB) error {
+	migrator := db.Migrator()
+	if !migrator.HasTable(&models.PasswordResetModel{}) || migrator.HasColumn(&models.PasswordResetModel{}, "TokenHash") {
+		return nil
+	}
+
+	return migrator.DropTable(&models.PasswordResetModel{})
 }
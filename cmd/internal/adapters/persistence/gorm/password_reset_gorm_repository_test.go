@@ -15,51 +15,91 @@ func TestPasswordResetRepository(t *testing.T) {
 	db := testutils.SetupTestDB(t, &models.PasswordResetModel{})
 	repo := gormRepo.NewPasswordResetGormRepository(db)
 
-	t.Run("SaveAndGet", func(t *testing.T) {
+	t.Run("ReplaceAndGet", func(t *testing.T) {
 		userID := uuid.New()
-		token := "test_token"
 		expiresAt := time.Now().Add(1 * time.Hour)
 
 		reset := &domain.PasswordReset{
-			Token:     token,
+			TokenHash: "test_hash",
 			UserID:    userID,
+			CreatedAt: time.Now(),
 			ExpiresAt: expiresAt,
 		}
 
-		err := repo.Save(reset)
+		err := repo.Replace(reset)
 		assert.NoError(t, err)
 
-		retrieved, err := repo.GetByToken(token)
+		retrieved, err := repo.GetByTokenHash(reset.TokenHash)
 		assert.NoError(t, err)
 		assert.NotNil(t, retrieved)
-		assert.Equal(t, reset.Token, retrieved.Token)
+		assert.Equal(t, reset.TokenHash, retrieved.TokenHash)
 		assert.Equal(t, reset.UserID, retrieved.UserID)
 		assert.WithinDuration(t, reset.ExpiresAt, retrieved.ExpiresAt, time.Second)
 	})
 
-	t.Run("GetByToken_NotFound", func(t *testing.T) {
-		result, err := repo.GetByToken("non_existent_token")
+	t.Run("Replace_KeepsOneResetPerUser", func(t *testing.T) {
+		userID := uuid.New()
+
+		first := &domain.PasswordReset{TokenHash: "first_hash", UserID: userID, ExpiresAt: time.Now().Add(time.Hour)}
+		second := &domain.PasswordReset{TokenHash: "second_hash", UserID: userID, ExpiresAt: time.Now().Add(time.Hour)}
+		assert.NoError(t, repo.Replace(first))
+		assert.NoError(t, repo.Replace(second))
+
+		_, err := repo.GetByTokenHash(first.TokenHash)
+		assert.Error(t, err, "the earlier token must no longer work")
+
+		_, err = repo.GetByTokenHash(second.TokenHash)
+		assert.NoError(t, err)
+	})
+
+	t.Run("GetByTokenHash_NotFound", func(t *testing.T) {
+		result, err := repo.GetByTokenHash("non_existent_hash")
 		assert.Error(t, err)
 		assert.Nil(t, result)
 	})
 
-	t.Run("Delete", func(t *testing.T) {
-		userID := uuid.New()
-		token := "some_token"
-
+	t.Run("TryDelete", func(t *testing.T) {
 		reset := &domain.PasswordReset{
-			Token:     token,
-			UserID:    userID,
+			TokenHash: "some_hash",
+			UserID:    uuid.New(),
 			ExpiresAt: time.Now().Add(1 * time.Hour),
 		}
 
-		err := repo.Save(reset)
+		err := repo.Replace(reset)
+		assert.NoError(t, err)
+
+		deleted, err := repo.TryDelete(reset.TokenHash)
 		assert.NoError(t, err)
+		assert.True(t, deleted)
 
-		err = repo.Delete(token)
+		deleted, err = repo.TryDelete(reset.TokenHash)
 		assert.NoError(t, err)
+		assert.False(t, deleted, "a token can only be used once")
 
-		_, err = repo.GetByToken(token)
+		_, err = repo.GetByTokenHash(reset.TokenHash)
 		assert.Error(t, err)
 	})
 }
+
+func TestDiscardLegacyPasswordResets(t *testing.T) {
+	type legacyPasswordResetModel struct {
+		Token     string    `gorm:"primaryKey"`
+		UserID    uuid.UUID `gorm:"type:uuid;not null"`
+		ExpiresAt time.Time `gorm:"not null"`
+	}
+
+	db := testutils.SetupTestDB(t)
+	assert.NoError(t, db.Table("password_resets").Migrator().CreateTable(&legacyPasswordResetModel{}))
+	assert.NoError(t, db.Table("password_resets").Create(&legacyPasswordResetModel{
+		Token:     "plain-token",
+		UserID:    uuid.New(),
+		ExpiresAt: time.Now().Add(time.Hour),
+	}).Error)
+
+	assert.NoError(t, gormRepo.DiscardLegacyPasswordResets(db))
+	assert.False(t, db.Migrator().HasTable(&models.PasswordResetModel{}))
+
+	assert.NoError(t, db.AutoMigrate(&models.PasswordResetModel{}))
+	assert.NoError(t, gormRepo.DiscardLegacyPasswordResets(db))
+	assert.True(t, db.Migrator().HasTable(&models.PasswordResetModel{}), "the current schema is kept")
+}
@@ -0,0 +1,129 @@
+package gorm
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"swapp-go/cmd/internal/adapters/persistence/models"
+	"swapp-go/cmd/internal/application/ports"
+	"swapp-go/cmd/internal/domain"
+	"time"
+)
+
+type SessionGormRepository struct {
+	db *gorm.DB
+}
+
+func NewSessionGormRepository(db *gorm.DB) ports.SessionRepository {
+	return &SessionGormRepository{db: db}
+}
+
+func toSessionModel(session *domain.Session) *models.SessionModel {
+	id := session.ID
+	if id == uuid.Nil {
+		id = uuid.New()
+	}
+
+	return &models.SessionModel{
+		ID:               id,
+		UserID:           session.UserID,
+		RefreshTokenHash: session.RefreshTokenHash,
+		UserAgent:        session.UserAgent,
+		IPAddress:        session.IPAddress,
+		CreatedAt:        session.CreatedAt,
+		LastUsedAt:       session.LastUsedAt,
+		ExpiresAt:        session.ExpiresAt,
+		RevokedAt:        session.RevokedAt,
+	}
+}
+
+func toDomainSession(model *models.SessionModel) *domain.Session {
+	return &domain.Session{
+		ID:               model.ID,
+		UserID:           model.UserID,
+		RefreshTokenHash: model.RefreshTokenHash,
+		UserAgent:        model.UserAgent,
+		IPAddress:        model.IPAddress,
+		CreatedAt:        model.CreatedAt,
+		LastUsedAt:       model.LastUsedAt,
+		ExpiresAt:        model.ExpiresAt,
+		RevokedAt:        model.RevokedAt,
+	}
+}
+
+func (sessionGorm *SessionGormRepository) Create(session *domain.Session) error {
+	model := toSessionModel(session)
+
+	if err := sessionGorm.db.Create(model).Error; err != nil {
+		return err
+	}
+
+	session.ID = model.ID
+	session.CreatedAt = model.CreatedAt
+
+	return nil
+}
+
+func (sessionGorm *SessionGormRepository) FindByID(id uuid.UUID) (*domain.Session, error) {
+	var model models.SessionModel
+
+	if err := sessionGorm.db.First(&model, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+
+	return toDomainSession(&model), nil
+}
+
+func (sessionGorm *SessionGormRepository) ListActiveByUser(userID uuid.UUID, now time.Time) ([]domain.Session, error) {
+	var sessionModels []models.SessionModel
+
+	err := sessionGorm.db.
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, now).
+		Order("last_used_at DESC").
+		Find(&sessionModels).Error
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]domain.Session, 0, len(sessionModels))
+	for i := range sessionModels {
+		sessions = append(sessions, *toDomainSession(&sessionModels[i]))
+	}
+
+	return sessions, nil
+}
+
+func (sessionGorm *SessionGormRepository) TryRotate(session *domain.Session, currentHash string) (bool, error) {
+	result := sessionGorm.db.Model(&models.SessionModel{}).
+		Where("id = ? AND refresh_token_hash = ? AND revoked_at IS NULL", session.ID, currentHash).
+		Updates(map[string]interface{}{
+			"refresh_token_hash": session.RefreshTokenHash,
+			"user_agent":         session.UserAgent,
+			"ip_address":         session.IPAddress,
+			"last_used_at":       session.LastUsedAt,
+			"expires_at":         session.ExpiresAt,
+		})
+
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+func (sessionGorm *SessionGormRepository) Revoke(id uuid.UUID, revokedAt time.Time) error {
+	return sessionGorm.db.Model(&models.SessionModel{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", revokedAt).Error
+}
+
+func (sessionGorm *SessionGormRepository) RevokeAllByUser(userID uuid.UUID, revokedAt time.Time) error {
+	return sessionGorm.db.Model(&models.SessionModel{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", revokedAt).Error
+}
+
+func (sessionGorm *SessionGormRepository) RevokeOthersByUser(userID uuid.UUID, keepID uuid.UUID, revokedAt time.Time) error {
+	return sessionGorm.db.Model(&models.SessionModel{}).
+		Where("user_id = ? AND id <> ? AND revoked_at IS NULL", userID, keepID).
+		Update("revoked_at", revokedAt).Error
+}
@@ -0,0 +1,142 @@
+package gorm_test
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	gormRepo "swapp-go/cmd/internal/adapters/persistence/gorm"
+	"swapp-go/cmd/internal/adapters/persistence/gorm/testutils"
+	"swapp-go/cmd/internal/adapters/persistence/models"
+	"swapp-go/cmd/internal/domain"
+	"testing"
+	"time"
+)
+
+func newTestSession(userID uuid.UUID, now time.Time) *domain.Session {
+	return &domain.Session{
+		UserID:           userID,
+		RefreshTokenHash: "initial-hash",
+		UserAgent:        "test-agent",
+		IPAddress:        "203.0.113.7",
+		CreatedAt:        now,
+		LastUsedAt:       now,
+		ExpiresAt:        now.Add(time.Hour),
+	}
+}
+
+func TestSessionRepository(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+
+	t.Run("CreateAndFind", func(t *testing.T) {
+		db := testutils.SetupTestDB(t, &models.SessionModel{})
+		repo := gormRepo.NewSessionGormRepository(db)
+
+		session := newTestSession(uuid.New(), now)
+		assert.NoError(t, repo.Create(session))
+		assert.NotEqual(t, uuid.Nil, session.ID)
+
+		found, err := repo.FindByID(session.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, session.UserID, found.UserID)
+		assert.Equal(t, "initial-hash", found.RefreshTokenHash)
+		assert.Equal(t, "test-agent", found.UserAgent)
+		assert.Nil(t, found.RevokedAt)
+		assert.WithinDuration(t, session.ExpiresAt, found.ExpiresAt, time.Second)
+	})
+
+	t.Run("TryRotate", func(t *testing.T) {
+		db := testutils.SetupTestDB(t, &models.SessionModel{})
+		repo := gormRepo.NewSessionGormRepository(db)
+
+		session := newTestSession(uuid.New(), now)
+		assert.NoError(t, repo.Create(session))
+
+		session.RefreshTokenHash = "rotated-hash"
+		session.IPAddress = "198.51.100.4"
+		rotated, err := repo.TryRotate(session, "initial-hash")
+		assert.NoError(t, err)
+		assert.True(t, rotated)
+
+		session.RefreshTokenHash = "another-hash"
+		rotated, err = repo.TryRotate(session, "initial-hash")
+		assert.NoError(t, err)
+		assert.False(t, rotated, "a stale hash must not rotate the session")
+
+		found, err := repo.FindByID(session.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, "rotated-hash", found.RefreshTokenHash)
+		assert.Equal(t, "198.51.100.4", found.IPAddress)
+	})
+
+	t.Run("RevokeAndListActive", func(t *testing.T) {
+		db := testutils.SetupTestDB(t, &models.SessionModel{})
+		repo := gormRepo.NewSessionGormRepository(db)
+		userID := uuid.New()
+
+		older := newTestSession(userID, now.Add(-time.Minute))
+		newer := newTestSession(userID, now)
+		revoked := newTestSession(userID, now)
+		expired := newTestSession(userID, now.Add(-2*time.Hour))
+		otherUser := newTestSession(uuid.New(), now)
+		for _, session := range []*domain.Session{older, newer, revoked, expired, otherUser} {
+			assert.NoError(t, repo.Create(session))
+		}
+
+		assert.NoError(t, repo.Revoke(revoked.ID, now))
+
+		rotated, err := repo.TryRotate(revoked, revoked.RefreshTokenHash)
+		assert.NoError(t, err)
+		assert.False(t, rotated, "a revoked session must not rotate")
+
+		sessions, err := repo.ListActiveByUser(userID, now)
+		assert.NoError(t, err)
+		assert.Len(t, sessions, 2)
+		assert.Equal(t, newer.ID, sessions[0].ID)
+		assert.Equal(t, older.ID, sessions[1].ID)
+
+		found, err := repo.FindByID(revoked.ID)
+		assert.NoError(t, err)
+		assert.NotNil(t, found.RevokedAt)
+	})
+
+	t.Run("RevokeAllByUser", func(t *testing.T) {
+		db := testutils.SetupTestDB(t, &models.SessionModel{})
+		repo := gormRepo.NewSessionGormRepository(db)
+		userID := uuid.New()
+
+		first := newTestSession(userID, now)
+		second := newTestSession(userID, now)
+		otherUser := newTestSession(uuid.New(), now)
+		for _, session := range []*domain.Session{first, second, otherUser} {
+			assert.NoError(t, repo.Create(session))
+		}
+
+		assert.NoError(t, repo.RevokeAllByUser(userID, now))
+
+		sessions, err := repo.ListActiveByUser(userID, now)
+		assert.NoError(t, err)
+		assert.Empty(t, sessions)
+
+		sessions, err = repo.ListActiveByUser(otherUser.UserID, now)
+		assert.NoError(t, err)
+		assert.Len(t, sessions, 1)
+	})
+
+	t.Run("RevokeOthersByUser", func(t *testing.T) {
+		db := testutils.SetupTestDB(t, &models.SessionModel{})
+		repo := gormRepo.NewSessionGormRepository(db)
+		userID := uuid.New()
+
+		current := newTestSession(userID, now)
+		other := newTestSession(userID, now)
+		for _, session := range []*domain.Session{current, other} {
+			assert.NoError(t, repo.Create(session))
+		}
+
+		assert.NoError(t, repo.RevokeOthersByUser(userID, current.ID, now))
+
+		sessions, err := repo.ListActiveByUser(userID, now)
+		assert.NoError(t, err)
+		assert.Len(t, sessions, 1)
+		assert.Equal(t, current.ID, sessions[0].ID)
+	})
+}
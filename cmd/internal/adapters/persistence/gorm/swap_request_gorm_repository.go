@@ -5,6 +5,7 @@ import (
 	"gorm.io/gorm"
 	"swapp-go/cmd/internal/adapters/persistence/models"
 	"swapp-go/cmd/internal/domain"
+	"time"
 )
 
 type SwapRequestGormRepository struct {
@@ -21,27 +22,91 @@ func toSwapRequestModel(swapRequest *domain.SwapRequest) *models.SwapRequestMode
 		id = uuid.New()
 	}
 
+	threadID := swapRequest.ThreadID
+	if threadID == uuid.Nil {
+		threadID = id
+	}
+
+	swapRequest.NormalizeItems()
+
+	var items []models.SwapRequestItemModel
+	for position, itemID := range swapRequest.OfferedItemIDs {
+		items = append(items, models.SwapRequestItemModel{
+			SwapRequestID: id,
+			ItemID:        itemID,
+			Role:          models.SwapRequestItemOffered,
+			Position:      position,
+		})
+	}
+	for position, itemID := range swapRequest.RequestedItemIDs {
+		items = append(items, models.SwapRequestItemModel{
+			SwapRequestID: id,
+			ItemID:        itemID,
+			Role:          models.SwapRequestItemRequested,
+			Position:      position,
+		})
+	}
+
 	return &models.SwapRequestModel{
-		ID:              id,
-		Status:          models.SwapRequestStatus(swapRequest.Status),
-		ReferenceNumber: swapRequest.ReferenceNumber,
-		OfferedItemID:   swapRequest.OfferedItemID,
-		RequestedItemID: swapRequest.RequestedItemID,
-		SenderID:        swapRequest.SenderID,
-		RecipientID:     swapRequest.RecipientID,
+		ID:                 id,
+		Status:             models.SwapRequestStatus(swapRequest.Status),
+		ReferenceNumber:    swapRequest.ReferenceNumber,
+		OfferedItemID:      swapRequest.OfferedItemID,
+		RequestedItemID:    swapRequest.RequestedItemID,
+		SenderID:           swapRequest.SenderID,
+		RecipientID:        swapRequest.RecipientID,
+		SenderConfirmed:    swapRequest.SenderConfirmed,
+		RecipientConfirmed: swapRequest.RecipientConfirmed,
+		ThreadID:           threadID,
+		ParentID:           swapRequest.ParentID,
+		Items:              items,
+		ExpiresAt:          swapRequest.ExpiresAt,
+		ReminderSentAt:     swapRequest.ReminderSentAt,
 	}
 }
 
 func toDomainSwapRequest(model *models.SwapRequestModel) *domain.SwapRequest {
-	return &domain.SwapRequest{
-		ID:              model.ID,
-		Status:          domain.SwapRequestStatus(model.Status),
-		ReferenceNumber: model.ReferenceNumber,
-		OfferedItemID:   model.OfferedItemID,
-		RequestedItemID: model.RequestedItemID,
-		SenderID:        model.SenderID,
-		RecipientID:     model.RecipientID,
+	threadID := model.ThreadID
+	if threadID == uuid.Nil {
+		threadID = model.ID
+	}
+
+	swapRequest := &domain.SwapRequest{
+		ID:                 model.ID,
+		Status:             domain.SwapRequestStatus(model.Status),
+		ReferenceNumber:    model.ReferenceNumber,
+		OfferedItemID:      model.OfferedItemID,
+		RequestedItemID:    model.RequestedItemID,
+		SenderID:           model.SenderID,
+		RecipientID:        model.RecipientID,
+		SenderConfirmed:    model.SenderConfirmed,
+		RecipientConfirmed: model.RecipientConfirmed,
+		ThreadID:           threadID,
+		ParentID:           model.ParentID,
+		ExpiresAt:          model.ExpiresAt,
+		ReminderSentAt:     model.ReminderSentAt,
+		CreatedAt:          model.CreatedAt,
+	}
+
+	for _, item := range model.Items {
+		switch item.Role {
+		case models.SwapRequestItemOffered:
+			swapRequest.OfferedItemIDs = append(swapRequest.OfferedItemIDs, item.ItemID)
+		case models.SwapRequestItemRequested:
+			swapRequest.RequestedItemIDs = append(swapRequest.RequestedItemIDs, item.ItemID)
+		}
 	}
+
+	swapRequest.NormalizeItems()
+
+	return swapRequest
+}
+
+// withItems preloads the bundle items of each swap request in their original order.
+func (swapRequestGorm *SwapRequestGormRepository) withItems() *gorm.DB {
+	return swapRequestGorm.db.Preload("Items", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position ASC")
+	})
 }
 
 func (swapRequestGorm *SwapRequestGormRepository) Create(swapRequest *domain.SwapRequest) error {
@@ -52,13 +117,15 @@ func (swapRequestGorm *SwapRequestGormRepository) Create(swapRequest *domain.Swa
 	}
 
 	swapRequest.ID = model.ID
+	swapRequest.ThreadID = model.ThreadID
+	swapRequest.CreatedAt = model.CreatedAt
 
 	return nil
 }
 
 func (swapRequestGorm *SwapRequestGormRepository) FindByID(id uuid.UUID) (*domain.SwapRequest, error) {
 	var model models.SwapRequestModel
-	if err := swapRequestGorm.db.First(&model, "id = ?", id).Error; err != nil {
+	if err := swapRequestGorm.withItems().First(&model, "id = ?", id).Error; err != nil {
 		return nil, err
 	}
 	return toDomainSwapRequest(&model), nil
@@ -66,7 +133,7 @@ func (swapRequestGorm *SwapRequestGormRepository) FindByID(id uuid.UUID) (*domai
 
 func (swapRequestGorm *SwapRequestGormRepository) FindByReferenceNumber(reference string) (*domain.SwapRequest, error) {
 	var model models.SwapRequestModel
-	if err := swapRequestGorm.db.First(&model, "reference_number = ?", reference).Error; err != nil {
+	if err := swapRequestGorm.withItems().First(&model, "reference_number = ?", reference).Error; err != nil {
 		return nil, err
 	}
 	return toDomainSwapRequest(&model), nil
@@ -74,7 +141,7 @@ func (swapRequestGorm *SwapRequestGormRepository) FindByReferenceNumber(referenc
 
 func (swapRequestGorm *SwapRequestGormRepository) ListByUser(userID uuid.UUID) ([]domain.SwapRequest, error) {
 	var modelsList []models.SwapRequestModel
-	if err := swapRequestGorm.db.Where(
+	if err := swapRequestGorm.withItems().Where(
 		"sender_id = ? OR recipient_id = ?", userID, userID,
 	).Find(&modelsList).Error; err != nil {
 		return nil, err
@@ -90,7 +157,40 @@ func (swapRequestGorm *SwapRequestGormRepository) ListByUser(userID uuid.UUID) (
 
 func (swapRequestGorm *SwapRequestGormRepository) ListByStatus(status domain.SwapRequestStatus) ([]domain.SwapRequest, error) {
 	var modelsList []models.SwapRequestModel
-	if err := swapRequestGorm.db.Where("status = ?", string(status)).Find(&modelsList).Error; err != nil {
+	if err := swapRequestGorm.withItems().Where("status = ?", string(status)).Find(&modelsList).Error; err != nil {
+		return nil, err
+	}
+
+	var domainList []domain.SwapRequest
+	for _, m := range modelsList {
+		domainList = append(domainList, *toDomainSwapRequest(&m))
+	}
+
+	return domainList, nil
+}
+
+func (swapRequestGorm *SwapRequestGormRepository) ListByThread(threadID uuid.UUID) ([]domain.SwapRequest, error) {
+	var modelsList []models.SwapRequestModel
+	if err := swapRequestGorm.withItems().Where(
+		"thread_id = ? OR id = ?", threadID, threadID,
+	).Order("created_at ASC").Find(&modelsList).Error; err != nil {
+		return nil, err
+	}
+
+	var domainList []domain.SwapRequest
+	for _, m := range modelsList {
+		domainList = append(domainList, *toDomainSwapRequest(&m))
+	}
+
+	return domainList, nil
+}
+
+// ListOverdue returns the pending requests whose expiry time is not after now.
+func (swapRequestGorm *SwapRequestGormRepository) ListOverdue(now time.Time) ([]domain.SwapRequest, error) {
+	var modelsList []models.SwapRequestModel
+	if err := swapRequestGorm.withItems().Where(
+		"status = ? AND expires_at IS NOT NULL AND expires_at <= ?", string(domain.StatusPending), now,
+	).Order("expires_at ASC").Find(&modelsList).Error; err != nil {
 		return nil, err
 	}
 
@@ -102,12 +202,106 @@ func (swapRequestGorm *SwapRequestGormRepository) ListByStatus(status domain.Swa
 	return domainList, nil
 }
 
+// ListExpiringSoon returns the pending requests that expire after now but not
+// after deadline and whose recipient has not been reminded yet.
+func (swapRequestGorm *SwapRequestGormRepository) ListExpiringSoon(now, deadline time.Time) ([]domain.SwapRequest, error) {
+	var modelsList []models.SwapRequestModel
+	if err := swapRequestGorm.withItems().Where(
+		"status = ? AND reminder_sent_at IS NULL AND expires_at > ? AND expires_at <= ?",
+		string(domain.StatusPending), now, deadline,
+	).Order("expires_at ASC").Find(&modelsList).Error; err != nil {
+		return nil, err
+	}
+
+	var domainList []domain.SwapRequest
+	for _, m := range modelsList {
+		domainList = append(domainList, *toDomainSwapRequest(&m))
+	}
+
+	return domainList, nil
+}
+
+// IsItemInSwapWithStatus reports whether the item takes part, on either side, in
+// a swap request currently in one of the given statuses.
+func (swapRequestGorm *SwapRequestGormRepository) IsItemInSwapWithStatus(itemID uuid.UUID, statuses ...domain.SwapRequestStatus) (bool, error) {
+	statusValues := make([]string, 0, len(statuses))
+	for _, status := range statuses {
+		statusValues = append(statusValues, string(status))
+	}
+
+	bundles := swapRequestGorm.db.Model(&models.SwapRequestItemModel{}).
+		Select("swap_request_id").
+		Where("item_id = ?", itemID)
+
+	var count int64
+	if err := swapRequestGorm.db.Model(&models.SwapRequestModel{}).
+		Where("status IN ?", statusValues).
+		Where("offered_item_id = ? OR requested_item_id = ? OR id IN (?)", itemID, itemID, bundles).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
 func (swapRequestGorm *SwapRequestGormRepository) UpdateStatus(id uuid.UUID, status domain.SwapRequestStatus) error {
 	return swapRequestGorm.db.Model(&models.SwapRequestModel{}).
 		Where("id = ?", id).
 		Update("status", string(status)).Error
 }
 
+func (swapRequestGorm *SwapRequestGormRepository) TryUpdateStatus(id uuid.UUID, from, to domain.SwapRequestStatus) (bool, error) {
+	result := swapRequestGorm.db.Model(&models.SwapRequestModel{}).
+		Where("id = ? AND status = ?", id, string(from)).
+		Update("status", string(to))
+
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// TryMarkReminderSent records the expiry reminder of a pending request and
+// reports whether this call did, so that each recipient is reminded once.
+func (swapRequestGorm *SwapRequestGormRepository) TryMarkReminderSent(id uuid.UUID, sentAt time.Time) (bool, error) {
+	result := swapRequestGorm.db.Model(&models.SwapRequestModel{}).
+		Where("id = ? AND status = ? AND reminder_sent_at IS NULL", id, string(domain.StatusPending)).
+		Update("reminder_sent_at", sentAt)
+
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+func (swapRequestGorm *SwapRequestGormRepository) TryMarkHandoverConfirmed(id uuid.UUID, party domain.SwapParty) (bool, error) {
+	var column string
+	switch party {
+	case domain.PartySender:
+		column = "sender_confirmed"
+	case domain.PartyRecipient:
+		column = "recipient_confirmed"
+	default:
+		return false, domain.NotSwapRequestParticipantErr
+	}
+
+	result := swapRequestGorm.db.Model(&models.SwapRequestModel{}).
+		Where("id = ? AND status = ? AND "+column+" = ?", id, string(domain.StatusAccepted), false).
+		Update(column, true)
+
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
 func (swapRequestGorm *SwapRequestGormRepository) Delete(id uuid.UUID) error {
+	if err := swapRequestGorm.db.Delete(&models.SwapRequestItemModel{}, "swap_request_id = ?", id).Error; err != nil {
+		return err
+	}
+
 	return swapRequestGorm.db.Delete(&models.SwapRequestModel{}, "id = ?", id).Error
 }
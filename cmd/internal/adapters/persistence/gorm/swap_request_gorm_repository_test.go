@@ -9,6 +9,7 @@ import (
 	"swapp-go/cmd/internal/adapters/persistence/models"
 	"swapp-go/cmd/internal/domain"
 	"testing"
+	"time"
 )
 
 var referenceNumber = "REF123456"
@@ -30,7 +31,7 @@ func cleanSwapRequestsTable(t *testing.T, db *gorm.DB) {
 }
 
 func TestSwapRequestGormRepository(t *testing.T) {
-	db := testutils.SetupTestDB(t, &models.SwapRequestModel{})
+	db := testutils.SetupTestDB(t, &models.SwapRequestModel{}, &models.SwapRequestItemModel{})
 	repo := gormRepo.NewSwapRequestGormRepository(db)
 
 	t.Run("Create", func(t *testing.T) {
@@ -50,6 +51,29 @@ func TestSwapRequestGormRepository(t *testing.T) {
 		assert.Equal(t, swap.ID, fetched.ID)
 	})
 
+	t.Run("Bundle round trip", func(t *testing.T) {
+		offered := []uuid.UUID{uuid.New(), uuid.New()}
+		requested := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+
+		swap := &domain.SwapRequest{
+			Status:           domain.StatusPending,
+			ReferenceNumber:  "BUNDLE123",
+			OfferedItemIDs:   offered,
+			RequestedItemIDs: requested,
+			SenderID:         uuid.New(),
+			RecipientID:      uuid.New(),
+		}
+		assert.NoError(t, repo.Create(swap))
+
+		fetched, err := repo.FindByID(swap.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, offered, fetched.OfferedItemIDs)
+		assert.Equal(t, requested, fetched.RequestedItemIDs)
+		assert.Equal(t, offered[0], fetched.OfferedItemID)
+		assert.Equal(t, requested[0], fetched.RequestedItemID)
+		assert.True(t, fetched.IsBundle())
+	})
+
 	t.Run("FindByReferenceNumber", func(t *testing.T) {
 		cleanSwapRequestsTable(t, db)
 
@@ -91,6 +115,146 @@ func TestSwapRequestGormRepository(t *testing.T) {
 		assert.Equal(t, domain.StatusPending, list[0].Status)
 	})
 
+	t.Run("ListByThread", func(t *testing.T) {
+		senderID, recipientID := uuid.New(), uuid.New()
+
+		root := createTestSwapRequest(uuid.New(), uuid.New(), senderID, recipientID)
+		assert.NoError(t, repo.Create(root))
+		assert.Equal(t, root.ID, root.ThreadID)
+
+		counter := createTestSwapRequest(root.RequestedItemID, uuid.New(), recipientID, senderID)
+		counter.ThreadID = root.ThreadID
+		counter.ParentID = &root.ID
+		assert.NoError(t, repo.Create(counter))
+
+		unrelated := createTestSwapRequest(uuid.New(), uuid.New(), senderID, recipientID)
+		assert.NoError(t, repo.Create(unrelated))
+
+		thread, err := repo.ListByThread(root.ThreadID)
+		assert.NoError(t, err)
+		assert.Len(t, thread, 2)
+		assert.Equal(t, root.ID, thread[0].ID)
+		assert.Equal(t, counter.ID, thread[1].ID)
+		assert.Equal(t, root.ID, *thread[1].ParentID)
+	})
+
+	t.Run("ListOverdue", func(t *testing.T) {
+		cleanSwapRequestsTable(t, db)
+
+		now := time.Now().UTC()
+		past, future := now.Add(-time.Hour), now.Add(time.Hour)
+
+		overdue := createTestSwapRequest(uuid.New(), uuid.New(), uuid.New(), uuid.New())
+		overdue.ExpiresAt = &past
+		assert.NoError(t, repo.Create(overdue))
+
+		notYetDue := createTestSwapRequest(uuid.New(), uuid.New(), uuid.New(), uuid.New())
+		notYetDue.ExpiresAt = &future
+		assert.NoError(t, repo.Create(notYetDue))
+
+		answered := createTestSwapRequest(uuid.New(), uuid.New(), uuid.New(), uuid.New())
+		answered.Status = domain.StatusAccepted
+		answered.ExpiresAt = &past
+		assert.NoError(t, repo.Create(answered))
+
+		neverExpires := createTestSwapRequest(uuid.New(), uuid.New(), uuid.New(), uuid.New())
+		assert.NoError(t, repo.Create(neverExpires))
+
+		result, err := repo.ListOverdue(now)
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+		assert.Equal(t, overdue.ID, result[0].ID)
+		assert.WithinDuration(t, past, *result[0].ExpiresAt, time.Second)
+	})
+
+	t.Run("ListExpiringSoonAndTryMarkReminderSent", func(t *testing.T) {
+		cleanSwapRequestsTable(t, db)
+
+		now := time.Now().UTC()
+		past, soon, later := now.Add(-time.Hour), now.Add(time.Hour), now.Add(48*time.Hour)
+
+		expiringSoon := createTestSwapRequest(uuid.New(), uuid.New(), uuid.New(), uuid.New())
+		expiringSoon.ExpiresAt = &soon
+		assert.NoError(t, repo.Create(expiringSoon))
+
+		expiringLater := createTestSwapRequest(uuid.New(), uuid.New(), uuid.New(), uuid.New())
+		expiringLater.ExpiresAt = &later
+		assert.NoError(t, repo.Create(expiringLater))
+
+		overdue := createTestSwapRequest(uuid.New(), uuid.New(), uuid.New(), uuid.New())
+		overdue.ExpiresAt = &past
+		assert.NoError(t, repo.Create(overdue))
+
+		answered := createTestSwapRequest(uuid.New(), uuid.New(), uuid.New(), uuid.New())
+		answered.Status = domain.StatusAccepted
+		answered.ExpiresAt = &soon
+		assert.NoError(t, repo.Create(answered))
+
+		deadline := now.Add(24 * time.Hour)
+		result, err := repo.ListExpiringSoon(now, deadline)
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+		assert.Equal(t, expiringSoon.ID, result[0].ID)
+		assert.Nil(t, result[0].ReminderSentAt)
+
+		marked, err := repo.TryMarkReminderSent(expiringSoon.ID, now)
+		assert.NoError(t, err)
+		assert.True(t, marked)
+
+		marked, err = repo.TryMarkReminderSent(expiringSoon.ID, now)
+		assert.NoError(t, err)
+		assert.False(t, marked, "a request is reminded once")
+
+		marked, err = repo.TryMarkReminderSent(answered.ID, now)
+		assert.NoError(t, err)
+		assert.False(t, marked, "only pending requests are reminded")
+
+		found, err := repo.FindByID(expiringSoon.ID)
+		assert.NoError(t, err)
+		assert.WithinDuration(t, now, *found.ReminderSentAt, time.Second)
+
+		result, err = repo.ListExpiringSoon(now, deadline)
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+	})
+
+	t.Run("IsItemInSwapWithStatus", func(t *testing.T) {
+		cleanSwapRequestsTable(t, db)
+
+		single := createTestSwapRequest(uuid.New(), uuid.New(), uuid.New(), uuid.New())
+		single.Status = domain.StatusAccepted
+		assert.NoError(t, repo.Create(single))
+
+		bundledItemID := uuid.New()
+		bundle := &domain.SwapRequest{
+			Status:           domain.StatusAccepted,
+			OfferedItemIDs:   []uuid.UUID{uuid.New()},
+			RequestedItemIDs: []uuid.UUID{uuid.New(), bundledItemID},
+			SenderID:         uuid.New(),
+			RecipientID:      uuid.New(),
+		}
+		assert.NoError(t, repo.Create(bundle))
+
+		pending := createTestSwapRequest(uuid.New(), uuid.New(), uuid.New(), uuid.New())
+		assert.NoError(t, repo.Create(pending))
+
+		swapped, err := repo.IsItemInSwapWithStatus(single.RequestedItemID, domain.StatusAccepted)
+		assert.NoError(t, err)
+		assert.True(t, swapped)
+
+		swapped, err = repo.IsItemInSwapWithStatus(bundledItemID, domain.StatusAccepted, domain.StatusDisputed)
+		assert.NoError(t, err)
+		assert.True(t, swapped)
+
+		swapped, err = repo.IsItemInSwapWithStatus(pending.RequestedItemID, domain.StatusAccepted)
+		assert.NoError(t, err)
+		assert.False(t, swapped)
+
+		swapped, err = repo.IsItemInSwapWithStatus(uuid.New(), domain.StatusAccepted)
+		assert.NoError(t, err)
+		assert.False(t, swapped)
+	})
+
 	t.Run("UpdateStatus", func(t *testing.T) {
 		swap := createTestSwapRequest(uuid.New(), uuid.New(), uuid.New(), uuid.New())
 		err := repo.Create(swap)
@@ -104,6 +268,66 @@ func TestSwapRequestGormRepository(t *testing.T) {
 		assert.Equal(t, domain.StatusAccepted, updated.Status)
 	})
 
+	t.Run("TryUpdateStatus", func(t *testing.T) {
+		swap := createTestSwapRequest(uuid.New(), uuid.New(), uuid.New(), uuid.New())
+		err := repo.Create(swap)
+		assert.NoError(t, err)
+
+		updated, err := repo.TryUpdateStatus(swap.ID, domain.StatusPending, domain.StatusAccepted)
+		assert.NoError(t, err)
+		assert.True(t, updated)
+
+		updated, err = repo.TryUpdateStatus(swap.ID, domain.StatusPending, domain.StatusRejected)
+		assert.NoError(t, err)
+		assert.False(t, updated)
+
+		fetched, err := repo.FindByID(swap.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.StatusAccepted, fetched.Status)
+	})
+
+	t.Run("TryMarkHandoverConfirmed", func(t *testing.T) {
+		swap := createTestSwapRequest(uuid.New(), uuid.New(), uuid.New(), uuid.New())
+		swap.Status = domain.StatusAccepted
+		err := repo.Create(swap)
+		assert.NoError(t, err)
+
+		confirmed, err := repo.TryMarkHandoverConfirmed(swap.ID, domain.PartySender)
+		assert.NoError(t, err)
+		assert.True(t, confirmed)
+
+		confirmed, err = repo.TryMarkHandoverConfirmed(swap.ID, domain.PartySender)
+		assert.NoError(t, err)
+		assert.False(t, confirmed, "a party cannot confirm twice")
+
+		updated, err := repo.FindByID(swap.ID)
+		assert.NoError(t, err)
+		assert.True(t, updated.SenderConfirmed)
+		assert.False(t, updated.RecipientConfirmed)
+
+		confirmed, err = repo.TryMarkHandoverConfirmed(swap.ID, domain.PartyRecipient)
+		assert.NoError(t, err)
+		assert.True(t, confirmed)
+
+		updated, err = repo.FindByID(swap.ID)
+		assert.NoError(t, err)
+		assert.True(t, updated.HandoverConfirmed())
+	})
+
+	t.Run("TryMarkHandoverConfirmedRequiresAccepted", func(t *testing.T) {
+		swap := createTestSwapRequest(uuid.New(), uuid.New(), uuid.New(), uuid.New())
+		swap.Status = domain.StatusCancelled
+		assert.NoError(t, repo.Create(swap))
+
+		confirmed, err := repo.TryMarkHandoverConfirmed(swap.ID, domain.PartySender)
+		assert.NoError(t, err)
+		assert.False(t, confirmed)
+
+		unchanged, err := repo.FindByID(swap.ID)
+		assert.NoError(t, err)
+		assert.False(t, unchanged.SenderConfirmed)
+	})
+
 	t.Run("Delete", func(t *testing.T) {
 		swap := createTestSwapRequest(uuid.New(), uuid.New(), uuid.New(), uuid.New())
 		err := repo.Create(swap)
@@ -0,0 +1,108 @@
+package gorm
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"swapp-go/cmd/internal/adapters/persistence/models"
+	"swapp-go/cmd/internal/application/ports"
+	"swapp-go/cmd/internal/domain"
+	"time"
+)
+
+type TwoFactorGormRepository struct {
+	db *gorm.DB
+}
+
+func NewTwoFactorGormRepository(db *gorm.DB) ports.TwoFactorRepository {
+	return &TwoFactorGormRepository{db: db}
+}
+
+func (repo *TwoFactorGormRepository) FindByUserID(userID uuid.UUID) (*domain.TwoFactor, error) {
+	var model models.TwoFactorModel
+
+	err := repo.db.First(&model, "user_id = ?", userID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.TwoFactor{
+		UserID:       model.UserID,
+		Secret:       model.Secret,
+		LastUsedStep: model.LastUsedStep,
+		EnabledAt:    model.EnabledAt,
+		CreatedAt:    model.CreatedAt,
+	}, nil
+}
+
+func (repo *TwoFactorGormRepository) Save(twoFactor *domain.TwoFactor) error {
+	model := &models.TwoFactorModel{
+		UserID:       twoFactor.UserID,
+		Secret:       twoFactor.Secret,
+		LastUsedStep: twoFactor.LastUsedStep,
+		EnabledAt:    twoFactor.EnabledAt,
+		CreatedAt:    twoFactor.CreatedAt,
+	}
+
+	return repo.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"secret", "last_used_step", "enabled_at", "created_at"}),
+	}).Create(model).Error
+}
+
+func (repo *TwoFactorGormRepository) Delete(userID uuid.UUID) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&models.RecoveryCodeModel{}, "user_id = ?", userID).Error; err != nil {
+			return err
+		}
+
+		return tx.Delete(&models.TwoFactorModel{}, "user_id = ?", userID).Error
+	})
+}
+
+func (repo *TwoFactorGormRepository) TryUseStep(userID uuid.UUID, step int64) (bool, error) {
+	result := repo.db.Model(&models.TwoFactorModel{}).
+		Where("user_id = ? AND last_used_step < ?", userID, step).
+		Update("last_used_step", step)
+
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+func (repo *TwoFactorGormRepository) ReplaceRecoveryCodes(userID uuid.UUID, codeHashes []string) error {
+	codes := make([]models.RecoveryCodeModel, 0, len(codeHashes))
+	for _, codeHash := range codeHashes {
+		codes = append(codes, models.RecoveryCodeModel{ID: uuid.New(), UserID: userID, CodeHash: codeHash})
+	}
+
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&models.RecoveryCodeModel{}, "user_id = ?", userID).Error; err != nil {
+			return err
+		}
+
+		if len(codes) == 0 {
+			return nil
+		}
+
+		return tx.Create(&codes).Error
+	})
+}
+
+func (repo *TwoFactorGormRepository) TryUseRecoveryCode(userID uuid.UUID, codeHash string, usedAt time.Time) (bool, error) {
+	result := repo.db.Model(&models.RecoveryCodeModel{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", usedAt)
+
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
@@ -0,0 +1,96 @@
+package gorm_test
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	gormRepo "swapp-go/cmd/internal/adapters/persistence/gorm"
+	"swapp-go/cmd/internal/adapters/persistence/gorm/testutils"
+	"swapp-go/cmd/internal/adapters/persistence/models"
+	"swapp-go/cmd/internal/domain"
+	"testing"
+	"time"
+)
+
+func TestTwoFactorRepository(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+
+	t.Run("SaveFindAndDelete", func(t *testing.T) {
+		db := testutils.SetupTestDB(t, &models.TwoFactorModel{}, &models.RecoveryCodeModel{})
+		repo := gormRepo.NewTwoFactorGormRepository(db)
+		userID := uuid.New()
+
+		pending := &domain.TwoFactor{UserID: userID, Secret: "FIRSTSECRET", CreatedAt: now}
+		assert.NoError(t, repo.Save(pending))
+
+		replaced := &domain.TwoFactor{UserID: userID, Secret: "SECONDSECRET", CreatedAt: now}
+		assert.NoError(t, repo.Save(replaced))
+
+		found, err := repo.FindByUserID(userID)
+		assert.NoError(t, err)
+		assert.Equal(t, "SECONDSECRET", found.Secret)
+		assert.False(t, found.IsEnabled())
+
+		found.EnabledAt = &now
+		assert.NoError(t, repo.Save(found))
+
+		found, err = repo.FindByUserID(userID)
+		assert.NoError(t, err)
+		assert.True(t, found.IsEnabled())
+
+		assert.NoError(t, repo.ReplaceRecoveryCodes(userID, []string{"hash-1"}))
+		assert.NoError(t, repo.Delete(userID))
+
+		found, err = repo.FindByUserID(userID)
+		assert.NoError(t, err)
+		assert.Nil(t, found)
+
+		used, err := repo.TryUseRecoveryCode(userID, "hash-1", now)
+		assert.NoError(t, err)
+		assert.False(t, used, "recovery codes are removed with the settings")
+	})
+
+	t.Run("TryUseStep", func(t *testing.T) {
+		db := testutils.SetupTestDB(t, &models.TwoFactorModel{})
+		repo := gormRepo.NewTwoFactorGormRepository(db)
+		userID := uuid.New()
+
+		assert.NoError(t, repo.Save(&domain.TwoFactor{UserID: userID, Secret: "SECRET", EnabledAt: &now, CreatedAt: now}))
+
+		used, err := repo.TryUseStep(userID, 100)
+		assert.NoError(t, err)
+		assert.True(t, used)
+
+		used, err = repo.TryUseStep(userID, 100)
+		assert.NoError(t, err)
+		assert.False(t, used, "a step cannot be used twice")
+
+		used, err = repo.TryUseStep(userID, 99)
+		assert.NoError(t, err)
+		assert.False(t, used, "an earlier step cannot be used after a later one")
+	})
+
+	t.Run("RecoveryCodes", func(t *testing.T) {
+		db := testutils.SetupTestDB(t, &models.RecoveryCodeModel{})
+		repo := gormRepo.NewTwoFactorGormRepository(db)
+		userID := uuid.New()
+
+		assert.NoError(t, repo.ReplaceRecoveryCodes(userID, []string{"hash-1", "hash-2"}))
+
+		used, err := repo.TryUseRecoveryCode(userID, "hash-1", now)
+		assert.NoError(t, err)
+		assert.True(t, used)
+
+		used, err = repo.TryUseRecoveryCode(userID, "hash-1", now)
+		assert.NoError(t, err)
+		assert.False(t, used, "a recovery code works once")
+
+		used, err = repo.TryUseRecoveryCode(uuid.New(), "hash-2", now)
+		assert.NoError(t, err)
+		assert.False(t, used, "recovery codes belong to one user")
+
+		assert.NoError(t, repo.ReplaceRecoveryCodes(userID, []string{"hash-3"}))
+		used, err = repo.TryUseRecoveryCode(userID, "hash-2", now)
+		assert.NoError(t, err)
+		assert.False(t, used, "replaced codes stop working")
+	})
+}
@@ -0,0 +1,26 @@
+package gorm
+
+import (
+	"gorm.io/gorm"
+	"swapp-go/cmd/internal/application/ports"
+)
+
+type GormUnitOfWork struct {
+	db *gorm.DB
+}
+
+func NewGormUnitOfWork(db *gorm.DB) ports.UnitOfWork {
+	return &GormUnitOfWork{db: db}
+}
+
+func (unitOfWork *GormUnitOfWork) Do(fn func(repos ports.Repositories) error) error {
+	return unitOfWork.db.Transaction(func(tx *gorm.DB) error {
+		return fn(ports.Repositories{
+			SwapRequests:  NewSwapRequestGormRepository(tx),
+			Items:         NewItemGormRepository(tx),
+			Users:         NewUserGormRepository(tx),
+			Outbox:        NewOutboxGormRepository(tx),
+			Notifications: NewNotificationGormRepository(tx),
+		})
+	})
+}
@@ -0,0 +1,86 @@
+package gorm_test
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	gormRepo "swapp-go/cmd/internal/adapters/persistence/gorm"
+	"swapp-go/cmd/internal/adapters/persistence/gorm/testutils"
+	"swapp-go/cmd/internal/adapters/persistence/models"
+	"swapp-go/cmd/internal/application/ports"
+	"swapp-go/cmd/internal/domain"
+	"testing"
+)
+
+func TestGormUnitOfWork(t *testing.T) {
+	db := testutils.SetupTestDB(t, &models.ItemModel{}, &models.ItemTagModel{}, &models.ItemPictureModel{}, &models.ItemPictureVariantModel{}, &models.SwapRequestModel{}, &models.SwapRequestItemModel{})
+	itemRepo := gormRepo.NewItemGormRepository(db)
+	swapRequestRepo := gormRepo.NewSwapRequestGormRepository(db)
+	unitOfWork := gormRepo.NewGormUnitOfWork(db)
+
+	t.Run("commits when the callback succeeds", func(t *testing.T) {
+		item := createTestItem(uuid.New())
+		assert.NoError(t, itemRepo.Create(item))
+
+		swap := createTestSwapRequest(item.ID, uuid.New(), item.UserID, uuid.New())
+
+		err := unitOfWork.Do(func(repos ports.Repositories) error {
+			if _, err := repos.Items.TryMarkItemAsOffered(item.ID); err != nil {
+				return err
+			}
+			return repos.SwapRequests.Create(swap)
+		})
+		assert.NoError(t, err)
+
+		var itemModel models.ItemModel
+		assert.NoError(t, db.First(&itemModel, "id = ?", item.ID).Error)
+		assert.True(t, itemModel.Offered)
+
+		_, err = swapRequestRepo.FindByID(swap.ID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("rolls back every write when the callback fails", func(t *testing.T) {
+		item := createTestItem(uuid.New())
+		assert.NoError(t, itemRepo.Create(item))
+
+		swap := createTestSwapRequest(item.ID, uuid.New(), item.UserID, uuid.New())
+		failure := errors.New("boom")
+
+		err := unitOfWork.Do(func(repos ports.Repositories) error {
+			if _, err := repos.Items.TryMarkItemAsOffered(item.ID); err != nil {
+				return err
+			}
+			if err := repos.SwapRequests.Create(swap); err != nil {
+				return err
+			}
+			return failure
+		})
+		assert.ErrorIs(t, err, failure)
+
+		var itemModel models.ItemModel
+		assert.NoError(t, db.First(&itemModel, "id = ?", item.ID).Error)
+		assert.False(t, itemModel.Offered)
+
+		_, err = swapRequestRepo.FindByID(swap.ID)
+		assert.Error(t, err)
+	})
+
+	t.Run("rolls back a status change when releasing the item fails", func(t *testing.T) {
+		swap := createTestSwapRequest(uuid.New(), uuid.New(), uuid.New(), uuid.New())
+		assert.NoError(t, swapRequestRepo.Create(swap))
+
+		err := unitOfWork.Do(func(repos ports.Repositories) error {
+			if err := repos.SwapRequests.UpdateStatus(swap.ID, domain.StatusRejected); err != nil {
+				return err
+			}
+			_, err := repos.Items.Update(swap.OfferedItemID, map[string]interface{}{"offered": false})
+			return err
+		})
+		assert.Error(t, err)
+
+		fetched, err := swapRequestRepo.FindByID(swap.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.StatusPending, fetched.Status)
+	})
+}
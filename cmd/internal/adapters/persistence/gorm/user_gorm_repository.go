@@ -22,6 +22,11 @@ func toUserModel(user *domain.User) *models.UserModel {
 		id = uuid.New()
 	}
 
+	role := user.Role
+	if role == "" {
+		role = domain.RoleUser
+	}
+
 	return &models.UserModel{
 		ID:       id,
 		Username: user.Username,
@@ -29,6 +34,14 @@ func toUserModel(user *domain.User) *models.UserModel {
 		Email:    user.Email,
 		Phone:    user.Phone,
 		Address:  user.Address,
+
+		EmailVerifiedAt:    user.EmailVerifiedAt,
+		VerificationSentAt: user.VerificationSentAt,
+
+		Role:        string(role),
+		SuspendedAt: user.SuspendedAt,
+
+		EmailOptOuts: user.NotificationPreferences.OptOuts(),
 	}
 }
 
@@ -40,6 +53,14 @@ func toDomainUser(model *models.UserModel) *domain.User {
 		Email:    model.Email,
 		Phone:    model.Phone,
 		Address:  model.Address,
+
+		EmailVerifiedAt:    model.EmailVerifiedAt,
+		VerificationSentAt: model.VerificationSentAt,
+
+		Role:        domain.Role(model.Role),
+		SuspendedAt: model.SuspendedAt,
+
+		NotificationPreferences: domain.ParseNotificationOptOuts(model.EmailOptOuts),
 	}
 }
 
@@ -51,6 +72,7 @@ func (userGorm *UserGormRepository) Create(user *domain.User) error {
 	}
 
 	user.ID = model.ID
+	user.Role = domain.Role(model.Role)
 
 	return nil
 }
@@ -101,3 +123,62 @@ func (userGorm *UserGormRepository) FindByEmail(email string) (*domain.User, err
 
 	return toDomainUser(&usermodel), nil
 }
+
+// List returns a page of users ordered by creation, oldest first.
+func (userGorm *UserGormRepository) List(query domain.UserListQuery) ([]domain.User, error) {
+	db := userGorm.db.Model(&models.UserModel{})
+
+	if query.Role != nil {
+		db = db.Where("role = ?", string(*query.Role))
+	}
+	if query.Suspended != nil {
+		if *query.Suspended {
+			db = db.Where("suspended_at IS NOT NULL")
+		} else {
+			db = db.Where("suspended_at IS NULL")
+		}
+	}
+
+	var userModels []models.UserModel
+	if err := db.Order("created_at ASC, id ASC").Limit(query.Limit).Offset(query.Offset).Find(&userModels).Error; err != nil {
+		return nil, err
+	}
+
+	users := make([]domain.User, 0, len(userModels))
+	for i := range userModels {
+		users = append(users, *toDomainUser(&userModels[i]))
+	}
+
+	return users, nil
+}
+
+// PromoteAdmins bootstraps the admin role on a deployment that has no admin
+// yet, by promoting the users whose verified email address is listed. Once an
+// admin exists it does nothing, so that roles are only changed through the
+// admin API and a demotion is not undone on the next start. It returns how
+// many users were promoted.
+func PromoteAdmins(db *gorm.DB, emails []string) (int64, error) {
+	if len(emails) == 0 {
+		return 0, nil
+	}
+
+	var promoted int64
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var admins int64
+		if err := tx.Model(&models.UserModel{}).Where("role = ?", string(domain.RoleAdmin)).Count(&admins).Error; err != nil {
+			return err
+		}
+		if admins > 0 {
+			return nil
+		}
+
+		result := tx.Model(&models.UserModel{}).
+			Where("email IN ? AND email_verified_at IS NOT NULL", emails).
+			Update("role", string(domain.RoleAdmin))
+		promoted = result.RowsAffected
+
+		return result.Error
+	})
+
+	return promoted, err
+}
@@ -7,8 +7,10 @@ import (
 	gormRepo "swapp-go/cmd/internal/adapters/persistence/gorm"
 	"swapp-go/cmd/internal/adapters/persistence/gorm/testutils"
 	"swapp-go/cmd/internal/adapters/persistence/models"
+	"swapp-go/cmd/internal/application/ports"
 	"swapp-go/cmd/internal/domain"
 	"testing"
+	"time"
 )
 
 var (
@@ -81,6 +83,71 @@ func TestGormUserRepository(t *testing.T) {
 		assert.Equal(t, updatedAddress, *updatedUser.Address)
 	})
 
+	t.Run("EmailVerification", func(t *testing.T) {
+		user := &domain.User{
+			Username: "test_user3",
+			Password: "hashed_password",
+			Email:    "test3@email.com",
+		}
+		err := repo.Create(user)
+		assert.NoError(t, err)
+
+		created, err := repo.FindByID(user.ID)
+		assert.NoError(t, err)
+		assert.False(t, created.IsEmailVerified())
+
+		verifiedAt := time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+		verified, err := repo.Update(user.ID, map[string]interface{}{"email_verified_at": verifiedAt})
+		assert.NoError(t, err)
+		assert.True(t, verified.IsEmailVerified())
+		assert.True(t, verifiedAt.Equal(*verified.EmailVerifiedAt))
+
+		cleared, err := repo.Update(user.ID, map[string]interface{}{"email": "changed3@email.com", "email_verified_at": nil})
+		assert.NoError(t, err)
+		assert.False(t, cleared.IsEmailVerified())
+	})
+
+	t.Run("RoleAndSuspension", func(t *testing.T) {
+		user := &domain.User{
+			Username: "test_user4",
+			Password: "hashed_password",
+			Email:    "test4@email.com",
+		}
+		assert.NoError(t, repo.Create(user))
+		assert.Equal(t, domain.RoleUser, user.Role)
+
+		suspendedAt := time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+		updated, err := repo.Update(user.ID, map[string]interface{}{"role": "moderator", "suspended_at": suspendedAt})
+		assert.NoError(t, err)
+		assert.Equal(t, domain.RoleModerator, updated.Role)
+		assert.True(t, updated.IsSuspended())
+	})
+
+	t.Run("NotificationPreferences", func(t *testing.T) {
+		user := &domain.User{
+			Username: "test_user5",
+			Password: "hashed_password",
+			Email:    "test5@email.com",
+			NotificationPreferences: domain.NotificationPreferences{
+				domain.NotifyAccepted: false,
+				domain.NotifyRejected: true,
+			},
+		}
+		assert.NoError(t, repo.Create(user))
+
+		found, err := repo.FindByID(user.ID)
+		assert.NoError(t, err)
+		assert.False(t, found.NotificationPreferences.Allows(domain.NotifyAccepted))
+		assert.True(t, found.NotificationPreferences.Allows(domain.NotifyRejected))
+		assert.True(t, found.NotificationPreferences.Allows(domain.NotifyNewRequest))
+
+		updated, err := repo.Update(user.ID, map[string]interface{}{"email_opt_outs": "new_request,expiring_soon"})
+		assert.NoError(t, err)
+		assert.True(t, updated.NotificationPreferences.Allows(domain.NotifyAccepted))
+		assert.False(t, updated.NotificationPreferences.Allows(domain.NotifyNewRequest))
+		assert.False(t, updated.NotificationPreferences.Allows(domain.NotifyExpiringSoon))
+	})
+
 	t.Run("NotFound", func(t *testing.T) {
 		randomID := uuid.New()
 
@@ -106,7 +173,7 @@ func TestGormUserRepository(t *testing.T) {
 			Address:  &address,
 		}
 
-		assert.NoError(t, db.Create(user).Error)
+		assert.NoError(t, repo.Create(user))
 
 		err := repo.Delete(user.ID)
 		assert.NoError(t, err)
@@ -116,3 +183,113 @@ func TestGormUserRepository(t *testing.T) {
 		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
 	})
 }
+
+func TestGormUserRepository_List(t *testing.T) {
+	db := testutils.SetupTestDB(t, &models.UserModel{})
+	repo := gormRepo.NewUserGormRepository(db)
+
+	suspendedAt := time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+	users := []*domain.User{
+		{Username: "alice", Email: "alice@email.com", Password: "hashed_password"},
+		{Username: "bob", Email: "bob@email.com", Password: "hashed_password", Role: domain.RoleModerator},
+		{Username: "carol", Email: "carol@email.com", Password: "hashed_password", SuspendedAt: &suspendedAt},
+	}
+	for i, user := range users {
+		assert.NoError(t, repo.Create(user))
+		assert.NoError(t, db.Model(&models.UserModel{}).Where("id = ?", user.ID).
+			Update("created_at", suspendedAt.Add(time.Duration(i)*time.Minute)).Error)
+	}
+
+	usernames := func(listed []domain.User) []string {
+		names := make([]string, 0, len(listed))
+		for _, user := range listed {
+			names = append(names, user.Username)
+		}
+		return names
+	}
+
+	moderator := domain.RoleModerator
+	suspended, active := true, false
+
+	testCases := []struct {
+		name     string
+		query    domain.UserListQuery
+		expected []string
+	}{
+		{"All", domain.UserListQuery{Limit: 10}, []string{"alice", "bob", "carol"}},
+		{"ByRole", domain.UserListQuery{Role: &moderator, Limit: 10}, []string{"bob"}},
+		{"Suspended", domain.UserListQuery{Suspended: &suspended, Limit: 10}, []string{"carol"}},
+		{"Active", domain.UserListQuery{Suspended: &active, Limit: 10}, []string{"alice", "bob"}},
+		{"Page", domain.UserListQuery{Limit: 1, Offset: 1}, []string{"bob"}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			listed, err := repo.List(testCase.query)
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expected, usernames(listed))
+		})
+	}
+}
+
+func TestPromoteAdmins(t *testing.T) {
+	verifiedAt := time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+	createUser := func(t *testing.T, repo ports.UserRepository, username string, verified bool) *domain.User {
+		t.Helper()
+
+		user := &domain.User{Username: username, Password: "hashed_password", Email: username + "@email.com"}
+		if err := repo.Create(user); err != nil {
+			t.Fatalf("failed to create user: %v", err)
+		}
+		if verified {
+			if _, err := repo.Update(user.ID, map[string]interface{}{"email_verified_at": verifiedAt}); err != nil {
+				t.Fatalf("failed to verify user: %v", err)
+			}
+		}
+
+		return user
+	}
+
+	t.Run("promotes verified listed users", func(t *testing.T) {
+		db := testutils.SetupTestDB(t, &models.UserModel{})
+		repo := gormRepo.NewUserGormRepository(db)
+
+		verified := createUser(t, repo, "owner", true)
+		unverified := createUser(t, repo, "squatter", false)
+		unlisted := createUser(t, repo, "bystander", true)
+
+		promoted, err := gormRepo.PromoteAdmins(db, []string{"owner@email.com", "squatter@email.com"})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), promoted)
+
+		for user, role := range map[*domain.User]domain.Role{
+			verified:   domain.RoleAdmin,
+			unverified: domain.RoleUser,
+			unlisted:   domain.RoleUser,
+		} {
+			found, err := repo.FindByID(user.ID)
+			assert.NoError(t, err)
+			assert.Equal(t, role, found.Role, user.Username)
+		}
+	})
+
+	t.Run("does nothing once an admin exists", func(t *testing.T) {
+		db := testutils.SetupTestDB(t, &models.UserModel{})
+		repo := gormRepo.NewUserGormRepository(db)
+
+		admin := createUser(t, repo, "admin", true)
+		demoted := createUser(t, repo, "former_admin", true)
+		if _, err := repo.Update(admin.ID, map[string]interface{}{"role": string(domain.RoleAdmin)}); err != nil {
+			t.Fatalf("failed to promote user: %v", err)
+		}
+
+		promoted, err := gormRepo.PromoteAdmins(db, []string{"former_admin@email.com"})
+		assert.NoError(t, err)
+		assert.Zero(t, promoted)
+
+		found, err := repo.FindByID(demoted.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.RoleUser, found.Role)
+	})
+}
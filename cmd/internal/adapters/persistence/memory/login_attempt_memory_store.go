@@ -0,0 +1,67 @@
+package memory
+
+import (
+	"swapp-go/cmd/internal/domain"
+	"sync"
+	"time"
+)
+
+// LoginAttemptMemoryStore keeps failed login counters in memory. It is meant
+// for tests and single-instance deployments; counters are lost on restart.
+type LoginAttemptMemoryStore struct {
+	mutex    sync.Mutex
+	attempts map[string]domain.LoginAttempts
+}
+
+func NewLoginAttemptMemoryStore() *LoginAttemptMemoryStore {
+	return &LoginAttemptMemoryStore{attempts: make(map[string]domain.LoginAttempts)}
+}
+
+func (store *LoginAttemptMemoryStore) Find(key string) (*domain.LoginAttempts, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	attempts, ok := store.attempts[key]
+	if !ok {
+		return nil, nil
+	}
+
+	return &attempts, nil
+}
+
+func (store *LoginAttemptMemoryStore) RecordFailure(key string, failedAt time.Time, windowStart time.Time) (*domain.LoginAttempts, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	attempts, ok := store.attempts[key]
+	if !ok || attempts.LastFailureAt.Before(windowStart) {
+		attempts = domain.LoginAttempts{Key: key, LockedUntil: attempts.LockedUntil}
+	}
+
+	attempts.Failures++
+	attempts.LastFailureAt = failedAt
+	store.attempts[key] = attempts
+
+	return &attempts, nil
+}
+
+func (store *LoginAttemptMemoryStore) Lock(key string, lockedUntil time.Time) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if attempts, ok := store.attempts[key]; ok {
+		attempts.LockedUntil = &lockedUntil
+		store.attempts[key] = attempts
+	}
+
+	return nil
+}
+
+func (store *LoginAttemptMemoryStore) Reset(key string) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	delete(store.attempts, key)
+
+	return nil
+}
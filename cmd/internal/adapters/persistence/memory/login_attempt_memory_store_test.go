@@ -0,0 +1,34 @@
+package memory_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"swapp-go/cmd/internal/adapters/persistence/memory"
+	"testing"
+	"time"
+)
+
+func TestLoginAttemptMemoryStore(t *testing.T) {
+	store := memory.NewLoginAttemptMemoryStore()
+	now := time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+	for i := 1; i <= 3; i++ {
+		attempts, err := store.RecordFailure("account:user", now, now.Add(-time.Hour))
+		assert.NoError(t, err)
+		assert.Equal(t, i, attempts.Failures)
+	}
+
+	assert.NoError(t, store.Lock("account:user", now.Add(time.Minute)))
+	attempts, err := store.Find("account:user")
+	assert.NoError(t, err)
+	assert.True(t, attempts.IsLocked(now))
+
+	later := now.Add(2 * time.Hour)
+	attempts, err = store.RecordFailure("account:user", later, later.Add(-time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, attempts.Failures, "old failures are forgotten")
+
+	assert.NoError(t, store.Reset("account:user"))
+	attempts, err = store.Find("account:user")
+	assert.NoError(t, err)
+	assert.Nil(t, attempts)
+}
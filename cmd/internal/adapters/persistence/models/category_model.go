@@ -0,0 +1,11 @@
+package models
+
+type CategoryModel struct {
+	Slug       string  `gorm:"primary_key;type:varchar(64)"`
+	Name       string  `gorm:"not null"`
+	ParentSlug *string `gorm:"type:varchar(64);index"`
+}
+
+func (CategoryModel) TableName() string {
+	return "categories"
+}
@@ -6,14 +6,18 @@ import (
 )
 
 type ItemModel struct {
-	ID          uuid.UUID `gorm:"primary_key"`
-	Name        string    `gorm:"not null"`
-	Description string    `gorm:"not null"`
-	PictureURL  string    `gorm:"not null"`
-	UserID      uuid.UUID
-	Offered     bool `gorm:"default:false"`
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	ID           uuid.UUID          `gorm:"primary_key"`
+	Name         string             `gorm:"not null"`
+	Description  string             `gorm:"not null"`
+	PictureURL   string             `gorm:"not null"`
+	UserID       uuid.UUID          `gorm:"index"`
+	Offered      bool               `gorm:"default:false"`
+	CategorySlug *string            `gorm:"type:varchar(64);index"`
+	Condition    string             `gorm:"type:varchar(20)"`
+	Tags         []ItemTagModel     `gorm:"foreignKey:ItemID"`
+	Pictures     []ItemPictureModel `gorm:"foreignKey:ItemID"`
+	CreatedAt    time.Time          `gorm:"index"`
+	UpdatedAt    time.Time
 }
 
 func (ItemModel) TableName() string {
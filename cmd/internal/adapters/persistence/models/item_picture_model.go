@@ -0,0 +1,23 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type ItemPictureModel struct {
+	ID         uuid.UUID `gorm:"primary_key;type:uuid"`
+	ItemID     uuid.UUID `gorm:"type:uuid;index:idx_item_pictures_item_position,priority:1"`
+	URL        string    `gorm:"not null"`
+	StorageKey string    `gorm:"type:varchar(255)"`
+	Width      int
+	Height     int
+	Position   int                       `gorm:"not null;index:idx_item_pictures_item_position,priority:2"`
+	IsPrimary  bool                      `gorm:"default:false"`
+	Variants   []ItemPictureVariantModel `gorm:"foreignKey:PictureID"`
+	CreatedAt  time.Time
+}
+
+func (ItemPictureModel) TableName() string {
+	return "item_pictures"
+}
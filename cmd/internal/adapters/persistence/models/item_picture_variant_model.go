@@ -0,0 +1,16 @@
+package models
+
+import "github.com/google/uuid"
+
+type ItemPictureVariantModel struct {
+	PictureID  uuid.UUID `gorm:"primary_key;type:uuid"`
+	Name       string    `gorm:"primary_key;type:varchar(32)"`
+	URL        string    `gorm:"not null"`
+	StorageKey string    `gorm:"type:varchar(255)"`
+	Width      int
+	Height     int
+}
+
+func (ItemPictureVariantModel) TableName() string {
+	return "item_picture_variants"
+}
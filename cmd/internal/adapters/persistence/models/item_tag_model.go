@@ -0,0 +1,12 @@
+package models
+
+import "github.com/google/uuid"
+
+type ItemTagModel struct {
+	ItemID uuid.UUID `gorm:"primary_key;type:uuid"`
+	Tag    string    `gorm:"primary_key;type:varchar(30);index"`
+}
+
+func (ItemTagModel) TableName() string {
+	return "item_tags"
+}
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+type LoginAttemptModel struct {
+	Key           string    `gorm:"primaryKey;type:varchar(255)"`
+	Failures      int       `gorm:"not null;default:0"`
+	LastFailureAt time.Time `gorm:"not null"`
+	LockedUntil   *time.Time
+}
+
+func (LoginAttemptModel) TableName() string {
+	return "login_attempts"
+}
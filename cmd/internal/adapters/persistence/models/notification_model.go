@@ -0,0 +1,20 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type NotificationModel struct {
+	ID              uuid.UUID `gorm:"primaryKey;type:uuid"`
+	UserID          uuid.UUID `gorm:"type:uuid;not null;index:idx_notifications_user,priority:1"`
+	Type            string    `gorm:"type:varchar(64);not null"`
+	SwapRequestID   uuid.UUID `gorm:"type:uuid;not null"`
+	ReferenceNumber string    `gorm:"not null"`
+	ReadAt          *time.Time
+	CreatedAt       time.Time `gorm:"index:idx_notifications_user,priority:2"`
+}
+
+func (NotificationModel) TableName() string {
+	return "notifications"
+}
@@ -0,0 +1,23 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type OutboxMessageModel struct {
+	ID            uuid.UUID `gorm:"primaryKey;type:uuid"`
+	Recipient     string    `gorm:"type:varchar(255);not null"`
+	Template      string    `gorm:"type:varchar(64);not null"`
+	Data          string    `gorm:"type:text;not null"`
+	Status        string    `gorm:"type:varchar(20);not null;index:idx_outbox_due,priority:1"`
+	Attempts      int       `gorm:"not null;default:0"`
+	NextAttemptAt time.Time `gorm:"not null;index:idx_outbox_due,priority:2"`
+	LastError     string    `gorm:"type:text"`
+	CreatedAt     time.Time
+	SentAt        *time.Time
+}
+
+func (OutboxMessageModel) TableName() string {
+	return "email_outbox"
+}
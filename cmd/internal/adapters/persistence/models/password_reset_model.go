@@ -6,8 +6,9 @@ import (
 )
 
 type PasswordResetModel struct {
-	Token     string    `gorm:"primaryKey"`
-	UserID    uuid.UUID `gorm:"type:uuid;not null"`
+	TokenHash string    `gorm:"primaryKey;size:64"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex"`
+	CreatedAt time.Time
 	ExpiresAt time.Time `gorm:"not null"`
 }
 
@@ -0,0 +1,17 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type RecoveryCodeModel struct {
+	ID       uuid.UUID `gorm:"primaryKey;type:uuid"`
+	UserID   uuid.UUID `gorm:"type:uuid;not null;index"`
+	CodeHash string    `gorm:"type:varchar(64);not null"`
+	UsedAt   *time.Time
+}
+
+func (RecoveryCodeModel) TableName() string {
+	return "recovery_codes"
+}
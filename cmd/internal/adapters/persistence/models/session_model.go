@@ -0,0 +1,22 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type SessionModel struct {
+	ID               uuid.UUID `gorm:"primaryKey;type:uuid"`
+	UserID           uuid.UUID `gorm:"type:uuid;not null;index"`
+	RefreshTokenHash string    `gorm:"type:varchar(64);not null"`
+	UserAgent        string    `gorm:"type:text"`
+	IPAddress        string    `gorm:"type:varchar(45)"`
+	CreatedAt        time.Time
+	LastUsedAt       time.Time
+	ExpiresAt        time.Time `gorm:"not null"`
+	RevokedAt        *time.Time
+}
+
+func (SessionModel) TableName() string {
+	return "sessions"
+}
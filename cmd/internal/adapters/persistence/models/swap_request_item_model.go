@@ -0,0 +1,21 @@
+package models
+
+import "github.com/google/uuid"
+
+type SwapRequestItemRole string
+
+const (
+	SwapRequestItemOffered   SwapRequestItemRole = "offered"
+	SwapRequestItemRequested SwapRequestItemRole = "requested"
+)
+
+type SwapRequestItemModel struct {
+	SwapRequestID uuid.UUID           `gorm:"type:uuid;primaryKey"`
+	ItemID        uuid.UUID           `gorm:"type:uuid;primaryKey;index"`
+	Role          SwapRequestItemRole `gorm:"type:varchar(20);not null"`
+	Position      int                 `gorm:"not null;default:0"`
+}
+
+func (SwapRequestItemModel) TableName() string {
+	return "swap_request_items"
+}
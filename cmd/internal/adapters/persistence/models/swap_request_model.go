@@ -8,15 +8,22 @@ import (
 type SwapRequestStatus string
 
 type SwapRequestModel struct {
-	ID              uuid.UUID         `gorm:"primary_key"`
-	Status          SwapRequestStatus `gorm:"type:varchar(20)"`
-	ReferenceNumber string
-	OfferedItemID   uuid.UUID `gorm:"type:uuid"`
-	RequestedItemID uuid.UUID `gorm:"type:uuid"`
-	SenderID        uuid.UUID `gorm:"type:uuid"`
-	RecipientID     uuid.UUID `gorm:"type:uuid"`
-	CreatedAt       time.Time
-	UpdatedAt       time.Time
+	ID                 uuid.UUID         `gorm:"primary_key"`
+	Status             SwapRequestStatus `gorm:"type:varchar(20)"`
+	ReferenceNumber    string
+	OfferedItemID      uuid.UUID              `gorm:"type:uuid"`
+	RequestedItemID    uuid.UUID              `gorm:"type:uuid"`
+	SenderID           uuid.UUID              `gorm:"type:uuid"`
+	RecipientID        uuid.UUID              `gorm:"type:uuid"`
+	SenderConfirmed    bool                   `gorm:"default:false"`
+	RecipientConfirmed bool                   `gorm:"default:false"`
+	ThreadID           uuid.UUID              `gorm:"type:uuid;index"`
+	ParentID           *uuid.UUID             `gorm:"type:uuid"`
+	Items              []SwapRequestItemModel `gorm:"foreignKey:SwapRequestID"`
+	ExpiresAt          *time.Time             `gorm:"index"`
+	ReminderSentAt     *time.Time
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
 }
 
 func (SwapRequestModel) TableName() string {
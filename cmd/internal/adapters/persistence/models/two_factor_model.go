@@ -0,0 +1,18 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type TwoFactorModel struct {
+	UserID       uuid.UUID `gorm:"primaryKey;type:uuid"`
+	Secret       string    `gorm:"type:varchar(64);not null"`
+	LastUsedStep int64     `gorm:"not null;default:0"`
+	EnabledAt    *time.Time
+	CreatedAt    time.Time
+}
+
+func (TwoFactorModel) TableName() string {
+	return "user_two_factors"
+}
@@ -6,12 +6,22 @@ import (
 )
 
 type UserModel struct {
-	ID        uuid.UUID `gorm:"primaryKey"`
-	Username  string    `gorm:"uniqueIndex;not null"`
-	Password  string    `gorm:"not null"`
-	Email     string    `gorm:"uniqueIndex;not null"`
-	Phone     *string
-	Address   *string
+	ID       uuid.UUID `gorm:"primaryKey"`
+	Username string    `gorm:"uniqueIndex;not null"`
+	Password string    `gorm:"not null"`
+	Email    string    `gorm:"uniqueIndex;not null"`
+	Phone    *string
+	Address  *string
+
+	EmailVerifiedAt    *time.Time
+	VerificationSentAt *time.Time
+
+	Role        string `gorm:"type:varchar(20);not null;default:user;index"`
+	SuspendedAt *time.Time
+
+	// EmailOptOuts lists the notification events the user is not emailed about.
+	EmailOptOuts string `gorm:"type:varchar(255);not null;default:''"`
+
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
@@ -0,0 +1,16 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+	"swapp-go/cmd/internal/domain"
+)
+
+type MockAccessTokenIssuer struct {
+	mock.Mock
+}
+
+func (m *MockAccessTokenIssuer) Issue(claims domain.AccessTokenClaims) (string, error) {
+	args := m.Called(claims)
+
+	return args.String(0), args.Error(1)
+}
@@ -0,0 +1,25 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+	"io"
+)
+
+type BlobStore struct {
+	mock.Mock
+}
+
+func (m *BlobStore) Put(key string, content io.Reader, size int64, contentType string) error {
+	args := m.Called(key, content, size, contentType)
+	return args.Error(0)
+}
+
+func (m *BlobStore) Delete(key string) error {
+	args := m.Called(key)
+	return args.Error(0)
+}
+
+func (m *BlobStore) URL(key string) string {
+	args := m.Called(key)
+	return args.String(0)
+}
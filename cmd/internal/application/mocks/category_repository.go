@@ -0,0 +1,26 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+	"swapp-go/cmd/internal/domain"
+)
+
+type CategoryRepository struct {
+	mock.Mock
+}
+
+func (m *CategoryRepository) List() ([]domain.Category, error) {
+	args := m.Called()
+	if list, ok := args.Get(0).([]domain.Category); ok {
+		return list, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *CategoryRepository) FindBySlug(slug string) (*domain.Category, error) {
+	args := m.Called(slug)
+	if category, ok := args.Get(0).(*domain.Category); ok {
+		return category, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
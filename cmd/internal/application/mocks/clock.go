@@ -0,0 +1,16 @@
+package mocks
+
+import "time"
+
+// Clock returns a fixed time that tests move forward explicitly.
+type Clock struct {
+	Current time.Time
+}
+
+func (m *Clock) Now() time.Time {
+	return m.Current
+}
+
+func (m *Clock) Advance(duration time.Duration) {
+	m.Current = m.Current.Add(duration)
+}
@@ -0,0 +1,18 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+	"swapp-go/cmd/internal/domain"
+)
+
+type ImageProcessor struct {
+	mock.Mock
+}
+
+func (m *ImageProcessor) Process(upload domain.PictureUpload) (*domain.ProcessedImage, error) {
+	args := m.Called(upload)
+	if image, ok := args.Get(0).(*domain.ProcessedImage); ok {
+		return image, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
@@ -40,3 +40,16 @@ func (m *ItemRepository) TryMarkItemAsOffered(itemID uuid.UUID) (bool, error) {
 	args := m.Called(itemID)
 	return args.Bool(0), args.Error(1)
 }
+
+func (m *ItemRepository) Search(query domain.ItemSearchQuery) (*domain.ItemSearchResult, error) {
+	args := m.Called(query)
+	if result, ok := args.Get(0).(*domain.ItemSearchResult); ok {
+		return result, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *ItemRepository) TransferOwnership(itemIDs []uuid.UUID, newOwnerID uuid.UUID) error {
+	args := m.Called(itemIDs, newOwnerID)
+	return args.Error(0)
+}
@@ -0,0 +1,23 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+	"swapp-go/cmd/internal/domain"
+)
+
+type MockLinkTokenSigner struct {
+	mock.Mock
+}
+
+func (m *MockLinkTokenSigner) SignLink(claims domain.LinkTokenClaims) (string, error) {
+	args := m.Called(claims)
+
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockLinkTokenSigner) VerifyLink(purpose string, token string) (*domain.LinkTokenClaims, error) {
+	args := m.Called(purpose, token)
+	claims, _ := args.Get(0).(*domain.LinkTokenClaims)
+
+	return claims, args.Error(1)
+}
@@ -0,0 +1,33 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+	"swapp-go/cmd/internal/domain"
+	"time"
+)
+
+type MockLoginAttemptStore struct {
+	mock.Mock
+}
+
+func (m *MockLoginAttemptStore) Find(key string) (*domain.LoginAttempts, error) {
+	args := m.Called(key)
+	attempts, _ := args.Get(0).(*domain.LoginAttempts)
+
+	return attempts, args.Error(1)
+}
+
+func (m *MockLoginAttemptStore) RecordFailure(key string, failedAt time.Time, windowStart time.Time) (*domain.LoginAttempts, error) {
+	args := m.Called(key, failedAt, windowStart)
+	attempts, _ := args.Get(0).(*domain.LoginAttempts)
+
+	return attempts, args.Error(1)
+}
+
+func (m *MockLoginAttemptStore) Lock(key string, lockedUntil time.Time) error {
+	return m.Called(key, lockedUntil).Error(0)
+}
+
+func (m *MockLoginAttemptStore) Reset(key string) error {
+	return m.Called(key).Error(0)
+}
@@ -0,0 +1,22 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+	"swapp-go/cmd/internal/domain"
+)
+
+type MockLoginThrottleService struct {
+	mock.Mock
+}
+
+func (m *MockLoginThrottleService) Check(account string, ipAddress string) error {
+	return m.Called(account, ipAddress).Error(0)
+}
+
+func (m *MockLoginThrottleService) RecordFailure(account string, ipAddress string, user *domain.User) error {
+	return m.Called(account, ipAddress, user).Error(0)
+}
+
+func (m *MockLoginThrottleService) RecordSuccess(account string) error {
+	return m.Called(account).Error(0)
+}
@@ -0,0 +1,41 @@
+package mocks
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"swapp-go/cmd/internal/domain"
+	"time"
+)
+
+type MockNotificationRepository struct {
+	mock.Mock
+}
+
+func (m *MockNotificationRepository) Create(notification *domain.Notification) error {
+	return m.Called(notification).Error(0)
+}
+
+func (m *MockNotificationRepository) List(userID uuid.UUID, query domain.NotificationQuery) ([]domain.Notification, error) {
+	args := m.Called(userID, query)
+	notifications, _ := args.Get(0).([]domain.Notification)
+
+	return notifications, args.Error(1)
+}
+
+func (m *MockNotificationRepository) CountUnread(userID uuid.UUID) (int64, error) {
+	args := m.Called(userID)
+
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockNotificationRepository) MarkRead(id, userID uuid.UUID, readAt time.Time) (bool, error) {
+	args := m.Called(id, userID, readAt)
+
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockNotificationRepository) MarkAllRead(userID uuid.UUID, readAt time.Time) (int64, error) {
+	args := m.Called(userID, readAt)
+
+	return args.Get(0).(int64), args.Error(1)
+}
@@ -0,0 +1,64 @@
+package mocks
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"swapp-go/cmd/internal/domain"
+	"time"
+)
+
+type MockOutboxRepository struct {
+	mock.Mock
+}
+
+func (m *MockOutboxRepository) Enqueue(message *domain.OutboxMessage) error {
+	return m.Called(message).Error(0)
+}
+
+func (m *MockOutboxRepository) FindByID(id uuid.UUID) (*domain.OutboxMessage, error) {
+	args := m.Called(id)
+
+	if message, ok := args.Get(0).(*domain.OutboxMessage); ok {
+		return message, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *MockOutboxRepository) List(query domain.OutboxQuery) ([]domain.OutboxMessage, error) {
+	args := m.Called(query)
+	messages, _ := args.Get(0).([]domain.OutboxMessage)
+
+	return messages, args.Error(1)
+}
+
+func (m *MockOutboxRepository) ListDue(now time.Time, limit int) ([]domain.OutboxMessage, error) {
+	args := m.Called(now, limit)
+	messages, _ := args.Get(0).([]domain.OutboxMessage)
+
+	return messages, args.Error(1)
+}
+
+func (m *MockOutboxRepository) TryClaim(id uuid.UUID, attempts int, leaseUntil time.Time) (bool, error) {
+	args := m.Called(id, attempts, leaseUntil)
+
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockOutboxRepository) MarkSent(id uuid.UUID, sentAt time.Time) error {
+	return m.Called(id, sentAt).Error(0)
+}
+
+func (m *MockOutboxRepository) MarkFailed(id uuid.UUID, nextAttemptAt time.Time, lastError string) error {
+	return m.Called(id, nextAttemptAt, lastError).Error(0)
+}
+
+func (m *MockOutboxRepository) MarkDead(id uuid.UUID, lastError string) error {
+	return m.Called(id, lastError).Error(0)
+}
+
+func (m *MockOutboxRepository) TryRequeue(id uuid.UUID, nextAttemptAt time.Time) (bool, error) {
+	args := m.Called(id, nextAttemptAt)
+
+	return args.Bool(0), args.Error(1)
+}
@@ -0,0 +1,14 @@
+package mocks
+
+// PasswordBlocklist forbids the passwords it lists, as they are written.
+type PasswordBlocklist []string
+
+func (m PasswordBlocklist) Contains(password string) bool {
+	for _, blocked := range m {
+		if blocked == password {
+			return true
+		}
+	}
+
+	return false
+}
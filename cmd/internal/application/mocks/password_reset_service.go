@@ -9,13 +9,13 @@ type MockPasswordResetRepository struct {
 	mock.Mock
 }
 
-func (m *MockPasswordResetRepository) Save(token *domain.PasswordReset) error {
-	args := m.Called(token)
+func (m *MockPasswordResetRepository) Replace(reset *domain.PasswordReset) error {
+	args := m.Called(reset)
 	return args.Error(0)
 }
 
-func (m *MockPasswordResetRepository) GetByToken(token string) (*domain.PasswordReset, error) {
-	args := m.Called(token)
+func (m *MockPasswordResetRepository) GetByTokenHash(tokenHash string) (*domain.PasswordReset, error) {
+	args := m.Called(tokenHash)
 	result := args.Get(0)
 	if result == nil {
 		return nil, args.Error(1)
@@ -23,7 +23,7 @@ func (m *MockPasswordResetRepository) GetByToken(token string) (*domain.Password
 	return result.(*domain.PasswordReset), args.Error(1)
 }
 
-func (m *MockPasswordResetRepository) Delete(token string) error {
-	args := m.Called(token)
-	return args.Error(0)
+func (m *MockPasswordResetRepository) TryDelete(tokenHash string) (bool, error) {
+	args := m.Called(tokenHash)
+	return args.Bool(0), args.Error(1)
 }
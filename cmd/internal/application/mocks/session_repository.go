@@ -0,0 +1,51 @@
+package mocks
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"swapp-go/cmd/internal/domain"
+	"time"
+)
+
+type MockSessionRepository struct {
+	mock.Mock
+}
+
+func (m *MockSessionRepository) Create(session *domain.Session) error {
+	return m.Called(session).Error(0)
+}
+
+func (m *MockSessionRepository) FindByID(id uuid.UUID) (*domain.Session, error) {
+	args := m.Called(id)
+
+	if session, ok := args.Get(0).(*domain.Session); ok {
+		return session, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *MockSessionRepository) ListActiveByUser(userID uuid.UUID, now time.Time) ([]domain.Session, error) {
+	args := m.Called(userID, now)
+	sessions, _ := args.Get(0).([]domain.Session)
+
+	return sessions, args.Error(1)
+}
+
+func (m *MockSessionRepository) TryRotate(session *domain.Session, currentHash string) (bool, error) {
+	args := m.Called(session, currentHash)
+
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockSessionRepository) Revoke(id uuid.UUID, revokedAt time.Time) error {
+	return m.Called(id, revokedAt).Error(0)
+}
+
+func (m *MockSessionRepository) RevokeAllByUser(userID uuid.UUID, revokedAt time.Time) error {
+	return m.Called(userID, revokedAt).Error(0)
+}
+
+func (m *MockSessionRepository) RevokeOthersByUser(userID uuid.UUID, keepID uuid.UUID, revokedAt time.Time) error {
+	return m.Called(userID, keepID, revokedAt).Error(0)
+}
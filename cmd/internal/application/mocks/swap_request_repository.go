@@ -4,6 +4,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
 	"swapp-go/cmd/internal/domain"
+	"time"
 )
 
 type SwapRequestRepository struct {
@@ -47,11 +48,55 @@ func (m *SwapRequestRepository) ListByStatus(status domain.SwapRequestStatus) ([
 	return nil, args.Error(1)
 }
 
+func (m *SwapRequestRepository) ListByThread(threadID uuid.UUID) ([]domain.SwapRequest, error) {
+	args := m.Called(threadID)
+	if list, ok := args.Get(0).([]domain.SwapRequest); ok {
+		return list, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *SwapRequestRepository) ListOverdue(now time.Time) ([]domain.SwapRequest, error) {
+	args := m.Called(now)
+	if list, ok := args.Get(0).([]domain.SwapRequest); ok {
+		return list, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *SwapRequestRepository) ListExpiringSoon(now, deadline time.Time) ([]domain.SwapRequest, error) {
+	args := m.Called(now, deadline)
+	if list, ok := args.Get(0).([]domain.SwapRequest); ok {
+		return list, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *SwapRequestRepository) TryMarkReminderSent(id uuid.UUID, sentAt time.Time) (bool, error) {
+	args := m.Called(id, sentAt)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *SwapRequestRepository) IsItemInSwapWithStatus(itemID uuid.UUID, statuses ...domain.SwapRequestStatus) (bool, error) {
+	args := m.Called(itemID, statuses)
+	return args.Bool(0), args.Error(1)
+}
+
 func (m *SwapRequestRepository) UpdateStatus(id uuid.UUID, status domain.SwapRequestStatus) error {
 	args := m.Called(id, status)
 	return args.Error(0)
 }
 
+func (m *SwapRequestRepository) TryUpdateStatus(id uuid.UUID, from, to domain.SwapRequestStatus) (bool, error) {
+	args := m.Called(id, from, to)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *SwapRequestRepository) TryMarkHandoverConfirmed(id uuid.UUID, party domain.SwapParty) (bool, error) {
+	args := m.Called(id, party)
+	return args.Bool(0), args.Error(1)
+}
+
 func (m *SwapRequestRepository) Delete(id uuid.UUID) error {
 	args := m.Called(id)
 	return args.Error(0)
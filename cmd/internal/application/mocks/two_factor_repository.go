@@ -0,0 +1,43 @@
+package mocks
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"swapp-go/cmd/internal/domain"
+	"time"
+)
+
+type MockTwoFactorRepository struct {
+	mock.Mock
+}
+
+func (m *MockTwoFactorRepository) FindByUserID(userID uuid.UUID) (*domain.TwoFactor, error) {
+	args := m.Called(userID)
+	twoFactor, _ := args.Get(0).(*domain.TwoFactor)
+
+	return twoFactor, args.Error(1)
+}
+
+func (m *MockTwoFactorRepository) Save(twoFactor *domain.TwoFactor) error {
+	return m.Called(twoFactor).Error(0)
+}
+
+func (m *MockTwoFactorRepository) Delete(userID uuid.UUID) error {
+	return m.Called(userID).Error(0)
+}
+
+func (m *MockTwoFactorRepository) TryUseStep(userID uuid.UUID, step int64) (bool, error) {
+	args := m.Called(userID, step)
+
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTwoFactorRepository) ReplaceRecoveryCodes(userID uuid.UUID, codeHashes []string) error {
+	return m.Called(userID, codeHashes).Error(0)
+}
+
+func (m *MockTwoFactorRepository) TryUseRecoveryCode(userID uuid.UUID, codeHash string, usedAt time.Time) (bool, error) {
+	args := m.Called(userID, codeHash, usedAt)
+
+	return args.Bool(0), args.Error(1)
+}
@@ -0,0 +1,12 @@
+package mocks
+
+import "swapp-go/cmd/internal/application/ports"
+
+// UnitOfWork hands its repositories straight to the callback without a transaction.
+type UnitOfWork struct {
+	Repositories ports.Repositories
+}
+
+func (m *UnitOfWork) Do(fn func(repos ports.Repositories) error) error {
+	return fn(m.Repositories)
+}
@@ -57,3 +57,13 @@ func (m *MockUserRepository) FindByEmail(email string) (*domain.User, error) {
 
 	return nil, args.Error(1)
 }
+
+func (m *MockUserRepository) List(query domain.UserListQuery) ([]domain.User, error) {
+	args := m.Called(query)
+
+	if users, ok := args.Get(0).([]domain.User); ok {
+		return users, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
@@ -0,0 +1,15 @@
+package ports
+
+import "swapp-go/cmd/internal/domain"
+
+// AccessTokenIssuer signs access tokens with the current signing key.
+type AccessTokenIssuer interface {
+	Issue(claims domain.AccessTokenClaims) (string, error)
+}
+
+// AccessTokenVerifier checks access tokens against every key that may still
+// have outstanding tokens, and publishes those keys for other services.
+type AccessTokenVerifier interface {
+	Verify(token string) (*domain.AccessTokenClaims, error)
+	PublicKeys() []domain.JSONWebKey
+}
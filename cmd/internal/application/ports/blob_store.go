@@ -0,0 +1,13 @@
+package ports
+
+import "io"
+
+// BlobStore keeps uploaded files. Keys are slash separated paths chosen by the
+// caller, such as "items/<item id>/<picture id>.jpg", and stay valid for as
+// long as the blob exists.
+type BlobStore interface {
+	Put(key string, content io.Reader, size int64, contentType string) error
+	Delete(key string) error
+	// URL returns the address clients use to fetch the blob stored under key.
+	URL(key string) string
+}
@@ -0,0 +1,8 @@
+package ports
+
+import "swapp-go/cmd/internal/domain"
+
+type CategoryRepository interface {
+	List() ([]domain.Category, error)
+	FindBySlug(slug string) (*domain.Category, error)
+}
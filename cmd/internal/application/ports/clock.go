@@ -0,0 +1,7 @@
+package ports
+
+import "time"
+
+type Clock interface {
+	Now() time.Time
+}
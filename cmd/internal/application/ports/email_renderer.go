@@ -0,0 +1,7 @@
+package ports
+
+import "swapp-go/cmd/internal/domain"
+
+type EmailRenderer interface {
+	Render(message *domain.EmailMessage) (*domain.RenderedEmail, error)
+}
@@ -0,0 +1,10 @@
+package ports
+
+import "swapp-go/cmd/internal/domain"
+
+// ImageProcessor validates an uploaded picture and turns it into the images
+// that get stored. It returns one of the domain image errors for uploads that
+// are too large, of an unsupported type, or not decodable.
+type ImageProcessor interface {
+	Process(upload domain.PictureUpload) (*domain.ProcessedImage, error)
+}
@@ -11,4 +11,6 @@ type ItemRepository interface {
 	Delete(id uuid.UUID) error
 	FindByID(id uuid.UUID) (*domain.Item, error)
 	TryMarkItemAsOffered(itemID uuid.UUID) (bool, error)
+	TransferOwnership(itemIDs []uuid.UUID, newOwnerID uuid.UUID) error
+	Search(query domain.ItemSearchQuery) (*domain.ItemSearchResult, error)
 }
@@ -0,0 +1,10 @@
+package ports
+
+import "swapp-go/cmd/internal/domain"
+
+// LinkTokenSigner signs the tokens of links mailed to users, so they can be
+// checked without storing them.
+type LinkTokenSigner interface {
+	SignLink(claims domain.LinkTokenClaims) (string, error)
+	VerifyLink(purpose string, token string) (*domain.LinkTokenClaims, error)
+}
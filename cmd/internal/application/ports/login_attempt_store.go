@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"swapp-go/cmd/internal/domain"
+	"time"
+)
+
+type LoginAttemptStore interface {
+	// Find returns nil when no failure is recorded for key.
+	Find(key string) (*domain.LoginAttempts, error)
+	// RecordFailure counts a failed login for key and returns the updated
+	// attempts. Failures older than windowStart are forgotten first.
+	RecordFailure(key string, failedAt time.Time, windowStart time.Time) (*domain.LoginAttempts, error)
+	Lock(key string, lockedUntil time.Time) error
+	Reset(key string) error
+}
@@ -0,0 +1,20 @@
+package ports
+
+import (
+	"github.com/google/uuid"
+	"swapp-go/cmd/internal/domain"
+	"time"
+)
+
+type NotificationRepository interface {
+	Create(notification *domain.Notification) error
+	List(userID uuid.UUID, query domain.NotificationQuery) ([]domain.Notification, error)
+	CountUnread(userID uuid.UUID) (int64, error)
+	// MarkRead marks one of the user's notifications as read. It reports
+	// whether the user has a notification with that ID; marking a read
+	// notification again leaves its read time unchanged.
+	MarkRead(id, userID uuid.UUID, readAt time.Time) (bool, error)
+	// MarkAllRead marks every unread notification of the user as read and
+	// returns how many there were.
+	MarkAllRead(userID uuid.UUID, readAt time.Time) (int64, error)
+}
@@ -0,0 +1,27 @@
+package ports
+
+import (
+	"github.com/google/uuid"
+	"swapp-go/cmd/internal/domain"
+	"time"
+)
+
+type OutboxRepository interface {
+	Enqueue(message *domain.OutboxMessage) error
+	FindByID(id uuid.UUID) (*domain.OutboxMessage, error)
+	List(query domain.OutboxQuery) ([]domain.OutboxMessage, error)
+	// ListDue returns up to limit pending messages whose next attempt is due,
+	// oldest first.
+	ListDue(now time.Time, limit int) ([]domain.OutboxMessage, error)
+	// TryClaim counts a delivery attempt and holds the message until
+	// leaseUntil, provided it is still pending with the given number of
+	// attempts. It reports whether the message was claimed, so that two
+	// dispatchers never send the same message at once.
+	TryClaim(id uuid.UUID, attempts int, leaseUntil time.Time) (bool, error)
+	MarkSent(id uuid.UUID, sentAt time.Time) error
+	MarkFailed(id uuid.UUID, nextAttemptAt time.Time, lastError string) error
+	MarkDead(id uuid.UUID, lastError string) error
+	// TryRequeue moves a dead message back to pending with a fresh set of
+	// attempts. It reports whether the message was dead.
+	TryRequeue(id uuid.UUID, nextAttemptAt time.Time) (bool, error)
+}
@@ -0,0 +1,7 @@
+package ports
+
+// PasswordBlocklist knows passwords that are too common or were exposed in
+// breaches, and so must not be chosen.
+type PasswordBlocklist interface {
+	Contains(password string) bool
+}
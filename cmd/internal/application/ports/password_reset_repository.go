@@ -3,7 +3,11 @@ package ports
 import "swapp-go/cmd/internal/domain"
 
 type PasswordResetRepository interface {
-	Save(token *domain.PasswordReset) error
-	GetByToken(token string) (*domain.PasswordReset, error)
-	Delete(token string) error
+	// Replace stores reset as the only pending reset of its user, discarding
+	// any earlier one.
+	Replace(reset *domain.PasswordReset) error
+	GetByTokenHash(tokenHash string) (*domain.PasswordReset, error)
+	// TryDelete removes the reset with the given token hash and reports whether
+	// it was still there, so that a token can only be used once.
+	TryDelete(tokenHash string) (bool, error)
 }
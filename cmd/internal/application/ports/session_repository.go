@@ -0,0 +1,21 @@
+package ports
+
+import (
+	"github.com/google/uuid"
+	"swapp-go/cmd/internal/domain"
+	"time"
+)
+
+type SessionRepository interface {
+	Create(session *domain.Session) error
+	FindByID(id uuid.UUID) (*domain.Session, error)
+	ListActiveByUser(userID uuid.UUID, now time.Time) ([]domain.Session, error)
+	// TryRotate stores the new refresh token hash and client details of session,
+	// provided the session is not revoked and its current hash is still
+	// currentHash. It reports whether the session was updated.
+	TryRotate(session *domain.Session, currentHash string) (bool, error)
+	Revoke(id uuid.UUID, revokedAt time.Time) error
+	RevokeAllByUser(userID uuid.UUID, revokedAt time.Time) error
+	// RevokeOthersByUser revokes every session of the user except keepID.
+	RevokeOthersByUser(userID uuid.UUID, keepID uuid.UUID, revokedAt time.Time) error
+}
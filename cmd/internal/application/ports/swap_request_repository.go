@@ -3,6 +3,7 @@ package ports
 import (
 	"github.com/google/uuid"
 	"swapp-go/cmd/internal/domain"
+	"time"
 )
 
 type SwapRequestRepository interface {
@@ -11,6 +12,16 @@ type SwapRequestRepository interface {
 	FindByReferenceNumber(reference string) (*domain.SwapRequest, error)
 	ListByUser(userID uuid.UUID) ([]domain.SwapRequest, error)
 	ListByStatus(status domain.SwapRequestStatus) ([]domain.SwapRequest, error)
+	ListByThread(threadID uuid.UUID) ([]domain.SwapRequest, error)
+	ListOverdue(now time.Time) ([]domain.SwapRequest, error)
+	ListExpiringSoon(now, deadline time.Time) ([]domain.SwapRequest, error)
+	IsItemInSwapWithStatus(itemID uuid.UUID, statuses ...domain.SwapRequestStatus) (bool, error)
 	UpdateStatus(id uuid.UUID, status domain.SwapRequestStatus) error
+	TryUpdateStatus(id uuid.UUID, from, to domain.SwapRequestStatus) (bool, error)
+	TryMarkReminderSent(id uuid.UUID, sentAt time.Time) (bool, error)
+	// TryMarkHandoverConfirmed records party's confirmation, provided the
+	// request is still accepted and party has not confirmed yet. It reports
+	// whether the confirmation was recorded.
+	TryMarkHandoverConfirmed(id uuid.UUID, party domain.SwapParty) (bool, error)
 	Delete(id uuid.UUID) error
 }
@@ -0,0 +1,26 @@
+package ports
+
+import (
+	"github.com/google/uuid"
+	"swapp-go/cmd/internal/domain"
+	"time"
+)
+
+type TwoFactorRepository interface {
+	// FindByUserID returns nil when the user never enrolled, so that callers
+	// can tell this apart from a failed lookup.
+	FindByUserID(userID uuid.UUID) (*domain.TwoFactor, error)
+	// Save creates or replaces the settings of twoFactor.UserID.
+	Save(twoFactor *domain.TwoFactor) error
+	Delete(userID uuid.UUID) error
+	// TryUseStep records step as the last used TOTP step, provided it is later
+	// than the one recorded so far. It reports whether the step was recorded.
+	TryUseStep(userID uuid.UUID, step int64) (bool, error)
+
+	// ReplaceRecoveryCodes discards the user's recovery codes and stores the
+	// given hashes instead.
+	ReplaceRecoveryCodes(userID uuid.UUID, codeHashes []string) error
+	// TryUseRecoveryCode marks an unused recovery code as used and reports
+	// whether there was one.
+	TryUseRecoveryCode(userID uuid.UUID, codeHash string, usedAt time.Time) (bool, error)
+}
@@ -0,0 +1,16 @@
+package ports
+
+// Repositories groups the repositories bound to a single unit of work.
+type Repositories struct {
+	SwapRequests  SwapRequestRepository
+	Items         ItemRepository
+	Users         UserRepository
+	Outbox        OutboxRepository
+	Notifications NotificationRepository
+}
+
+// UnitOfWork runs fn inside a single transaction. The transaction is committed
+// when fn returns nil and rolled back otherwise.
+type UnitOfWork interface {
+	Do(fn func(repos Repositories) error) error
+}
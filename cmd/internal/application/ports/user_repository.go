@@ -12,4 +12,5 @@ type UserRepository interface {
 	FindByID(id uuid.UUID) (*domain.User, error)
 	FindByUsername(username string) (*domain.User, error)
 	FindByEmail(email string) (*domain.User, error)
+	List(query domain.UserListQuery) ([]domain.User, error)
 }
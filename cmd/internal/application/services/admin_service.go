@@ -0,0 +1,133 @@
+package services
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"swapp-go/cmd/internal/application/ports"
+	"swapp-go/cmd/internal/domain"
+)
+
+var (
+	ModeratedUserNotFoundErr = errors.New("user not found")
+	CannotModerateSelfErr    = errors.New("cannot moderate your own account")
+	InsufficientRoleErr      = errors.New("role is not high enough for this action")
+)
+
+type AdminService struct {
+	userRepo    ports.UserRepository
+	sessionRepo ports.SessionRepository
+	clock       ports.Clock
+}
+
+func NewAdminService(userRepo ports.UserRepository, sessionRepo ports.SessionRepository, clock ports.Clock) *AdminService {
+	return &AdminService{
+		userRepo:    userRepo,
+		sessionRepo: sessionRepo,
+		clock:       clock,
+	}
+}
+
+func (service *AdminService) ListUsers(query domain.UserListQuery) ([]domain.User, error) {
+	if err := query.Normalize(); err != nil {
+		return nil, err
+	}
+
+	return service.userRepo.List(query)
+}
+
+// SuspendUser keeps a user from signing in and ends all of their sessions.
+// Suspending a suspended user is not an error.
+func (service *AdminService) SuspendUser(actorID, userID uuid.UUID) (*domain.User, error) {
+	_, user, err := service.moderatedUsers(actorID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.IsSuspended() {
+		return user, nil
+	}
+
+	now := service.clock.Now()
+
+	user, err = service.userRepo.Update(userID, map[string]interface{}{"suspended_at": now})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = service.sessionRepo.RevokeAllByUser(userID, now); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (service *AdminService) UnsuspendUser(actorID, userID uuid.UUID) (*domain.User, error) {
+	_, user, err := service.moderatedUsers(actorID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.IsSuspended() {
+		return user, nil
+	}
+
+	return service.userRepo.Update(userID, map[string]interface{}{"suspended_at": nil})
+}
+
+// SetRole changes the role of another user and ends their sessions, since
+// access tokens carry the role they were issued with. The actor can grant
+// roles up to their own, but only to users below them.
+func (service *AdminService) SetRole(actorID, userID uuid.UUID, role domain.Role) (*domain.User, error) {
+	if !role.IsValid() {
+		return nil, domain.InvalidRoleErr
+	}
+
+	actor, user, err := service.moderatedUsers(actorID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !actor.Role.Includes(role) {
+		return nil, InsufficientRoleErr
+	}
+
+	if user.Role == role {
+		return user, nil
+	}
+
+	user, err = service.userRepo.Update(userID, map[string]interface{}{"role": string(role)})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = service.sessionRepo.RevokeAllByUser(userID, service.clock.Now()); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// moderatedUsers loads the actor and the user an action targets, after checking
+// that the actor, as currently stored rather than as claimed by their token,
+// outranks the target.
+func (service *AdminService) moderatedUsers(actorID, userID uuid.UUID) (*domain.User, *domain.User, error) {
+	if actorID == userID {
+		return nil, nil, CannotModerateSelfErr
+	}
+
+	actor, err := service.userRepo.FindByID(actorID)
+	if err != nil {
+		return nil, nil, InsufficientRoleErr
+	}
+
+	user, err := service.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, nil, ModeratedUserNotFoundErr
+	}
+
+	if actor.IsSuspended() || !actor.Role.Outranks(user.Role) {
+		return nil, nil, InsufficientRoleErr
+	}
+
+	return actor, user, nil
+}
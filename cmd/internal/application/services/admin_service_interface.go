@@ -0,0 +1,13 @@
+package services
+
+import (
+	"github.com/google/uuid"
+	"swapp-go/cmd/internal/domain"
+)
+
+type AdminServiceInterface interface {
+	ListUsers(query domain.UserListQuery) ([]domain.User, error)
+	SuspendUser(actorID, userID uuid.UUID) (*domain.User, error)
+	UnsuspendUser(actorID, userID uuid.UUID) (*domain.User, error)
+	SetRole(actorID, userID uuid.UUID, role domain.Role) (*domain.User, error)
+}
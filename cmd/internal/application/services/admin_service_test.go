@@ -0,0 +1,238 @@
+package services_test
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	testMocks "swapp-go/cmd/internal/application/mocks"
+	"swapp-go/cmd/internal/application/services"
+	"swapp-go/cmd/internal/domain"
+	"testing"
+)
+
+func setupAdminServiceTest() (*services.AdminService, *testMocks.MockUserRepository, *testMocks.MockSessionRepository) {
+	mockUserRepo := new(testMocks.MockUserRepository)
+	mockSessionRepo := new(testMocks.MockSessionRepository)
+
+	service := services.NewAdminService(mockUserRepo, mockSessionRepo, &testMocks.Clock{Current: testNow})
+
+	return service, mockUserRepo, mockSessionRepo
+}
+
+func TestAdminService_ListUsers(t *testing.T) {
+	t.Run("clamps the page size", func(t *testing.T) {
+		service, mockUserRepo, _ := setupAdminServiceTest()
+
+		users := []domain.User{{ID: uuid.New(), Username: "user"}}
+		mockUserRepo.On("List", domain.UserListQuery{Limit: domain.MaxUserListLimit}).Return(users, nil).Once()
+
+		listed, err := service.ListUsers(domain.UserListQuery{Limit: 10_000})
+		assert.NoError(t, err)
+		assert.Equal(t, users, listed)
+	})
+
+	t.Run("invalid role filter", func(t *testing.T) {
+		service, mockUserRepo, _ := setupAdminServiceTest()
+
+		role := domain.Role("root")
+		_, err := service.ListUsers(domain.UserListQuery{Role: &role})
+		assert.ErrorIs(t, err, domain.InvalidRoleErr)
+
+		mockUserRepo.AssertNotCalled(t, "List", mock.Anything)
+	})
+}
+
+func TestAdminService_SuspendUser(t *testing.T) {
+	moderator := &domain.User{ID: uuid.New(), Username: "moderator", Role: domain.RoleModerator}
+	admin := &domain.User{ID: uuid.New(), Username: "admin", Role: domain.RoleAdmin}
+
+	t.Run("suspends the user and revokes their sessions", func(t *testing.T) {
+		service, mockUserRepo, mockSessionRepo := setupAdminServiceTest()
+
+		user := &domain.User{ID: uuid.New(), Role: domain.RoleUser}
+		suspended := &domain.User{ID: user.ID, Role: domain.RoleUser, SuspendedAt: &testNow}
+
+		mockUserRepo.On("FindByID", moderator.ID).Return(moderator, nil)
+		mockUserRepo.On("FindByID", user.ID).Return(user, nil)
+		mockUserRepo.On("Update", user.ID, map[string]interface{}{"suspended_at": testNow}).Return(suspended, nil).Once()
+		mockSessionRepo.On("RevokeAllByUser", user.ID, testNow).Return(nil).Once()
+
+		result, err := service.SuspendUser(moderator.ID, user.ID)
+		assert.NoError(t, err)
+		assert.True(t, result.IsSuspended())
+
+		mockUserRepo.AssertExpectations(t)
+		mockSessionRepo.AssertExpectations(t)
+	})
+
+	t.Run("already suspended", func(t *testing.T) {
+		service, mockUserRepo, mockSessionRepo := setupAdminServiceTest()
+
+		user := &domain.User{ID: uuid.New(), Role: domain.RoleUser, SuspendedAt: &testNow}
+
+		mockUserRepo.On("FindByID", moderator.ID).Return(moderator, nil)
+		mockUserRepo.On("FindByID", user.ID).Return(user, nil)
+
+		result, err := service.SuspendUser(moderator.ID, user.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, user, result)
+
+		mockUserRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		mockSessionRepo.AssertNotCalled(t, "RevokeAllByUser", mock.Anything, mock.Anything)
+	})
+
+	t.Run("moderator cannot suspend a peer or a superior", func(t *testing.T) {
+		for _, target := range []*domain.User{
+			{ID: uuid.New(), Role: domain.RoleModerator},
+			admin,
+		} {
+			service, mockUserRepo, _ := setupAdminServiceTest()
+
+			mockUserRepo.On("FindByID", moderator.ID).Return(moderator, nil)
+			mockUserRepo.On("FindByID", target.ID).Return(target, nil)
+
+			_, err := service.SuspendUser(moderator.ID, target.ID)
+			assert.ErrorIs(t, err, services.InsufficientRoleErr, string(target.Role))
+
+			mockUserRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		}
+	})
+
+	t.Run("demoted actor is refused", func(t *testing.T) {
+		service, mockUserRepo, _ := setupAdminServiceTest()
+
+		demoted := &domain.User{ID: uuid.New(), Role: domain.RoleUser}
+		user := &domain.User{ID: uuid.New(), Role: domain.RoleUser}
+
+		mockUserRepo.On("FindByID", demoted.ID).Return(demoted, nil)
+		mockUserRepo.On("FindByID", user.ID).Return(user, nil)
+
+		_, err := service.SuspendUser(demoted.ID, user.ID)
+		assert.ErrorIs(t, err, services.InsufficientRoleErr)
+	})
+
+	t.Run("own account", func(t *testing.T) {
+		service, mockUserRepo, _ := setupAdminServiceTest()
+
+		_, err := service.SuspendUser(admin.ID, admin.ID)
+		assert.ErrorIs(t, err, services.CannotModerateSelfErr)
+
+		mockUserRepo.AssertNotCalled(t, "FindByID", mock.Anything)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		service, mockUserRepo, _ := setupAdminServiceTest()
+
+		missingID := uuid.New()
+		mockUserRepo.On("FindByID", admin.ID).Return(admin, nil)
+		mockUserRepo.On("FindByID", missingID).Return(nil, errors.New("record not found"))
+
+		_, err := service.SuspendUser(admin.ID, missingID)
+		assert.ErrorIs(t, err, services.ModeratedUserNotFoundErr)
+	})
+}
+
+func TestAdminService_UnsuspendUser(t *testing.T) {
+	service, mockUserRepo, _ := setupAdminServiceTest()
+
+	moderator := &domain.User{ID: uuid.New(), Role: domain.RoleModerator}
+	user := &domain.User{ID: uuid.New(), Role: domain.RoleUser, SuspendedAt: &testNow}
+
+	mockUserRepo.On("FindByID", moderator.ID).Return(moderator, nil)
+	mockUserRepo.On("FindByID", user.ID).Return(user, nil)
+	mockUserRepo.On("Update", user.ID, map[string]interface{}{"suspended_at": nil}).
+		Return(&domain.User{ID: user.ID, Role: domain.RoleUser}, nil).Once()
+
+	result, err := service.UnsuspendUser(moderator.ID, user.ID)
+	assert.NoError(t, err)
+	assert.False(t, result.IsSuspended())
+
+	mockUserRepo.AssertExpectations(t)
+}
+
+func TestAdminService_SetRole(t *testing.T) {
+	admin := &domain.User{ID: uuid.New(), Role: domain.RoleAdmin}
+
+	t.Run("promotes a user", func(t *testing.T) {
+		service, mockUserRepo, mockSessionRepo := setupAdminServiceTest()
+
+		user := &domain.User{ID: uuid.New(), Role: domain.RoleUser}
+
+		mockUserRepo.On("FindByID", admin.ID).Return(admin, nil)
+		mockUserRepo.On("FindByID", user.ID).Return(user, nil)
+		mockUserRepo.On("Update", user.ID, map[string]interface{}{"role": "moderator"}).
+			Return(&domain.User{ID: user.ID, Role: domain.RoleModerator}, nil).Once()
+		mockSessionRepo.On("RevokeAllByUser", user.ID, testNow).Return(nil).Once()
+
+		result, err := service.SetRole(admin.ID, user.ID, domain.RoleModerator)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.RoleModerator, result.Role)
+		mockSessionRepo.AssertExpectations(t)
+	})
+
+	t.Run("demotion ends the sessions of the user", func(t *testing.T) {
+		service, mockUserRepo, mockSessionRepo := setupAdminServiceTest()
+
+		moderator := &domain.User{ID: uuid.New(), Role: domain.RoleModerator}
+
+		mockUserRepo.On("FindByID", admin.ID).Return(admin, nil)
+		mockUserRepo.On("FindByID", moderator.ID).Return(moderator, nil)
+		mockUserRepo.On("Update", moderator.ID, map[string]interface{}{"role": "user"}).
+			Return(&domain.User{ID: moderator.ID, Role: domain.RoleUser}, nil).Once()
+		mockSessionRepo.On("RevokeAllByUser", moderator.ID, testNow).Return(nil).Once()
+
+		result, err := service.SetRole(admin.ID, moderator.ID, domain.RoleUser)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.RoleUser, result.Role)
+		mockSessionRepo.AssertExpectations(t)
+	})
+
+	t.Run("unchanged role keeps the sessions", func(t *testing.T) {
+		service, mockUserRepo, mockSessionRepo := setupAdminServiceTest()
+
+		moderator := &domain.User{ID: uuid.New(), Role: domain.RoleModerator}
+
+		mockUserRepo.On("FindByID", admin.ID).Return(admin, nil)
+		mockUserRepo.On("FindByID", moderator.ID).Return(moderator, nil)
+
+		_, err := service.SetRole(admin.ID, moderator.ID, domain.RoleModerator)
+		assert.NoError(t, err)
+		mockUserRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		mockSessionRepo.AssertNotCalled(t, "RevokeAllByUser", mock.Anything, mock.Anything)
+	})
+
+	t.Run("moderator cannot grant admin", func(t *testing.T) {
+		service, mockUserRepo, _ := setupAdminServiceTest()
+
+		moderator := &domain.User{ID: uuid.New(), Role: domain.RoleModerator}
+		user := &domain.User{ID: uuid.New(), Role: domain.RoleUser}
+
+		mockUserRepo.On("FindByID", moderator.ID).Return(moderator, nil)
+		mockUserRepo.On("FindByID", user.ID).Return(user, nil)
+
+		_, err := service.SetRole(moderator.ID, user.ID, domain.RoleAdmin)
+		assert.ErrorIs(t, err, services.InsufficientRoleErr)
+
+		mockUserRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("admin cannot demote another admin", func(t *testing.T) {
+		service, mockUserRepo, _ := setupAdminServiceTest()
+
+		otherAdmin := &domain.User{ID: uuid.New(), Role: domain.RoleAdmin}
+
+		mockUserRepo.On("FindByID", admin.ID).Return(admin, nil)
+		mockUserRepo.On("FindByID", otherAdmin.ID).Return(otherAdmin, nil)
+
+		_, err := service.SetRole(admin.ID, otherAdmin.ID, domain.RoleUser)
+		assert.ErrorIs(t, err, services.InsufficientRoleErr)
+	})
+
+	t.Run("invalid role", func(t *testing.T) {
+		service, _, _ := setupAdminServiceTest()
+
+		_, err := service.SetRole(admin.ID, uuid.New(), "root")
+		assert.ErrorIs(t, err, domain.InvalidRoleErr)
+	})
+}
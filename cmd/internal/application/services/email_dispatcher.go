@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+	"log"
+	"swapp-go/cmd/internal/application/ports"
+	"swapp-go/cmd/internal/domain"
+	"time"
+)
+
+// EmailDispatchPolicy sets how the outbox is delivered. A message that fails
+// is retried after BaseDelay, doubled for every earlier attempt up to MaxDelay,
+// and declared dead after MaxAttempts. A claimed message is held for
+// LeaseDuration, after which another dispatcher may retry it if the first one
+// never reported back.
+type EmailDispatchPolicy struct {
+	BatchSize     int
+	MaxAttempts   int
+	BaseDelay     time.Duration
+	MaxDelay      time.Duration
+	LeaseDuration time.Duration
+}
+
+// EmailDispatcher periodically delivers the emails queued in the outbox.
+type EmailDispatcher struct {
+	repo         ports.OutboxRepository
+	emailService ports.EmailService
+	clock        ports.Clock
+	policy       EmailDispatchPolicy
+	interval     time.Duration
+}
+
+func NewEmailDispatcher(
+	repo ports.OutboxRepository,
+	emailService ports.EmailService,
+	clock ports.Clock,
+	policy EmailDispatchPolicy,
+	interval time.Duration,
+) *EmailDispatcher {
+	return &EmailDispatcher{
+		repo:         repo,
+		emailService: emailService,
+		clock:        clock,
+		policy:       policy,
+		interval:     interval,
+	}
+}
+
+// Run dispatches once immediately and then on every interval until ctx is cancelled.
+func (dispatcher *EmailDispatcher) Run(ctx context.Context) {
+	dispatcher.dispatchAndLog()
+
+	ticker := time.NewTicker(dispatcher.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			dispatcher.dispatchAndLog()
+		}
+	}
+}
+
+// Dispatch tries to deliver one batch of due messages and returns how many
+// were sent. Failures on individual messages are recorded on the message and
+// do not stop the batch.
+func (dispatcher *EmailDispatcher) Dispatch() (int, error) {
+	now := dispatcher.clock.Now()
+
+	due, err := dispatcher.repo.ListDue(now, dispatcher.policy.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for i := range due {
+		delivered, err := dispatcher.deliver(&due[i], now)
+		if err != nil {
+			log.Printf("Failed to update outbox message %s: %v", due[i].ID, err)
+			continue
+		}
+		if delivered {
+			sent++
+		}
+	}
+
+	return sent, nil
+}
+
+func (dispatcher *EmailDispatcher) dispatchAndLog() {
+	sent, err := dispatcher.Dispatch()
+	if err != nil {
+		log.Printf("Failed to dispatch the email outbox: %v", err)
+		return
+	}
+
+	if sent > 0 {
+		log.Printf("Sent %d emails from the outbox", sent)
+	}
+}
+
+// deliver claims message and sends it, then records the outcome. It reports
+// whether the message was sent.
+func (dispatcher *EmailDispatcher) deliver(message *domain.OutboxMessage, now time.Time) (bool, error) {
+	claimed, err := dispatcher.repo.TryClaim(message.ID, message.Attempts, now.Add(dispatcher.policy.LeaseDuration))
+	if err != nil || !claimed {
+		return false, err
+	}
+
+	attempts := message.Attempts + 1
+
+	sendErr := dispatcher.emailService.SendEmail(&message.EmailMessage)
+	if sendErr == nil {
+		return true, dispatcher.repo.MarkSent(message.ID, dispatcher.clock.Now())
+	}
+
+	if attempts >= dispatcher.policy.MaxAttempts {
+		log.Printf("Giving up on outbox message %s after %d attempts: %v", message.ID, attempts, sendErr)
+		return false, dispatcher.repo.MarkDead(message.ID, sendErr.Error())
+	}
+
+	nextAttemptAt := dispatcher.clock.Now().Add(dispatcher.retryDelay(attempts))
+
+	return false, dispatcher.repo.MarkFailed(message.ID, nextAttemptAt, sendErr.Error())
+}
+
+// retryDelay returns how long to wait after the given number of failed attempts.
+func (dispatcher *EmailDispatcher) retryDelay(attempts int) time.Duration {
+	delay := dispatcher.policy.BaseDelay
+	for i := 1; i < attempts && delay < dispatcher.policy.MaxDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, dispatcher.policy.MaxDelay)
+}
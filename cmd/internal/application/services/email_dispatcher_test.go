@@ -0,0 +1,152 @@
+package services_test
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	testMocks "swapp-go/cmd/internal/application/mocks"
+	"swapp-go/cmd/internal/application/services"
+	"swapp-go/cmd/internal/domain"
+	"testing"
+	"time"
+)
+
+var testEmailDispatchPolicy = services.EmailDispatchPolicy{
+	BatchSize:     10,
+	MaxAttempts:   3,
+	BaseDelay:     time.Minute,
+	MaxDelay:      3 * time.Minute,
+	LeaseDuration: 5 * time.Minute,
+}
+
+func setupEmailDispatcherTest() (*services.EmailDispatcher, *testMocks.MockOutboxRepository, *testMocks.MockEmailService) {
+	mockOutbox := new(testMocks.MockOutboxRepository)
+	mockEmailService := new(testMocks.MockEmailService)
+
+	dispatcher := services.NewEmailDispatcher(
+		mockOutbox,
+		mockEmailService,
+		&testMocks.Clock{Current: testNow},
+		testEmailDispatchPolicy,
+		time.Minute,
+	)
+
+	return dispatcher, mockOutbox, mockEmailService
+}
+
+func newDueOutboxMessage(attempts int) domain.OutboxMessage {
+	message := domain.NewOutboxMessage(domain.EmailMessage{
+		Recipient: "user@example.com",
+		Template:  domain.EmailTemplatePasswordReset,
+		Data:      domain.EmailData{"Username": "user"},
+	}, testNow)
+	message.Attempts = attempts
+
+	return *message
+}
+
+func TestEmailDispatcher_Dispatch(t *testing.T) {
+	leaseUntil := testNow.Add(testEmailDispatchPolicy.LeaseDuration)
+
+	t.Run("sends due messages", func(t *testing.T) {
+		dispatcher, mockOutbox, mockEmailService := setupEmailDispatcherTest()
+
+		message := newDueOutboxMessage(0)
+
+		mockOutbox.On("ListDue", testNow, 10).Return([]domain.OutboxMessage{message}, nil).Once()
+		mockOutbox.On("TryClaim", message.ID, 0, leaseUntil).Return(true, nil).Once()
+		mockEmailService.On("SendEmail", &message.EmailMessage).Return(nil).Once()
+		mockOutbox.On("MarkSent", message.ID, testNow).Return(nil).Once()
+
+		sent, err := dispatcher.Dispatch()
+		assert.NoError(t, err)
+		assert.Equal(t, 1, sent)
+
+		mockOutbox.AssertExpectations(t)
+		mockEmailService.AssertExpectations(t)
+	})
+
+	t.Run("skips messages claimed by another dispatcher", func(t *testing.T) {
+		dispatcher, mockOutbox, mockEmailService := setupEmailDispatcherTest()
+
+		message := newDueOutboxMessage(0)
+
+		mockOutbox.On("ListDue", testNow, 10).Return([]domain.OutboxMessage{message}, nil).Once()
+		mockOutbox.On("TryClaim", message.ID, 0, leaseUntil).Return(false, nil).Once()
+
+		sent, err := dispatcher.Dispatch()
+		assert.NoError(t, err)
+		assert.Zero(t, sent)
+
+		mockEmailService.AssertNotCalled(t, "SendEmail", mock.Anything)
+	})
+
+	t.Run("failed delivery backs off exponentially", func(t *testing.T) {
+		tests := []struct {
+			attempts int
+			delay    time.Duration
+		}{
+			{0, time.Minute},
+			{1, 2 * time.Minute},
+		}
+
+		for _, test := range tests {
+			dispatcher, mockOutbox, mockEmailService := setupEmailDispatcherTest()
+
+			message := newDueOutboxMessage(test.attempts)
+
+			mockOutbox.On("ListDue", testNow, 10).Return([]domain.OutboxMessage{message}, nil).Once()
+			mockOutbox.On("TryClaim", message.ID, test.attempts, leaseUntil).Return(true, nil).Once()
+			mockEmailService.On("SendEmail", mock.Anything).Return(errors.New("connection refused")).Once()
+			mockOutbox.On("MarkFailed", message.ID, testNow.Add(test.delay), "connection refused").Return(nil).Once()
+
+			sent, err := dispatcher.Dispatch()
+			assert.NoError(t, err)
+			assert.Zero(t, sent)
+
+			mockOutbox.AssertExpectations(t)
+		}
+	})
+
+	t.Run("last failed attempt moves the message to the dead letters", func(t *testing.T) {
+		dispatcher, mockOutbox, mockEmailService := setupEmailDispatcherTest()
+
+		message := newDueOutboxMessage(testEmailDispatchPolicy.MaxAttempts - 1)
+
+		mockOutbox.On("ListDue", testNow, 10).Return([]domain.OutboxMessage{message}, nil).Once()
+		mockOutbox.On("TryClaim", message.ID, message.Attempts, leaseUntil).Return(true, nil).Once()
+		mockEmailService.On("SendEmail", mock.Anything).Return(errors.New("mailbox unavailable")).Once()
+		mockOutbox.On("MarkDead", message.ID, "mailbox unavailable").Return(nil).Once()
+
+		_, err := dispatcher.Dispatch()
+		assert.NoError(t, err)
+
+		mockOutbox.AssertExpectations(t)
+		mockOutbox.AssertNotCalled(t, "MarkFailed", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("failure on one message does not stop the batch", func(t *testing.T) {
+		dispatcher, mockOutbox, mockEmailService := setupEmailDispatcherTest()
+
+		failing, succeeding := newDueOutboxMessage(0), newDueOutboxMessage(0)
+
+		mockOutbox.On("ListDue", testNow, 10).Return([]domain.OutboxMessage{failing, succeeding}, nil).Once()
+		mockOutbox.On("TryClaim", failing.ID, 0, leaseUntil).Return(false, errors.New("db error")).Once()
+		mockOutbox.On("TryClaim", succeeding.ID, 0, leaseUntil).Return(true, nil).Once()
+		mockEmailService.On("SendEmail", mock.Anything).Return(nil).Once()
+		mockOutbox.On("MarkSent", succeeding.ID, testNow).Return(nil).Once()
+
+		sent, err := dispatcher.Dispatch()
+		assert.NoError(t, err)
+		assert.Equal(t, 1, sent)
+	})
+
+	t.Run("list error", func(t *testing.T) {
+		dispatcher, mockOutbox, _ := setupEmailDispatcherTest()
+
+		mockOutbox.On("ListDue", testNow, 10).Return(nil, errors.New("db error")).Once()
+
+		_, err := dispatcher.Dispatch()
+		assert.EqualError(t, err, "db error")
+	})
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"swapp-go/cmd/internal/application/ports"
+	"swapp-go/cmd/internal/domain"
+)
+
+var (
+	OutboxMessageNotFoundErr = errors.New("outbox message not found")
+	OutboxMessageNotDeadErr  = errors.New("only dead outbox messages can be replayed")
+	// OutboxMessageLinkDiscardedErr is returned for dead verification and
+	// password reset emails, whose link was discarded when they died.
+	OutboxMessageLinkDiscardedErr = errors.New("outbox message link was discarded")
+)
+
+// EmailOutboxService queues emails for the EmailDispatcher and lets admins
+// inspect and replay them. It implements ports.EmailService, so that services
+// without a unit of work send through the outbox as well.
+type EmailOutboxService struct {
+	repo  ports.OutboxRepository
+	clock ports.Clock
+}
+
+func NewEmailOutboxService(repo ports.OutboxRepository, clock ports.Clock) *EmailOutboxService {
+	return &EmailOutboxService{
+		repo:  repo,
+		clock: clock,
+	}
+}
+
+// SendEmail queues message for delivery. It only fails when the outbox cannot
+// be written.
+func (service *EmailOutboxService) SendEmail(message *domain.EmailMessage) error {
+	return service.repo.Enqueue(domain.NewOutboxMessage(*message, service.clock.Now()))
+}
+
+func (service *EmailOutboxService) ListMessages(query domain.OutboxQuery) ([]domain.OutboxMessage, error) {
+	if err := query.Normalize(); err != nil {
+		return nil, err
+	}
+
+	return service.repo.List(query)
+}
+
+func (service *EmailOutboxService) FindMessage(id uuid.UUID) (*domain.OutboxMessage, error) {
+	message, err := service.repo.FindByID(id)
+	if err != nil {
+		return nil, OutboxMessageNotFoundErr
+	}
+
+	return message, nil
+}
+
+// Replay gives a dead message a fresh set of delivery attempts, starting now.
+// Emails that are pointless without the link discarded when they died cannot
+// be replayed; the user has to ask for a new one.
+func (service *EmailOutboxService) Replay(id uuid.UUID) (*domain.OutboxMessage, error) {
+	message, err := service.FindMessage(id)
+	if err != nil {
+		return nil, err
+	}
+	if message.Template.NeedsSecretLink() {
+		return nil, OutboxMessageLinkDiscardedErr
+	}
+
+	requeued, err := service.repo.TryRequeue(id, service.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+	if !requeued {
+		return nil, OutboxMessageNotDeadErr
+	}
+
+	return service.repo.FindByID(id)
+}
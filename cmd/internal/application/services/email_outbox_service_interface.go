@@ -0,0 +1,12 @@
+package services
+
+import (
+	"github.com/google/uuid"
+	"swapp-go/cmd/internal/domain"
+)
+
+type EmailOutboxServiceInterface interface {
+	ListMessages(query domain.OutboxQuery) ([]domain.OutboxMessage, error)
+	FindMessage(id uuid.UUID) (*domain.OutboxMessage, error)
+	Replay(id uuid.UUID) (*domain.OutboxMessage, error)
+}
@@ -0,0 +1,110 @@
+package services_test
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	testMocks "swapp-go/cmd/internal/application/mocks"
+	"swapp-go/cmd/internal/application/services"
+	"swapp-go/cmd/internal/domain"
+	"testing"
+)
+
+func TestEmailOutboxService(t *testing.T) {
+	setup := func() (*services.EmailOutboxService, *testMocks.MockOutboxRepository) {
+		mockOutbox := new(testMocks.MockOutboxRepository)
+
+		return services.NewEmailOutboxService(mockOutbox, &testMocks.Clock{Current: testNow}), mockOutbox
+	}
+
+	t.Run("SendEmail queues the message", func(t *testing.T) {
+		service, mockOutbox := setup()
+
+		mockOutbox.On("Enqueue", mock.MatchedBy(func(message *domain.OutboxMessage) bool {
+			return message.Recipient == "user@example.com" &&
+				message.Status == domain.OutboxPending &&
+				message.NextAttemptAt.Equal(testNow)
+		})).Return(nil).Once()
+
+		err := service.SendEmail(&domain.EmailMessage{
+			Recipient: "user@example.com",
+			Template:  domain.EmailTemplatePasswordReset,
+			Data:      domain.EmailData{"Username": "user"},
+		})
+		assert.NoError(t, err)
+
+		mockOutbox.AssertExpectations(t)
+	})
+
+	t.Run("ListMessages rejects an unknown status", func(t *testing.T) {
+		service, mockOutbox := setup()
+
+		status := domain.OutboxStatus("lost")
+		_, err := service.ListMessages(domain.OutboxQuery{Status: &status})
+		assert.ErrorIs(t, err, domain.InvalidOutboxStatusErr)
+
+		mockOutbox.AssertNotCalled(t, "List", mock.Anything)
+	})
+
+	t.Run("Replay requeues a dead message", func(t *testing.T) {
+		service, mockOutbox := setup()
+
+		message := newDueOutboxMessage(3)
+		message.Template = domain.EmailTemplateSwapRequestAccepted
+		message.Status = domain.OutboxDead
+		requeued := message
+		requeued.Status = domain.OutboxPending
+		requeued.Attempts = 0
+
+		mockOutbox.On("FindByID", message.ID).Return(&message, nil).Once()
+		mockOutbox.On("TryRequeue", message.ID, testNow).Return(true, nil).Once()
+		mockOutbox.On("FindByID", message.ID).Return(&requeued, nil).Once()
+
+		replayed, err := service.Replay(message.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.OutboxPending, replayed.Status)
+	})
+
+	t.Run("Replay refuses messages that are not dead", func(t *testing.T) {
+		service, mockOutbox := setup()
+
+		message := newDueOutboxMessage(0)
+		message.Template = domain.EmailTemplateSwapRequestAccepted
+
+		mockOutbox.On("FindByID", message.ID).Return(&message, nil).Once()
+		mockOutbox.On("TryRequeue", message.ID, testNow).Return(false, nil).Once()
+
+		_, err := service.Replay(message.ID)
+		assert.ErrorIs(t, err, services.OutboxMessageNotDeadErr)
+	})
+
+	t.Run("Replay refuses emails whose link was discarded", func(t *testing.T) {
+		for _, template := range []domain.EmailTemplate{
+			domain.EmailTemplatePasswordReset,
+			domain.EmailTemplateEmailVerification,
+		} {
+			service, mockOutbox := setup()
+
+			message := newDueOutboxMessage(3)
+			message.Template = template
+			message.Status = domain.OutboxDead
+
+			mockOutbox.On("FindByID", message.ID).Return(&message, nil).Once()
+
+			_, err := service.Replay(message.ID)
+			assert.ErrorIs(t, err, services.OutboxMessageLinkDiscardedErr, template)
+			mockOutbox.AssertNotCalled(t, "TryRequeue", mock.Anything, mock.Anything)
+		}
+	})
+
+	t.Run("Replay unknown message", func(t *testing.T) {
+		service, mockOutbox := setup()
+
+		id := uuid.New()
+		mockOutbox.On("FindByID", id).Return(nil, errors.New("record not found")).Once()
+
+		_, err := service.Replay(id)
+		assert.ErrorIs(t, err, services.OutboxMessageNotFoundErr)
+	})
+}
@@ -0,0 +1,130 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"net/url"
+	"swapp-go/cmd/internal/application/ports"
+	"swapp-go/cmd/internal/domain"
+	"time"
+)
+
+var (
+	InvalidVerificationTokenErr = errors.New("invalid or expired verification token")
+	EmailAlreadyVerifiedErr     = errors.New("email address is already verified")
+	VerificationThrottledErr    = errors.New("a verification email was sent recently")
+)
+
+type EmailVerificationService struct {
+	userRepo       ports.UserRepository
+	signer         ports.LinkTokenSigner
+	emailService   ports.EmailService
+	clock          ports.Clock
+	linkURL        string
+	tokenTTL       time.Duration
+	resendInterval time.Duration
+}
+
+func NewEmailVerificationService(
+	userRepo ports.UserRepository,
+	signer ports.LinkTokenSigner,
+	emailService ports.EmailService,
+	clock ports.Clock,
+	linkURL string,
+	tokenTTL time.Duration,
+	resendInterval time.Duration,
+) *EmailVerificationService {
+	return &EmailVerificationService{
+		userRepo:       userRepo,
+		signer:         signer,
+		emailService:   emailService,
+		clock:          clock,
+		linkURL:        linkURL,
+		tokenTTL:       tokenTTL,
+		resendInterval: resendInterval,
+	}
+}
+
+// SendVerification mails a verification link for the user's current email
+// address. The token is bound to that address, so links sent before an email
+// change stop working.
+func (service *EmailVerificationService) SendVerification(user *domain.User) error {
+	now := service.clock.Now()
+
+	token, err := service.signer.SignLink(domain.LinkTokenClaims{
+		Purpose:   domain.EmailVerificationPurpose,
+		UserID:    user.ID,
+		Value:     user.Email,
+		ExpiresAt: now.Add(service.tokenTTL),
+	})
+	if err != nil {
+		return err
+	}
+
+	email := &domain.EmailMessage{
+		Recipient: user.Email,
+		Template:  domain.EmailTemplateEmailVerification,
+		Data: domain.EmailData{
+			"Username":        user.Username,
+			"VerificationURL": service.verificationLink(token),
+		},
+	}
+
+	if err = service.emailService.SendEmail(email); err != nil {
+		return err
+	}
+
+	if _, err = service.userRepo.Update(user.ID, map[string]interface{}{"verification_sent_at": now}); err != nil {
+		return err
+	}
+	user.VerificationSentAt = &now
+
+	return nil
+}
+
+// Resend mails a new verification link, at most once per resend interval.
+func (service *EmailVerificationService) Resend(userID uuid.UUID) error {
+	user, err := service.userRepo.FindByID(userID)
+	if err != nil {
+		return err
+	}
+
+	if user.IsEmailVerified() {
+		return EmailAlreadyVerifiedErr
+	}
+
+	if user.VerificationSentAt != nil && service.clock.Now().Before(user.VerificationSentAt.Add(service.resendInterval)) {
+		return VerificationThrottledErr
+	}
+
+	return service.SendVerification(user)
+}
+
+// Verify marks the email address a token was issued for as verified. Verifying
+// an address twice is not an error.
+func (service *EmailVerificationService) Verify(token string) (*domain.User, error) {
+	claims, err := service.signer.VerifyLink(domain.EmailVerificationPurpose, token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", InvalidVerificationTokenErr, err)
+	}
+
+	user, err := service.userRepo.FindByID(claims.UserID)
+	if err != nil {
+		return nil, InvalidVerificationTokenErr
+	}
+
+	if user.Email != claims.Value {
+		return nil, fmt.Errorf("%w: email address has changed", InvalidVerificationTokenErr)
+	}
+
+	if user.IsEmailVerified() {
+		return user, nil
+	}
+
+	return service.userRepo.Update(user.ID, map[string]interface{}{"email_verified_at": service.clock.Now()})
+}
+
+func (service *EmailVerificationService) verificationLink(token string) string {
+	return service.linkURL + "?token=" + url.QueryEscape(token)
+}
@@ -0,0 +1,12 @@
+package services
+
+import (
+	"github.com/google/uuid"
+	"swapp-go/cmd/internal/domain"
+)
+
+type EmailVerificationServiceInterface interface {
+	SendVerification(user *domain.User) error
+	Resend(userID uuid.UUID) error
+	Verify(token string) (*domain.User, error)
+}
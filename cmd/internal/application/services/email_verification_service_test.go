@@ -0,0 +1,181 @@
+package services_test
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	testMocks "swapp-go/cmd/internal/application/mocks"
+	"swapp-go/cmd/internal/application/services"
+	"swapp-go/cmd/internal/domain"
+	"testing"
+	"time"
+)
+
+const (
+	testVerificationURL    = "https://swapp.example/verify-email"
+	testVerificationTTL    = 48 * time.Hour
+	testVerificationResend = 5 * time.Minute
+)
+
+func setupEmailVerificationServiceTest() (
+	*services.EmailVerificationService,
+	*testMocks.MockUserRepository,
+	*testMocks.MockLinkTokenSigner,
+	*testMocks.MockEmailService,
+) {
+	mockUserRepo := new(testMocks.MockUserRepository)
+	mockSigner := new(testMocks.MockLinkTokenSigner)
+	mockEmailService := new(testMocks.MockEmailService)
+
+	service := services.NewEmailVerificationService(
+		mockUserRepo,
+		mockSigner,
+		mockEmailService,
+		&testMocks.Clock{Current: testNow},
+		testVerificationURL,
+		testVerificationTTL,
+		testVerificationResend,
+	)
+
+	return service, mockUserRepo, mockSigner, mockEmailService
+}
+
+func TestEmailVerificationService_SendVerification(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		service, mockUserRepo, mockSigner, mockEmailService := setupEmailVerificationServiceTest()
+		user := &domain.User{ID: uuid.New(), Username: "user", Email: "user@example.com"}
+
+		mockSigner.On("SignLink", domain.LinkTokenClaims{
+			Purpose:   domain.EmailVerificationPurpose,
+			UserID:    user.ID,
+			Value:     user.Email,
+			ExpiresAt: testNow.Add(testVerificationTTL),
+		}).Return("signed-token", nil).Once()
+		mockEmailService.On("SendEmail", mock.MatchedBy(func(message *domain.EmailMessage) bool {
+			return message.Recipient == user.Email &&
+				message.Template == domain.EmailTemplateEmailVerification &&
+				message.Data["VerificationURL"] == testVerificationURL+"?token=signed-token"
+		})).Return(nil).Once()
+		mockUserRepo.On("Update", user.ID, map[string]interface{}{"verification_sent_at": testNow}).Return(user, nil).Once()
+
+		err := service.SendVerification(user)
+		assert.NoError(t, err)
+		assert.Equal(t, testNow, *user.VerificationSentAt)
+
+		mockSigner.AssertExpectations(t)
+		mockEmailService.AssertExpectations(t)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("email failure is not recorded as sent", func(t *testing.T) {
+		service, mockUserRepo, mockSigner, mockEmailService := setupEmailVerificationServiceTest()
+		user := &domain.User{ID: uuid.New(), Email: "user@example.com"}
+
+		mockSigner.On("SignLink", mock.AnythingOfType("domain.LinkTokenClaims")).Return("signed-token", nil).Once()
+		mockEmailService.On("SendEmail", mock.AnythingOfType("*domain.EmailMessage")).Return(errors.New("smtp down")).Once()
+
+		err := service.SendVerification(user)
+		assert.EqualError(t, err, "smtp down")
+		assert.Nil(t, user.VerificationSentAt)
+		mockUserRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
+
+func TestEmailVerificationService_Resend(t *testing.T) {
+	userID := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		service, mockUserRepo, mockSigner, mockEmailService := setupEmailVerificationServiceTest()
+		sentAt := testNow.Add(-testVerificationResend)
+		user := &domain.User{ID: userID, Email: "user@example.com", VerificationSentAt: &sentAt}
+
+		mockUserRepo.On("FindByID", userID).Return(user, nil).Once()
+		mockSigner.On("SignLink", mock.AnythingOfType("domain.LinkTokenClaims")).Return("signed-token", nil).Once()
+		mockEmailService.On("SendEmail", mock.AnythingOfType("*domain.EmailMessage")).Return(nil).Once()
+		mockUserRepo.On("Update", userID, mock.Anything).Return(user, nil).Once()
+
+		err := service.Resend(userID)
+		assert.NoError(t, err)
+		mockEmailService.AssertExpectations(t)
+	})
+
+	t.Run("throttled", func(t *testing.T) {
+		service, mockUserRepo, _, mockEmailService := setupEmailVerificationServiceTest()
+		sentAt := testNow.Add(-time.Minute)
+
+		mockUserRepo.On("FindByID", userID).Return(&domain.User{ID: userID, VerificationSentAt: &sentAt}, nil).Once()
+
+		err := service.Resend(userID)
+		assert.ErrorIs(t, err, services.VerificationThrottledErr)
+		mockEmailService.AssertNotCalled(t, "SendEmail", mock.Anything)
+	})
+
+	t.Run("already verified", func(t *testing.T) {
+		service, mockUserRepo, _, mockEmailService := setupEmailVerificationServiceTest()
+		verifiedAt := testNow.Add(-time.Hour)
+
+		mockUserRepo.On("FindByID", userID).Return(&domain.User{ID: userID, EmailVerifiedAt: &verifiedAt}, nil).Once()
+
+		err := service.Resend(userID)
+		assert.ErrorIs(t, err, services.EmailAlreadyVerifiedErr)
+		mockEmailService.AssertNotCalled(t, "SendEmail", mock.Anything)
+	})
+}
+
+func TestEmailVerificationService_Verify(t *testing.T) {
+	userID := uuid.New()
+	claims := &domain.LinkTokenClaims{
+		Purpose: domain.EmailVerificationPurpose,
+		UserID:  userID,
+		Value:   "user@example.com",
+	}
+
+	t.Run("success", func(t *testing.T) {
+		service, mockUserRepo, mockSigner, _ := setupEmailVerificationServiceTest()
+		verifiedUser := &domain.User{ID: userID, Email: "user@example.com", EmailVerifiedAt: &testNow}
+
+		mockSigner.On("VerifyLink", domain.EmailVerificationPurpose, "signed-token").Return(claims, nil).Once()
+		mockUserRepo.On("FindByID", userID).Return(&domain.User{ID: userID, Email: "user@example.com"}, nil).Once()
+		mockUserRepo.On("Update", userID, map[string]interface{}{"email_verified_at": testNow}).Return(verifiedUser, nil).Once()
+
+		user, err := service.Verify("signed-token")
+		assert.NoError(t, err)
+		assert.True(t, user.IsEmailVerified())
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("already verified", func(t *testing.T) {
+		service, mockUserRepo, mockSigner, _ := setupEmailVerificationServiceTest()
+		verifiedAt := testNow.Add(-time.Hour)
+
+		mockSigner.On("VerifyLink", domain.EmailVerificationPurpose, "signed-token").Return(claims, nil).Once()
+		mockUserRepo.On("FindByID", userID).Return(&domain.User{ID: userID, Email: "user@example.com", EmailVerifiedAt: &verifiedAt}, nil).Once()
+
+		user, err := service.Verify("signed-token")
+		assert.NoError(t, err)
+		assert.Equal(t, verifiedAt, *user.EmailVerifiedAt)
+		mockUserRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		service, mockUserRepo, mockSigner, _ := setupEmailVerificationServiceTest()
+
+		mockSigner.On("VerifyLink", domain.EmailVerificationPurpose, "forged").Return(nil, domain.InvalidLinkTokenErr).Once()
+
+		_, err := service.Verify("forged")
+		assert.ErrorIs(t, err, services.InvalidVerificationTokenErr)
+		mockUserRepo.AssertNotCalled(t, "FindByID", mock.Anything)
+	})
+
+	t.Run("email changed since the token was sent", func(t *testing.T) {
+		service, mockUserRepo, mockSigner, _ := setupEmailVerificationServiceTest()
+
+		mockSigner.On("VerifyLink", domain.EmailVerificationPurpose, "signed-token").Return(claims, nil).Once()
+		mockUserRepo.On("FindByID", userID).Return(&domain.User{ID: userID, Email: "new@example.com"}, nil).Once()
+
+		_, err := service.Verify("signed-token")
+		assert.ErrorIs(t, err, services.InvalidVerificationTokenErr)
+		mockUserRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
@@ -1,41 +1,257 @@
 package services
 
 import (
+	"bytes"
+	"fmt"
 	"github.com/google/uuid"
+	"log"
 	"swapp-go/cmd/internal/application/ports"
 	"swapp-go/cmd/internal/domain"
 )
 
 type ItemService struct {
-	repo ports.ItemRepository
+	repo           ports.ItemRepository
+	categoryRepo   ports.CategoryRepository
+	blobStore      ports.BlobStore
+	imageProcessor ports.ImageProcessor
 }
 
-func NewItemService(repo ports.ItemRepository) *ItemService {
-	return &ItemService{repo: repo}
+func NewItemService(repo ports.ItemRepository, categoryRepo ports.CategoryRepository, blobStore ports.BlobStore, imageProcessor ports.ImageProcessor) *ItemService {
+	return &ItemService{repo: repo, categoryRepo: categoryRepo, blobStore: blobStore, imageProcessor: imageProcessor}
 }
 
-func (itemService *ItemService) Create(item *domain.Item) error {
-	return itemService.repo.Create(item)
+// Create stores the uploaded pictures and then the item. The pictures are
+// discarded again if the item cannot be created.
+func (itemService *ItemService) Create(item *domain.Item, uploads []domain.PictureUpload) error {
+	if err := itemService.validateCategory(item.CategorySlug); err != nil {
+		return err
+	}
+
+	if len(item.Pictures)+len(uploads) > domain.MaxItemPictures {
+		return domain.TooManyItemPicturesErr
+	}
+
+	if item.ID == uuid.Nil {
+		item.ID = uuid.New()
+	}
+
+	pictures, err := itemService.storePictures(item.ID, uploads)
+	if err != nil {
+		return err
+	}
+
+	if err = item.AddPictures(pictures); err != nil {
+		itemService.discardPictures(pictures)
+		return err
+	}
+
+	if err = itemService.repo.Create(item); err != nil {
+		itemService.discardPictures(pictures)
+		return err
+	}
+
+	return nil
 }
 
-func (itemService *ItemService) Update(id uuid.UUID, fields map[string]interface{}) (*domain.Item, error) {
-	_, err := itemService.repo.FindByID(id)
+// Update changes the given fields and appends the uploaded pictures after the
+// existing ones.
+func (itemService *ItemService) Update(id uuid.UUID, fields map[string]interface{}, uploads []domain.PictureUpload) (*domain.Item, error) {
+	item, err := itemService.repo.FindByID(id)
 	if err != nil {
 		return nil, err
 	}
 
+	if categorySlug, ok := fields["category_slug"].(string); ok {
+		if err = itemService.validateCategory(categorySlug); err != nil {
+			return nil, err
+		}
+	}
+
+	var pictures []domain.ItemPicture
+	if len(uploads) > 0 {
+		if len(item.Pictures)+len(uploads) > domain.MaxItemPictures {
+			return nil, domain.TooManyItemPicturesErr
+		}
+
+		if pictures, err = itemService.storePictures(id, uploads); err != nil {
+			return nil, err
+		}
+
+		if err = item.AddPictures(pictures); err != nil {
+			itemService.discardPictures(pictures)
+			return nil, err
+		}
+		fields["pictures"] = item.Pictures
+		fields["picture_url"] = item.PictureURL
+	}
+
 	updatedItem, err := itemService.repo.Update(id, fields)
 	if err != nil {
+		itemService.discardPictures(pictures)
 		return nil, err
 	}
 
 	return updatedItem, nil
 }
 
+// Delete removes the item and then the stored files of its pictures.
 func (itemService *ItemService) Delete(id uuid.UUID) error {
-	return itemService.repo.Delete(id)
+	item, err := itemService.repo.FindByID(id)
+	if err != nil {
+		return err
+	}
+
+	if err = itemService.repo.Delete(id); err != nil {
+		return err
+	}
+
+	itemService.discardPictures(item.Pictures)
+
+	return nil
+}
+
+// ReorderPictures sets the display order of the item's pictures and, unless
+// primaryID is uuid.Nil, its primary picture.
+func (itemService *ItemService) ReorderPictures(itemID uuid.UUID, pictureIDs []uuid.UUID, primaryID uuid.UUID) (*domain.Item, error) {
+	item, err := itemService.repo.FindByID(itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = item.ReorderPictures(pictureIDs, primaryID); err != nil {
+		return nil, err
+	}
+
+	return itemService.savePictures(item)
+}
+
+// DeletePicture removes a picture from the item, discards its stored file and
+// returns it.
+func (itemService *ItemService) DeletePicture(itemID uuid.UUID, pictureID uuid.UUID) (*domain.ItemPicture, error) {
+	item, err := itemService.repo.FindByID(itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	picture, err := item.RemovePicture(pictureID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = itemService.savePictures(item); err != nil {
+		return nil, err
+	}
+
+	itemService.discardPictures([]domain.ItemPicture{*picture})
+
+	return picture, nil
 }
 
 func (itemService *ItemService) FindByID(id uuid.UUID) (*domain.Item, error) {
 	return itemService.repo.FindByID(id)
 }
+
+func (itemService *ItemService) ListCategories() ([]domain.Category, error) {
+	return itemService.categoryRepo.List()
+}
+
+func (itemService *ItemService) Search(query domain.ItemSearchQuery) (*domain.ItemSearchResult, error) {
+	if err := query.Normalize(); err != nil {
+		return nil, err
+	}
+
+	return itemService.repo.Search(query)
+}
+
+// storePictures validates and normalizes every upload, then puts the resulting
+// picture and its variants in the blob store and returns the matching pictures.
+// All uploads are processed before anything is stored, so an invalid upload
+// leaves no files behind. Nothing is kept if any of the writes fails.
+func (itemService *ItemService) storePictures(itemID uuid.UUID, uploads []domain.PictureUpload) ([]domain.ItemPicture, error) {
+	processedImages := make([]*domain.ProcessedImage, 0, len(uploads))
+	for _, upload := range uploads {
+		processed, err := itemService.imageProcessor.Process(upload)
+		if err != nil {
+			return nil, err
+		}
+		processedImages = append(processedImages, processed)
+	}
+
+	pictures := make([]domain.ItemPicture, 0, len(processedImages))
+	for _, processed := range processedImages {
+		picture := domain.ItemPicture{ID: uuid.New(), ItemID: itemID}
+		pictures = append(pictures, picture)
+		stored := &pictures[len(pictures)-1]
+
+		key := fmt.Sprintf("items/%s/%s%s", itemID, picture.ID, processed.Extension)
+		if err := itemService.putImage(key, processed.ContentType, processed.Picture); err != nil {
+			itemService.discardPictures(pictures)
+			return nil, err
+		}
+		stored.URL = itemService.blobStore.URL(key)
+		stored.StorageKey = key
+		stored.Width = processed.Picture.Width
+		stored.Height = processed.Picture.Height
+
+		for _, variant := range processed.Variants {
+			key = fmt.Sprintf("items/%s/%s_%s%s", itemID, picture.ID, variant.Name, processed.Extension)
+			if err := itemService.putImage(key, processed.ContentType, variant); err != nil {
+				itemService.discardPictures(pictures)
+				return nil, err
+			}
+			stored.Variants = append(stored.Variants, domain.PictureVariant{
+				Name:       variant.Name,
+				URL:        itemService.blobStore.URL(key),
+				StorageKey: key,
+				Width:      variant.Width,
+				Height:     variant.Height,
+			})
+		}
+	}
+
+	return pictures, nil
+}
+
+func (itemService *ItemService) putImage(key string, contentType string, image domain.EncodedImage) error {
+	return itemService.blobStore.Put(key, bytes.NewReader(image.Content), int64(len(image.Content)), contentType)
+}
+
+// discardPictures deletes the stored files of the pictures and their variants.
+// Failures are only logged since the pictures are no longer referenced.
+func (itemService *ItemService) discardPictures(pictures []domain.ItemPicture) {
+	for _, picture := range pictures {
+		keys := []string{picture.StorageKey}
+		for _, variant := range picture.Variants {
+			keys = append(keys, variant.StorageKey)
+		}
+
+		for _, key := range keys {
+			if key == "" {
+				continue
+			}
+
+			if err := itemService.blobStore.Delete(key); err != nil {
+				log.Printf("Failed to delete stored picture %s: %v", key, err)
+			}
+		}
+	}
+}
+
+func (itemService *ItemService) savePictures(item *domain.Item) (*domain.Item, error) {
+	return itemService.repo.Update(item.ID, map[string]interface{}{
+		"pictures":    item.Pictures,
+		"picture_url": item.PictureURL,
+	})
+}
+
+func (itemService *ItemService) validateCategory(slug string) error {
+	if slug == "" {
+		return nil
+	}
+
+	if _, err := itemService.categoryRepo.FindBySlug(slug); err != nil {
+		return domain.UnknownCategoryErr
+	}
+
+	return nil
+}
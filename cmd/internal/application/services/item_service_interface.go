@@ -6,8 +6,12 @@ import (
 )
 
 type ItemServiceInterface interface {
-	Create(item *domain.Item) error
-	Update(id uuid.UUID, fields map[string]interface{}) (*domain.Item, error)
+	Create(item *domain.Item, uploads []domain.PictureUpload) error
+	Update(id uuid.UUID, fields map[string]interface{}, uploads []domain.PictureUpload) (*domain.Item, error)
 	Delete(id uuid.UUID) error
+	ReorderPictures(itemID uuid.UUID, pictureIDs []uuid.UUID, primaryID uuid.UUID) (*domain.Item, error)
+	DeletePicture(itemID uuid.UUID, pictureID uuid.UUID) (*domain.ItemPicture, error)
 	FindByID(id uuid.UUID) (*domain.Item, error)
+	Search(query domain.ItemSearchQuery) (*domain.ItemSearchResult, error)
+	ListCategories() ([]domain.Category, error)
 }
@@ -2,11 +2,14 @@ package services_test
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 	"swapp-go/cmd/internal/application/mocks"
 	"testing"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"swapp-go/cmd/internal/application/services"
 	"swapp-go/cmd/internal/domain"
 )
@@ -22,22 +25,61 @@ func Item(id uuid.UUID) *domain.Item {
 	}
 }
 
+func itemWithPictures(id uuid.UUID, count int) *domain.Item {
+	item := Item(id)
+	for i := 0; i < count; i++ {
+		item.Pictures = append(item.Pictures, domain.ItemPicture{
+			ID:         uuid.New(),
+			ItemID:     id,
+			URL:        fmt.Sprintf("/uploads/%d.jpg", i),
+			StorageKey: fmt.Sprintf("items/%s/%d.jpg", id, i),
+			Position:   i,
+			IsPrimary:  i == 0,
+		})
+	}
+	if count > 0 {
+		item.PictureURL = item.Pictures[0].URL
+	}
+
+	return item
+}
+
+func pictureUpload(filename string) domain.PictureUpload {
+	return domain.PictureUpload{
+		Filename:    filename,
+		ContentType: "image/jpeg",
+		Size:        5,
+		Content:     strings.NewReader(filename),
+	}
+}
+
+func processedImage(content string) *domain.ProcessedImage {
+	return &domain.ProcessedImage{
+		ContentType: "image/jpeg",
+		Extension:   ".jpg",
+		Picture:     domain.EncodedImage{Width: 800, Height: 600, Content: []byte(content)},
+		Variants: []domain.EncodedImage{
+			{Name: "thumbnail", Width: 240, Height: 180, Content: []byte(content + "_thumbnail")},
+		},
+	}
+}
+
 func TestItemService(t *testing.T) {
 	t.Run("CreateItem_Success", func(t *testing.T) {
 		mockRepo := new(mocks.ItemRepository)
-		service := services.NewItemService(mockRepo)
+		service := services.NewItemService(mockRepo, new(mocks.CategoryRepository), new(mocks.BlobStore), new(mocks.ImageProcessor))
 
 		item := Item(uuid.New())
 		mockRepo.On("Create", item).Return(nil)
 
-		err := service.Create(item)
+		err := service.Create(item, nil)
 		assert.NoError(t, err)
 		mockRepo.AssertExpectations(t)
 	})
 
 	t.Run("UpdateItem_Success", func(t *testing.T) {
 		mockRepo := new(mocks.ItemRepository)
-		service := services.NewItemService(mockRepo)
+		service := services.NewItemService(mockRepo, new(mocks.CategoryRepository), new(mocks.BlobStore), new(mocks.ImageProcessor))
 
 		itemID := uuid.New()
 		fields := map[string]interface{}{"name": "Updated"}
@@ -46,7 +88,7 @@ func TestItemService(t *testing.T) {
 		mockRepo.On("FindByID", itemID).Return(item, nil)
 		mockRepo.On("Update", itemID, fields).Return(item, nil)
 
-		updated, err := service.Update(itemID, fields)
+		updated, err := service.Update(itemID, fields, nil)
 		assert.NoError(t, err)
 		assert.Equal(t, item, updated)
 
@@ -55,14 +97,14 @@ func TestItemService(t *testing.T) {
 
 	t.Run("UpdateItem_NotFound", func(t *testing.T) {
 		mockRepo := new(mocks.ItemRepository)
-		service := services.NewItemService(mockRepo)
+		service := services.NewItemService(mockRepo, new(mocks.CategoryRepository), new(mocks.BlobStore), new(mocks.ImageProcessor))
 
 		itemID := uuid.New()
 		fields := map[string]interface{}{"name": "Doesn't matter"}
 
 		mockRepo.On("FindByID", itemID).Return((*domain.Item)(nil), errors.New("not found"))
 
-		item, err := service.Update(itemID, fields)
+		item, err := service.Update(itemID, fields, nil)
 		assert.Nil(t, item)
 		assert.Error(t, err)
 
@@ -71,19 +113,25 @@ func TestItemService(t *testing.T) {
 
 	t.Run("DeleteItem_Success", func(t *testing.T) {
 		mockRepo := new(mocks.ItemRepository)
-		service := services.NewItemService(mockRepo)
+		mockBlobStore := new(mocks.BlobStore)
+		service := services.NewItemService(mockRepo, new(mocks.CategoryRepository), mockBlobStore, new(mocks.ImageProcessor))
 
 		itemID := uuid.New()
+		item := itemWithPictures(itemID, 2)
+		mockRepo.On("FindByID", itemID).Return(item, nil)
 		mockRepo.On("Delete", itemID).Return(nil)
+		mockBlobStore.On("Delete", item.Pictures[0].StorageKey).Return(nil)
+		mockBlobStore.On("Delete", item.Pictures[1].StorageKey).Return(errors.New("already gone"))
 
 		err := service.Delete(itemID)
 		assert.NoError(t, err)
 		mockRepo.AssertExpectations(t)
+		mockBlobStore.AssertExpectations(t)
 	})
 
 	t.Run("GetItemByID_Success", func(t *testing.T) {
 		mockRepo := new(mocks.ItemRepository)
-		service := services.NewItemService(mockRepo)
+		service := services.NewItemService(mockRepo, new(mocks.CategoryRepository), new(mocks.BlobStore), new(mocks.ImageProcessor))
 
 		itemID := uuid.New()
 		item := Item(itemID)
@@ -95,4 +143,371 @@ func TestItemService(t *testing.T) {
 		assert.Equal(t, item, result)
 		mockRepo.AssertExpectations(t)
 	})
+	t.Run("Search_AppliesDefaults", func(t *testing.T) {
+		mockRepo := new(mocks.ItemRepository)
+		service := services.NewItemService(mockRepo, new(mocks.CategoryRepository), new(mocks.BlobStore), new(mocks.ImageProcessor))
+
+		expected := &domain.ItemSearchResult{Items: []domain.Item{*Item(uuid.New())}}
+		mockRepo.On("Search", domain.ItemSearchQuery{
+			Keywords: "bike",
+			Sort:     domain.ItemSortNewest,
+			Limit:    domain.DefaultItemSearchLimit,
+		}).Return(expected, nil)
+
+		result, err := service.Search(domain.ItemSearchQuery{Keywords: "bike"})
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Search_InvalidSort", func(t *testing.T) {
+		mockRepo := new(mocks.ItemRepository)
+		service := services.NewItemService(mockRepo, new(mocks.CategoryRepository), new(mocks.BlobStore), new(mocks.ImageProcessor))
+
+		_, err := service.Search(domain.ItemSearchQuery{Sort: "price"})
+		assert.ErrorIs(t, err, domain.InvalidItemSortErr)
+		mockRepo.AssertNotCalled(t, "Search", mock.Anything)
+	})
+
+	t.Run("CreateItem_UnknownCategory", func(t *testing.T) {
+		mockRepo := new(mocks.ItemRepository)
+		mockCategoryRepo := new(mocks.CategoryRepository)
+		service := services.NewItemService(mockRepo, mockCategoryRepo, new(mocks.BlobStore), new(mocks.ImageProcessor))
+
+		item := Item(uuid.New())
+		item.CategorySlug = "spaceships"
+		mockCategoryRepo.On("FindBySlug", "spaceships").Return(nil, errors.New("not found"))
+
+		err := service.Create(item, nil)
+		assert.ErrorIs(t, err, domain.UnknownCategoryErr)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("CreateItem_KnownCategory", func(t *testing.T) {
+		mockRepo := new(mocks.ItemRepository)
+		mockCategoryRepo := new(mocks.CategoryRepository)
+		service := services.NewItemService(mockRepo, mockCategoryRepo, new(mocks.BlobStore), new(mocks.ImageProcessor))
+
+		item := Item(uuid.New())
+		item.CategorySlug = "bikes"
+		mockCategoryRepo.On("FindBySlug", "bikes").Return(&domain.Category{Slug: "bikes"}, nil)
+		mockRepo.On("Create", item).Return(nil)
+
+		err := service.Create(item, nil)
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("UpdateItem_UnknownCategory", func(t *testing.T) {
+		mockRepo := new(mocks.ItemRepository)
+		mockCategoryRepo := new(mocks.CategoryRepository)
+		service := services.NewItemService(mockRepo, mockCategoryRepo, new(mocks.BlobStore), new(mocks.ImageProcessor))
+
+		itemID := uuid.New()
+		mockRepo.On("FindByID", itemID).Return(Item(itemID), nil)
+		mockCategoryRepo.On("FindBySlug", "spaceships").Return(nil, errors.New("not found"))
+
+		_, err := service.Update(itemID, map[string]interface{}{"category_slug": "spaceships"}, nil)
+		assert.ErrorIs(t, err, domain.UnknownCategoryErr)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("ListCategories", func(t *testing.T) {
+		mockCategoryRepo := new(mocks.CategoryRepository)
+		service := services.NewItemService(new(mocks.ItemRepository), mockCategoryRepo, new(mocks.BlobStore), new(mocks.ImageProcessor))
+
+		categories := []domain.Category{{Slug: "electronics", Name: "Electronics"}}
+		mockCategoryRepo.On("List").Return(categories, nil)
+
+		result, err := service.ListCategories()
+		assert.NoError(t, err)
+		assert.Equal(t, categories, result)
+	})
+
+	t.Run("CreateItem_StoresPictures", func(t *testing.T) {
+		mockRepo := new(mocks.ItemRepository)
+		mockBlobStore := new(mocks.BlobStore)
+		mockImageProcessor := new(mocks.ImageProcessor)
+		service := services.NewItemService(mockRepo, new(mocks.CategoryRepository), mockBlobStore, mockImageProcessor)
+
+		item := Item(uuid.Nil)
+		uploads := []domain.PictureUpload{
+			pictureUpload("front.JPG"),
+			pictureUpload("back.png"),
+		}
+
+		mockImageProcessor.On("Process", uploads[0]).Return(processedImage("front"), nil)
+		mockImageProcessor.On("Process", uploads[1]).Return(processedImage("back"), nil)
+		mockBlobStore.On("Put", mock.MatchedBy(func(key string) bool {
+			return strings.HasPrefix(key, "items/") && strings.HasSuffix(key, ".jpg")
+		}), mock.Anything, mock.Anything, "image/jpeg").Return(nil)
+		mockBlobStore.On("URL", mock.Anything).Return("https://files.test/picture")
+		mockRepo.On("Create", item).Return(nil)
+
+		err := service.Create(item, uploads)
+		assert.NoError(t, err)
+		assert.NotEqual(t, uuid.Nil, item.ID)
+		assert.Len(t, item.Pictures, 2)
+		assert.Equal(t, "https://files.test/picture", item.PictureURL)
+		assert.True(t, item.Pictures[0].IsPrimary)
+		assert.Equal(t, 1, item.Pictures[1].Position)
+		assert.Equal(t, 800, item.Pictures[0].Width)
+		assert.Equal(t, 600, item.Pictures[0].Height)
+		assert.Equal(t, fmt.Sprintf("items/%s/%s.jpg", item.ID, item.Pictures[0].ID), item.Pictures[0].StorageKey)
+		assert.Len(t, item.Pictures[0].Variants, 1)
+		assert.Equal(t, "thumbnail", item.Pictures[0].Variants[0].Name)
+		assert.Equal(t, 240, item.Pictures[0].Variants[0].Width)
+		assert.Equal(t, fmt.Sprintf("items/%s/%s_thumbnail.jpg", item.ID, item.Pictures[0].ID), item.Pictures[0].Variants[0].StorageKey)
+		mockBlobStore.AssertNumberOfCalls(t, "Put", 4)
+		mockBlobStore.AssertCalled(t, "Put", item.Pictures[0].StorageKey, mock.Anything, int64(len("front")), "image/jpeg")
+		mockBlobStore.AssertCalled(t, "Put", item.Pictures[1].Variants[0].StorageKey, mock.Anything, int64(len("back_thumbnail")), "image/jpeg")
+	})
+
+	t.Run("CreateItem_RejectsInvalidPicture", func(t *testing.T) {
+		mockRepo := new(mocks.ItemRepository)
+		mockBlobStore := new(mocks.BlobStore)
+		mockImageProcessor := new(mocks.ImageProcessor)
+		service := services.NewItemService(mockRepo, new(mocks.CategoryRepository), mockBlobStore, mockImageProcessor)
+
+		uploads := []domain.PictureUpload{pictureUpload("front.jpg"), pictureUpload("notes.txt")}
+		mockImageProcessor.On("Process", uploads[0]).Return(processedImage("front"), nil)
+		mockImageProcessor.On("Process", uploads[1]).Return(nil, domain.UnsupportedImageTypeErr)
+
+		err := service.Create(Item(uuid.New()), uploads)
+		assert.ErrorIs(t, err, domain.UnsupportedImageTypeErr)
+		mockBlobStore.AssertNotCalled(t, "Put", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("CreateItem_DiscardsPicturesOnFailure", func(t *testing.T) {
+		mockRepo := new(mocks.ItemRepository)
+		mockBlobStore := new(mocks.BlobStore)
+		mockImageProcessor := new(mocks.ImageProcessor)
+		service := services.NewItemService(mockRepo, new(mocks.CategoryRepository), mockBlobStore, mockImageProcessor)
+
+		item := Item(uuid.New())
+		var storedKeys []string
+
+		mockImageProcessor.On("Process", mock.Anything).Return(processedImage("front"), nil)
+		mockBlobStore.On("Put", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) { storedKeys = append(storedKeys, args.String(0)) }).
+			Return(nil)
+		mockBlobStore.On("URL", mock.Anything).Return("https://files.test/picture")
+		mockBlobStore.On("Delete", mock.Anything).Return(nil)
+		mockRepo.On("Create", item).Return(errors.New("db error"))
+
+		err := service.Create(item, []domain.PictureUpload{pictureUpload("front.jpg")})
+		assert.Error(t, err)
+		assert.Len(t, storedKeys, 2)
+		for _, key := range storedKeys {
+			mockBlobStore.AssertCalled(t, "Delete", key)
+		}
+	})
+
+	t.Run("CreateItem_StoreFailure", func(t *testing.T) {
+		mockRepo := new(mocks.ItemRepository)
+		mockBlobStore := new(mocks.BlobStore)
+		mockImageProcessor := new(mocks.ImageProcessor)
+		service := services.NewItemService(mockRepo, new(mocks.CategoryRepository), mockBlobStore, mockImageProcessor)
+
+		item := Item(uuid.New())
+		uploads := []domain.PictureUpload{pictureUpload("front.jpg"), pictureUpload("back.jpg")}
+		var storedKeys []string
+
+		mockImageProcessor.On("Process", uploads[0]).Return(processedImage("front"), nil)
+		mockImageProcessor.On("Process", uploads[1]).Return(processedImage("back"), nil)
+		mockBlobStore.On("Put", mock.Anything, mock.Anything, int64(len("back")), mock.Anything).
+			Return(errors.New("bucket unavailable"))
+		mockBlobStore.On("Put", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) { storedKeys = append(storedKeys, args.String(0)) }).
+			Return(nil)
+		mockBlobStore.On("URL", mock.Anything).Return("https://files.test/picture")
+		mockBlobStore.On("Delete", mock.Anything).Return(nil)
+
+		err := service.Create(item, uploads)
+		assert.EqualError(t, err, "bucket unavailable")
+		assert.Len(t, storedKeys, 2)
+		for _, key := range storedKeys {
+			mockBlobStore.AssertCalled(t, "Delete", key)
+		}
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("CreateItem_TooManyPictures", func(t *testing.T) {
+		mockRepo := new(mocks.ItemRepository)
+		mockBlobStore := new(mocks.BlobStore)
+		service := services.NewItemService(mockRepo, new(mocks.CategoryRepository), mockBlobStore, new(mocks.ImageProcessor))
+
+		uploads := make([]domain.PictureUpload, domain.MaxItemPictures+1)
+
+		err := service.Create(Item(uuid.New()), uploads)
+		assert.ErrorIs(t, err, domain.TooManyItemPicturesErr)
+		mockBlobStore.AssertNotCalled(t, "Put", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("UpdateItem_AppendsPictures", func(t *testing.T) {
+		mockRepo := new(mocks.ItemRepository)
+		mockBlobStore := new(mocks.BlobStore)
+		mockImageProcessor := new(mocks.ImageProcessor)
+		service := services.NewItemService(mockRepo, new(mocks.CategoryRepository), mockBlobStore, mockImageProcessor)
+
+		itemID := uuid.New()
+		item := itemWithPictures(itemID, 2)
+
+		mockImageProcessor.On("Process", mock.Anything).Return(processedImage("new"), nil)
+		mockBlobStore.On("Put", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		mockBlobStore.On("URL", mock.Anything).Return("/uploads/new.jpg")
+		mockRepo.On("FindByID", itemID).Return(item, nil)
+		mockRepo.On("Update", itemID, mock.MatchedBy(func(fields map[string]interface{}) bool {
+			pictures, ok := fields["pictures"].([]domain.ItemPicture)
+			return ok && len(pictures) == 3 &&
+				pictures[2].URL == "/uploads/new.jpg" && pictures[2].Position == 2 && !pictures[2].IsPrimary &&
+				len(pictures[2].Variants) == 1 &&
+				fields["picture_url"] == "/uploads/0.jpg" &&
+				fields["name"] == "Updated"
+		})).Return(item, nil)
+
+		_, err := service.Update(itemID, map[string]interface{}{"name": "Updated"}, []domain.PictureUpload{pictureUpload("new.jpg")})
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("UpdateItem_TooManyPictures", func(t *testing.T) {
+		mockRepo := new(mocks.ItemRepository)
+		mockBlobStore := new(mocks.BlobStore)
+		service := services.NewItemService(mockRepo, new(mocks.CategoryRepository), mockBlobStore, new(mocks.ImageProcessor))
+
+		itemID := uuid.New()
+		mockRepo.On("FindByID", itemID).Return(itemWithPictures(itemID, domain.MaxItemPictures), nil)
+
+		_, err := service.Update(itemID, map[string]interface{}{}, []domain.PictureUpload{pictureUpload("new.jpg")})
+		assert.ErrorIs(t, err, domain.TooManyItemPicturesErr)
+		mockBlobStore.AssertNotCalled(t, "Put", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("ReorderPictures_Success", func(t *testing.T) {
+		mockRepo := new(mocks.ItemRepository)
+		service := services.NewItemService(mockRepo, new(mocks.CategoryRepository), new(mocks.BlobStore), new(mocks.ImageProcessor))
+
+		itemID := uuid.New()
+		item := itemWithPictures(itemID, 3)
+		first, second, third := item.Pictures[0].ID, item.Pictures[1].ID, item.Pictures[2].ID
+
+		mockRepo.On("FindByID", itemID).Return(item, nil)
+		mockRepo.On("Update", itemID, mock.MatchedBy(func(fields map[string]interface{}) bool {
+			pictures := fields["pictures"].([]domain.ItemPicture)
+			return pictures[0].ID == third && pictures[1].ID == first && pictures[2].ID == second &&
+				pictures[1].IsPrimary && fields["picture_url"] == "/uploads/0.jpg"
+		})).Return(item, nil)
+
+		_, err := service.ReorderPictures(itemID, []uuid.UUID{third, first, second}, uuid.Nil)
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("ReorderPictures_NewPrimary", func(t *testing.T) {
+		mockRepo := new(mocks.ItemRepository)
+		service := services.NewItemService(mockRepo, new(mocks.CategoryRepository), new(mocks.BlobStore), new(mocks.ImageProcessor))
+
+		itemID := uuid.New()
+		item := itemWithPictures(itemID, 2)
+		first, second := item.Pictures[0].ID, item.Pictures[1].ID
+
+		mockRepo.On("FindByID", itemID).Return(item, nil)
+		mockRepo.On("Update", itemID, mock.MatchedBy(func(fields map[string]interface{}) bool {
+			pictures := fields["pictures"].([]domain.ItemPicture)
+			return !pictures[0].IsPrimary && pictures[1].IsPrimary && fields["picture_url"] == "/uploads/1.jpg"
+		})).Return(item, nil)
+
+		_, err := service.ReorderPictures(itemID, []uuid.UUID{first, second}, second)
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("ReorderPictures_InvalidOrder", func(t *testing.T) {
+		itemID := uuid.New()
+		item := itemWithPictures(itemID, 2)
+		first, second := item.Pictures[0].ID, item.Pictures[1].ID
+
+		invalidOrders := [][]uuid.UUID{
+			{first},
+			{first, first},
+			{first, uuid.New()},
+			{first, second, uuid.New()},
+		}
+
+		for _, order := range invalidOrders {
+			mockRepo := new(mocks.ItemRepository)
+			service := services.NewItemService(mockRepo, new(mocks.CategoryRepository), new(mocks.BlobStore), new(mocks.ImageProcessor))
+			mockRepo.On("FindByID", itemID).Return(itemWithPictures(itemID, 2), nil)
+
+			_, err := service.ReorderPictures(itemID, order, uuid.Nil)
+			assert.ErrorIs(t, err, domain.InvalidPictureOrderErr)
+			mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		}
+
+		mockRepo := new(mocks.ItemRepository)
+		service := services.NewItemService(mockRepo, new(mocks.CategoryRepository), new(mocks.BlobStore), new(mocks.ImageProcessor))
+		mockRepo.On("FindByID", itemID).Return(item, nil)
+
+		_, err := service.ReorderPictures(itemID, []uuid.UUID{first, second}, uuid.New())
+		assert.ErrorIs(t, err, domain.ItemPictureNotFoundErr)
+	})
+
+	t.Run("DeletePicture_PromotesNextPrimary", func(t *testing.T) {
+		mockRepo := new(mocks.ItemRepository)
+		mockBlobStore := new(mocks.BlobStore)
+		service := services.NewItemService(mockRepo, new(mocks.CategoryRepository), mockBlobStore, new(mocks.ImageProcessor))
+
+		itemID := uuid.New()
+		item := itemWithPictures(itemID, 3)
+		primaryID := item.Pictures[0].ID
+		mockBlobStore.On("Delete", item.Pictures[0].StorageKey).Return(nil)
+
+		mockRepo.On("FindByID", itemID).Return(item, nil)
+		mockRepo.On("Update", itemID, mock.MatchedBy(func(fields map[string]interface{}) bool {
+			pictures := fields["pictures"].([]domain.ItemPicture)
+			return len(pictures) == 2 && pictures[0].IsPrimary && pictures[0].Position == 0 &&
+				pictures[1].Position == 1 && fields["picture_url"] == "/uploads/1.jpg"
+		})).Return(item, nil)
+
+		picture, err := service.DeletePicture(itemID, primaryID)
+		assert.NoError(t, err)
+		assert.Equal(t, "/uploads/0.jpg", picture.URL)
+		mockRepo.AssertExpectations(t)
+		mockBlobStore.AssertExpectations(t)
+	})
+
+	t.Run("DeletePicture_LastPicture", func(t *testing.T) {
+		mockRepo := new(mocks.ItemRepository)
+		mockBlobStore := new(mocks.BlobStore)
+		service := services.NewItemService(mockRepo, new(mocks.CategoryRepository), mockBlobStore, new(mocks.ImageProcessor))
+
+		itemID := uuid.New()
+		item := itemWithPictures(itemID, 1)
+		mockBlobStore.On("Delete", item.Pictures[0].StorageKey).Return(nil)
+
+		mockRepo.On("FindByID", itemID).Return(item, nil)
+		mockRepo.On("Update", itemID, mock.MatchedBy(func(fields map[string]interface{}) bool {
+			return len(fields["pictures"].([]domain.ItemPicture)) == 0 && fields["picture_url"] == ""
+		})).Return(item, nil)
+
+		_, err := service.DeletePicture(itemID, item.Pictures[0].ID)
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("DeletePicture_NotFound", func(t *testing.T) {
+		mockRepo := new(mocks.ItemRepository)
+		service := services.NewItemService(mockRepo, new(mocks.CategoryRepository), new(mocks.BlobStore), new(mocks.ImageProcessor))
+
+		itemID := uuid.New()
+		mockRepo.On("FindByID", itemID).Return(itemWithPictures(itemID, 1), nil)
+
+		_, err := service.DeletePicture(itemID, uuid.New())
+		assert.ErrorIs(t, err, domain.ItemPictureNotFoundErr)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
 }
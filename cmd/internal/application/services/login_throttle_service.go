@@ -0,0 +1,194 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"swapp-go/cmd/internal/application/ports"
+	"swapp-go/cmd/internal/domain"
+	"time"
+)
+
+var LoginThrottledErr = errors.New("too many failed login attempts")
+
+// LoginThrottledError tells when the next login attempt will be accepted.
+type LoginThrottledError struct {
+	RetryAt time.Time
+}
+
+func (err *LoginThrottledError) Error() string {
+	return fmt.Sprintf("%v, try again after %s", LoginThrottledErr, err.RetryAt.Format(time.RFC3339))
+}
+
+func (err *LoginThrottledError) Unwrap() error {
+	return LoginThrottledErr
+}
+
+// LoginThrottlePolicy sets how failed logins slow down further attempts. After
+// each failure the account and the address have to wait BaseDelay, doubled for
+// every earlier failure up to MaxDelay. Reaching the failure limit locks them
+// for LockoutDuration. Failures older than FailureWindow are forgotten.
+type LoginThrottlePolicy struct {
+	AccountMaxFailures int
+	IPMaxFailures      int
+	BaseDelay          time.Duration
+	MaxDelay           time.Duration
+	LockoutDuration    time.Duration
+	FailureWindow      time.Duration
+}
+
+type LoginThrottleService struct {
+	store        ports.LoginAttemptStore
+	emailService ports.EmailService
+	clock        ports.Clock
+	policy       LoginThrottlePolicy
+}
+
+func NewLoginThrottleService(
+	store ports.LoginAttemptStore,
+	emailService ports.EmailService,
+	clock ports.Clock,
+	policy LoginThrottlePolicy,
+) *LoginThrottleService {
+	return &LoginThrottleService{
+		store:        store,
+		emailService: emailService,
+		clock:        clock,
+		policy:       policy,
+	}
+}
+
+// Check refuses a login attempt with a LoginThrottledError while the account
+// or the address it comes from has to wait.
+func (service *LoginThrottleService) Check(account string, ipAddress string) error {
+	now := service.clock.Now()
+
+	for _, key := range throttleKeys(account, ipAddress) {
+		attempts, err := service.store.Find(key)
+		if err != nil {
+			return err
+		}
+
+		if attempts != nil && attempts.IsLocked(now) {
+			return &LoginThrottledError{RetryAt: *attempts.LockedUntil}
+		}
+	}
+
+	return nil
+}
+
+// RecordFailure counts a failed attempt against the account and the address.
+// When this locks out the account, its owner is told by email if user is
+// known; unknown accounts are locked all the same, so that lockouts do not
+// reveal which usernames exist.
+func (service *LoginThrottleService) RecordFailure(account string, ipAddress string, user *domain.User) error {
+	now := service.clock.Now()
+	windowStart := now.Add(-service.policy.FailureWindow)
+
+	accountAttempts, err := service.store.RecordFailure(accountThrottleKey(account), now, windowStart)
+	if err != nil {
+		return err
+	}
+
+	// The store still holds the lock from before this failure, so a lockout
+	// that was already running is not announced again.
+	wasLocked := accountAttempts.IsLocked(now)
+
+	lockedUntil, lockedOut := service.lockUntil(accountAttempts.Failures, service.policy.AccountMaxFailures, now)
+	if err = service.store.Lock(accountAttempts.Key, lockedUntil); err != nil {
+		return err
+	}
+
+	if lockedOut && !wasLocked && user != nil {
+		service.sendLockoutEmail(user, lockedUntil)
+	}
+
+	if ipAddress == "" {
+		return nil
+	}
+
+	ipAttempts, err := service.store.RecordFailure(ipThrottleKey(ipAddress), now, windowStart)
+	if err != nil {
+		return err
+	}
+
+	lockedUntil, _ = service.lockUntil(ipAttempts.Failures, service.policy.IPMaxFailures, now)
+
+	return service.store.Lock(ipAttempts.Key, lockedUntil)
+}
+
+// RecordSuccess forgets the failures of the account. Those of the address are
+// kept, so that one valid login does not reset a guessing run on others.
+func (service *LoginThrottleService) RecordSuccess(account string) error {
+	return service.store.Reset(accountThrottleKey(account))
+}
+
+// lockUntil returns how long a key with the given number of failures has to
+// wait, and whether that is a lockout rather than a backoff.
+func (service *LoginThrottleService) lockUntil(failures int, maxFailures int, now time.Time) (time.Time, bool) {
+	if failures >= maxFailures {
+		return now.Add(service.policy.LockoutDuration), true
+	}
+
+	delay := service.policy.BaseDelay
+	for i := 1; i < failures && delay < service.policy.MaxDelay; i++ {
+		delay *= 2
+	}
+
+	return now.Add(min(delay, service.policy.MaxDelay)), false
+}
+
+// sendLockoutEmail tells the user about the lockout without failing the
+// login attempt that caused it.
+func (service *LoginThrottleService) sendLockoutEmail(user *domain.User, lockedUntil time.Time) {
+	email := &domain.EmailMessage{
+		Recipient: user.Email,
+		Template:  domain.EmailTemplateAccountLocked,
+		Data: domain.EmailData{
+			"Username":    user.Username,
+			"LockedUntil": lockedUntil.UTC().Format(time.RFC1123),
+		},
+	}
+
+	if err := service.emailService.SendEmail(email); err != nil {
+		log.Printf("Failed to send lockout email to user %s: %v", user.ID, err)
+	}
+}
+
+func throttleKeys(account string, ipAddress string) []string {
+	keys := []string{accountThrottleKey(account)}
+	if ipAddress != "" {
+		keys = append(keys, ipThrottleKey(ipAddress))
+	}
+
+	return keys
+}
+
+func accountThrottleKey(account string) string {
+	return "account:" + account
+}
+
+func ipThrottleKey(ipAddress string) string {
+	return "ip:" + ipAddress
+}
+
+// passwordLoginAccount is the throttled account of a password login. Usernames
+// are folded so that case variants share one counter.
+func passwordLoginAccount(username string) string {
+	return "password:" + strings.ToLower(username)
+}
+
+// twoFactorLoginAccount is the throttled account of the second login step. It
+// is kept apart from the password step, whose counter a correct password
+// resets.
+func twoFactorLoginAccount(user *domain.User) string {
+	return "two-factor:" + user.ID.String()
+}
+
+// passwordChangeAccount is the throttled account of the current password
+// check when changing passwords, so that a stolen access token cannot be used
+// to guess it quickly.
+func passwordChangeAccount(user *domain.User) string {
+	return "password-change:" + user.ID.String()
+}
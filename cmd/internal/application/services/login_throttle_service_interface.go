@@ -0,0 +1,9 @@
+package services
+
+import "swapp-go/cmd/internal/domain"
+
+type LoginThrottleServiceInterface interface {
+	Check(account string, ipAddress string) error
+	RecordFailure(account string, ipAddress string, user *domain.User) error
+	RecordSuccess(account string) error
+}
@@ -0,0 +1,186 @@
+package services_test
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	testMocks "swapp-go/cmd/internal/application/mocks"
+	"swapp-go/cmd/internal/application/services"
+	"swapp-go/cmd/internal/domain"
+	"testing"
+	"time"
+)
+
+const (
+	testThrottleAccount = "password:test_user"
+	testThrottleIP      = "203.0.113.7"
+)
+
+var testThrottlePolicy = services.LoginThrottlePolicy{
+	AccountMaxFailures: 5,
+	IPMaxFailures:      20,
+	BaseDelay:          time.Second,
+	MaxDelay:           time.Minute,
+	LockoutDuration:    15 * time.Minute,
+	FailureWindow:      time.Hour,
+}
+
+func setupLoginThrottleServiceTest() (*services.LoginThrottleService, *testMocks.MockLoginAttemptStore, *testMocks.MockEmailService) {
+	mockStore := new(testMocks.MockLoginAttemptStore)
+	mockEmailService := new(testMocks.MockEmailService)
+
+	service := services.NewLoginThrottleService(mockStore, mockEmailService, &testMocks.Clock{Current: testNow}, testThrottlePolicy)
+
+	return service, mockStore, mockEmailService
+}
+
+// expectFailure records the failure count a key reaches and the lock it gets.
+func expectFailure(mockStore *testMocks.MockLoginAttemptStore, key string, failures int, lockedFor time.Duration) {
+	expectFailureWhileLocked(mockStore, key, failures, nil, lockedFor)
+}
+
+// expectFailureWhileLocked is expectFailure for a key that still has the lock
+// lockedUntil from earlier failures.
+func expectFailureWhileLocked(
+	mockStore *testMocks.MockLoginAttemptStore,
+	key string,
+	failures int,
+	lockedUntil *time.Time,
+	lockedFor time.Duration,
+) {
+	mockStore.On("RecordFailure", key, testNow, testNow.Add(-testThrottlePolicy.FailureWindow)).
+		Return(&domain.LoginAttempts{Key: key, Failures: failures, LastFailureAt: testNow, LockedUntil: lockedUntil}, nil).Once()
+	mockStore.On("Lock", key, testNow.Add(lockedFor)).Return(nil).Once()
+}
+
+func TestLoginThrottleService_Check(t *testing.T) {
+	t.Run("allows unlocked keys", func(t *testing.T) {
+		service, mockStore, _ := setupLoginThrottleServiceTest()
+		expired := testNow.Add(-time.Second)
+
+		mockStore.On("Find", "account:"+testThrottleAccount).Return(nil, nil)
+		mockStore.On("Find", "ip:"+testThrottleIP).Return(&domain.LoginAttempts{Failures: 3, LockedUntil: &expired}, nil)
+
+		assert.NoError(t, service.Check(testThrottleAccount, testThrottleIP))
+	})
+
+	t.Run("refuses a locked address", func(t *testing.T) {
+		service, mockStore, _ := setupLoginThrottleServiceTest()
+		lockedUntil := testNow.Add(time.Minute)
+
+		mockStore.On("Find", "account:"+testThrottleAccount).Return(nil, nil)
+		mockStore.On("Find", "ip:"+testThrottleIP).Return(&domain.LoginAttempts{Failures: 20, LockedUntil: &lockedUntil}, nil)
+
+		err := service.Check(testThrottleAccount, testThrottleIP)
+		assert.ErrorIs(t, err, services.LoginThrottledErr)
+
+		var throttledErr *services.LoginThrottledError
+		assert.ErrorAs(t, err, &throttledErr)
+		assert.Equal(t, lockedUntil, throttledErr.RetryAt)
+	})
+
+	t.Run("store failure", func(t *testing.T) {
+		service, mockStore, _ := setupLoginThrottleServiceTest()
+
+		mockStore.On("Find", "account:"+testThrottleAccount).Return(nil, errors.New("db down"))
+
+		assert.EqualError(t, service.Check(testThrottleAccount, testThrottleIP), "db down")
+	})
+}
+
+func TestLoginThrottleService_RecordFailure(t *testing.T) {
+	user := &domain.User{ID: uuid.New(), Username: "test_user", Email: "test@example.com"}
+
+	t.Run("backs off exponentially", func(t *testing.T) {
+		tests := []struct {
+			failures int
+			delay    time.Duration
+		}{
+			{1, time.Second},
+			{2, 2 * time.Second},
+			{4, 8 * time.Second},
+		}
+
+		for _, test := range tests {
+			service, mockStore, mockEmailService := setupLoginThrottleServiceTest()
+
+			expectFailure(mockStore, "account:"+testThrottleAccount, test.failures, test.delay)
+			expectFailure(mockStore, "ip:"+testThrottleIP, test.failures, test.delay)
+
+			assert.NoError(t, service.RecordFailure(testThrottleAccount, testThrottleIP, user))
+
+			mockStore.AssertExpectations(t)
+			mockEmailService.AssertNotCalled(t, "SendEmail", mock.Anything)
+		}
+	})
+
+	t.Run("caps the backoff", func(t *testing.T) {
+		service, mockStore, _ := setupLoginThrottleServiceTest()
+
+		expectFailure(mockStore, "account:"+testThrottleAccount, 1, time.Second)
+		expectFailure(mockStore, "ip:"+testThrottleIP, 19, testThrottlePolicy.MaxDelay)
+
+		assert.NoError(t, service.RecordFailure(testThrottleAccount, testThrottleIP, nil))
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("locks out and emails the owner once", func(t *testing.T) {
+		service, mockStore, mockEmailService := setupLoginThrottleServiceTest()
+
+		expectFailure(mockStore, "account:"+testThrottleAccount, 5, testThrottlePolicy.LockoutDuration)
+		expectFailure(mockStore, "ip:"+testThrottleIP, 5, 16*time.Second)
+		mockEmailService.On("SendEmail", mock.MatchedBy(func(message *domain.EmailMessage) bool {
+			return message.Recipient == user.Email
+		})).Return(nil).Once()
+
+		assert.NoError(t, service.RecordFailure(testThrottleAccount, testThrottleIP, user))
+
+		lockedUntil := testNow.Add(testThrottlePolicy.LockoutDuration)
+		expectFailureWhileLocked(mockStore, "account:"+testThrottleAccount, 6, &lockedUntil, testThrottlePolicy.LockoutDuration)
+		expectFailure(mockStore, "ip:"+testThrottleIP, 6, 32*time.Second)
+
+		assert.NoError(t, service.RecordFailure(testThrottleAccount, testThrottleIP, user))
+
+		mockStore.AssertExpectations(t)
+		mockEmailService.AssertExpectations(t)
+	})
+
+	t.Run("emails the owner again when a new lockout starts", func(t *testing.T) {
+		service, mockStore, mockEmailService := setupLoginThrottleServiceTest()
+
+		expiredAt := testNow.Add(-time.Minute)
+		expectFailureWhileLocked(mockStore, "account:"+testThrottleAccount, 6, &expiredAt, testThrottlePolicy.LockoutDuration)
+		expectFailure(mockStore, "ip:"+testThrottleIP, 6, 32*time.Second)
+		mockEmailService.On("SendEmail", mock.MatchedBy(func(message *domain.EmailMessage) bool {
+			return message.Recipient == user.Email
+		})).Return(nil).Once()
+
+		assert.NoError(t, service.RecordFailure(testThrottleAccount, testThrottleIP, user))
+
+		mockStore.AssertExpectations(t)
+		mockEmailService.AssertExpectations(t)
+	})
+
+	t.Run("unknown accounts are locked without an email", func(t *testing.T) {
+		service, mockStore, mockEmailService := setupLoginThrottleServiceTest()
+
+		expectFailure(mockStore, "account:"+testThrottleAccount, 5, testThrottlePolicy.LockoutDuration)
+		expectFailure(mockStore, "ip:"+testThrottleIP, 5, 16*time.Second)
+
+		assert.NoError(t, service.RecordFailure(testThrottleAccount, testThrottleIP, nil))
+
+		mockStore.AssertExpectations(t)
+		mockEmailService.AssertNotCalled(t, "SendEmail", mock.Anything)
+	})
+}
+
+func TestLoginThrottleService_RecordSuccess(t *testing.T) {
+	service, mockStore, _ := setupLoginThrottleServiceTest()
+
+	mockStore.On("Reset", "account:"+testThrottleAccount).Return(nil).Once()
+
+	assert.NoError(t, service.RecordSuccess(testThrottleAccount))
+	mockStore.AssertExpectations(t)
+	mockStore.AssertNotCalled(t, "Reset", "ip:"+testThrottleIP)
+}
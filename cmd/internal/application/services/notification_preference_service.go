@@ -0,0 +1,94 @@
+package services
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"swapp-go/cmd/internal/application/ports"
+	"swapp-go/cmd/internal/domain"
+)
+
+var InvalidUnsubscribeTokenErr = errors.New("invalid unsubscribe link")
+
+type NotificationPreferenceService struct {
+	userRepo ports.UserRepository
+	signer   ports.LinkTokenSigner
+}
+
+func NewNotificationPreferenceService(userRepo ports.UserRepository, signer ports.LinkTokenSigner) *NotificationPreferenceService {
+	return &NotificationPreferenceService{userRepo: userRepo, signer: signer}
+}
+
+// GetPreferences returns the user's preference for every notification event.
+func (service *NotificationPreferenceService) GetPreferences(userID uuid.UUID) (domain.NotificationPreferences, error) {
+	user, err := service.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return user.NotificationPreferences.All(), nil
+}
+
+// UpdatePreferences applies changes on top of the user's preferences; events
+// left out of changes keep their current setting.
+func (service *NotificationPreferenceService) UpdatePreferences(
+	userID uuid.UUID,
+	changes domain.NotificationPreferences,
+) (domain.NotificationPreferences, error) {
+	for event := range changes {
+		if !event.IsValid() {
+			return nil, domain.InvalidNotificationEventErr
+		}
+	}
+
+	user, err := service.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	preferences := user.NotificationPreferences.All()
+	for event, enabled := range changes {
+		preferences[event] = enabled
+	}
+
+	if err = service.save(userID, preferences); err != nil {
+		return nil, err
+	}
+
+	return preferences, nil
+}
+
+// Unsubscribe turns off the event a one-click unsubscribe link was signed for
+// and returns it. Following the same link again is harmless.
+func (service *NotificationPreferenceService) Unsubscribe(token string) (domain.NotificationEvent, error) {
+	claims, err := service.signer.VerifyLink(domain.UnsubscribePurpose, token)
+	if err != nil {
+		return "", InvalidUnsubscribeTokenErr
+	}
+
+	event := domain.NotificationEvent(claims.Value)
+	if !event.IsValid() {
+		return "", InvalidUnsubscribeTokenErr
+	}
+
+	user, err := service.userRepo.FindByID(claims.UserID)
+	if err != nil {
+		return "", InvalidUnsubscribeTokenErr
+	}
+
+	preferences := user.NotificationPreferences.All()
+	preferences[event] = false
+
+	if err = service.save(user.ID, preferences); err != nil {
+		return "", err
+	}
+
+	return event, nil
+}
+
+func (service *NotificationPreferenceService) save(userID uuid.UUID, preferences domain.NotificationPreferences) error {
+	_, err := service.userRepo.Update(userID, map[string]interface{}{
+		"email_opt_outs": preferences.OptOuts(),
+	})
+
+	return err
+}
@@ -0,0 +1,12 @@
+package services
+
+import (
+	"github.com/google/uuid"
+	"swapp-go/cmd/internal/domain"
+)
+
+type NotificationPreferenceServiceInterface interface {
+	GetPreferences(userID uuid.UUID) (domain.NotificationPreferences, error)
+	UpdatePreferences(userID uuid.UUID, changes domain.NotificationPreferences) (domain.NotificationPreferences, error)
+	Unsubscribe(token string) (domain.NotificationEvent, error)
+}
@@ -0,0 +1,130 @@
+package services_test
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	testMocks "swapp-go/cmd/internal/application/mocks"
+	"swapp-go/cmd/internal/application/services"
+	"swapp-go/cmd/internal/domain"
+	"testing"
+)
+
+func setupNotificationPreferenceServiceTest() (
+	*services.NotificationPreferenceService,
+	*testMocks.MockUserRepository,
+	*testMocks.MockLinkTokenSigner,
+) {
+	mockUserRepo := new(testMocks.MockUserRepository)
+	mockSigner := new(testMocks.MockLinkTokenSigner)
+
+	return services.NewNotificationPreferenceService(mockUserRepo, mockSigner), mockUserRepo, mockSigner
+}
+
+func TestNotificationPreferenceService_GetPreferences(t *testing.T) {
+	service, mockUserRepo, _ := setupNotificationPreferenceServiceTest()
+	userID := uuid.New()
+
+	mockUserRepo.On("FindByID", userID).Return(&domain.User{
+		ID:                      userID,
+		NotificationPreferences: domain.NotificationPreferences{domain.NotifyRejected: false},
+	}, nil).Once()
+
+	preferences, err := service.GetPreferences(userID)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.NotificationPreferences{
+		domain.NotifyNewRequest:   true,
+		domain.NotifyAccepted:     true,
+		domain.NotifyRejected:     false,
+		domain.NotifyCancelled:    true,
+		domain.NotifyExpiringSoon: true,
+	}, preferences)
+}
+
+func TestNotificationPreferenceService_UpdatePreferences(t *testing.T) {
+	userID := uuid.New()
+
+	t.Run("merges changes into the current preferences", func(t *testing.T) {
+		service, mockUserRepo, _ := setupNotificationPreferenceServiceTest()
+
+		mockUserRepo.On("FindByID", userID).Return(&domain.User{
+			ID:                      userID,
+			NotificationPreferences: domain.NotificationPreferences{domain.NotifyRejected: false},
+		}, nil).Once()
+		mockUserRepo.On("Update", userID, map[string]interface{}{"email_opt_outs": "accepted,rejected"}).
+			Return(&domain.User{}, nil).Once()
+
+		preferences, err := service.UpdatePreferences(userID, domain.NotificationPreferences{domain.NotifyAccepted: false})
+		assert.NoError(t, err)
+		assert.False(t, preferences[domain.NotifyAccepted])
+		assert.False(t, preferences[domain.NotifyRejected])
+		assert.True(t, preferences[domain.NotifyNewRequest])
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("unknown event", func(t *testing.T) {
+		service, mockUserRepo, _ := setupNotificationPreferenceServiceTest()
+
+		_, err := service.UpdatePreferences(userID, domain.NotificationPreferences{"newsletter": false})
+		assert.ErrorIs(t, err, domain.InvalidNotificationEventErr)
+		mockUserRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
+
+func TestNotificationPreferenceService_Unsubscribe(t *testing.T) {
+	userID := uuid.New()
+
+	t.Run("turns off the signed event", func(t *testing.T) {
+		service, mockUserRepo, mockSigner := setupNotificationPreferenceServiceTest()
+
+		mockSigner.On("VerifyLink", domain.UnsubscribePurpose, "token").Return(&domain.LinkTokenClaims{
+			Purpose: domain.UnsubscribePurpose,
+			UserID:  userID,
+			Value:   string(domain.NotifyNewRequest),
+		}, nil).Once()
+		mockUserRepo.On("FindByID", userID).Return(&domain.User{ID: userID}, nil).Once()
+		mockUserRepo.On("Update", userID, map[string]interface{}{"email_opt_outs": "new_request"}).
+			Return(&domain.User{}, nil).Once()
+
+		event, err := service.Unsubscribe("token")
+		assert.NoError(t, err)
+		assert.Equal(t, domain.NotifyNewRequest, event)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		service, mockUserRepo, mockSigner := setupNotificationPreferenceServiceTest()
+
+		mockSigner.On("VerifyLink", domain.UnsubscribePurpose, "forged").Return(nil, domain.InvalidLinkTokenErr).Once()
+
+		_, err := service.Unsubscribe("forged")
+		assert.ErrorIs(t, err, services.InvalidUnsubscribeTokenErr)
+		mockUserRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("unknown event", func(t *testing.T) {
+		service, _, mockSigner := setupNotificationPreferenceServiceTest()
+
+		mockSigner.On("VerifyLink", domain.UnsubscribePurpose, "token").Return(&domain.LinkTokenClaims{
+			UserID: userID,
+			Value:  "newsletter",
+		}, nil).Once()
+
+		_, err := service.Unsubscribe("token")
+		assert.ErrorIs(t, err, services.InvalidUnsubscribeTokenErr)
+	})
+
+	t.Run("deleted user", func(t *testing.T) {
+		service, mockUserRepo, mockSigner := setupNotificationPreferenceServiceTest()
+
+		mockSigner.On("VerifyLink", domain.UnsubscribePurpose, "token").Return(&domain.LinkTokenClaims{
+			UserID: userID,
+			Value:  string(domain.NotifyAccepted),
+		}, nil).Once()
+		mockUserRepo.On("FindByID", userID).Return(nil, errors.New("record not found")).Once()
+
+		_, err := service.Unsubscribe("token")
+		assert.ErrorIs(t, err, services.InvalidUnsubscribeTokenErr)
+	})
+}
@@ -0,0 +1,55 @@
+package services
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"swapp-go/cmd/internal/application/ports"
+	"swapp-go/cmd/internal/domain"
+)
+
+var NotificationNotFoundErr = errors.New("notification not found")
+
+// NotificationService reads and clears the in-app inbox that
+// SwapRequestService fills with every swap event.
+type NotificationService struct {
+	repo  ports.NotificationRepository
+	clock ports.Clock
+}
+
+func NewNotificationService(repo ports.NotificationRepository, clock ports.Clock) *NotificationService {
+	return &NotificationService{
+		repo:  repo,
+		clock: clock,
+	}
+}
+
+func (service *NotificationService) List(userID uuid.UUID, query domain.NotificationQuery) ([]domain.Notification, error) {
+	query.Normalize()
+
+	return service.repo.List(userID, query)
+}
+
+// CountUnread returns the number shown on the client's badge.
+func (service *NotificationService) CountUnread(userID uuid.UUID) (int64, error) {
+	return service.repo.CountUnread(userID)
+}
+
+// MarkRead marks one of the user's notifications as read. Notifications of
+// other users are reported as not found.
+func (service *NotificationService) MarkRead(id, userID uuid.UUID) error {
+	found, err := service.repo.MarkRead(id, userID, service.clock.Now())
+	if err != nil {
+		return err
+	}
+	if !found {
+		return NotificationNotFoundErr
+	}
+
+	return nil
+}
+
+// MarkAllRead marks every unread notification of the user as read and returns
+// how many there were.
+func (service *NotificationService) MarkAllRead(userID uuid.UUID) (int64, error) {
+	return service.repo.MarkAllRead(userID, service.clock.Now())
+}
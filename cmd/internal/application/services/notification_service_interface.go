@@ -0,0 +1,13 @@
+package services
+
+import (
+	"github.com/google/uuid"
+	"swapp-go/cmd/internal/domain"
+)
+
+type NotificationServiceInterface interface {
+	List(userID uuid.UUID, query domain.NotificationQuery) ([]domain.Notification, error)
+	CountUnread(userID uuid.UUID) (int64, error)
+	MarkRead(id, userID uuid.UUID) error
+	MarkAllRead(userID uuid.UUID) (int64, error)
+}
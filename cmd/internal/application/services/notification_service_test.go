@@ -0,0 +1,87 @@
+package services_test
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	testMocks "swapp-go/cmd/internal/application/mocks"
+	"swapp-go/cmd/internal/application/services"
+	"swapp-go/cmd/internal/domain"
+	"testing"
+)
+
+func setupNotificationServiceTest() (*services.NotificationService, *testMocks.MockNotificationRepository) {
+	mockRepo := new(testMocks.MockNotificationRepository)
+
+	return services.NewNotificationService(mockRepo, &testMocks.Clock{Current: testNow}), mockRepo
+}
+
+func TestNotificationService_List(t *testing.T) {
+	userID := uuid.New()
+
+	testCases := []struct {
+		name     string
+		query    domain.NotificationQuery
+		expected domain.NotificationQuery
+	}{
+		{"default page", domain.NotificationQuery{}, domain.NotificationQuery{Limit: domain.DefaultNotificationListLimit}},
+		{"page too large", domain.NotificationQuery{Limit: 1000, Offset: 20}, domain.NotificationQuery{Limit: domain.MaxNotificationListLimit, Offset: 20}},
+		{"unread only", domain.NotificationQuery{UnreadOnly: true, Limit: 5, Offset: -1}, domain.NotificationQuery{UnreadOnly: true, Limit: 5}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			service, mockRepo := setupNotificationServiceTest()
+
+			notifications := []domain.Notification{{ID: uuid.New(), UserID: userID}}
+			mockRepo.On("List", userID, testCase.expected).Return(notifications, nil).Once()
+
+			listed, err := service.List(userID, testCase.query)
+			assert.NoError(t, err)
+			assert.Equal(t, notifications, listed)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestNotificationService_MarkRead(t *testing.T) {
+	notificationID := uuid.New()
+	userID := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		service, mockRepo := setupNotificationServiceTest()
+
+		mockRepo.On("MarkRead", notificationID, userID, testNow).Return(true, nil).Once()
+
+		assert.NoError(t, service.MarkRead(notificationID, userID))
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		service, mockRepo := setupNotificationServiceTest()
+
+		mockRepo.On("MarkRead", notificationID, userID, testNow).Return(false, nil).Once()
+
+		assert.ErrorIs(t, service.MarkRead(notificationID, userID), services.NotificationNotFoundErr)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		service, mockRepo := setupNotificationServiceTest()
+
+		repoErr := errors.New("database down")
+		mockRepo.On("MarkRead", notificationID, userID, testNow).Return(false, repoErr).Once()
+
+		assert.ErrorIs(t, service.MarkRead(notificationID, userID), repoErr)
+	})
+}
+
+func TestNotificationService_MarkAllRead(t *testing.T) {
+	service, mockRepo := setupNotificationServiceTest()
+	userID := uuid.New()
+
+	mockRepo.On("MarkAllRead", userID, testNow).Return(int64(3), nil).Once()
+
+	marked, err := service.MarkAllRead(userID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), marked)
+}
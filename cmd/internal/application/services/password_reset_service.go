@@ -1,42 +1,133 @@
 package services
 
 import (
-	"github.com/google/uuid"
+	"errors"
+	"net/url"
 	"swapp-go/cmd/internal/application/ports"
 	"swapp-go/cmd/internal/domain"
+	"swapp-go/cmd/internal/utils"
 	"time"
 )
 
+var InvalidResetTokenErr = errors.New("invalid or expired password reset token")
+
+// resetTokenSize is the number of random bytes in a password reset token.
+const resetTokenSize = 32
+
 type PasswordResetService struct {
-	ResetTokenRepo ports.PasswordResetRepository
+	resetRepo    ports.PasswordResetRepository
+	userRepo     ports.UserRepository
+	sessionRepo  ports.SessionRepository
+	passwords    PasswordServiceInterface
+	emailService ports.EmailService
+	clock        ports.Clock
+	linkURL      string
+	tokenTTL     time.Duration
 }
 
-func NewPasswordResetService(tokenRepo ports.PasswordResetRepository) *PasswordResetService {
+func NewPasswordResetService(
+	resetRepo ports.PasswordResetRepository,
+	userRepo ports.UserRepository,
+	sessionRepo ports.SessionRepository,
+	passwords PasswordServiceInterface,
+	emailService ports.EmailService,
+	clock ports.Clock,
+	linkURL string,
+	tokenTTL time.Duration,
+) *PasswordResetService {
 	return &PasswordResetService{
-		ResetTokenRepo: tokenRepo,
+		resetRepo:    resetRepo,
+		userRepo:     userRepo,
+		sessionRepo:  sessionRepo,
+		passwords:    passwords,
+		emailService: emailService,
+		clock:        clock,
+		linkURL:      linkURL,
+		tokenTTL:     tokenTTL,
 	}
 }
 
-func (service *PasswordResetService) GenerateAndSaveToken(userID uuid.UUID) (string, error) {
-	token := uuid.NewString()
-	resetToken := &domain.PasswordReset{
-		Token:     token,
-		UserID:    userID,
-		ExpiresAt: time.Now().Add(1 * time.Hour),
+// RequestReset mails a password reset link to the account registered with
+// email, replacing any link sent before. Unknown addresses are ignored so that
+// callers cannot tell whether an account exists.
+func (service *PasswordResetService) RequestReset(email string) error {
+	user, err := service.userRepo.FindByEmail(email)
+	if err != nil || user == nil {
+		return nil
 	}
 
-	err := service.ResetTokenRepo.Save(resetToken)
+	token, err := utils.GenerateRandomToken(resetTokenSize)
 	if err != nil {
-		return "", err
+		return err
+	}
+
+	now := service.clock.Now()
+	reset := &domain.PasswordReset{
+		TokenHash: utils.HashToken(token),
+		UserID:    user.ID,
+		CreatedAt: now,
+		ExpiresAt: now.Add(service.tokenTTL),
 	}
 
-	return token, nil
+	if err = service.resetRepo.Replace(reset); err != nil {
+		return err
+	}
+
+	return service.emailService.SendEmail(&domain.EmailMessage{
+		Recipient: user.Email,
+		Template:  domain.EmailTemplatePasswordReset,
+		Data: domain.EmailData{
+			"Username": user.Username,
+			"ResetURL": service.resetLink(token),
+		},
+	})
 }
 
-func (service *PasswordResetService) ValidateToken(token string) (*domain.PasswordReset, error) {
-	return service.ResetTokenRepo.GetByToken(token)
+// ResetPassword sets a new password for the owner of token and signs them out
+// everywhere. Each token works once; a password the policy rejects does not
+// use it up.
+func (service *PasswordResetService) ResetPassword(token string, newPassword string) error {
+	tokenHash := utils.HashToken(token)
+
+	reset, err := service.resetRepo.GetByTokenHash(tokenHash)
+	if err != nil || reset == nil {
+		return InvalidResetTokenErr
+	}
+
+	now := service.clock.Now()
+	if reset.IsExpired(now) {
+		return InvalidResetTokenErr
+	}
+
+	user, err := service.userRepo.FindByID(reset.UserID)
+	if err != nil {
+		return InvalidResetTokenErr
+	}
+
+	if err = service.passwords.Validate(newPassword, user); err != nil {
+		return err
+	}
+
+	deleted, err := service.resetRepo.TryDelete(tokenHash)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return InvalidResetTokenErr
+	}
+
+	hashedPassword, err := service.passwords.Hash(newPassword)
+	if err != nil {
+		return err
+	}
+
+	if _, err = service.userRepo.Update(reset.UserID, map[string]interface{}{"password": hashedPassword}); err != nil {
+		return err
+	}
+
+	return service.sessionRepo.RevokeAllByUser(reset.UserID, now)
 }
 
-func (service *PasswordResetService) DeleteToken(token string) error {
-	return service.ResetTokenRepo.Delete(token)
+func (service *PasswordResetService) resetLink(token string) string {
+	return service.linkURL + "?token=" + url.QueryEscape(token)
 }
@@ -0,0 +1,6 @@
+package services
+
+type PasswordResetServiceInterface interface {
+	RequestReset(email string) error
+	ResetPassword(token string, newPassword string) error
+}
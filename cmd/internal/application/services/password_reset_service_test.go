@@ -2,7 +2,10 @@ package services_test
 
 import (
 	"errors"
+	"golang.org/x/crypto/bcrypt"
+	"regexp"
 	"swapp-go/cmd/internal/application/mocks"
+	"swapp-go/cmd/internal/utils"
 	"testing"
 	"time"
 
@@ -13,66 +16,171 @@ import (
 	"swapp-go/cmd/internal/domain"
 )
 
+const (
+	testResetURL = "https://swapp.example/reset-password"
+	testResetTTL = time.Hour
+)
+
 var (
 	validToken   = "valid_token"
 	invalidToken = "invalid_token"
 )
 
-func TestPasswordResetService(t *testing.T) {
-	t.Run("GenerateAndSaveToken_Success", func(t *testing.T) {
-		repo := new(mocks.MockPasswordResetRepository)
-		resetService := services.NewPasswordResetService(repo)
-		userID := uuid.New()
-
-		repo.On("Save", mock.AnythingOfType("*domain.PasswordReset")).Return(nil)
+type passwordResetMocks struct {
+	resetRepo    *mocks.MockPasswordResetRepository
+	userRepo     *mocks.MockUserRepository
+	sessionRepo  *mocks.MockSessionRepository
+	emailService *mocks.MockEmailService
+}
 
-		token, err := resetService.GenerateAndSaveToken(userID)
+func setupPasswordResetServiceTest(t *testing.T) (*services.PasswordResetService, passwordResetMocks) {
+	m := passwordResetMocks{
+		resetRepo:    new(mocks.MockPasswordResetRepository),
+		userRepo:     new(mocks.MockUserRepository),
+		sessionRepo:  new(mocks.MockSessionRepository),
+		emailService: new(mocks.MockEmailService),
+	}
+
+	resetService := services.NewPasswordResetService(
+		m.resetRepo,
+		m.userRepo,
+		m.sessionRepo,
+		newTestPasswordService(t, bcrypt.MinCost),
+		m.emailService,
+		&mocks.Clock{Current: testNow},
+		testResetURL,
+		testResetTTL,
+	)
+
+	return resetService, m
+}
 
+func TestPasswordResetService(t *testing.T) {
+	t.Run("RequestReset_Success", func(t *testing.T) {
+		resetService, m := setupPasswordResetServiceTest(t)
+		user := &domain.User{ID: uuid.New(), Username: "user", Email: "user@example.com"}
+
+		var stored *domain.PasswordReset
+		var sent *domain.EmailMessage
+		m.userRepo.On("FindByEmail", user.Email).Return(user, nil)
+		m.resetRepo.On("Replace", mock.AnythingOfType("*domain.PasswordReset")).
+			Run(func(args mock.Arguments) { stored = args.Get(0).(*domain.PasswordReset) }).
+			Return(nil)
+		m.emailService.On("SendEmail", mock.AnythingOfType("*domain.EmailMessage")).
+			Run(func(args mock.Arguments) { sent = args.Get(0).(*domain.EmailMessage) }).
+			Return(nil)
+
+		err := resetService.RequestReset(user.Email)
 		assert.NoError(t, err)
-		assert.NotEmpty(t, token)
-		repo.AssertCalled(t, "Save", mock.AnythingOfType("*domain.PasswordReset"))
-	})
 
-	t.Run("ValidateToken_Valid", func(t *testing.T) {
-		repo := new(mocks.MockPasswordResetRepository)
-		resetService := services.NewPasswordResetService(repo)
+		assert.Equal(t, user.ID, stored.UserID)
+		assert.Equal(t, testNow.Add(testResetTTL), stored.ExpiresAt)
+		assert.Equal(t, user.Email, sent.Recipient)
 
-		expiresAt := time.Now().Add(1 * time.Hour)
-		resetToken := &domain.PasswordReset{
-			Token:     validToken,
-			UserID:    uuid.New(),
-			ExpiresAt: expiresAt,
+		assert.Equal(t, domain.EmailTemplatePasswordReset, sent.Template)
+
+		resetURL, _ := sent.Data["ResetURL"].(string)
+		link := regexp.MustCompile(regexp.QuoteMeta(testResetURL) + `\?token=(\S+)`).FindStringSubmatch(resetURL)
+		if assert.Len(t, link, 2) {
+			assert.Equal(t, stored.TokenHash, utils.HashToken(link[1]), "only the hash of the mailed token is stored")
+			assert.NotContains(t, resetURL, stored.TokenHash)
 		}
+	})
 
-		repo.On("GetByToken", validToken).Return(resetToken, nil)
+	t.Run("RequestReset_UnknownEmail", func(t *testing.T) {
+		resetService, m := setupPasswordResetServiceTest(t)
 
-		token, err := resetService.ValidateToken(validToken)
+		m.userRepo.On("FindByEmail", "nobody@example.com").Return(nil, errors.New("record not found"))
 
+		err := resetService.RequestReset("nobody@example.com")
 		assert.NoError(t, err)
-		assert.Equal(t, resetToken, token)
+		m.resetRepo.AssertNotCalled(t, "Replace", mock.Anything)
+		m.emailService.AssertNotCalled(t, "SendEmail", mock.Anything)
 	})
 
-	t.Run("ValidateToken_Invalid", func(t *testing.T) {
-		repo := new(mocks.MockPasswordResetRepository)
-		resetService := services.NewPasswordResetService(repo)
+	t.Run("ResetPassword_Success", func(t *testing.T) {
+		resetService, m := setupPasswordResetServiceTest(t)
+		userID := uuid.New()
+		tokenHash := utils.HashToken(validToken)
+
+		m.resetRepo.On("GetByTokenHash", tokenHash).Return(&domain.PasswordReset{
+			TokenHash: tokenHash,
+			UserID:    userID,
+			ExpiresAt: testNow.Add(time.Minute),
+		}, nil)
+		m.userRepo.On("FindByID", userID).Return(&domain.User{ID: userID, Username: "user", Email: "user@example.com"}, nil)
+		m.resetRepo.On("TryDelete", tokenHash).Return(true, nil)
+		m.userRepo.On("Update", userID, mock.MatchedBy(func(fields map[string]interface{}) bool {
+			hash, ok := fields["password"].(string)
+			return ok && utils.CheckPasswordHash("newSecurePass123", hash)
+		})).Return(&domain.User{ID: userID}, nil)
+		m.sessionRepo.On("RevokeAllByUser", userID, testNow).Return(nil)
+
+		err := resetService.ResetPassword(validToken, "newSecurePass123")
+		assert.NoError(t, err)
+
+		m.userRepo.AssertExpectations(t)
+		m.sessionRepo.AssertExpectations(t)
+	})
+
+	t.Run("ResetPassword_WeakPasswordKeepsToken", func(t *testing.T) {
+		resetService, m := setupPasswordResetServiceTest(t)
+		userID := uuid.New()
+		tokenHash := utils.HashToken(validToken)
+
+		m.resetRepo.On("GetByTokenHash", tokenHash).Return(&domain.PasswordReset{
+			TokenHash: tokenHash,
+			UserID:    userID,
+			ExpiresAt: testNow.Add(time.Minute),
+		}, nil)
+		m.userRepo.On("FindByID", userID).Return(&domain.User{ID: userID, Username: "user", Email: "user@example.com"}, nil)
+
+		err := resetService.ResetPassword(validToken, "short")
+		assert.ErrorIs(t, err, domain.WeakPasswordErr)
+		m.resetRepo.AssertNotCalled(t, "TryDelete", mock.Anything)
+		m.userRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
 
-		repo.On("GetByToken", invalidToken).Return(nil, errors.New("not found"))
+	t.Run("ResetPassword_InvalidToken", func(t *testing.T) {
+		resetService, m := setupPasswordResetServiceTest(t)
 
-		token, err := resetService.ValidateToken(invalidToken)
+		m.resetRepo.On("GetByTokenHash", utils.HashToken(invalidToken)).Return(nil, errors.New("not found"))
 
-		assert.Error(t, err)
-		assert.Nil(t, token)
+		err := resetService.ResetPassword(invalidToken, "newSecurePass123")
+		assert.ErrorIs(t, err, services.InvalidResetTokenErr)
+		m.userRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
 	})
 
-	t.Run("DeleteToken_Success", func(t *testing.T) {
-		repo := new(mocks.MockPasswordResetRepository)
-		resetService := services.NewPasswordResetService(repo)
+	t.Run("ResetPassword_ExpiredToken", func(t *testing.T) {
+		resetService, m := setupPasswordResetServiceTest(t)
+		tokenHash := utils.HashToken(validToken)
+
+		m.resetRepo.On("GetByTokenHash", tokenHash).Return(&domain.PasswordReset{
+			TokenHash: tokenHash,
+			UserID:    uuid.New(),
+			ExpiresAt: testNow,
+		}, nil)
 
-		repo.On("Delete", validToken).Return(nil)
+		err := resetService.ResetPassword(validToken, "newSecurePass123")
+		assert.ErrorIs(t, err, services.InvalidResetTokenErr)
+		m.resetRepo.AssertNotCalled(t, "TryDelete", mock.Anything)
+	})
 
-		err := resetService.DeleteToken(validToken)
+	t.Run("ResetPassword_TokenAlreadyUsed", func(t *testing.T) {
+		resetService, m := setupPasswordResetServiceTest(t)
+		tokenHash := utils.HashToken(validToken)
 
-		assert.NoError(t, err)
-		repo.AssertCalled(t, "Delete", validToken)
+		m.resetRepo.On("GetByTokenHash", tokenHash).Return(&domain.PasswordReset{
+			TokenHash: tokenHash,
+			UserID:    uuid.New(),
+			ExpiresAt: testNow.Add(time.Minute),
+		}, nil)
+		m.userRepo.On("FindByID", mock.Anything).Return(&domain.User{Username: "user", Email: "user@example.com"}, nil)
+		m.resetRepo.On("TryDelete", tokenHash).Return(false, nil)
+
+		err := resetService.ResetPassword(validToken, "newSecurePass123")
+		assert.ErrorIs(t, err, services.InvalidResetTokenErr)
+		m.userRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		m.sessionRepo.AssertNotCalled(t, "RevokeAllByUser", mock.Anything, mock.Anything)
 	})
 }
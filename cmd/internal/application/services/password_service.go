@@ -0,0 +1,80 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"golang.org/x/crypto/bcrypt"
+	"swapp-go/cmd/internal/application/ports"
+	"swapp-go/cmd/internal/domain"
+	"swapp-go/cmd/internal/utils"
+)
+
+var InvalidPasswordHashCostErr = errors.New("password hash cost is out of range")
+
+// PasswordService applies the password policy and hashes passwords at the
+// configured bcrypt cost.
+type PasswordService struct {
+	policy    domain.PasswordPolicy
+	blocklist ports.PasswordBlocklist
+	hashCost  int
+	// unknownUserHash is checked against when a login names no user, so that
+	// such logins take as long as those with a wrong password.
+	unknownUserHash string
+}
+
+func NewPasswordService(policy domain.PasswordPolicy, blocklist ports.PasswordBlocklist, hashCost int) (*PasswordService, error) {
+	if hashCost < bcrypt.MinCost || hashCost > bcrypt.MaxCost {
+		return nil, fmt.Errorf("%w: %d", InvalidPasswordHashCostErr, hashCost)
+	}
+
+	unknownUserHash, err := utils.HashPassword("unknown user password", hashCost)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PasswordService{
+		policy:          policy,
+		blocklist:       blocklist,
+		hashCost:        hashCost,
+		unknownUserHash: unknownUserHash,
+	}, nil
+}
+
+// Validate checks that password may be chosen by user.
+func (service *PasswordService) Validate(password string, user *domain.User) error {
+	err := service.policy.Check(password, user.Username, user.Email)
+
+	if service.blocklist.Contains(password) {
+		var policyErr *domain.PasswordPolicyError
+		if !errors.As(err, &policyErr) {
+			policyErr = &domain.PasswordPolicyError{}
+		}
+		policyErr.Violations = append(policyErr.Violations, "is too common, choose a less predictable password")
+
+		return policyErr
+	}
+
+	return err
+}
+
+func (service *PasswordService) Hash(password string) (string, error) {
+	return utils.HashPassword(password, service.hashCost)
+}
+
+// Verify reports whether password matches hash. An empty hash stands for an
+// unknown user and never matches, but takes as long to check.
+func (service *PasswordService) Verify(password string, hash string) bool {
+	if hash == "" {
+		utils.CheckPasswordHash(password, service.unknownUserHash)
+		return false
+	}
+
+	return utils.CheckPasswordHash(password, hash)
+}
+
+// NeedsRehash reports whether hash was made with a lower cost than the
+// current one and should be replaced at the next successful login.
+func (service *PasswordService) NeedsRehash(hash string) bool {
+	cost, err := utils.PasswordHashCost(hash)
+	return err == nil && cost < service.hashCost
+}
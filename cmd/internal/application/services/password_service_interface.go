@@ -0,0 +1,10 @@
+package services
+
+import "swapp-go/cmd/internal/domain"
+
+type PasswordServiceInterface interface {
+	Validate(password string, user *domain.User) error
+	Hash(password string) (string, error)
+	Verify(password string, hash string) bool
+	NeedsRehash(hash string) bool
+}
@@ -0,0 +1,87 @@
+package services_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+	"swapp-go/cmd/internal/application/mocks"
+	"swapp-go/cmd/internal/application/services"
+	"swapp-go/cmd/internal/domain"
+	"swapp-go/cmd/internal/utils"
+	"testing"
+)
+
+var testPasswordPolicy = domain.PasswordPolicy{MinLength: 8, MinCharacterClasses: 2}
+
+const blockedPassword = "Password1234"
+
+func newTestPasswordService(t *testing.T, hashCost int) *services.PasswordService {
+	t.Helper()
+
+	passwordService, err := services.NewPasswordService(testPasswordPolicy, mocks.PasswordBlocklist{blockedPassword}, hashCost)
+	assert.NoError(t, err)
+
+	return passwordService
+}
+
+func TestPasswordService_Validate(t *testing.T) {
+	passwordService := newTestPasswordService(t, bcrypt.MinCost)
+	user := &domain.User{Username: "swapper", Email: "swapper@example.com"}
+
+	testCases := []struct {
+		name       string
+		password   string
+		violations int
+	}{
+		{"valid", "blue-Lantern", 0},
+		{"too short", "ab1", 1},
+		{"too long", string(make([]byte, domain.MaxPasswordBytes+1)) + "a1", 1},
+		{"single character class", "onlyletters", 1},
+		{"same as username", "SWAPPER1", 0},
+		{"equal to username", "Swapper", 2},
+		{"equal to email", "swapper@example.com", 1},
+		{"blocked", blockedPassword, 1},
+		{"empty", "", 2},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := passwordService.Validate(testCase.password, user)
+			if testCase.violations == 0 {
+				assert.NoError(t, err)
+				return
+			}
+
+			var policyErr *domain.PasswordPolicyError
+			if assert.ErrorAs(t, err, &policyErr) {
+				assert.Len(t, policyErr.Violations, testCase.violations, policyErr.Error())
+			}
+			assert.ErrorIs(t, err, domain.WeakPasswordErr)
+		})
+	}
+}
+
+func TestPasswordService_Hashing(t *testing.T) {
+	passwordService := newTestPasswordService(t, bcrypt.MinCost+1)
+
+	hash, err := passwordService.Hash("blue-Lantern")
+	assert.NoError(t, err)
+
+	cost, err := utils.PasswordHashCost(hash)
+	assert.NoError(t, err)
+	assert.Equal(t, bcrypt.MinCost+1, cost)
+
+	assert.True(t, passwordService.Verify("blue-Lantern", hash))
+	assert.False(t, passwordService.Verify("red-Lantern", hash))
+	assert.False(t, passwordService.Verify("blue-Lantern", ""))
+
+	assert.False(t, passwordService.NeedsRehash(hash))
+
+	weakHash, err := utils.HashPassword("blue-Lantern", bcrypt.MinCost)
+	assert.NoError(t, err)
+	assert.True(t, passwordService.NeedsRehash(weakHash))
+}
+
+func TestNewPasswordService_InvalidCost(t *testing.T) {
+	_, err := services.NewPasswordService(testPasswordPolicy, mocks.PasswordBlocklist{}, bcrypt.MaxCost+1)
+	assert.ErrorIs(t, err, services.InvalidPasswordHashCostErr)
+}
@@ -0,0 +1,215 @@
+package services
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"log"
+	"strings"
+	"swapp-go/cmd/internal/application/ports"
+	"swapp-go/cmd/internal/domain"
+	"swapp-go/cmd/internal/utils"
+	"time"
+)
+
+var (
+	InvalidRefreshTokenErr = errors.New("invalid or expired refresh token")
+	RefreshTokenReusedErr  = errors.New("refresh token has already been used")
+	SessionNotFoundErr     = errors.New("session not found")
+	SessionRevokedErr      = errors.New("session has been revoked or has expired")
+)
+
+// refreshTokenSecretSize is the number of random bytes in a refresh token.
+const refreshTokenSecretSize = 32
+
+type SessionService struct {
+	repo            ports.SessionRepository
+	userRepo        ports.UserRepository
+	tokenIssuer     ports.AccessTokenIssuer
+	clock           ports.Clock
+	accessTokenTTL  time.Duration
+	refreshTokenTTL time.Duration
+}
+
+func NewSessionService(
+	repo ports.SessionRepository,
+	userRepo ports.UserRepository,
+	tokenIssuer ports.AccessTokenIssuer,
+	clock ports.Clock,
+	accessTokenTTL time.Duration,
+	refreshTokenTTL time.Duration,
+) *SessionService {
+	return &SessionService{
+		repo:            repo,
+		userRepo:        userRepo,
+		tokenIssuer:     tokenIssuer,
+		clock:           clock,
+		accessTokenTTL:  accessTokenTTL,
+		refreshTokenTTL: refreshTokenTTL,
+	}
+}
+
+// Start opens a new session for an authenticated user.
+func (service *SessionService) Start(user *domain.User, client domain.SessionClient) (*domain.AuthTokens, error) {
+	if user.IsSuspended() {
+		return nil, domain.AccountSuspendedErr
+	}
+
+	secret, err := utils.GenerateRandomToken(refreshTokenSecretSize)
+	if err != nil {
+		return nil, err
+	}
+
+	now := service.clock.Now()
+	session := &domain.Session{
+		ID:               uuid.New(),
+		UserID:           user.ID,
+		RefreshTokenHash: utils.HashToken(secret),
+		UserAgent:        client.UserAgent,
+		IPAddress:        client.IPAddress,
+		CreatedAt:        now,
+		LastUsedAt:       now,
+		ExpiresAt:        now.Add(service.refreshTokenTTL),
+	}
+
+	if err = service.repo.Create(session); err != nil {
+		return nil, err
+	}
+
+	return service.issueTokens(user, session, secret)
+}
+
+// Refresh exchanges a refresh token for a new access token and a new refresh
+// token. Presenting a refresh token that was already exchanged means it leaked,
+// so the whole session is revoked.
+func (service *SessionService) Refresh(refreshToken string, client domain.SessionClient) (*domain.AuthTokens, error) {
+	sessionID, secret, err := parseRefreshToken(refreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := service.repo.FindByID(sessionID)
+	if err != nil {
+		return nil, InvalidRefreshTokenErr
+	}
+
+	now := service.clock.Now()
+	if !session.IsActive(now) {
+		return nil, InvalidRefreshTokenErr
+	}
+
+	currentHash := session.RefreshTokenHash
+	if utils.HashToken(secret) != currentHash {
+		service.revokeReusedSession(session.ID)
+		return nil, RefreshTokenReusedErr
+	}
+
+	user, err := service.userRepo.FindByID(session.UserID)
+	if err != nil || user.IsSuspended() {
+		return nil, InvalidRefreshTokenErr
+	}
+
+	newSecret, err := utils.GenerateRandomToken(refreshTokenSecretSize)
+	if err != nil {
+		return nil, err
+	}
+
+	session.RefreshTokenHash = utils.HashToken(newSecret)
+	session.UserAgent = client.UserAgent
+	session.IPAddress = client.IPAddress
+	session.LastUsedAt = now
+	session.ExpiresAt = now.Add(service.refreshTokenTTL)
+
+	rotated, err := service.repo.TryRotate(session, currentHash)
+	if err != nil {
+		return nil, err
+	}
+	if !rotated {
+		// Another request exchanged the same token first.
+		service.revokeReusedSession(session.ID)
+		return nil, RefreshTokenReusedErr
+	}
+
+	return service.issueTokens(user, session, newSecret)
+}
+
+// Revoke ends one of the user's sessions. Revoking a session twice is not an error.
+func (service *SessionService) Revoke(userID uuid.UUID, sessionID uuid.UUID) error {
+	session, err := service.repo.FindByID(sessionID)
+	if err != nil || session.UserID != userID {
+		return SessionNotFoundErr
+	}
+
+	if session.RevokedAt != nil {
+		return nil
+	}
+
+	return service.repo.Revoke(sessionID, service.clock.Now())
+}
+
+// ListActive returns the sessions of the user that can still be refreshed,
+// most recently used first.
+func (service *SessionService) ListActive(userID uuid.UUID) ([]domain.Session, error) {
+	return service.repo.ListActiveByUser(userID, service.clock.Now())
+}
+
+// ValidateSession checks that an access token's session has not been revoked.
+func (service *SessionService) ValidateSession(sessionID uuid.UUID) error {
+	session, err := service.repo.FindByID(sessionID)
+	if err != nil {
+		return SessionNotFoundErr
+	}
+
+	if !session.IsActive(service.clock.Now()) {
+		return SessionRevokedErr
+	}
+
+	return nil
+}
+
+func (service *SessionService) issueTokens(user *domain.User, session *domain.Session, secret string) (*domain.AuthTokens, error) {
+	now := service.clock.Now()
+	accessTokenExpiresAt := now.Add(service.accessTokenTTL)
+
+	accessToken, err := service.tokenIssuer.Issue(domain.AccessTokenClaims{
+		UserID:    user.ID,
+		Email:     user.Email,
+		Role:      user.Role,
+		SessionID: session.ID,
+		IssuedAt:  now,
+		ExpiresAt: accessTokenExpiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.AuthTokens{
+		SessionID:             session.ID,
+		AccessToken:           accessToken,
+		AccessTokenExpiresAt:  accessTokenExpiresAt,
+		RefreshToken:          session.ID.String() + "." + secret,
+		RefreshTokenExpiresAt: session.ExpiresAt,
+	}, nil
+}
+
+func (service *SessionService) revokeReusedSession(sessionID uuid.UUID) {
+	log.Printf("Refresh token reuse detected, revoking session %s", sessionID)
+
+	if err := service.repo.Revoke(sessionID, service.clock.Now()); err != nil {
+		log.Printf("Failed to revoke session %s: %v", sessionID, err)
+	}
+}
+
+// parseRefreshToken splits a refresh token into the ID of its session and its secret.
+func parseRefreshToken(refreshToken string) (uuid.UUID, string, error) {
+	id, secret, found := strings.Cut(refreshToken, ".")
+	if !found || secret == "" {
+		return uuid.Nil, "", InvalidRefreshTokenErr
+	}
+
+	sessionID, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, "", InvalidRefreshTokenErr
+	}
+
+	return sessionID, secret, nil
+}
@@ -0,0 +1,14 @@
+package services
+
+import (
+	"github.com/google/uuid"
+	"swapp-go/cmd/internal/domain"
+)
+
+type SessionServiceInterface interface {
+	Start(user *domain.User, client domain.SessionClient) (*domain.AuthTokens, error)
+	Refresh(refreshToken string, client domain.SessionClient) (*domain.AuthTokens, error)
+	Revoke(userID uuid.UUID, sessionID uuid.UUID) error
+	ListActive(userID uuid.UUID) ([]domain.Session, error)
+	ValidateSession(sessionID uuid.UUID) error
+}
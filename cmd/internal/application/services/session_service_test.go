@@ -0,0 +1,268 @@
+package services_test
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"strings"
+	testMocks "swapp-go/cmd/internal/application/mocks"
+	"swapp-go/cmd/internal/application/services"
+	"swapp-go/cmd/internal/domain"
+	"swapp-go/cmd/internal/utils"
+	"testing"
+	"time"
+)
+
+const (
+	testAccessTokenTTL  = 15 * time.Minute
+	testRefreshTokenTTL = 30 * 24 * time.Hour
+)
+
+var testClient = domain.SessionClient{UserAgent: "test-agent", IPAddress: "203.0.113.7"}
+
+func setupSessionServiceTest(t *testing.T) (*services.SessionService, *testMocks.MockSessionRepository, *testMocks.MockUserRepository, *testMocks.Clock) {
+	t.Helper()
+
+	mockSessionRepo := new(testMocks.MockSessionRepository)
+	mockUserRepo := new(testMocks.MockUserRepository)
+	mockTokenIssuer := new(testMocks.MockAccessTokenIssuer)
+	mockTokenIssuer.On("Issue", mock.AnythingOfType("domain.AccessTokenClaims")).Return("access-token", nil)
+	clock := &testMocks.Clock{Current: testNow}
+
+	service := services.NewSessionService(mockSessionRepo, mockUserRepo, mockTokenIssuer, clock, testAccessTokenTTL, testRefreshTokenTTL)
+
+	return service, mockSessionRepo, mockUserRepo, clock
+}
+
+// activeSession returns a session whose current refresh token is the returned token.
+func activeSession(userID uuid.UUID) (*domain.Session, string) {
+	secret := "current-secret"
+	session := &domain.Session{
+		ID:               uuid.New(),
+		UserID:           userID,
+		RefreshTokenHash: utils.HashToken(secret),
+		CreatedAt:        testNow.Add(-time.Hour),
+		LastUsedAt:       testNow.Add(-time.Hour),
+		ExpiresAt:        testNow.Add(time.Hour),
+	}
+
+	return session, session.ID.String() + "." + secret
+}
+
+func TestSessionService_Start(t *testing.T) {
+	mockSessionRepo := new(testMocks.MockSessionRepository)
+	mockTokenIssuer := new(testMocks.MockAccessTokenIssuer)
+	service := services.NewSessionService(
+		mockSessionRepo,
+		new(testMocks.MockUserRepository),
+		mockTokenIssuer,
+		&testMocks.Clock{Current: testNow},
+		testAccessTokenTTL,
+		testRefreshTokenTTL,
+	)
+	user := &domain.User{ID: uuid.New(), Email: "test@example.com"}
+
+	var created *domain.Session
+	mockSessionRepo.On("Create", mock.AnythingOfType("*domain.Session")).
+		Run(func(args mock.Arguments) { created = args.Get(0).(*domain.Session) }).
+		Return(nil)
+
+	mockTokenIssuer.On("Issue", mock.MatchedBy(func(claims domain.AccessTokenClaims) bool {
+		return claims.UserID == user.ID &&
+			claims.Email == user.Email &&
+			claims.SessionID == created.ID &&
+			claims.IssuedAt.Equal(testNow) &&
+			claims.ExpiresAt.Equal(testNow.Add(testAccessTokenTTL))
+	})).Return("access-token", nil)
+
+	tokens, err := service.Start(user, testClient)
+	assert.NoError(t, err)
+	assert.Equal(t, "access-token", tokens.AccessToken)
+	assert.Equal(t, testNow.Add(testAccessTokenTTL), tokens.AccessTokenExpiresAt)
+	assert.Equal(t, testNow.Add(testRefreshTokenTTL), tokens.RefreshTokenExpiresAt)
+	assert.Equal(t, created.ID, tokens.SessionID)
+	assert.Equal(t, user.ID, created.UserID)
+	assert.Equal(t, "test-agent", created.UserAgent)
+	assert.Equal(t, "203.0.113.7", created.IPAddress)
+
+	sessionID, secret, _ := strings.Cut(tokens.RefreshToken, ".")
+	assert.Equal(t, created.ID.String(), sessionID)
+	assert.Equal(t, utils.HashToken(secret), created.RefreshTokenHash)
+	assert.NotEqual(t, secret, created.RefreshTokenHash)
+}
+
+func TestSessionService_Refresh(t *testing.T) {
+	user := &domain.User{ID: uuid.New(), Email: "test@example.com"}
+
+	t.Run("RotatesRefreshToken", func(t *testing.T) {
+		service, mockSessionRepo, mockUserRepo, clock := setupSessionServiceTest(t)
+		session, refreshToken := activeSession(user.ID)
+		currentHash := session.RefreshTokenHash
+		clock.Advance(time.Minute)
+
+		mockSessionRepo.On("FindByID", session.ID).Return(session, nil)
+		mockUserRepo.On("FindByID", user.ID).Return(user, nil)
+		mockSessionRepo.On("TryRotate", session, currentHash).Return(true, nil)
+
+		tokens, err := service.Refresh(refreshToken, testClient)
+		assert.NoError(t, err)
+		assert.NotEqual(t, refreshToken, tokens.RefreshToken)
+		assert.True(t, strings.HasPrefix(tokens.RefreshToken, session.ID.String()+"."))
+		assert.NotEqual(t, currentHash, session.RefreshTokenHash)
+		assert.Equal(t, clock.Now(), session.LastUsedAt)
+		assert.Equal(t, clock.Now().Add(testRefreshTokenTTL), session.ExpiresAt)
+		mockSessionRepo.AssertNotCalled(t, "Revoke", mock.Anything, mock.Anything)
+	})
+
+	t.Run("SuspendedUserCannotRefresh", func(t *testing.T) {
+		service, mockSessionRepo, mockUserRepo, _ := setupSessionServiceTest(t)
+		session, refreshToken := activeSession(user.ID)
+		suspendedAt := testNow.Add(-time.Minute)
+
+		mockSessionRepo.On("FindByID", session.ID).Return(session, nil)
+		mockUserRepo.On("FindByID", user.ID).Return(&domain.User{ID: user.ID, SuspendedAt: &suspendedAt}, nil)
+
+		_, err := service.Refresh(refreshToken, testClient)
+		assert.ErrorIs(t, err, services.InvalidRefreshTokenErr)
+		mockSessionRepo.AssertNotCalled(t, "TryRotate", mock.Anything, mock.Anything)
+	})
+
+	t.Run("ReusedTokenRevokesSession", func(t *testing.T) {
+		service, mockSessionRepo, _, _ := setupSessionServiceTest(t)
+		session, _ := activeSession(user.ID)
+
+		mockSessionRepo.On("FindByID", session.ID).Return(session, nil)
+		mockSessionRepo.On("Revoke", session.ID, testNow).Return(nil)
+
+		_, err := service.Refresh(session.ID.String()+".rotated-secret", testClient)
+		assert.ErrorIs(t, err, services.RefreshTokenReusedErr)
+		mockSessionRepo.AssertExpectations(t)
+	})
+
+	t.Run("ConcurrentRotationRevokesSession", func(t *testing.T) {
+		service, mockSessionRepo, mockUserRepo, _ := setupSessionServiceTest(t)
+		session, refreshToken := activeSession(user.ID)
+
+		mockSessionRepo.On("FindByID", session.ID).Return(session, nil)
+		mockUserRepo.On("FindByID", user.ID).Return(user, nil)
+		mockSessionRepo.On("TryRotate", session, mock.Anything).Return(false, nil)
+		mockSessionRepo.On("Revoke", session.ID, testNow).Return(nil)
+
+		_, err := service.Refresh(refreshToken, testClient)
+		assert.ErrorIs(t, err, services.RefreshTokenReusedErr)
+		mockSessionRepo.AssertExpectations(t)
+	})
+
+	t.Run("RevokedSession", func(t *testing.T) {
+		service, mockSessionRepo, _, _ := setupSessionServiceTest(t)
+		session, refreshToken := activeSession(user.ID)
+		revokedAt := testNow.Add(-time.Minute)
+		session.RevokedAt = &revokedAt
+
+		mockSessionRepo.On("FindByID", session.ID).Return(session, nil)
+
+		_, err := service.Refresh(refreshToken, testClient)
+		assert.ErrorIs(t, err, services.InvalidRefreshTokenErr)
+		mockSessionRepo.AssertNotCalled(t, "TryRotate", mock.Anything, mock.Anything)
+	})
+
+	t.Run("ExpiredSession", func(t *testing.T) {
+		service, mockSessionRepo, _, clock := setupSessionServiceTest(t)
+		session, refreshToken := activeSession(user.ID)
+		clock.Advance(2 * time.Hour)
+
+		mockSessionRepo.On("FindByID", session.ID).Return(session, nil)
+
+		_, err := service.Refresh(refreshToken, testClient)
+		assert.ErrorIs(t, err, services.InvalidRefreshTokenErr)
+	})
+
+	t.Run("MalformedToken", func(t *testing.T) {
+		service, mockSessionRepo, _, _ := setupSessionServiceTest(t)
+
+		for _, refreshToken := range []string{"", "no-separator", "not-a-uuid.secret", uuid.NewString() + "."} {
+			_, err := service.Refresh(refreshToken, testClient)
+			assert.ErrorIs(t, err, services.InvalidRefreshTokenErr, refreshToken)
+		}
+		mockSessionRepo.AssertNotCalled(t, "FindByID", mock.Anything)
+	})
+
+	t.Run("UnknownSession", func(t *testing.T) {
+		service, mockSessionRepo, _, _ := setupSessionServiceTest(t)
+		sessionID := uuid.New()
+
+		mockSessionRepo.On("FindByID", sessionID).Return(nil, errors.New("record not found"))
+
+		_, err := service.Refresh(sessionID.String()+".secret", testClient)
+		assert.ErrorIs(t, err, services.InvalidRefreshTokenErr)
+	})
+}
+
+func TestSessionService_Revoke(t *testing.T) {
+	userID := uuid.New()
+
+	t.Run("Success", func(t *testing.T) {
+		service, mockSessionRepo, _, _ := setupSessionServiceTest(t)
+		session, _ := activeSession(userID)
+
+		mockSessionRepo.On("FindByID", session.ID).Return(session, nil)
+		mockSessionRepo.On("Revoke", session.ID, testNow).Return(nil)
+
+		assert.NoError(t, service.Revoke(userID, session.ID))
+		mockSessionRepo.AssertExpectations(t)
+	})
+
+	t.Run("OtherUsersSession", func(t *testing.T) {
+		service, mockSessionRepo, _, _ := setupSessionServiceTest(t)
+		session, _ := activeSession(uuid.New())
+
+		mockSessionRepo.On("FindByID", session.ID).Return(session, nil)
+
+		err := service.Revoke(userID, session.ID)
+		assert.ErrorIs(t, err, services.SessionNotFoundErr)
+		mockSessionRepo.AssertNotCalled(t, "Revoke", mock.Anything, mock.Anything)
+	})
+
+	t.Run("AlreadyRevoked", func(t *testing.T) {
+		service, mockSessionRepo, _, _ := setupSessionServiceTest(t)
+		session, _ := activeSession(userID)
+		revokedAt := testNow.Add(-time.Minute)
+		session.RevokedAt = &revokedAt
+
+		mockSessionRepo.On("FindByID", session.ID).Return(session, nil)
+
+		assert.NoError(t, service.Revoke(userID, session.ID))
+		mockSessionRepo.AssertNotCalled(t, "Revoke", mock.Anything, mock.Anything)
+	})
+}
+
+func TestSessionService_ValidateSession(t *testing.T) {
+	service, mockSessionRepo, _, _ := setupSessionServiceTest(t)
+
+	active, _ := activeSession(uuid.New())
+	revoked, _ := activeSession(uuid.New())
+	revokedAt := testNow.Add(-time.Minute)
+	revoked.RevokedAt = &revokedAt
+	unknownID := uuid.New()
+
+	mockSessionRepo.On("FindByID", active.ID).Return(active, nil)
+	mockSessionRepo.On("FindByID", revoked.ID).Return(revoked, nil)
+	mockSessionRepo.On("FindByID", unknownID).Return(nil, errors.New("record not found"))
+
+	assert.NoError(t, service.ValidateSession(active.ID))
+	assert.ErrorIs(t, service.ValidateSession(revoked.ID), services.SessionRevokedErr)
+	assert.ErrorIs(t, service.ValidateSession(unknownID), services.SessionNotFoundErr)
+}
+
+func TestSessionService_ListActive(t *testing.T) {
+	service, mockSessionRepo, _, _ := setupSessionServiceTest(t)
+	userID := uuid.New()
+	session, _ := activeSession(userID)
+
+	mockSessionRepo.On("ListActiveByUser", userID, testNow).Return([]domain.Session{*session}, nil)
+
+	sessions, err := service.ListActive(userID)
+	assert.NoError(t, err)
+	assert.Len(t, sessions, 1)
+}
@@ -4,67 +4,104 @@ import (
 	"errors"
 	"fmt"
 	"github.com/google/uuid"
+	"log"
+	"net/url"
 	"swapp-go/cmd/internal/application/ports"
 	"swapp-go/cmd/internal/domain"
+	"time"
 )
 
-var ItemAlreadyOfferedErr = errors.New("item is already out for offer")
+var (
+	ItemAlreadyOfferedErr    = errors.New("item is already out for offer")
+	SwapRequestNotFoundErr   = errors.New("swap request not found")
+	OfferedItemNotFoundErr   = errors.New("offered item not found")
+	RequestedItemNotFoundErr = errors.New("requested item not found")
+	OfferedItemNotOwnedErr   = errors.New("offered item does not belong to the sender")
+	RequestedItemNotOwnedErr = errors.New("requested item does not belong to the recipient")
+	RequestedItemSwappedErr  = errors.New("requested item is already part of an accepted swap")
+	SelfSwapErr              = errors.New("cannot create a swap request with yourself")
+	SwapRequestChangedErr    = errors.New("swap request changed while it was being updated")
+)
+
+// committedSwapStatuses are the statuses in which a request's items are promised to the other party.
+var committedSwapStatuses = []domain.SwapRequestStatus{domain.StatusAccepted, domain.StatusDisputed}
 
 type SwapRequestService struct {
-	repo         ports.SwapRequestRepository
-	userRepo     ports.UserRepository
-	itemRepo     ports.ItemRepository
-	emailService ports.EmailService
+	repo           ports.SwapRequestRepository
+	userRepo       ports.UserRepository
+	itemRepo       ports.ItemRepository
+	unitOfWork     ports.UnitOfWork
+	clock          ports.Clock
+	requestTTL     time.Duration
+	reminderBefore time.Duration
+	signer         ports.LinkTokenSigner
+	unsubscribeURL string
 }
 
+// NewSwapRequestService creates the service. Recipients are reminded
+// reminderBefore a pending request expires; unsubscribeURL receives the
+// token of the one-click unsubscribe links in swap emails.
 func NewSwapRequestService(
 	repo ports.SwapRequestRepository,
 	userRepo ports.UserRepository,
 	itemRepo ports.ItemRepository,
-	emailService ports.EmailService,
+	unitOfWork ports.UnitOfWork,
+	clock ports.Clock,
+	requestTTL time.Duration,
+	reminderBefore time.Duration,
+	signer ports.LinkTokenSigner,
+	unsubscribeURL string,
 ) *SwapRequestService {
 	return &SwapRequestService{
-		repo:         repo,
-		userRepo:     userRepo,
-		itemRepo:     itemRepo,
-		emailService: emailService,
+		repo:           repo,
+		userRepo:       userRepo,
+		itemRepo:       itemRepo,
+		unitOfWork:     unitOfWork,
+		clock:          clock,
+		requestTTL:     requestTTL,
+		reminderBefore: reminderBefore,
+		signer:         signer,
+		unsubscribeURL: unsubscribeURL,
 	}
 }
 
 func (service *SwapRequestService) Create(request *domain.SwapRequest) error {
-	offeredItemID := request.OfferedItemID
+	if request.SenderID == request.RecipientID {
+		return SelfSwapErr
+	}
 
-	item, err := service.itemRepo.FindByID(offeredItemID)
-	if err != nil {
-		return errors.New("offered item not found")
+	request.NormalizeItems()
+	if err := request.ValidateItems(); err != nil {
+		return err
 	}
 
-	success, err := service.itemRepo.TryMarkItemAsOffered(item.ID)
+	sender, err := service.userRepo.FindByID(request.SenderID)
 	if err != nil {
 		return err
 	}
-	if !success {
-		return ItemAlreadyOfferedErr
+	if !sender.IsEmailVerified() {
+		return domain.EmailNotVerifiedErr
 	}
 
-	if err = service.setItemOfferedStatus(item.ID, true); err != nil {
-		return errors.New("failed to mark item as offered")
+	if request.ExpiresAt == nil {
+		request.ExpiresAt = service.expiresAt()
 	}
 
-	if err = service.repo.Create(request); err != nil {
-		_ = service.setItemOfferedStatus(item.ID, false)
-		return err
-	}
+	return service.unitOfWork.Do(func(repos ports.Repositories) error {
+		if err := validateSwapItems(repos, request); err != nil {
+			return err
+		}
 
-	subject := fmt.Sprintf("New Swap Request Created (reference %v)", request.ReferenceNumber)
+		if err := lockOfferedItems(repos.Items, request.OfferedItemIDs); err != nil {
+			return err
+		}
 
-	service.sendEmailToUser(
-		request.RecipientID,
-		subject,
-		fmt.Sprintf("You have a new swap request from %s", service.getUsernameSafe(request.SenderID)),
-	)
+		if err := repos.SwapRequests.Create(request); err != nil {
+			return err
+		}
 
-	return nil
+		return service.notify(repos, domain.EmailTemplateSwapRequestCreated, request, request.RecipientID)
+	})
 }
 
 func (service *SwapRequestService) FindByID(id uuid.UUID) (*domain.SwapRequest, error) {
@@ -83,92 +120,625 @@ func (service *SwapRequestService) ListByStatus(status domain.SwapRequestStatus)
 	return service.repo.ListByStatus(status)
 }
 
-func (service *SwapRequestService) UpdateStatus(id uuid.UUID, status domain.SwapRequestStatus) error {
-	swapRequest, err := service.repo.FindByID(id)
-	if err != nil {
-		return err
+func (service *SwapRequestService) UpdateStatus(id, userID uuid.UUID, status domain.SwapRequestStatus) error {
+	// Countering opens a new request, so it has to go through CounterOffer.
+	if status == domain.StatusCountered {
+		return domain.CounterOfferRequiredErr
 	}
 
-	if err = service.repo.UpdateStatus(id, status); err != nil {
-		return err
-	}
+	return service.unitOfWork.Do(func(repos ports.Repositories) error {
+		swapRequest, err := repos.SwapRequests.FindByID(id)
+		if err != nil {
+			return SwapRequestNotFoundErr
+		}
+
+		party, ok := swapRequest.PartyOf(userID)
+		if !ok {
+			return domain.NotSwapRequestParticipantErr
+		}
+
+		if status == domain.StatusAccepted && swapRequest.IsOverdue(service.clock.Now()) {
+			return domain.SwapRequestExpiredErr
+		}
 
-	subject := fmt.Sprintf("Swap request with reference %s has been %s", swapRequest.ReferenceNumber, status)
+		from := swapRequest.Status
+		if err = swapRequest.TransitionTo(status, party); err != nil {
+			return err
+		}
+
+		// The other party may have changed the request since it was read.
+		updated, err := repos.SwapRequests.TryUpdateStatus(id, from, status)
+		if err != nil {
+			return err
+		}
+		if !updated {
+			return SwapRequestChangedErr
+		}
+
+		if status.ReleasesItems() {
+			if err = releaseOfferedItems(repos.Items, swapRequest.AllOfferedItemIDs()); err != nil {
+				return fmt.Errorf("error releasing items after %s: %w", status, err)
+			}
+		}
+
+		return service.enqueueStatusEmail(repos, swapRequest, party, status)
+	})
+}
 
+// enqueueStatusEmail tells the other party that party moved the request to status.
+func (service *SwapRequestService) enqueueStatusEmail(
+	repos ports.Repositories,
+	swapRequest *domain.SwapRequest,
+	party domain.SwapParty,
+	status domain.SwapRequestStatus,
+) error {
 	switch status {
 	case domain.StatusAccepted:
-		service.sendEmailToUser(
-			swapRequest.SenderID,
-			subject,
-			fmt.Sprintf("Good news! Your swap request has been accepted by %s.", service.getUsernameSafe(swapRequest.RecipientID)),
-		)
+		return service.notify(repos, domain.EmailTemplateSwapRequestAccepted, swapRequest, swapRequest.SenderID)
 	case domain.StatusRejected:
-		service.sendEmailToUser(
-			swapRequest.SenderID,
-			subject,
-			fmt.Sprintf("Sorry, your swap request has been rejected by %s.", service.getUsernameSafe(swapRequest.RecipientID)),
-		)
-		if err = service.setItemOfferedStatus(swapRequest.OfferedItemID, false); err != nil {
-			return fmt.Errorf("error releasing item after rejection: %w", err)
-		}
+		return service.notify(repos, domain.EmailTemplateSwapRequestRejected, swapRequest, swapRequest.SenderID)
+	case domain.StatusDisputed:
+		return service.notify(repos, domain.EmailTemplateSwapRequestDisputed, swapRequest, counterpartOf(swapRequest, party))
 	case domain.StatusCancelled:
-		if err = service.setItemOfferedStatus(swapRequest.OfferedItemID, false); err != nil {
-			return fmt.Errorf("error releasing item after cancellation: %w", err)
+		return service.notify(repos, domain.EmailTemplateSwapRequestCancelled, swapRequest, counterpartOf(swapRequest, party))
+	default:
+		return nil
+	}
+}
+
+// ConfirmHandover records that userID handed over and received the items of
+// an accepted swap. The confirmation that completes the handover also
+// completes the swap, in the same transaction.
+func (service *SwapRequestService) ConfirmHandover(id, userID uuid.UUID) (*domain.SwapRequest, error) {
+	var swapRequest *domain.SwapRequest
+
+	err := service.unitOfWork.Do(func(repos ports.Repositories) error {
+		var err error
+
+		swapRequest, err = repos.SwapRequests.FindByID(id)
+		if err != nil {
+			return SwapRequestNotFoundErr
+		}
+
+		party, ok := swapRequest.PartyOf(userID)
+		if !ok {
+			return domain.NotSwapRequestParticipantErr
+		}
+
+		if err = swapRequest.ConfirmHandover(party); err != nil {
+			return err
+		}
+
+		confirmed, err := repos.SwapRequests.TryMarkHandoverConfirmed(id, party)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			return SwapRequestChangedErr
+		}
+
+		// Read the request again to see a confirmation the other party made
+		// since it was loaded.
+		swapRequest, err = repos.SwapRequests.FindByID(id)
+		if err != nil {
+			return err
+		}
+
+		if !swapRequest.HandoverConfirmed() {
+			return service.notify(repos, domain.EmailTemplateHandoverConfirmed, swapRequest, counterpartOf(swapRequest, party))
 		}
+
+		swapRequest, err = service.complete(repos, swapRequest)
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	return swapRequest, nil
 }
 
-func (service *SwapRequestService) Delete(id uuid.UUID) error {
+// complete moves a request whose handover both parties confirmed to completed
+// and exchanges the ownership of its items. When the other party's
+// confirmation completed the swap concurrently, it returns the request as
+// stored.
+func (service *SwapRequestService) complete(repos ports.Repositories, swapRequest *domain.SwapRequest) (*domain.SwapRequest, error) {
+	from := swapRequest.Status
+	if err := swapRequest.TransitionTo(domain.StatusCompleted, domain.PartySystem); err != nil {
+		return nil, err
+	}
+
+	completed, err := repos.SwapRequests.TryUpdateStatus(swapRequest.ID, from, domain.StatusCompleted)
+	if err != nil {
+		return nil, err
+	}
+	if !completed {
+		stored, err := repos.SwapRequests.FindByID(swapRequest.ID)
+		if err != nil {
+			return nil, err
+		}
+		if stored.Status != domain.StatusCompleted {
+			return nil, SwapRequestChangedErr
+		}
+
+		return stored, nil
+	}
+
+	if err = repos.Items.TransferOwnership(swapRequest.AllOfferedItemIDs(), swapRequest.RecipientID); err != nil {
+		return nil, fmt.Errorf("error transferring offered items: %w", err)
+	}
+
+	if err = repos.Items.TransferOwnership(swapRequest.AllRequestedItemIDs(), swapRequest.SenderID); err != nil {
+		return nil, fmt.Errorf("error transferring requested items: %w", err)
+	}
+
+	if err = service.notifyBothParties(repos, domain.EmailTemplateSwapCompleted, swapRequest); err != nil {
+		return nil, err
+	}
+
+	return swapRequest, nil
+}
+
+func (service *SwapRequestService) CounterOffer(id, userID uuid.UUID, offer domain.CounterOffer) (*domain.SwapRequest, error) {
+	var counter *domain.SwapRequest
+
+	err := service.unitOfWork.Do(func(repos ports.Repositories) error {
+		original, err := repos.SwapRequests.FindByID(id)
+		if err != nil {
+			return SwapRequestNotFoundErr
+		}
+
+		party, ok := original.PartyOf(userID)
+		if !ok {
+			return domain.NotSwapRequestParticipantErr
+		}
+
+		// A counter offer opens a new request, so it needs a verified address
+		// just like Create.
+		user, err := repos.Users.FindByID(userID)
+		if err != nil {
+			return err
+		}
+		if !user.IsEmailVerified() {
+			return domain.EmailNotVerifiedErr
+		}
+
+		if original.IsOverdue(service.clock.Now()) {
+			return domain.SwapRequestExpiredErr
+		}
+
+		from := original.Status
+		counter, err = original.Counter(party, offer)
+		if err != nil {
+			return err
+		}
+		counter.ExpiresAt = service.expiresAt()
+
+		if err = validateSwapItems(repos, counter); err != nil {
+			return err
+		}
+
+		countered, err := repos.SwapRequests.TryUpdateStatus(original.ID, from, original.Status)
+		if err != nil {
+			return err
+		}
+		if !countered {
+			return SwapRequestChangedErr
+		}
+
+		if err = releaseOfferedItems(repos.Items, original.AllOfferedItemIDs()); err != nil {
+			return fmt.Errorf("error releasing items after counter offer: %w", err)
+		}
+
+		if err = lockOfferedItems(repos.Items, counter.OfferedItemIDs); err != nil {
+			return err
+		}
+
+		if err = repos.SwapRequests.Create(counter); err != nil {
+			return err
+		}
+
+		return service.notify(repos, domain.EmailTemplateSwapRequestCountered, counter, counter.RecipientID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return counter, nil
+}
+
+func (service *SwapRequestService) ListThread(id, userID uuid.UUID) ([]domain.SwapRequest, error) {
 	swapRequest, err := service.repo.FindByID(id)
 	if err != nil {
-		return err
+		return nil, SwapRequestNotFoundErr
 	}
 
-	if err = service.repo.Delete(id); err != nil {
-		return err
+	if _, ok := swapRequest.PartyOf(userID); !ok {
+		return nil, domain.NotSwapRequestParticipantErr
 	}
 
-	subject := fmt.Sprintf("Swap request with reference %s has been cancelled", swapRequest.ReferenceNumber)
+	return service.repo.ListByThread(swapRequest.ThreadID)
+}
+
+// ForceCancel cancels a pending, accepted or disputed request on behalf of a
+// moderator, releases the offered items and tells both parties.
+func (service *SwapRequestService) ForceCancel(id uuid.UUID) (*domain.SwapRequest, error) {
+	var swapRequest *domain.SwapRequest
+
+	err := service.unitOfWork.Do(func(repos ports.Repositories) error {
+		var err error
 
-	service.sendEmailToUser(
-		swapRequest.RecipientID,
-		subject,
-		fmt.Sprintf("The swap request from %s has been cancelled.", service.getUsernameSafe(swapRequest.SenderID)),
-	)
+		swapRequest, err = repos.SwapRequests.FindByID(id)
+		if err != nil {
+			return SwapRequestNotFoundErr
+		}
+
+		from := swapRequest.Status
+		if err = swapRequest.TransitionTo(domain.StatusCancelled, domain.PartyModerator); err != nil {
+			return err
+		}
+
+		cancelled, err := repos.SwapRequests.TryUpdateStatus(id, from, domain.StatusCancelled)
+		if err != nil {
+			return err
+		}
+		if !cancelled {
+			return SwapRequestChangedErr
+		}
+
+		if err = releaseOfferedItems(repos.Items, swapRequest.AllOfferedItemIDs()); err != nil {
+			return fmt.Errorf("error releasing items after cancellation: %w", err)
+		}
 
-	return service.setItemOfferedStatus(swapRequest.OfferedItemID, false)
+		return service.notifyBothParties(repos, domain.EmailTemplateSwapRequestCancelled, swapRequest)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return swapRequest, nil
 }
 
-func (service *SwapRequestService) setItemOfferedStatus(itemID uuid.UUID, offered bool) error {
-	_, err := service.itemRepo.Update(itemID, map[string]interface{}{
+// ExpireOverdue moves every pending request past its expiry time to expired,
+// releases the offered items and notifies both parties. It returns how many
+// requests were expired; failures on individual requests are logged and skipped.
+func (service *SwapRequestService) ExpireOverdue() (int, error) {
+	overdue, err := service.repo.ListOverdue(service.clock.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	expiredCount := 0
+	for i := range overdue {
+		expired, err := service.expire(&overdue[i])
+		if err != nil {
+			log.Printf("Failed to expire swap request %s: %v", overdue[i].ID, err)
+			continue
+		}
+		if expired {
+			expiredCount++
+		}
+	}
+
+	return expiredCount, nil
+}
+
+func (service *SwapRequestService) expire(swapRequest *domain.SwapRequest) (bool, error) {
+	if err := swapRequest.TransitionTo(domain.StatusExpired, domain.PartySystem); err != nil {
+		return false, err
+	}
+
+	var expired bool
+
+	err := service.unitOfWork.Do(func(repos ports.Repositories) error {
+		var err error
+
+		// The recipient may have answered since the request was listed.
+		expired, err = repos.SwapRequests.TryUpdateStatus(swapRequest.ID, domain.StatusPending, domain.StatusExpired)
+		if err != nil || !expired {
+			return err
+		}
+
+		if err = releaseOfferedItems(repos.Items, swapRequest.AllOfferedItemIDs()); err != nil {
+			return fmt.Errorf("error releasing items after expiry: %w", err)
+		}
+
+		return service.notifyBothParties(repos, domain.EmailTemplateSwapRequestExpired, swapRequest)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return expired, nil
+}
+
+// RemindExpiring emails the recipient of every pending request that expires
+// within the reminder window, once per request. It returns how many requests
+// were marked as reminded; failures on individual requests are logged and
+// skipped.
+func (service *SwapRequestService) RemindExpiring() (int, error) {
+	if service.reminderBefore <= 0 {
+		return 0, nil
+	}
+
+	now := service.clock.Now()
+	expiring, err := service.repo.ListExpiringSoon(now, now.Add(service.reminderBefore))
+	if err != nil {
+		return 0, err
+	}
+
+	remindedCount := 0
+	for i := range expiring {
+		reminded, err := service.remind(&expiring[i], now)
+		if err != nil {
+			log.Printf("Failed to remind recipient of swap request %s: %v", expiring[i].ID, err)
+			continue
+		}
+		if reminded {
+			remindedCount++
+		}
+	}
+
+	return remindedCount, nil
+}
+
+func (service *SwapRequestService) remind(swapRequest *domain.SwapRequest, now time.Time) (bool, error) {
+	var reminded bool
+
+	err := service.unitOfWork.Do(func(repos ports.Repositories) error {
+		var err error
+
+		// Another sweep may have reminded the recipient, or they may have answered.
+		reminded, err = repos.SwapRequests.TryMarkReminderSent(swapRequest.ID, now)
+		if err != nil || !reminded {
+			return err
+		}
+
+		return service.notify(repos, domain.EmailTemplateSwapRequestExpiring, swapRequest, swapRequest.RecipientID)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return reminded, nil
+}
+
+// Delete withdraws a pending request on behalf of its sender. The request is
+// cancelled rather than removed, so that it stays in both parties' history
+// and in its counter-offer thread.
+func (service *SwapRequestService) Delete(id uuid.UUID) error {
+	return service.unitOfWork.Do(func(repos ports.Repositories) error {
+		swapRequest, err := repos.SwapRequests.FindByID(id)
+		if err != nil {
+			return SwapRequestNotFoundErr
+		}
+
+		if err = swapRequest.Withdraw(); err != nil {
+			return err
+		}
+
+		withdrawn, err := repos.SwapRequests.TryUpdateStatus(id, domain.StatusPending, domain.StatusCancelled)
+		if err != nil {
+			return err
+		}
+		if !withdrawn {
+			return SwapRequestChangedErr
+		}
+
+		if err = releaseOfferedItems(repos.Items, swapRequest.AllOfferedItemIDs()); err != nil {
+			return err
+		}
+
+		return service.notify(repos, domain.EmailTemplateSwapRequestWithdrawn, swapRequest, swapRequest.RecipientID)
+	})
+}
+
+// expiresAt returns the expiry time for a request opened now, or nil when expiry is disabled.
+func (service *SwapRequestService) expiresAt() *time.Time {
+	if service.requestTTL <= 0 {
+		return nil
+	}
+
+	expiresAt := service.clock.Now().Add(service.requestTTL)
+	return &expiresAt
+}
+
+// validateSwapItems checks that every item exists, belongs to the expected side
+// of the request and that no requested item is already promised in another swap.
+func validateSwapItems(repos ports.Repositories, request *domain.SwapRequest) error {
+	for _, itemID := range request.AllOfferedItemIDs() {
+		offeredItem, err := repos.Items.FindByID(itemID)
+		if err != nil {
+			return OfferedItemNotFoundErr
+		}
+		if offeredItem.UserID != request.SenderID {
+			return OfferedItemNotOwnedErr
+		}
+	}
+
+	for _, itemID := range request.AllRequestedItemIDs() {
+		requestedItem, err := repos.Items.FindByID(itemID)
+		if err != nil {
+			return RequestedItemNotFoundErr
+		}
+		if requestedItem.UserID != request.RecipientID {
+			return RequestedItemNotOwnedErr
+		}
+
+		swapped, err := repos.SwapRequests.IsItemInSwapWithStatus(itemID, committedSwapStatuses...)
+		if err != nil {
+			return err
+		}
+		if swapped {
+			return RequestedItemSwappedErr
+		}
+	}
+
+	return nil
+}
+
+// lockOfferedItems marks every item as offered, failing if any of them is already out for offer.
+func lockOfferedItems(itemRepo ports.ItemRepository, itemIDs []uuid.UUID) error {
+	for _, itemID := range itemIDs {
+		success, err := itemRepo.TryMarkItemAsOffered(itemID)
+		if err != nil {
+			return err
+		}
+		if !success {
+			return ItemAlreadyOfferedErr
+		}
+	}
+
+	return nil
+}
+
+func releaseOfferedItems(itemRepo ports.ItemRepository, itemIDs []uuid.UUID) error {
+	for _, itemID := range itemIDs {
+		if err := setItemOfferedStatus(itemRepo, itemID, false); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func setItemOfferedStatus(itemRepo ports.ItemRepository, itemID uuid.UUID, offered bool) error {
+	_, err := itemRepo.Update(itemID, map[string]interface{}{
 		"offered": offered,
 	})
 
 	return err
 }
 
-func (service *SwapRequestService) sendEmailToUser(userID uuid.UUID, subject, body string) {
-	user, err := service.userRepo.FindByID(userID)
+// notify tells one of the parties of swapRequest about the event behind
+// template. The event is always recorded in the user's in-app inbox and is
+// emailed unless the user opted out of it. Both are written in the unit of
+// work, so that they only happen if the change they announce is committed.
+func (service *SwapRequestService) notify(
+	repos ports.Repositories,
+	template domain.EmailTemplate,
+	swapRequest *domain.SwapRequest,
+	userID uuid.UUID,
+) error {
+	notification := domain.NewSwapNotification(userID, template, swapRequest, service.clock.Now())
+	if err := repos.Notifications.Create(notification); err != nil {
+		return fmt.Errorf("error recording notification for user %s: %w", userID, err)
+	}
+
+	return service.enqueueSwapEmail(repos, template, swapRequest, userID)
+}
+
+func (service *SwapRequestService) notifyBothParties(
+	repos ports.Repositories,
+	template domain.EmailTemplate,
+	swapRequest *domain.SwapRequest,
+) error {
+	if err := service.notify(repos, template, swapRequest, swapRequest.SenderID); err != nil {
+		return err
+	}
+
+	return service.notify(repos, template, swapRequest, swapRequest.RecipientID)
+}
+
+// enqueueSwapEmail queues an email about swapRequest to one of its parties in
+// the outbox. Emails about an event the user opted out of are skipped; the
+// others carry a link to opt out.
+func (service *SwapRequestService) enqueueSwapEmail(
+	repos ports.Repositories,
+	template domain.EmailTemplate,
+	swapRequest *domain.SwapRequest,
+	userID uuid.UUID,
+) error {
+	user, err := repos.Users.FindByID(userID)
 	if err != nil {
-		fmt.Printf("Failed to find user %s for email: %v\n", userID, err)
-		return
+		return fmt.Errorf("error finding user %s for email: %w", userID, err)
 	}
 
-	email := &domain.EmailMessage{
+	event, optional := domain.NotificationEventOf(template)
+	if optional && !user.NotificationPreferences.Allows(event) {
+		return nil
+	}
+
+	party, _ := swapRequest.PartyOf(userID)
+
+	email := domain.EmailMessage{
 		Recipient: user.Email,
-		Subject:   subject,
-		Body:      body,
+		Template:  template,
+		Data: domain.EmailData{
+			"Username":       user.Username,
+			"Counterpart":    usernameOf(repos.Users, counterpartOf(swapRequest, party)),
+			"IsSender":       party == domain.PartySender,
+			"Reference":      swapRequest.ReferenceNumber,
+			"SwapRequestID":  swapRequest.ID.String(),
+			"OfferedItems":   itemSummaries(repos.Items, swapRequest.AllOfferedItemIDs()),
+			"RequestedItems": itemSummaries(repos.Items, swapRequest.AllRequestedItemIDs()),
+			"ExpiresAt":      formatExpiry(swapRequest.ExpiresAt),
+		},
 	}
 
-	if err = service.emailService.SendEmail(email); err != nil {
-		fmt.Printf("Failed to send email to %s: %v\n", user.Email, err)
+	if optional {
+		link, err := service.unsubscribeLink(user.ID, event)
+		if err != nil {
+			return err
+		}
+		email.Data[domain.UnsubscribeURLKey] = link
 	}
+
+	return repos.Outbox.Enqueue(domain.NewOutboxMessage(email, service.clock.Now()))
+}
+
+// unsubscribeLink returns the one-click link that turns off event emails for the user.
+func (service *SwapRequestService) unsubscribeLink(userID uuid.UUID, event domain.NotificationEvent) (string, error) {
+	token, err := service.signer.SignLink(domain.LinkTokenClaims{
+		Purpose: domain.UnsubscribePurpose,
+		UserID:  userID,
+		Value:   string(event),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return service.unsubscribeURL + "?token=" + url.QueryEscape(token), nil
+}
+
+func formatExpiry(expiresAt *time.Time) string {
+	if expiresAt == nil {
+		return ""
+	}
+
+	return expiresAt.UTC().Format(time.RFC1123)
+}
+
+// itemSummaries describes items for an email. Items that cannot be found are
+// left out rather than failing the email.
+func itemSummaries(itemRepo ports.ItemRepository, itemIDs []uuid.UUID) []map[string]any {
+	summaries := make([]map[string]any, 0, len(itemIDs))
+	for _, itemID := range itemIDs {
+		item, err := itemRepo.FindByID(itemID)
+		if err != nil {
+			continue
+		}
+
+		summaries = append(summaries, map[string]any{
+			"Name":       item.Name,
+			"PictureURL": item.PictureURL,
+		})
+	}
+
+	return summaries
+}
+
+// counterpartOf returns the participant on the other side of party.
+func counterpartOf(swapRequest *domain.SwapRequest, party domain.SwapParty) uuid.UUID {
+	if party == domain.PartyRecipient {
+		return swapRequest.SenderID
+	}
+
+	return swapRequest.RecipientID
 }
 
-func (service *SwapRequestService) getUsernameSafe(userID uuid.UUID) string {
-	user, err := service.userRepo.FindByID(userID)
+func usernameOf(userRepo ports.UserRepository, userID uuid.UUID) string {
+	user, err := userRepo.FindByID(userID)
 	if err != nil {
 		return "Unknown User"
 	}
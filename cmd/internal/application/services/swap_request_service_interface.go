@@ -11,6 +11,12 @@ type SwapRequestServiceInterface interface {
 	FindByReferenceNumber(reference string) (*domain.SwapRequest, error)
 	ListByUser(userID uuid.UUID) ([]domain.SwapRequest, error)
 	ListByStatus(status domain.SwapRequestStatus) ([]domain.SwapRequest, error)
-	UpdateStatus(id uuid.UUID, status domain.SwapRequestStatus) error
+	UpdateStatus(id, userID uuid.UUID, status domain.SwapRequestStatus) error
+	ConfirmHandover(id, userID uuid.UUID) (*domain.SwapRequest, error)
+	CounterOffer(id, userID uuid.UUID, offer domain.CounterOffer) (*domain.SwapRequest, error)
+	ListThread(id, userID uuid.UUID) ([]domain.SwapRequest, error)
+	ForceCancel(id uuid.UUID) (*domain.SwapRequest, error)
+	ExpireOverdue() (int, error)
+	RemindExpiring() (int, error)
 	Delete(id uuid.UUID) error
 }
@@ -6,45 +6,128 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	testMocks "swapp-go/cmd/internal/application/mocks"
+	"swapp-go/cmd/internal/application/ports"
 	"swapp-go/cmd/internal/application/services"
 	"swapp-go/cmd/internal/domain"
 	"testing"
+	"time"
 )
 
+var (
+	testNow            = time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+	testRequestTTL     = 72 * time.Hour
+	testReminderBefore = 24 * time.Hour
+)
+
+const testUnsubscribeURL = "https://api.example.com/notifications/unsubscribe"
+
+// newUnsubscribeSigner returns a signer for the unsubscribe links of swap emails.
+func newUnsubscribeSigner() *testMocks.MockLinkTokenSigner {
+	signer := new(testMocks.MockLinkTokenSigner)
+	signer.On("SignLink", mock.MatchedBy(func(claims domain.LinkTokenClaims) bool {
+		return claims.Purpose == domain.UnsubscribePurpose
+	})).Return("unsubscribe-token", nil)
+
+	return signer
+}
+
 func setupSwapRequestServiceTest() (
 	*services.SwapRequestService,
 	*testMocks.SwapRequestRepository,
 	*testMocks.ItemRepository,
 	*testMocks.MockUserRepository,
-	*testMocks.MockEmailService,
+	*testMocks.MockOutboxRepository,
+) {
+	service, mockSwapRequestRepo, mockItemRepo, mockUserRepo, mockOutbox, _ := setupSwapRequestServiceWithInbox()
+
+	return service, mockSwapRequestRepo, mockItemRepo, mockUserRepo, mockOutbox
+}
+
+// setupSwapRequestServiceWithInbox also returns the repository of in-app
+// notifications, which accepts every notification the service records.
+func setupSwapRequestServiceWithInbox() (
+	*services.SwapRequestService,
+	*testMocks.SwapRequestRepository,
+	*testMocks.ItemRepository,
+	*testMocks.MockUserRepository,
+	*testMocks.MockOutboxRepository,
+	*testMocks.MockNotificationRepository,
 ) {
 	mockSwapRequestRepo := new(testMocks.SwapRequestRepository)
 	mockItemRepo := new(testMocks.ItemRepository)
 	mockUserRepo := new(testMocks.MockUserRepository)
-	mockEmailService := new(testMocks.MockEmailService)
-
-	service := services.NewSwapRequestService(mockSwapRequestRepo, mockUserRepo, mockItemRepo, mockEmailService)
+	mockOutbox := new(testMocks.MockOutboxRepository)
+	mockNotifications := new(testMocks.MockNotificationRepository)
+	mockNotifications.On("Create", mock.AnythingOfType("*domain.Notification")).Return(nil)
+	mockUnitOfWork := &testMocks.UnitOfWork{Repositories: ports.Repositories{
+		SwapRequests:  mockSwapRequestRepo,
+		Items:         mockItemRepo,
+		Users:         mockUserRepo,
+		Outbox:        mockOutbox,
+		Notifications: mockNotifications,
+	}}
+
+	service := services.NewSwapRequestService(
+		mockSwapRequestRepo,
+		mockUserRepo,
+		mockItemRepo,
+		mockUnitOfWork,
+		&testMocks.Clock{Current: testNow},
+		testRequestTTL,
+		testReminderBefore,
+		newUnsubscribeSigner(),
+		testUnsubscribeURL,
+	)
+
+	return service, mockSwapRequestRepo, mockItemRepo, mockUserRepo, mockOutbox, mockNotifications
+}
 
-	return service, mockSwapRequestRepo, mockItemRepo, mockUserRepo, mockEmailService
+// expectEmailItems lets swap emails look up the items they describe.
+func expectEmailItems(mockItemRepo *testMocks.ItemRepository) {
+	mockItemRepo.On("FindByID", mock.Anything).Return(&domain.Item{Name: "Item"}, nil)
 }
 
 func TestSwapRequestService_Create(t *testing.T) {
 	testItemID := uuid.New()
-	testRequest := &domain.SwapRequest{
-		OfferedItemID: testItemID,
+	requestedItemID := uuid.New()
+	senderID := uuid.New()
+	recipientID := uuid.New()
+
+	newRequest := func() *domain.SwapRequest {
+		return &domain.SwapRequest{
+			OfferedItemID:   testItemID,
+			RequestedItemID: requestedItemID,
+			SenderID:        senderID,
+			RecipientID:     recipientID,
+		}
+	}
+
+	// expectValidItems sets up the lookups that pass ownership and availability checks.
+	expectValidItems := func(mockSwapRequestRepo *testMocks.SwapRequestRepository, mockItemRepo *testMocks.ItemRepository) {
+		mockItemRepo.On("FindByID", testItemID).Return(&domain.Item{ID: testItemID, UserID: senderID}, nil).Once()
+		mockItemRepo.On("FindByID", requestedItemID).Return(&domain.Item{ID: requestedItemID, UserID: recipientID}, nil).Once()
+		mockSwapRequestRepo.On("IsItemInSwapWithStatus", requestedItemID, mock.Anything).Return(false, nil).Once()
+	}
+
+	// expectVerifiedSender sets up the sender lookup that passes the email verification check.
+	expectVerifiedSender := func(mockUserRepo *testMocks.MockUserRepository) {
+		mockUserRepo.On("FindByID", senderID).Return(&domain.User{
+			ID:              senderID,
+			Username:        "sender",
+			Email:           "sender@example.com",
+			EmailVerifiedAt: &testNow,
+		}, nil).Once()
 	}
 
 	t.Run("success", func(t *testing.T) {
-		service, mockSwapRequestRepo, mockItemRepo, mockUserRepo, mockEmailService := setupSwapRequestServiceTest()
+		service, mockSwapRequestRepo, mockItemRepo, mockUserRepo, mockOutbox := setupSwapRequestServiceTest()
+		testRequest := newRequest()
 
-		mockItemRepo.On("FindByID", testItemID).Return(&domain.Item{ID: testItemID}, nil).Once()
+		expectVerifiedSender(mockUserRepo)
+		expectValidItems(mockSwapRequestRepo, mockItemRepo)
 		mockItemRepo.On("TryMarkItemAsOffered", testItemID).Return(true, nil).Once()
-		mockItemRepo.On("Update", testItemID, mock.Anything).Return(&domain.Item{ID: testItemID}, nil).Once()
 		mockSwapRequestRepo.On("Create", testRequest).Return(nil).Once()
 
-		senderID := uuid.UUID{}
-		recipientID := uuid.UUID{}
-
 		mockUserRepo.On("FindByID", recipientID).Return(&domain.User{
 			ID:       recipientID,
 			Username: "recipient",
@@ -57,66 +140,209 @@ func TestSwapRequestService_Create(t *testing.T) {
 			Email:    "sender@example.com",
 		}, nil).Once()
 
-		mockEmailService.On("SendEmail", mock.AnythingOfType("*domain.EmailMessage")).Return(nil).Once()
+		expectEmailItems(mockItemRepo)
+		mockOutbox.On("Enqueue", mock.AnythingOfType("*domain.OutboxMessage")).Return(nil).Once()
 
 		err := service.Create(testRequest)
 		assert.NoError(t, err)
+		assert.Equal(t, testNow.Add(testRequestTTL), *testRequest.ExpiresAt)
 
 		mockItemRepo.AssertExpectations(t)
 		mockSwapRequestRepo.AssertExpectations(t)
 		mockUserRepo.AssertExpectations(t)
-		mockEmailService.AssertExpectations(t)
+		mockOutbox.AssertExpectations(t)
 	})
 
-	t.Run("offered item not found", func(t *testing.T) {
+	t.Run("self swap", func(t *testing.T) {
 		service, _, mockItemRepo, _, _ := setupSwapRequestServiceTest()
+		testRequest := newRequest()
+		testRequest.RecipientID = senderID
+
+		err := service.Create(testRequest)
+		assert.ErrorIs(t, err, services.SelfSwapErr)
+		mockItemRepo.AssertNotCalled(t, "FindByID", mock.Anything)
+	})
 
+	t.Run("offered item not found", func(t *testing.T) {
+		service, _, mockItemRepo, mockUserRepo, _ := setupSwapRequestServiceTest()
+
+		expectVerifiedSender(mockUserRepo)
 		mockItemRepo.On("FindByID", testItemID).Return(nil, errors.New("not found")).Once()
 
-		err := service.Create(testRequest)
-		assert.EqualError(t, err, "offered item not found")
+		err := service.Create(newRequest())
+		assert.ErrorIs(t, err, services.OfferedItemNotFoundErr)
 		mockItemRepo.AssertExpectations(t)
 	})
 
+	t.Run("offered item not owned by sender", func(t *testing.T) {
+		service, _, mockItemRepo, mockUserRepo, _ := setupSwapRequestServiceTest()
+
+		expectVerifiedSender(mockUserRepo)
+		mockItemRepo.On("FindByID", testItemID).Return(&domain.Item{ID: testItemID, UserID: uuid.New()}, nil).Once()
+
+		err := service.Create(newRequest())
+		assert.ErrorIs(t, err, services.OfferedItemNotOwnedErr)
+		mockItemRepo.AssertNotCalled(t, "TryMarkItemAsOffered", mock.Anything)
+	})
+
+	t.Run("requested item not found", func(t *testing.T) {
+		service, _, mockItemRepo, mockUserRepo, _ := setupSwapRequestServiceTest()
+
+		expectVerifiedSender(mockUserRepo)
+		mockItemRepo.On("FindByID", testItemID).Return(&domain.Item{ID: testItemID, UserID: senderID}, nil).Once()
+		mockItemRepo.On("FindByID", requestedItemID).Return(nil, errors.New("not found")).Once()
+
+		err := service.Create(newRequest())
+		assert.ErrorIs(t, err, services.RequestedItemNotFoundErr)
+	})
+
+	t.Run("requested item not owned by recipient", func(t *testing.T) {
+		service, _, mockItemRepo, mockUserRepo, _ := setupSwapRequestServiceTest()
+
+		expectVerifiedSender(mockUserRepo)
+		mockItemRepo.On("FindByID", testItemID).Return(&domain.Item{ID: testItemID, UserID: senderID}, nil).Once()
+		mockItemRepo.On("FindByID", requestedItemID).Return(&domain.Item{ID: requestedItemID, UserID: senderID}, nil).Once()
+
+		err := service.Create(newRequest())
+		assert.ErrorIs(t, err, services.RequestedItemNotOwnedErr)
+	})
+
+	t.Run("requested item already in an accepted swap", func(t *testing.T) {
+		service, mockSwapRequestRepo, mockItemRepo, mockUserRepo, _ := setupSwapRequestServiceTest()
+
+		expectVerifiedSender(mockUserRepo)
+		mockItemRepo.On("FindByID", testItemID).Return(&domain.Item{ID: testItemID, UserID: senderID}, nil).Once()
+		mockItemRepo.On("FindByID", requestedItemID).Return(&domain.Item{ID: requestedItemID, UserID: recipientID}, nil).Once()
+		mockSwapRequestRepo.On("IsItemInSwapWithStatus", requestedItemID, []domain.SwapRequestStatus{domain.StatusAccepted, domain.StatusDisputed}).
+			Return(true, nil).Once()
+
+		err := service.Create(newRequest())
+		assert.ErrorIs(t, err, services.RequestedItemSwappedErr)
+		mockItemRepo.AssertNotCalled(t, "TryMarkItemAsOffered", mock.Anything)
+		mockSwapRequestRepo.AssertExpectations(t)
+	})
+
 	t.Run("item already offered", func(t *testing.T) {
-		service, _, mockItemRepo, _, _ := setupSwapRequestServiceTest()
+		service, mockSwapRequestRepo, mockItemRepo, mockUserRepo, _ := setupSwapRequestServiceTest()
 
-		mockItemRepo.On("FindByID", testItemID).Return(&domain.Item{ID: testItemID}, nil).Once()
+		expectVerifiedSender(mockUserRepo)
+		expectValidItems(mockSwapRequestRepo, mockItemRepo)
 		mockItemRepo.On("TryMarkItemAsOffered", testItemID).Return(false, nil).Once()
 
-		err := service.Create(testRequest)
+		err := service.Create(newRequest())
 		assert.ErrorIs(t, err, services.ItemAlreadyOfferedErr)
 		mockItemRepo.AssertExpectations(t)
 	})
 
 	t.Run("TryMarkItemAsOffered error", func(t *testing.T) {
-		service, _, mockItemRepo, _, _ := setupSwapRequestServiceTest()
+		service, mockSwapRequestRepo, mockItemRepo, mockUserRepo, _ := setupSwapRequestServiceTest()
 
-		mockItemRepo.On("FindByID", testItemID).Return(&domain.Item{ID: testItemID}, nil).Once()
+		expectVerifiedSender(mockUserRepo)
+		expectValidItems(mockSwapRequestRepo, mockItemRepo)
 		mockItemRepo.On("TryMarkItemAsOffered", testItemID).Return(false, errors.New("db error")).Once()
 
-		err := service.Create(testRequest)
+		err := service.Create(newRequest())
 		assert.EqualError(t, err, "db error")
 		mockItemRepo.AssertExpectations(t)
 	})
 
-	t.Run("repo.Create error rolls back offered flag", func(t *testing.T) {
-		service, mockSwapRequestRepo, mockItemRepo, _, _ := setupSwapRequestServiceTest()
+	t.Run("repo.Create error is returned without notifying", func(t *testing.T) {
+		service, mockSwapRequestRepo, mockItemRepo, mockUserRepo, mockOutbox := setupSwapRequestServiceTest()
+		testRequest := newRequest()
 
-		mockItemRepo.On("FindByID", testItemID).Return(&domain.Item{ID: testItemID}, nil).Once()
+		expectVerifiedSender(mockUserRepo)
+		expectValidItems(mockSwapRequestRepo, mockItemRepo)
 		mockItemRepo.On("TryMarkItemAsOffered", testItemID).Return(true, nil).Once()
-		mockItemRepo.On("Update", testItemID, mock.MatchedBy(func(m map[string]interface{}) bool {
-			return m["offered"] == true
-		})).Return(&domain.Item{ID: testItemID}, nil).Once()
 		mockSwapRequestRepo.On("Create", testRequest).Return(errors.New("create error")).Once()
-		mockItemRepo.On("Update", testItemID, mock.MatchedBy(func(m map[string]interface{}) bool {
-			return m["offered"] == false
-		})).Return(&domain.Item{ID: testItemID}, nil).Once()
 
 		err := service.Create(testRequest)
 		assert.EqualError(t, err, "create error")
 		mockItemRepo.AssertExpectations(t)
 		mockSwapRequestRepo.AssertExpectations(t)
+		mockOutbox.AssertNotCalled(t, "Enqueue", mock.Anything)
+	})
+
+	t.Run("bundle locks every offered item", func(t *testing.T) {
+		service, mockSwapRequestRepo, mockItemRepo, mockUserRepo, mockOutbox := setupSwapRequestServiceTest()
+
+		secondItemID := uuid.New()
+		bundle := &domain.SwapRequest{
+			OfferedItemIDs:   []uuid.UUID{testItemID, secondItemID},
+			RequestedItemIDs: []uuid.UUID{requestedItemID},
+			SenderID:         senderID,
+			RecipientID:      recipientID,
+		}
+
+		expectVerifiedSender(mockUserRepo)
+		expectValidItems(mockSwapRequestRepo, mockItemRepo)
+		mockItemRepo.On("FindByID", secondItemID).Return(&domain.Item{ID: secondItemID, UserID: senderID}, nil).Once()
+		mockItemRepo.On("TryMarkItemAsOffered", testItemID).Return(true, nil).Once()
+		mockItemRepo.On("TryMarkItemAsOffered", secondItemID).Return(true, nil).Once()
+		mockSwapRequestRepo.On("Create", bundle).Return(nil).Once()
+		mockUserRepo.On("FindByID", mock.Anything).Return(&domain.User{Username: "user", Email: "user@example.com"}, nil)
+		expectEmailItems(mockItemRepo)
+		mockOutbox.On("Enqueue", mock.AnythingOfType("*domain.OutboxMessage")).Return(nil).Once()
+
+		err := service.Create(bundle)
+		assert.NoError(t, err)
+		assert.Equal(t, testItemID, bundle.OfferedItemID)
+
+		mockItemRepo.AssertExpectations(t)
+		mockSwapRequestRepo.AssertExpectations(t)
+	})
+
+	t.Run("bundle fails when any offered item is already offered", func(t *testing.T) {
+		service, mockSwapRequestRepo, mockItemRepo, mockUserRepo, _ := setupSwapRequestServiceTest()
+
+		secondItemID := uuid.New()
+		bundle := &domain.SwapRequest{
+			OfferedItemIDs:   []uuid.UUID{testItemID, secondItemID},
+			RequestedItemIDs: []uuid.UUID{requestedItemID},
+			SenderID:         senderID,
+			RecipientID:      recipientID,
+		}
+
+		expectVerifiedSender(mockUserRepo)
+		expectValidItems(mockSwapRequestRepo, mockItemRepo)
+		mockItemRepo.On("FindByID", secondItemID).Return(&domain.Item{ID: secondItemID, UserID: senderID}, nil).Once()
+		mockItemRepo.On("TryMarkItemAsOffered", testItemID).Return(true, nil).Once()
+		mockItemRepo.On("TryMarkItemAsOffered", secondItemID).Return(false, nil).Once()
+
+		err := service.Create(bundle)
+		assert.ErrorIs(t, err, services.ItemAlreadyOfferedErr)
+		mockSwapRequestRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("unverified sender", func(t *testing.T) {
+		service, mockSwapRequestRepo, mockItemRepo, mockUserRepo, _ := setupSwapRequestServiceTest()
+
+		mockUserRepo.On("FindByID", senderID).Return(&domain.User{ID: senderID, Email: "sender@example.com"}, nil).Once()
+
+		err := service.Create(newRequest())
+		assert.ErrorIs(t, err, domain.EmailNotVerifiedErr)
+		mockItemRepo.AssertNotCalled(t, "FindByID", mock.Anything)
+		mockSwapRequestRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("invalid bundle is rejected before touching items", func(t *testing.T) {
+		service, _, mockItemRepo, _, _ := setupSwapRequestServiceTest()
+
+		err := service.Create(&domain.SwapRequest{
+			OfferedItemIDs: []uuid.UUID{testItemID},
+			SenderID:       senderID,
+			RecipientID:    recipientID,
+		})
+		assert.ErrorIs(t, err, domain.MissingSwapItemsErr)
+
+		err = service.Create(&domain.SwapRequest{
+			OfferedItemIDs:   []uuid.UUID{testItemID},
+			RequestedItemIDs: []uuid.UUID{testItemID},
+			SenderID:         senderID,
+			RecipientID:      recipientID,
+		})
+		assert.ErrorIs(t, err, domain.OverlappingSwapItemsErr)
+
+		mockItemRepo.AssertNotCalled(t, "FindByID", mock.Anything)
 	})
 }
 
@@ -242,22 +468,52 @@ func TestSwapRequestService_ListByStatus(t *testing.T) {
 func TestSwapRequestService_UpdateStatus(t *testing.T) {
 	swapRequestID := uuid.New()
 	offeredItemID := uuid.New()
-	senderID := uuid.UUID{}
-	recipientID := uuid.UUID{}
-
-	swapRequest := &domain.SwapRequest{
-		ID:              swapRequestID,
-		OfferedItemID:   offeredItemID,
-		SenderID:        senderID,
-		RecipientID:     recipientID,
-		ReferenceNumber: "REF123",
+	senderID := uuid.New()
+	recipientID := uuid.New()
+
+	newSwapRequest := func(status domain.SwapRequestStatus) *domain.SwapRequest {
+		return &domain.SwapRequest{
+			ID:              swapRequestID,
+			Status:          status,
+			OfferedItemID:   offeredItemID,
+			SenderID:        senderID,
+			RecipientID:     recipientID,
+			ReferenceNumber: "REF123",
+		}
 	}
 
+	t.Run("overdue request cannot be accepted", func(t *testing.T) {
+		service, mockSwapRequestRepo, _, _, mockOutbox := setupSwapRequestServiceTest()
+
+		overdue := newSwapRequest(domain.StatusPending)
+		expiresAt := testNow.Add(-time.Minute)
+		overdue.ExpiresAt = &expiresAt
+
+		mockSwapRequestRepo.On("FindByID", swapRequestID).Return(overdue, nil).Once()
+
+		err := service.UpdateStatus(swapRequestID, recipientID, domain.StatusAccepted)
+		assert.ErrorIs(t, err, domain.SwapRequestExpiredErr)
+
+		mockSwapRequestRepo.AssertNotCalled(t, "TryUpdateStatus", mock.Anything, mock.Anything, mock.Anything)
+		mockOutbox.AssertNotCalled(t, "Enqueue", mock.Anything)
+	})
+
+	t.Run("countering requires a counter offer", func(t *testing.T) {
+		service, mockSwapRequestRepo, mockItemRepo, _, mockOutbox := setupSwapRequestServiceTest()
+
+		err := service.UpdateStatus(swapRequestID, recipientID, domain.StatusCountered)
+		assert.ErrorIs(t, err, domain.CounterOfferRequiredErr)
+
+		mockSwapRequestRepo.AssertNotCalled(t, "FindByID", mock.Anything)
+		mockItemRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		mockOutbox.AssertNotCalled(t, "Enqueue", mock.Anything)
+	})
+
 	t.Run("success - accepted", func(t *testing.T) {
-		service, mockSwapRequestRepo, mockItemRepo, mockUserRepo, mockEmailService := setupSwapRequestServiceTest()
+		service, mockSwapRequestRepo, mockItemRepo, mockUserRepo, mockOutbox := setupSwapRequestServiceTest()
 
-		mockSwapRequestRepo.On("FindByID", swapRequestID).Return(swapRequest, nil).Once()
-		mockSwapRequestRepo.On("UpdateStatus", swapRequestID, domain.StatusAccepted).Return(nil).Once()
+		mockSwapRequestRepo.On("FindByID", swapRequestID).Return(newSwapRequest(domain.StatusPending), nil).Once()
+		mockSwapRequestRepo.On("TryUpdateStatus", swapRequestID, domain.StatusPending, domain.StatusAccepted).Return(true, nil).Once()
 
 		mockUserRepo.On("FindByID", recipientID).Return(&domain.User{
 			ID:       recipientID,
@@ -271,22 +527,66 @@ func TestSwapRequestService_UpdateStatus(t *testing.T) {
 			Email:    "sender@example.com",
 		}, nil).Once()
 
-		mockEmailService.On("SendEmail", mock.AnythingOfType("*domain.EmailMessage")).Return(nil).Once()
+		expectEmailItems(mockItemRepo)
+		mockOutbox.On("Enqueue", mock.AnythingOfType("*domain.OutboxMessage")).Return(nil).Once()
 
-		err := service.UpdateStatus(swapRequestID, domain.StatusAccepted)
+		err := service.UpdateStatus(swapRequestID, recipientID, domain.StatusAccepted)
 		assert.NoError(t, err)
 
 		mockItemRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
 		mockSwapRequestRepo.AssertExpectations(t)
 		mockUserRepo.AssertExpectations(t)
-		mockEmailService.AssertExpectations(t)
+		mockOutbox.AssertExpectations(t)
+	})
+
+	t.Run("email carries an unsubscribe link", func(t *testing.T) {
+		service, mockSwapRequestRepo, mockItemRepo, mockUserRepo, mockOutbox := setupSwapRequestServiceTest()
+
+		mockSwapRequestRepo.On("FindByID", swapRequestID).Return(newSwapRequest(domain.StatusPending), nil).Once()
+		mockSwapRequestRepo.On("TryUpdateStatus", swapRequestID, domain.StatusPending, domain.StatusAccepted).Return(true, nil).Once()
+		mockUserRepo.On("FindByID", mock.Anything).Return(&domain.User{ID: senderID, Username: "sender", Email: "sender@example.com"}, nil)
+		expectEmailItems(mockItemRepo)
+		mockOutbox.On("Enqueue", mock.MatchedBy(func(message *domain.OutboxMessage) bool {
+			return message.Data[domain.UnsubscribeURLKey] == testUnsubscribeURL+"?token=unsubscribe-token"
+		})).Return(nil).Once()
+
+		err := service.UpdateStatus(swapRequestID, recipientID, domain.StatusAccepted)
+		assert.NoError(t, err)
+		mockOutbox.AssertExpectations(t)
+	})
+
+	t.Run("sender who opted out is not emailed but still notified in the app", func(t *testing.T) {
+		service, mockSwapRequestRepo, _, mockUserRepo, mockOutbox, mockNotifications := setupSwapRequestServiceWithInbox()
+
+		mockSwapRequestRepo.On("FindByID", swapRequestID).Return(newSwapRequest(domain.StatusPending), nil).Once()
+		mockSwapRequestRepo.On("TryUpdateStatus", swapRequestID, domain.StatusPending, domain.StatusAccepted).Return(true, nil).Once()
+		mockUserRepo.On("FindByID", senderID).Return(&domain.User{
+			ID:                      senderID,
+			Username:                "sender",
+			Email:                   "sender@example.com",
+			NotificationPreferences: domain.NotificationPreferences{domain.NotifyAccepted: false},
+		}, nil).Once()
+
+		err := service.UpdateStatus(swapRequestID, recipientID, domain.StatusAccepted)
+		assert.NoError(t, err)
+
+		mockSwapRequestRepo.AssertExpectations(t)
+		mockOutbox.AssertNotCalled(t, "Enqueue", mock.Anything)
+		mockNotifications.AssertCalled(t, "Create", mock.MatchedBy(func(notification *domain.Notification) bool {
+			return notification.UserID == senderID &&
+				notification.Type == domain.NotificationType(domain.EmailTemplateSwapRequestAccepted) &&
+				notification.SwapRequestID == swapRequestID &&
+				notification.CreatedAt.Equal(testNow) &&
+				!notification.IsRead()
+		}))
+		mockNotifications.AssertNumberOfCalls(t, "Create", 1)
 	})
 
 	t.Run("success - rejected", func(t *testing.T) {
-		service, mockSwapRequestRepo, mockItemRepo, mockUserRepo, mockEmailService := setupSwapRequestServiceTest()
+		service, mockSwapRequestRepo, mockItemRepo, mockUserRepo, mockOutbox := setupSwapRequestServiceTest()
 
-		mockSwapRequestRepo.On("FindByID", swapRequestID).Return(swapRequest, nil).Once()
-		mockSwapRequestRepo.On("UpdateStatus", swapRequestID, domain.StatusRejected).Return(nil).Once()
+		mockSwapRequestRepo.On("FindByID", swapRequestID).Return(newSwapRequest(domain.StatusPending), nil).Once()
+		mockSwapRequestRepo.On("TryUpdateStatus", swapRequestID, domain.StatusPending, domain.StatusRejected).Return(true, nil).Once()
 		mockItemRepo.On("Update", offeredItemID, mock.MatchedBy(func(fields map[string]interface{}) bool {
 			return fields["offered"] == false
 		})).Return(&domain.Item{}, nil).Once()
@@ -303,32 +603,66 @@ func TestSwapRequestService_UpdateStatus(t *testing.T) {
 			Email:    "sender@example.com",
 		}, nil).Once()
 
-		mockEmailService.On("SendEmail", mock.AnythingOfType("*domain.EmailMessage")).Return(nil).Once()
+		expectEmailItems(mockItemRepo)
+		mockOutbox.On("Enqueue", mock.AnythingOfType("*domain.OutboxMessage")).Return(nil).Once()
 
-		err := service.UpdateStatus(swapRequestID, domain.StatusRejected)
+		err := service.UpdateStatus(swapRequestID, recipientID, domain.StatusRejected)
 		assert.NoError(t, err)
 
 		mockItemRepo.AssertExpectations(t)
 		mockSwapRequestRepo.AssertExpectations(t)
 		mockUserRepo.AssertExpectations(t)
-		mockEmailService.AssertExpectations(t)
+		mockOutbox.AssertExpectations(t)
+	})
+
+	t.Run("outbox failure fails the update", func(t *testing.T) {
+		service, mockSwapRequestRepo, mockItemRepo, mockUserRepo, mockOutbox := setupSwapRequestServiceTest()
+
+		mockSwapRequestRepo.On("FindByID", swapRequestID).Return(newSwapRequest(domain.StatusPending), nil).Once()
+		mockSwapRequestRepo.On("TryUpdateStatus", swapRequestID, domain.StatusPending, domain.StatusAccepted).Return(true, nil).Once()
+		mockUserRepo.On("FindByID", mock.Anything).Return(&domain.User{Username: "user", Email: "user@example.com"}, nil)
+		expectEmailItems(mockItemRepo)
+		mockOutbox.On("Enqueue", mock.AnythingOfType("*domain.OutboxMessage")).Return(errors.New("db error")).Once()
+
+		err := service.UpdateStatus(swapRequestID, recipientID, domain.StatusAccepted)
+		assert.EqualError(t, err, "db error")
 	})
 
 	t.Run("success - cancelled", func(t *testing.T) {
-		service, mockSwapRequestRepo, mockItemRepo, mockUserRepo, _ := setupSwapRequestServiceTest()
+		service, mockSwapRequestRepo, mockItemRepo, mockUserRepo, mockOutbox, mockNotifications := setupSwapRequestServiceWithInbox()
 
-		mockSwapRequestRepo.On("FindByID", swapRequestID).Return(swapRequest, nil).Once()
-		mockSwapRequestRepo.On("UpdateStatus", swapRequestID, domain.StatusCancelled).Return(nil).Once()
+		mockSwapRequestRepo.On("FindByID", swapRequestID).Return(newSwapRequest(domain.StatusPending), nil).Once()
+		mockSwapRequestRepo.On("TryUpdateStatus", swapRequestID, domain.StatusPending, domain.StatusCancelled).Return(true, nil).Once()
 		mockItemRepo.On("Update", offeredItemID, mock.MatchedBy(func(fields map[string]interface{}) bool {
 			return fields["offered"] == false
 		})).Return(&domain.Item{}, nil).Once()
+		mockUserRepo.On("FindByID", recipientID).Return(&domain.User{
+			ID:       recipientID,
+			Username: "recipient",
+			Email:    "recipient@example.com",
+		}, nil).Once()
+		mockUserRepo.On("FindByID", senderID).Return(&domain.User{
+			ID:       senderID,
+			Username: "sender",
+			Email:    "sender@example.com",
+		}, nil).Once()
+		expectEmailItems(mockItemRepo)
+		mockOutbox.On("Enqueue", mock.MatchedBy(func(message *domain.OutboxMessage) bool {
+			return message.Recipient == "recipient@example.com" &&
+				message.Template == domain.EmailTemplateSwapRequestCancelled
+		})).Return(nil).Once()
 
-		err := service.UpdateStatus(swapRequestID, domain.StatusCancelled)
+		err := service.UpdateStatus(swapRequestID, senderID, domain.StatusCancelled)
 		assert.NoError(t, err)
 
 		mockItemRepo.AssertExpectations(t)
 		mockSwapRequestRepo.AssertExpectations(t)
-		mockUserRepo.AssertNotCalled(t, "FindByID", mock.Anything)
+		mockOutbox.AssertExpectations(t)
+		mockNotifications.AssertCalled(t, "Create", mock.MatchedBy(func(notification *domain.Notification) bool {
+			return notification.UserID == recipientID &&
+				notification.Type == domain.NotificationType(domain.EmailTemplateSwapRequestCancelled)
+		}))
+		mockNotifications.AssertNumberOfCalls(t, "Create", 1)
 	})
 
 	t.Run("error - not found", func(t *testing.T) {
@@ -336,21 +670,78 @@ func TestSwapRequestService_UpdateStatus(t *testing.T) {
 
 		mockSwapRequestRepo.On("FindByID", swapRequestID).Return(nil, errors.New("not found")).Once()
 
-		err := service.UpdateStatus(swapRequestID, domain.StatusAccepted)
-		assert.Error(t, err)
+		err := service.UpdateStatus(swapRequestID, recipientID, domain.StatusAccepted)
+		assert.ErrorIs(t, err, services.SwapRequestNotFoundErr)
 
 		mockItemRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
 		mockUserRepo.AssertNotCalled(t, "FindByID", mock.Anything)
 		mockSwapRequestRepo.AssertExpectations(t)
 	})
 
+	t.Run("error - not a participant", func(t *testing.T) {
+		service, mockSwapRequestRepo, _, _, _ := setupSwapRequestServiceTest()
+
+		mockSwapRequestRepo.On("FindByID", swapRequestID).Return(newSwapRequest(domain.StatusPending), nil).Once()
+
+		err := service.UpdateStatus(swapRequestID, uuid.New(), domain.StatusAccepted)
+		assert.ErrorIs(t, err, domain.NotSwapRequestParticipantErr)
+
+		mockSwapRequestRepo.AssertNotCalled(t, "TryUpdateStatus", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("error - sender cannot accept", func(t *testing.T) {
+		service, mockSwapRequestRepo, _, _, _ := setupSwapRequestServiceTest()
+
+		mockSwapRequestRepo.On("FindByID", swapRequestID).Return(newSwapRequest(domain.StatusPending), nil).Once()
+
+		err := service.UpdateStatus(swapRequestID, senderID, domain.StatusAccepted)
+		assert.ErrorIs(t, err, domain.TransitionNotPermittedErr)
+
+		mockSwapRequestRepo.AssertNotCalled(t, "TryUpdateStatus", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("error - accepted request cannot go back to pending", func(t *testing.T) {
+		service, mockSwapRequestRepo, _, _, _ := setupSwapRequestServiceTest()
+
+		mockSwapRequestRepo.On("FindByID", swapRequestID).Return(newSwapRequest(domain.StatusAccepted), nil).Once()
+
+		err := service.UpdateStatus(swapRequestID, recipientID, domain.StatusPending)
+		assert.ErrorIs(t, err, domain.InvalidStatusTransitionErr)
+
+		mockSwapRequestRepo.AssertNotCalled(t, "TryUpdateStatus", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("error - rejected request cannot be accepted", func(t *testing.T) {
+		service, mockSwapRequestRepo, _, _, _ := setupSwapRequestServiceTest()
+
+		mockSwapRequestRepo.On("FindByID", swapRequestID).Return(newSwapRequest(domain.StatusRejected), nil).Once()
+
+		err := service.UpdateStatus(swapRequestID, recipientID, domain.StatusAccepted)
+		assert.ErrorIs(t, err, domain.InvalidStatusTransitionErr)
+
+		mockSwapRequestRepo.AssertNotCalled(t, "TryUpdateStatus", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("error - request changed concurrently", func(t *testing.T) {
+		service, mockSwapRequestRepo, mockItemRepo, _, mockOutbox := setupSwapRequestServiceTest()
+
+		mockSwapRequestRepo.On("FindByID", swapRequestID).Return(newSwapRequest(domain.StatusPending), nil).Once()
+		mockSwapRequestRepo.On("TryUpdateStatus", swapRequestID, domain.StatusPending, domain.StatusCancelled).Return(false, nil).Once()
+
+		err := service.UpdateStatus(swapRequestID, senderID, domain.StatusCancelled)
+		assert.ErrorIs(t, err, services.SwapRequestChangedErr)
+
+		mockItemRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		mockOutbox.AssertNotCalled(t, "Enqueue", mock.Anything)
+	})
+
 	t.Run("error - update failed", func(t *testing.T) {
 		service, mockSwapRequestRepo, mockItemRepo, mockUserRepo, _ := setupSwapRequestServiceTest()
 
-		mockSwapRequestRepo.On("FindByID", swapRequestID).Return(swapRequest, nil).Once()
-		mockSwapRequestRepo.On("UpdateStatus", swapRequestID, domain.StatusAccepted).Return(errors.New("update error")).Once()
+		mockSwapRequestRepo.On("FindByID", swapRequestID).Return(newSwapRequest(domain.StatusPending), nil).Once()
+		mockSwapRequestRepo.On("TryUpdateStatus", swapRequestID, domain.StatusPending, domain.StatusAccepted).Return(false, errors.New("update error")).Once()
 
-		err := service.UpdateStatus(swapRequestID, domain.StatusAccepted)
+		err := service.UpdateStatus(swapRequestID, recipientID, domain.StatusAccepted)
 		assert.Error(t, err)
 
 		mockItemRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
@@ -359,33 +750,19 @@ func TestSwapRequestService_UpdateStatus(t *testing.T) {
 	})
 
 	t.Run("error - reset offered status failed", func(t *testing.T) {
-		service, mockSwapRequestRepo, mockItemRepo, mockUserRepo, mockEmailService := setupSwapRequestServiceTest()
+		service, mockSwapRequestRepo, mockItemRepo, mockUserRepo, mockOutbox := setupSwapRequestServiceTest()
 
-		mockSwapRequestRepo.On("FindByID", swapRequestID).Return(swapRequest, nil).Once()
-		mockSwapRequestRepo.On("UpdateStatus", swapRequestID, domain.StatusRejected).Return(nil).Once()
+		mockSwapRequestRepo.On("FindByID", swapRequestID).Return(newSwapRequest(domain.StatusPending), nil).Once()
+		mockSwapRequestRepo.On("TryUpdateStatus", swapRequestID, domain.StatusPending, domain.StatusRejected).Return(true, nil).Once()
 		mockItemRepo.On("Update", offeredItemID, mock.Anything).Return(nil, errors.New("update error")).Once()
 
-		mockUserRepo.On("FindByID", recipientID).Return(&domain.User{
-			ID:       recipientID,
-			Username: "recipient",
-			Email:    "recipient@example.com",
-		}, nil).Once()
-
-		mockUserRepo.On("FindByID", senderID).Return(&domain.User{
-			ID:       senderID,
-			Username: "sender",
-			Email:    "sender@example.com",
-		}, nil).Once()
-
-		mockEmailService.On("SendEmail", mock.AnythingOfType("*domain.EmailMessage")).Return(nil).Once()
-
-		err := service.UpdateStatus(swapRequestID, domain.StatusRejected)
+		err := service.UpdateStatus(swapRequestID, recipientID, domain.StatusRejected)
 		assert.Error(t, err)
 
 		mockItemRepo.AssertExpectations(t)
 		mockSwapRequestRepo.AssertExpectations(t)
-		mockUserRepo.AssertExpectations(t)
-		mockEmailService.AssertExpectations(t)
+		mockUserRepo.AssertNotCalled(t, "FindByID", mock.Anything)
+		mockOutbox.AssertNotCalled(t, "Enqueue", mock.Anything)
 	})
 }
 
@@ -397,6 +774,7 @@ func TestSwapRequestService_Delete(t *testing.T) {
 
 	swapRequest := &domain.SwapRequest{
 		ID:              swapRequestID,
+		Status:          domain.StatusPending,
 		OfferedItemID:   offeredItemID,
 		SenderID:        senderID,
 		RecipientID:     recipientID,
@@ -404,10 +782,11 @@ func TestSwapRequestService_Delete(t *testing.T) {
 	}
 
 	t.Run("success", func(t *testing.T) {
-		service, mockSwapRequestRepo, mockItemRepo, mockUserRepo, mockEmailService := setupSwapRequestServiceTest()
+		service, mockSwapRequestRepo, mockItemRepo, mockUserRepo, mockOutbox := setupSwapRequestServiceTest()
 
-		mockSwapRequestRepo.On("FindByID", swapRequestID).Return(swapRequest, nil).Once()
-		mockSwapRequestRepo.On("Delete", swapRequestID).Return(nil).Once()
+		pending := *swapRequest
+		mockSwapRequestRepo.On("FindByID", swapRequestID).Return(&pending, nil).Once()
+		mockSwapRequestRepo.On("TryUpdateStatus", swapRequestID, domain.StatusPending, domain.StatusCancelled).Return(true, nil).Once()
 		mockItemRepo.On("Update", offeredItemID, mock.Anything).Return(&domain.Item{}, nil).Once()
 
 		mockUserRepo.On("FindByID", recipientID).Return(&domain.User{
@@ -422,15 +801,42 @@ func TestSwapRequestService_Delete(t *testing.T) {
 			Email:    "sender@example.com",
 		}, nil).Once()
 
-		mockEmailService.On("SendEmail", mock.AnythingOfType("*domain.EmailMessage")).Return(nil).Once()
+		expectEmailItems(mockItemRepo)
+		mockOutbox.On("Enqueue", mock.MatchedBy(func(message *domain.OutboxMessage) bool {
+			return message.Template == domain.EmailTemplateSwapRequestWithdrawn
+		})).Return(nil).Once()
 
 		err := service.Delete(swapRequestID)
 		assert.NoError(t, err)
 
 		mockItemRepo.AssertExpectations(t)
 		mockSwapRequestRepo.AssertExpectations(t)
+		mockSwapRequestRepo.AssertNotCalled(t, "Delete", mock.Anything)
 		mockUserRepo.AssertExpectations(t)
-		mockEmailService.AssertExpectations(t)
+		mockOutbox.AssertExpectations(t)
+	})
+
+	t.Run("answered request cannot be withdrawn", func(t *testing.T) {
+		for _, status := range []domain.SwapRequestStatus{
+			domain.StatusAccepted, domain.StatusDisputed, domain.StatusRejected,
+			domain.StatusExpired, domain.StatusCountered, domain.StatusCompleted,
+		} {
+			t.Run(string(status), func(t *testing.T) {
+				service, mockSwapRequestRepo, mockItemRepo, _, mockOutbox := setupSwapRequestServiceTest()
+
+				answered := *swapRequest
+				answered.Status = status
+				mockSwapRequestRepo.On("FindByID", swapRequestID).Return(&answered, nil).Once()
+
+				err := service.Delete(swapRequestID)
+				assert.ErrorIs(t, err, domain.InvalidStatusTransitionErr)
+
+				mockSwapRequestRepo.AssertNotCalled(t, "TryUpdateStatus", mock.Anything, mock.Anything, mock.Anything)
+				mockSwapRequestRepo.AssertNotCalled(t, "Delete", mock.Anything)
+				mockItemRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+				mockOutbox.AssertNotCalled(t, "Enqueue", mock.Anything)
+			})
+		}
 	})
 
 	t.Run("not found", func(t *testing.T) {
@@ -439,52 +845,629 @@ func TestSwapRequestService_Delete(t *testing.T) {
 		mockSwapRequestRepo.On("FindByID", swapRequestID).Return(nil, errors.New("not found")).Once()
 
 		err := service.Delete(swapRequestID)
-		assert.Error(t, err)
+		assert.ErrorIs(t, err, services.SwapRequestNotFoundErr)
 
 		mockItemRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
 		mockSwapRequestRepo.AssertExpectations(t)
 	})
 
-	t.Run("delete failed", func(t *testing.T) {
+	t.Run("request changed concurrently", func(t *testing.T) {
 		service, mockSwapRequestRepo, mockItemRepo, _, _ := setupSwapRequestServiceTest()
 
-		mockSwapRequestRepo.On("FindByID", swapRequestID).Return(swapRequest, nil).Once()
-		mockSwapRequestRepo.On("Delete", swapRequestID).Return(errors.New("delete error")).Once()
+		pending := *swapRequest
+		mockSwapRequestRepo.On("FindByID", swapRequestID).Return(&pending, nil).Once()
+		mockSwapRequestRepo.On("TryUpdateStatus", swapRequestID, domain.StatusPending, domain.StatusCancelled).Return(false, nil).Once()
 
 		err := service.Delete(swapRequestID)
-		assert.Error(t, err)
+		assert.ErrorIs(t, err, services.SwapRequestChangedErr)
 
 		mockItemRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
 		mockSwapRequestRepo.AssertExpectations(t)
 	})
 
 	t.Run("reset offered status failed", func(t *testing.T) {
-		service, mockSwapRequestRepo, mockItemRepo, mockUserRepo, mockEmailService := setupSwapRequestServiceTest()
+		service, mockSwapRequestRepo, mockItemRepo, mockUserRepo, mockOutbox := setupSwapRequestServiceTest()
 
-		mockSwapRequestRepo.On("FindByID", swapRequestID).Return(swapRequest, nil).Once()
-		mockSwapRequestRepo.On("Delete", swapRequestID).Return(nil).Once()
+		pending := *swapRequest
+		mockSwapRequestRepo.On("FindByID", swapRequestID).Return(&pending, nil).Once()
+		mockSwapRequestRepo.On("TryUpdateStatus", swapRequestID, domain.StatusPending, domain.StatusCancelled).Return(true, nil).Once()
 		mockItemRepo.On("Update", offeredItemID, mock.Anything).Return(nil, errors.New("update error")).Once()
 
+		err := service.Delete(swapRequestID)
+		assert.Error(t, err)
+
+		mockItemRepo.AssertExpectations(t)
+		mockSwapRequestRepo.AssertExpectations(t)
+		mockUserRepo.AssertNotCalled(t, "FindByID", mock.Anything)
+		mockOutbox.AssertNotCalled(t, "Enqueue", mock.Anything)
+	})
+}
+
+func TestSwapRequestService_ConfirmHandover(t *testing.T) {
+	swapRequestID := uuid.New()
+	offeredItemID := uuid.New()
+	requestedItemID := uuid.New()
+	senderID := uuid.New()
+	recipientID := uuid.New()
+
+	newSwapRequest := func(status domain.SwapRequestStatus, senderConfirmed, recipientConfirmed bool) *domain.SwapRequest {
+		return &domain.SwapRequest{
+			ID:                 swapRequestID,
+			Status:             status,
+			OfferedItemID:      offeredItemID,
+			RequestedItemID:    requestedItemID,
+			SenderID:           senderID,
+			RecipientID:        recipientID,
+			ReferenceNumber:    "REF123",
+			SenderConfirmed:    senderConfirmed,
+			RecipientConfirmed: recipientConfirmed,
+		}
+	}
+
+	t.Run("first confirmation waits for the other party", func(t *testing.T) {
+		service, mockSwapRequestRepo, mockItemRepo, mockUserRepo, mockOutbox := setupSwapRequestServiceTest()
+
+		mockSwapRequestRepo.On("FindByID", swapRequestID).Return(newSwapRequest(domain.StatusAccepted, false, false), nil).Once()
+		mockSwapRequestRepo.On("TryMarkHandoverConfirmed", swapRequestID, domain.PartySender).Return(true, nil).Once()
+		mockSwapRequestRepo.On("FindByID", swapRequestID).Return(newSwapRequest(domain.StatusAccepted, true, false), nil).Once()
+		mockUserRepo.On("FindByID", mock.Anything).Return(&domain.User{Username: "user", Email: "user@example.com"}, nil)
+		expectEmailItems(mockItemRepo)
+		mockOutbox.On("Enqueue", mock.AnythingOfType("*domain.OutboxMessage")).Return(nil).Once()
+
+		result, err := service.ConfirmHandover(swapRequestID, senderID)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.StatusAccepted, result.Status)
+		assert.True(t, result.SenderConfirmed)
+
+		mockSwapRequestRepo.AssertNotCalled(t, "TryUpdateStatus", mock.Anything, mock.Anything, mock.Anything)
+		mockItemRepo.AssertNotCalled(t, "TransferOwnership", mock.Anything, mock.Anything)
+		mockOutbox.AssertExpectations(t)
+	})
+
+	t.Run("second confirmation completes the swap", func(t *testing.T) {
+		service, mockSwapRequestRepo, mockItemRepo, mockUserRepo, mockOutbox := setupSwapRequestServiceTest()
+
+		mockSwapRequestRepo.On("FindByID", swapRequestID).Return(newSwapRequest(domain.StatusAccepted, true, false), nil).Once()
+		mockSwapRequestRepo.On("TryMarkHandoverConfirmed", swapRequestID, domain.PartyRecipient).Return(true, nil).Once()
+		mockSwapRequestRepo.On("FindByID", swapRequestID).Return(newSwapRequest(domain.StatusAccepted, true, true), nil).Once()
+		mockSwapRequestRepo.On("TryUpdateStatus", swapRequestID, domain.StatusAccepted, domain.StatusCompleted).Return(true, nil).Once()
+		mockItemRepo.On("TransferOwnership", []uuid.UUID{offeredItemID}, recipientID).Return(nil).Once()
+		mockItemRepo.On("TransferOwnership", []uuid.UUID{requestedItemID}, senderID).Return(nil).Once()
+		mockUserRepo.On("FindByID", mock.Anything).Return(&domain.User{Username: "user", Email: "user@example.com"}, nil)
+		expectEmailItems(mockItemRepo)
+		mockOutbox.On("Enqueue", mock.AnythingOfType("*domain.OutboxMessage")).Return(nil).Twice()
+
+		result, err := service.ConfirmHandover(swapRequestID, recipientID)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.StatusCompleted, result.Status)
+
+		mockSwapRequestRepo.AssertExpectations(t)
+		mockItemRepo.AssertExpectations(t)
+		mockOutbox.AssertExpectations(t)
+	})
+
+	t.Run("confirmation that sees the other one completes the swap", func(t *testing.T) {
+		service, mockSwapRequestRepo, mockItemRepo, mockUserRepo, mockOutbox := setupSwapRequestServiceTest()
+
+		mockSwapRequestRepo.On("FindByID", swapRequestID).Return(newSwapRequest(domain.StatusAccepted, false, false), nil).Once()
+		mockSwapRequestRepo.On("TryMarkHandoverConfirmed", swapRequestID, domain.PartySender).Return(true, nil).Once()
+		mockSwapRequestRepo.On("FindByID", swapRequestID).Return(newSwapRequest(domain.StatusAccepted, true, true), nil).Once()
+		mockSwapRequestRepo.On("TryUpdateStatus", swapRequestID, domain.StatusAccepted, domain.StatusCompleted).Return(true, nil).Once()
+		mockItemRepo.On("TransferOwnership", mock.Anything, mock.Anything).Return(nil).Twice()
+		mockUserRepo.On("FindByID", mock.Anything).Return(&domain.User{Username: "user", Email: "user@example.com"}, nil)
+		expectEmailItems(mockItemRepo)
+		mockOutbox.On("Enqueue", mock.AnythingOfType("*domain.OutboxMessage")).Return(nil).Twice()
+
+		result, err := service.ConfirmHandover(swapRequestID, senderID)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.StatusCompleted, result.Status)
+		mockItemRepo.AssertExpectations(t)
+	})
+
+	t.Run("concurrent completion does not exchange items twice", func(t *testing.T) {
+		service, mockSwapRequestRepo, mockItemRepo, _, _ := setupSwapRequestServiceTest()
+
+		mockSwapRequestRepo.On("FindByID", swapRequestID).Return(newSwapRequest(domain.StatusAccepted, true, false), nil).Once()
+		mockSwapRequestRepo.On("TryMarkHandoverConfirmed", swapRequestID, domain.PartyRecipient).Return(true, nil).Once()
+		mockSwapRequestRepo.On("FindByID", swapRequestID).Return(newSwapRequest(domain.StatusAccepted, true, true), nil).Once()
+		mockSwapRequestRepo.On("TryUpdateStatus", swapRequestID, domain.StatusAccepted, domain.StatusCompleted).Return(false, nil).Once()
+		mockSwapRequestRepo.On("FindByID", swapRequestID).Return(newSwapRequest(domain.StatusCompleted, true, true), nil).Once()
+
+		result, err := service.ConfirmHandover(swapRequestID, recipientID)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.StatusCompleted, result.Status)
+
+		mockItemRepo.AssertNotCalled(t, "TransferOwnership", mock.Anything, mock.Anything)
+	})
+
+	t.Run("swap cancelled while completing", func(t *testing.T) {
+		service, mockSwapRequestRepo, mockItemRepo, _, mockOutbox := setupSwapRequestServiceTest()
+
+		mockSwapRequestRepo.On("FindByID", swapRequestID).Return(newSwapRequest(domain.StatusAccepted, true, false), nil).Once()
+		mockSwapRequestRepo.On("TryMarkHandoverConfirmed", swapRequestID, domain.PartyRecipient).Return(true, nil).Once()
+		mockSwapRequestRepo.On("FindByID", swapRequestID).Return(newSwapRequest(domain.StatusAccepted, true, true), nil).Once()
+		mockSwapRequestRepo.On("TryUpdateStatus", swapRequestID, domain.StatusAccepted, domain.StatusCompleted).Return(false, nil).Once()
+		mockSwapRequestRepo.On("FindByID", swapRequestID).Return(newSwapRequest(domain.StatusCancelled, true, true), nil).Once()
+
+		_, err := service.ConfirmHandover(swapRequestID, recipientID)
+		assert.ErrorIs(t, err, services.SwapRequestChangedErr)
+
+		mockItemRepo.AssertNotCalled(t, "TransferOwnership", mock.Anything, mock.Anything)
+		mockOutbox.AssertNotCalled(t, "Enqueue", mock.Anything)
+	})
+
+	t.Run("swap cancelled before the confirmation was recorded", func(t *testing.T) {
+		service, mockSwapRequestRepo, _, _, mockOutbox := setupSwapRequestServiceTest()
+
+		mockSwapRequestRepo.On("FindByID", swapRequestID).Return(newSwapRequest(domain.StatusAccepted, false, false), nil).Once()
+		mockSwapRequestRepo.On("TryMarkHandoverConfirmed", swapRequestID, domain.PartySender).Return(false, nil).Once()
+
+		_, err := service.ConfirmHandover(swapRequestID, senderID)
+		assert.ErrorIs(t, err, services.SwapRequestChangedErr)
+
+		mockOutbox.AssertNotCalled(t, "Enqueue", mock.Anything)
+	})
+
+	t.Run("already confirmed", func(t *testing.T) {
+		service, mockSwapRequestRepo, _, _, _ := setupSwapRequestServiceTest()
+
+		mockSwapRequestRepo.On("FindByID", swapRequestID).Return(newSwapRequest(domain.StatusAccepted, true, false), nil).Once()
+
+		_, err := service.ConfirmHandover(swapRequestID, senderID)
+		assert.ErrorIs(t, err, domain.HandoverAlreadyConfirmedErr)
+
+		mockSwapRequestRepo.AssertNotCalled(t, "TryMarkHandoverConfirmed", mock.Anything, mock.Anything)
+	})
+
+	t.Run("request not accepted", func(t *testing.T) {
+		service, mockSwapRequestRepo, _, _, _ := setupSwapRequestServiceTest()
+
+		mockSwapRequestRepo.On("FindByID", swapRequestID).Return(newSwapRequest(domain.StatusPending, false, false), nil).Once()
+
+		_, err := service.ConfirmHandover(swapRequestID, senderID)
+		assert.ErrorIs(t, err, domain.InvalidStatusTransitionErr)
+	})
+
+	t.Run("not a participant", func(t *testing.T) {
+		service, mockSwapRequestRepo, _, _, _ := setupSwapRequestServiceTest()
+
+		mockSwapRequestRepo.On("FindByID", swapRequestID).Return(newSwapRequest(domain.StatusAccepted, false, false), nil).Once()
+
+		_, err := service.ConfirmHandover(swapRequestID, uuid.New())
+		assert.ErrorIs(t, err, domain.NotSwapRequestParticipantErr)
+	})
+}
+
+func TestSwapRequestService_CounterOffer(t *testing.T) {
+	swapRequestID := uuid.New()
+	senderID := uuid.New()
+	recipientID := uuid.New()
+	offeredItemID := uuid.New()
+	requestedItemID := uuid.New()
+	alternativeItemID := uuid.New()
+
+	newSwapRequest := func() *domain.SwapRequest {
+		return &domain.SwapRequest{
+			ID:              swapRequestID,
+			Status:          domain.StatusPending,
+			ReferenceNumber: "REF123",
+			OfferedItemID:   offeredItemID,
+			RequestedItemID: requestedItemID,
+			SenderID:        senderID,
+			RecipientID:     recipientID,
+			ThreadID:        swapRequestID,
+		}
+	}
+
+	offer := domain.CounterOffer{ReferenceNumber: "REF456", RequestedItemIDs: []uuid.UUID{alternativeItemID}}
+
+	verifiedAt := testNow.Add(-24 * time.Hour)
+	verifiedUser := &domain.User{Username: "user", Email: "user@example.com", EmailVerifiedAt: &verifiedAt}
+
+	t.Run("success", func(t *testing.T) {
+		service, mockSwapRequestRepo, mockItemRepo, mockUserRepo, mockOutbox := setupSwapRequestServiceTest()
+
+		mockSwapRequestRepo.On("FindByID", swapRequestID).Return(newSwapRequest(), nil).Once()
+		mockUserRepo.On("FindByID", recipientID).Return(verifiedUser, nil).Once()
+		mockItemRepo.On("FindByID", requestedItemID).Return(&domain.Item{ID: requestedItemID, UserID: recipientID}, nil).Once()
+		mockItemRepo.On("FindByID", alternativeItemID).Return(&domain.Item{ID: alternativeItemID, UserID: senderID}, nil).Once()
+		mockSwapRequestRepo.On("IsItemInSwapWithStatus", alternativeItemID, mock.Anything).Return(false, nil).Once()
+		mockSwapRequestRepo.On("TryUpdateStatus", swapRequestID, domain.StatusPending, domain.StatusCountered).Return(true, nil).Once()
+		mockItemRepo.On("Update", offeredItemID, mock.MatchedBy(func(fields map[string]interface{}) bool {
+			return fields["offered"] == false
+		})).Return(&domain.Item{}, nil).Once()
+		mockItemRepo.On("TryMarkItemAsOffered", requestedItemID).Return(true, nil).Once()
+		mockSwapRequestRepo.On("Create", mock.AnythingOfType("*domain.SwapRequest")).Return(nil).Once()
+		mockUserRepo.On("FindByID", mock.Anything).Return(&domain.User{Username: "user", Email: "user@example.com"}, nil)
+		expectEmailItems(mockItemRepo)
+		mockOutbox.On("Enqueue", mock.AnythingOfType("*domain.OutboxMessage")).Return(nil).Once()
+
+		counter, err := service.CounterOffer(swapRequestID, recipientID, offer)
+		assert.NoError(t, err)
+		assert.Equal(t, recipientID, counter.SenderID)
+		assert.Equal(t, senderID, counter.RecipientID)
+		assert.Equal(t, requestedItemID, counter.OfferedItemID)
+		assert.Equal(t, alternativeItemID, counter.RequestedItemID)
+		assert.Equal(t, swapRequestID, counter.ThreadID)
+		assert.Equal(t, swapRequestID, *counter.ParentID)
+		assert.Equal(t, domain.StatusPending, counter.Status)
+
+		mockSwapRequestRepo.AssertExpectations(t)
+		mockItemRepo.AssertExpectations(t)
+		mockOutbox.AssertExpectations(t)
+	})
+
+	t.Run("only the recipient can counter", func(t *testing.T) {
+		service, mockSwapRequestRepo, _, mockUserRepo, _ := setupSwapRequestServiceTest()
+
+		mockSwapRequestRepo.On("FindByID", swapRequestID).Return(newSwapRequest(), nil).Once()
+		mockUserRepo.On("FindByID", senderID).Return(verifiedUser, nil).Once()
+
+		_, err := service.CounterOffer(swapRequestID, senderID, offer)
+		assert.ErrorIs(t, err, domain.TransitionNotPermittedErr)
+
+		mockSwapRequestRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("unverified user cannot counter", func(t *testing.T) {
+		service, mockSwapRequestRepo, mockItemRepo, mockUserRepo, mockOutbox := setupSwapRequestServiceTest()
+
+		mockSwapRequestRepo.On("FindByID", swapRequestID).Return(newSwapRequest(), nil).Once()
+		mockUserRepo.On("FindByID", recipientID).Return(&domain.User{Username: "user", Email: "user@example.com"}, nil).Once()
+
+		_, err := service.CounterOffer(swapRequestID, recipientID, offer)
+		assert.ErrorIs(t, err, domain.EmailNotVerifiedErr)
+
+		mockSwapRequestRepo.AssertNotCalled(t, "TryUpdateStatus", mock.Anything, mock.Anything, mock.Anything)
+		mockSwapRequestRepo.AssertNotCalled(t, "Create", mock.Anything)
+		mockItemRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		mockOutbox.AssertNotCalled(t, "Enqueue", mock.Anything)
+	})
+
+	t.Run("unchanged offer", func(t *testing.T) {
+		service, mockSwapRequestRepo, _, mockUserRepo, _ := setupSwapRequestServiceTest()
+
+		mockSwapRequestRepo.On("FindByID", swapRequestID).Return(newSwapRequest(), nil).Once()
+		mockUserRepo.On("FindByID", recipientID).Return(verifiedUser, nil).Once()
+
+		_, err := service.CounterOffer(swapRequestID, recipientID, domain.CounterOffer{})
+		assert.ErrorIs(t, err, domain.CounterOfferUnchangedErr)
+	})
+
+	t.Run("requested item not owned by original sender", func(t *testing.T) {
+		service, mockSwapRequestRepo, mockItemRepo, mockUserRepo, _ := setupSwapRequestServiceTest()
+
+		mockSwapRequestRepo.On("FindByID", swapRequestID).Return(newSwapRequest(), nil).Once()
+		mockUserRepo.On("FindByID", recipientID).Return(verifiedUser, nil).Once()
+		mockItemRepo.On("FindByID", requestedItemID).Return(&domain.Item{ID: requestedItemID, UserID: recipientID}, nil).Once()
+		mockItemRepo.On("FindByID", alternativeItemID).Return(&domain.Item{ID: alternativeItemID, UserID: uuid.New()}, nil).Once()
+
+		_, err := service.CounterOffer(swapRequestID, recipientID, offer)
+		assert.ErrorIs(t, err, services.RequestedItemNotOwnedErr)
+
+		mockSwapRequestRepo.AssertNotCalled(t, "TryUpdateStatus", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("request changed concurrently", func(t *testing.T) {
+		service, mockSwapRequestRepo, mockItemRepo, mockUserRepo, _ := setupSwapRequestServiceTest()
+
+		mockSwapRequestRepo.On("FindByID", swapRequestID).Return(newSwapRequest(), nil).Once()
+		mockUserRepo.On("FindByID", recipientID).Return(verifiedUser, nil).Once()
+		mockItemRepo.On("FindByID", requestedItemID).Return(&domain.Item{ID: requestedItemID, UserID: recipientID}, nil).Once()
+		mockItemRepo.On("FindByID", alternativeItemID).Return(&domain.Item{ID: alternativeItemID, UserID: senderID}, nil).Once()
+		mockSwapRequestRepo.On("IsItemInSwapWithStatus", alternativeItemID, mock.Anything).Return(false, nil).Once()
+		mockSwapRequestRepo.On("TryUpdateStatus", swapRequestID, domain.StatusPending, domain.StatusCountered).Return(false, nil).Once()
+
+		_, err := service.CounterOffer(swapRequestID, recipientID, offer)
+		assert.ErrorIs(t, err, services.SwapRequestChangedErr)
+
+		mockItemRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		mockSwapRequestRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("counter item already offered", func(t *testing.T) {
+		service, mockSwapRequestRepo, mockItemRepo, mockUserRepo, _ := setupSwapRequestServiceTest()
+
+		mockSwapRequestRepo.On("FindByID", swapRequestID).Return(newSwapRequest(), nil).Once()
+		mockUserRepo.On("FindByID", recipientID).Return(verifiedUser, nil).Once()
+		mockItemRepo.On("FindByID", requestedItemID).Return(&domain.Item{ID: requestedItemID, UserID: recipientID}, nil).Once()
+		mockItemRepo.On("FindByID", alternativeItemID).Return(&domain.Item{ID: alternativeItemID, UserID: senderID}, nil).Once()
+		mockSwapRequestRepo.On("IsItemInSwapWithStatus", alternativeItemID, mock.Anything).Return(false, nil).Once()
+		mockSwapRequestRepo.On("TryUpdateStatus", swapRequestID, domain.StatusPending, domain.StatusCountered).Return(true, nil).Once()
+		mockItemRepo.On("Update", offeredItemID, mock.Anything).Return(&domain.Item{}, nil).Once()
+		mockItemRepo.On("TryMarkItemAsOffered", requestedItemID).Return(false, nil).Once()
+
+		_, err := service.CounterOffer(swapRequestID, recipientID, offer)
+		assert.ErrorIs(t, err, services.ItemAlreadyOfferedErr)
+
+		mockSwapRequestRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+}
+
+func TestSwapRequestService_ListThread(t *testing.T) {
+	swapRequestID := uuid.New()
+	threadID := uuid.New()
+	senderID := uuid.New()
+	swapRequest := &domain.SwapRequest{ID: swapRequestID, ThreadID: threadID, SenderID: senderID, RecipientID: uuid.New()}
+	thread := []domain.SwapRequest{{ID: threadID}, *swapRequest}
+
+	t.Run("success", func(t *testing.T) {
+		service, mockSwapRequestRepo, _, _, _ := setupSwapRequestServiceTest()
+
+		mockSwapRequestRepo.On("FindByID", swapRequestID).Return(swapRequest, nil).Once()
+		mockSwapRequestRepo.On("ListByThread", threadID).Return(thread, nil).Once()
+
+		result, err := service.ListThread(swapRequestID, senderID)
+		assert.NoError(t, err)
+		assert.Equal(t, thread, result)
+	})
+
+	t.Run("not a participant", func(t *testing.T) {
+		service, mockSwapRequestRepo, _, _, _ := setupSwapRequestServiceTest()
+
+		mockSwapRequestRepo.On("FindByID", swapRequestID).Return(swapRequest, nil).Once()
+
+		_, err := service.ListThread(swapRequestID, uuid.New())
+		assert.ErrorIs(t, err, domain.NotSwapRequestParticipantErr)
+
+		mockSwapRequestRepo.AssertNotCalled(t, "ListByThread", mock.Anything)
+	})
+}
+
+func TestSwapRequestService_ExpireOverdue(t *testing.T) {
+	senderID := uuid.New()
+	recipientID := uuid.New()
+	offeredItemID := uuid.New()
+
+	newOverdueRequest := func() domain.SwapRequest {
+		expiresAt := testNow.Add(-time.Hour)
+		return domain.SwapRequest{
+			ID:              uuid.New(),
+			Status:          domain.StatusPending,
+			ReferenceNumber: "REF123",
+			OfferedItemID:   offeredItemID,
+			RequestedItemID: uuid.New(),
+			SenderID:        senderID,
+			RecipientID:     recipientID,
+			ExpiresAt:       &expiresAt,
+		}
+	}
+
+	t.Run("expires overdue requests and notifies both parties", func(t *testing.T) {
+		service, mockSwapRequestRepo, mockItemRepo, mockUserRepo, mockOutbox := setupSwapRequestServiceTest()
+
+		overdue := newOverdueRequest()
+
+		mockSwapRequestRepo.On("ListOverdue", testNow).Return([]domain.SwapRequest{overdue}, nil).Once()
+		mockSwapRequestRepo.On("TryUpdateStatus", overdue.ID, domain.StatusPending, domain.StatusExpired).Return(true, nil).Once()
+		mockItemRepo.On("Update", offeredItemID, mock.MatchedBy(func(fields map[string]interface{}) bool {
+			return fields["offered"] == false
+		})).Return(&domain.Item{}, nil).Once()
+		mockUserRepo.On("FindByID", senderID).Return(&domain.User{Username: "sender", Email: "sender@example.com"}, nil)
+		mockUserRepo.On("FindByID", recipientID).Return(&domain.User{Username: "recipient", Email: "recipient@example.com"}, nil)
+		expectEmailItems(mockItemRepo)
+		mockOutbox.On("Enqueue", mock.MatchedBy(func(message *domain.OutboxMessage) bool {
+			return message.Recipient == "sender@example.com"
+		})).Return(nil).Once()
+		mockOutbox.On("Enqueue", mock.MatchedBy(func(message *domain.OutboxMessage) bool {
+			return message.Recipient == "recipient@example.com"
+		})).Return(nil).Once()
+
+		expired, err := service.ExpireOverdue()
+		assert.NoError(t, err)
+		assert.Equal(t, 1, expired)
+
+		mockSwapRequestRepo.AssertExpectations(t)
+		mockItemRepo.AssertExpectations(t)
+		mockOutbox.AssertExpectations(t)
+	})
+
+	t.Run("request answered in the meantime is left alone", func(t *testing.T) {
+		service, mockSwapRequestRepo, mockItemRepo, _, mockOutbox := setupSwapRequestServiceTest()
+
+		overdue := newOverdueRequest()
+
+		mockSwapRequestRepo.On("ListOverdue", testNow).Return([]domain.SwapRequest{overdue}, nil).Once()
+		mockSwapRequestRepo.On("TryUpdateStatus", overdue.ID, domain.StatusPending, domain.StatusExpired).Return(false, nil).Once()
+
+		expired, err := service.ExpireOverdue()
+		assert.NoError(t, err)
+		assert.Equal(t, 0, expired)
+
+		mockItemRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		mockOutbox.AssertNotCalled(t, "Enqueue", mock.Anything)
+	})
+
+	t.Run("failure on one request does not stop the sweep", func(t *testing.T) {
+		service, mockSwapRequestRepo, mockItemRepo, mockUserRepo, mockOutbox := setupSwapRequestServiceTest()
+
+		failing, succeeding := newOverdueRequest(), newOverdueRequest()
+
+		mockSwapRequestRepo.On("ListOverdue", testNow).Return([]domain.SwapRequest{failing, succeeding}, nil).Once()
+		mockSwapRequestRepo.On("TryUpdateStatus", failing.ID, domain.StatusPending, domain.StatusExpired).Return(false, errors.New("db error")).Once()
+		mockSwapRequestRepo.On("TryUpdateStatus", succeeding.ID, domain.StatusPending, domain.StatusExpired).Return(true, nil).Once()
+		mockItemRepo.On("Update", offeredItemID, mock.Anything).Return(&domain.Item{}, nil).Once()
+		mockUserRepo.On("FindByID", mock.Anything).Return(&domain.User{Username: "user", Email: "user@example.com"}, nil)
+		expectEmailItems(mockItemRepo)
+		mockOutbox.On("Enqueue", mock.AnythingOfType("*domain.OutboxMessage")).Return(nil).Twice()
+
+		expired, err := service.ExpireOverdue()
+		assert.NoError(t, err)
+		assert.Equal(t, 1, expired)
+
+		mockSwapRequestRepo.AssertExpectations(t)
+	})
+
+	t.Run("list error", func(t *testing.T) {
+		service, mockSwapRequestRepo, _, _, _ := setupSwapRequestServiceTest()
+
+		mockSwapRequestRepo.On("ListOverdue", testNow).Return(nil, errors.New("db error")).Once()
+
+		_, err := service.ExpireOverdue()
+		assert.EqualError(t, err, "db error")
+	})
+}
+
+func TestSwapRequestService_RemindExpiring(t *testing.T) {
+	senderID := uuid.New()
+	recipientID := uuid.New()
+	deadline := testNow.Add(testReminderBefore)
+
+	newExpiringRequest := func() domain.SwapRequest {
+		expiresAt := testNow.Add(time.Hour)
+		return domain.SwapRequest{
+			ID:              uuid.New(),
+			Status:          domain.StatusPending,
+			ReferenceNumber: "REF123",
+			OfferedItemID:   uuid.New(),
+			RequestedItemID: uuid.New(),
+			SenderID:        senderID,
+			RecipientID:     recipientID,
+			ExpiresAt:       &expiresAt,
+		}
+	}
+
+	t.Run("reminds the recipient once", func(t *testing.T) {
+		service, mockSwapRequestRepo, mockItemRepo, mockUserRepo, mockOutbox := setupSwapRequestServiceTest()
+
+		expiring := newExpiringRequest()
+		alreadyReminded := newExpiringRequest()
+
+		mockSwapRequestRepo.On("ListExpiringSoon", testNow, deadline).Return([]domain.SwapRequest{expiring, alreadyReminded}, nil).Once()
+		mockSwapRequestRepo.On("TryMarkReminderSent", expiring.ID, testNow).Return(true, nil).Once()
+		mockSwapRequestRepo.On("TryMarkReminderSent", alreadyReminded.ID, testNow).Return(false, nil).Once()
+		mockUserRepo.On("FindByID", recipientID).Return(&domain.User{ID: recipientID, Username: "recipient", Email: "recipient@example.com"}, nil)
+		mockUserRepo.On("FindByID", senderID).Return(&domain.User{ID: senderID, Username: "sender", Email: "sender@example.com"}, nil)
+		expectEmailItems(mockItemRepo)
+		mockOutbox.On("Enqueue", mock.MatchedBy(func(message *domain.OutboxMessage) bool {
+			return message.Recipient == "recipient@example.com" &&
+				message.Template == domain.EmailTemplateSwapRequestExpiring &&
+				message.Data["ExpiresAt"] == expiring.ExpiresAt.Format(time.RFC1123)
+		})).Return(nil).Once()
+
+		reminded, err := service.RemindExpiring()
+		assert.NoError(t, err)
+		assert.Equal(t, 1, reminded)
+
+		mockSwapRequestRepo.AssertExpectations(t)
+		mockOutbox.AssertExpectations(t)
+	})
+
+	t.Run("recipient who opted out is marked but not emailed", func(t *testing.T) {
+		service, mockSwapRequestRepo, _, mockUserRepo, mockOutbox := setupSwapRequestServiceTest()
+
+		expiring := newExpiringRequest()
+
+		mockSwapRequestRepo.On("ListExpiringSoon", testNow, deadline).Return([]domain.SwapRequest{expiring}, nil).Once()
+		mockSwapRequestRepo.On("TryMarkReminderSent", expiring.ID, testNow).Return(true, nil).Once()
 		mockUserRepo.On("FindByID", recipientID).Return(&domain.User{
-			ID:       recipientID,
-			Username: "recipient",
-			Email:    "recipient@example.com",
+			ID:                      recipientID,
+			Email:                   "recipient@example.com",
+			NotificationPreferences: domain.NotificationPreferences{domain.NotifyExpiringSoon: false},
 		}, nil).Once()
 
-		mockUserRepo.On("FindByID", senderID).Return(&domain.User{
-			ID:       senderID,
-			Username: "sender",
-			Email:    "sender@example.com",
-		}, nil).Once()
+		reminded, err := service.RemindExpiring()
+		assert.NoError(t, err)
+		assert.Equal(t, 1, reminded)
+		mockOutbox.AssertNotCalled(t, "Enqueue", mock.Anything)
+	})
 
-		mockEmailService.On("SendEmail", mock.AnythingOfType("*domain.EmailMessage")).Return(nil).Once()
+	t.Run("reminders disabled", func(t *testing.T) {
+		mockSwapRequestRepo := new(testMocks.SwapRequestRepository)
+		service := services.NewSwapRequestService(
+			mockSwapRequestRepo,
+			new(testMocks.MockUserRepository),
+			new(testMocks.ItemRepository),
+			&testMocks.UnitOfWork{},
+			&testMocks.Clock{Current: testNow},
+			testRequestTTL,
+			0,
+			newUnsubscribeSigner(),
+			testUnsubscribeURL,
+		)
+
+		reminded, err := service.RemindExpiring()
+		assert.NoError(t, err)
+		assert.Zero(t, reminded)
+		mockSwapRequestRepo.AssertNotCalled(t, "ListExpiringSoon", mock.Anything, mock.Anything)
+	})
+}
 
-		err := service.Delete(swapRequestID)
-		assert.Error(t, err)
+func TestSwapRequestService_ForceCancel(t *testing.T) {
+	senderID := uuid.New()
+	recipientID := uuid.New()
+	offeredItemID := uuid.New()
+
+	newRequest := func(status domain.SwapRequestStatus) *domain.SwapRequest {
+		return &domain.SwapRequest{
+			ID:              uuid.New(),
+			Status:          status,
+			ReferenceNumber: "REF123",
+			OfferedItemID:   offeredItemID,
+			RequestedItemID: uuid.New(),
+			SenderID:        senderID,
+			RecipientID:     recipientID,
+		}
+	}
+
+	t.Run("cancels a disputed request and notifies both parties", func(t *testing.T) {
+		service, mockSwapRequestRepo, mockItemRepo, mockUserRepo, mockOutbox := setupSwapRequestServiceTest()
+
+		swapRequest := newRequest(domain.StatusDisputed)
+
+		mockSwapRequestRepo.On("FindByID", swapRequest.ID).Return(swapRequest, nil).Once()
+		mockSwapRequestRepo.On("TryUpdateStatus", swapRequest.ID, domain.StatusDisputed, domain.StatusCancelled).Return(true, nil).Once()
+		mockItemRepo.On("Update", offeredItemID, mock.MatchedBy(func(fields map[string]interface{}) bool {
+			return fields["offered"] == false
+		})).Return(&domain.Item{}, nil).Once()
+		mockUserRepo.On("FindByID", mock.Anything).Return(&domain.User{Username: "user", Email: "user@example.com"}, nil)
+		expectEmailItems(mockItemRepo)
+		mockOutbox.On("Enqueue", mock.AnythingOfType("*domain.OutboxMessage")).Return(nil).Twice()
+
+		cancelled, err := service.ForceCancel(swapRequest.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.StatusCancelled, cancelled.Status)
 
-		mockItemRepo.AssertExpectations(t)
 		mockSwapRequestRepo.AssertExpectations(t)
-		mockUserRepo.AssertExpectations(t)
-		mockEmailService.AssertExpectations(t)
+		mockItemRepo.AssertExpectations(t)
+		mockOutbox.AssertExpectations(t)
+	})
+
+	t.Run("finished request cannot be cancelled", func(t *testing.T) {
+		service, mockSwapRequestRepo, _, _, mockOutbox := setupSwapRequestServiceTest()
+
+		swapRequest := newRequest(domain.StatusCompleted)
+
+		mockSwapRequestRepo.On("FindByID", swapRequest.ID).Return(swapRequest, nil).Once()
+
+		_, err := service.ForceCancel(swapRequest.ID)
+		assert.ErrorIs(t, err, domain.InvalidStatusTransitionErr)
+
+		mockSwapRequestRepo.AssertNotCalled(t, "TryUpdateStatus", mock.Anything, mock.Anything, mock.Anything)
+		mockOutbox.AssertNotCalled(t, "Enqueue", mock.Anything)
+	})
+
+	t.Run("request changed in the meantime", func(t *testing.T) {
+		service, mockSwapRequestRepo, mockItemRepo, _, _ := setupSwapRequestServiceTest()
+
+		swapRequest := newRequest(domain.StatusPending)
+
+		mockSwapRequestRepo.On("FindByID", swapRequest.ID).Return(swapRequest, nil).Once()
+		mockSwapRequestRepo.On("TryUpdateStatus", swapRequest.ID, domain.StatusPending, domain.StatusCancelled).Return(false, nil).Once()
+
+		_, err := service.ForceCancel(swapRequest.ID)
+		assert.ErrorIs(t, err, services.SwapRequestChangedErr)
+
+		mockItemRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		service, mockSwapRequestRepo, _, _, _ := setupSwapRequestServiceTest()
+
+		missingID := uuid.New()
+		mockSwapRequestRepo.On("FindByID", missingID).Return(nil, errors.New("record not found")).Once()
+
+		_, err := service.ForceCancel(missingID)
+		assert.ErrorIs(t, err, services.SwapRequestNotFoundErr)
 	})
 }
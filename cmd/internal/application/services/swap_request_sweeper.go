@@ -0,0 +1,54 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// SwapRequestSweeper periodically expires pending swap requests that were never
+// answered and reminds recipients of those about to expire.
+type SwapRequestSweeper struct {
+	swapRequestService SwapRequestServiceInterface
+	interval           time.Duration
+}
+
+func NewSwapRequestSweeper(swapRequestService SwapRequestServiceInterface, interval time.Duration) *SwapRequestSweeper {
+	return &SwapRequestSweeper{
+		swapRequestService: swapRequestService,
+		interval:           interval,
+	}
+}
+
+// Run sweeps once immediately and then on every interval until ctx is cancelled.
+func (sweeper *SwapRequestSweeper) Run(ctx context.Context) {
+	sweeper.Sweep()
+
+	ticker := time.NewTicker(sweeper.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			sweeper.Sweep()
+		}
+	}
+}
+
+func (sweeper *SwapRequestSweeper) Sweep() {
+	expired, err := sweeper.swapRequestService.ExpireOverdue()
+	if err != nil {
+		log.Printf("Failed to sweep expired swap requests: %v", err)
+	} else if expired > 0 {
+		log.Printf("Expired %d overdue swap requests", expired)
+	}
+
+	reminded, err := sweeper.swapRequestService.RemindExpiring()
+	if err != nil {
+		log.Printf("Failed to remind recipients of expiring swap requests: %v", err)
+	} else if reminded > 0 {
+		log.Printf("Reminded recipients of %d expiring swap requests", reminded)
+	}
+}
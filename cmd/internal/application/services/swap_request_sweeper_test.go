@@ -0,0 +1,108 @@
+package services_test
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	testMocks "swapp-go/cmd/internal/application/mocks"
+	"swapp-go/cmd/internal/application/ports"
+	"swapp-go/cmd/internal/application/services"
+	"swapp-go/cmd/internal/domain"
+	"testing"
+	"time"
+)
+
+func TestSwapRequestSweeper(t *testing.T) {
+	mockSwapRequestRepo := new(testMocks.SwapRequestRepository)
+	mockItemRepo := new(testMocks.ItemRepository)
+	mockUserRepo := new(testMocks.MockUserRepository)
+	mockOutbox := new(testMocks.MockOutboxRepository)
+	mockNotifications := new(testMocks.MockNotificationRepository)
+	mockNotifications.On("Create", mock.AnythingOfType("*domain.Notification")).Return(nil)
+	clock := &testMocks.Clock{Current: testNow}
+
+	service := services.NewSwapRequestService(
+		mockSwapRequestRepo,
+		mockUserRepo,
+		mockItemRepo,
+		&testMocks.UnitOfWork{Repositories: ports.Repositories{
+			SwapRequests:  mockSwapRequestRepo,
+			Items:         mockItemRepo,
+			Users:         mockUserRepo,
+			Outbox:        mockOutbox,
+			Notifications: mockNotifications,
+		}},
+		clock,
+		testRequestTTL,
+		testReminderBefore,
+		newUnsubscribeSigner(),
+		testUnsubscribeURL,
+	)
+	sweeper := services.NewSwapRequestSweeper(service, time.Hour)
+
+	request := &domain.SwapRequest{
+		OfferedItemID:   uuid.New(),
+		RequestedItemID: uuid.New(),
+		SenderID:        uuid.New(),
+		RecipientID:     uuid.New(),
+	}
+
+	mockItemRepo.On("FindByID", request.OfferedItemID).Return(&domain.Item{UserID: request.SenderID}, nil).Once()
+	mockItemRepo.On("FindByID", request.RequestedItemID).Return(&domain.Item{UserID: request.RecipientID}, nil).Once()
+	mockSwapRequestRepo.On("IsItemInSwapWithStatus", request.RequestedItemID, mock.Anything).Return(false, nil).Once()
+	mockItemRepo.On("TryMarkItemAsOffered", request.OfferedItemID).Return(true, nil).Once()
+	mockSwapRequestRepo.On("Create", request).Return(nil).Once()
+	mockUserRepo.On("FindByID", mock.Anything).Return(&domain.User{Username: "user", Email: "user@example.com", EmailVerifiedAt: &testNow}, nil)
+	expectEmailItems(mockItemRepo)
+	mockOutbox.On("Enqueue", mock.AnythingOfType("*domain.OutboxMessage")).Return(nil)
+
+	assert.NoError(t, service.Create(request))
+
+	t.Run("nothing is due before the deadline", func(t *testing.T) {
+		mockSwapRequestRepo.On("ListOverdue", clock.Now()).Return([]domain.SwapRequest{}, nil).Once()
+		mockSwapRequestRepo.On("ListExpiringSoon", clock.Now(), clock.Now().Add(testReminderBefore)).Return([]domain.SwapRequest{}, nil).Once()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		sweeper.Run(ctx)
+
+		mockSwapRequestRepo.AssertNotCalled(t, "TryUpdateStatus", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("recipient is reminded shortly before the deadline", func(t *testing.T) {
+		clock.Advance(testRequestTTL - testReminderBefore/2)
+
+		expiring := *request
+		expiring.Status = domain.StatusPending
+
+		mockSwapRequestRepo.On("ListOverdue", clock.Now()).Return([]domain.SwapRequest{}, nil).Once()
+		mockSwapRequestRepo.On("ListExpiringSoon", clock.Now(), clock.Now().Add(testReminderBefore)).
+			Return([]domain.SwapRequest{expiring}, nil).Once()
+		mockSwapRequestRepo.On("TryMarkReminderSent", request.ID, clock.Now()).Return(true, nil).Once()
+
+		sweeper.Sweep()
+
+		mockSwapRequestRepo.AssertExpectations(t)
+		mockOutbox.AssertCalled(t, "Enqueue", mock.MatchedBy(func(message *domain.OutboxMessage) bool {
+			return message.Template == domain.EmailTemplateSwapRequestExpiring
+		}))
+	})
+
+	t.Run("request expires once the clock passes the deadline", func(t *testing.T) {
+		clock.Current = *request.ExpiresAt
+
+		overdue := *request
+		overdue.Status = domain.StatusPending
+
+		mockSwapRequestRepo.On("ListOverdue", *request.ExpiresAt).Return([]domain.SwapRequest{overdue}, nil).Once()
+		mockSwapRequestRepo.On("TryUpdateStatus", request.ID, domain.StatusPending, domain.StatusExpired).Return(true, nil).Once()
+		mockItemRepo.On("Update", request.OfferedItemID, mock.Anything).Return(&domain.Item{}, nil).Once()
+		mockSwapRequestRepo.On("ListExpiringSoon", clock.Now(), clock.Now().Add(testReminderBefore)).Return([]domain.SwapRequest{}, nil).Once()
+
+		sweeper.Sweep()
+
+		mockSwapRequestRepo.AssertExpectations(t)
+		mockItemRepo.AssertExpectations(t)
+	})
+}
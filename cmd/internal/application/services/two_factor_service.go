@@ -0,0 +1,355 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"strings"
+	"swapp-go/cmd/internal/application/ports"
+	"swapp-go/cmd/internal/domain"
+	"swapp-go/cmd/internal/utils"
+	"time"
+)
+
+var (
+	TwoFactorNotEnrolledErr    = errors.New("two-factor authentication is not set up")
+	TwoFactorAlreadyEnabledErr = errors.New("two-factor authentication is already enabled")
+	InvalidTwoFactorCodeErr    = errors.New("invalid two-factor authentication code")
+	InvalidLoginChallengeErr   = errors.New("invalid or expired login challenge")
+)
+
+var (
+	recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+	// recoveryCodeSeparators are dropped from recovery codes before hashing.
+	recoveryCodeSeparators = strings.NewReplacer("-", "", " ", "")
+)
+
+const (
+	// totpSkew is how many time steps a code may be off, for clock drift.
+	totpSkew = 1
+	// recoveryCodeCount recovery codes of recoveryCodeSize random bytes are
+	// handed out when two-factor authentication is enabled.
+	recoveryCodeCount = 10
+	recoveryCodeSize  = 10
+)
+
+type TwoFactorService struct {
+	repo          ports.TwoFactorRepository
+	userRepo      ports.UserRepository
+	signer        ports.LinkTokenSigner
+	loginThrottle LoginThrottleServiceInterface
+	clock         ports.Clock
+	issuer        string
+	challengeTTL  time.Duration
+}
+
+func NewTwoFactorService(
+	repo ports.TwoFactorRepository,
+	userRepo ports.UserRepository,
+	signer ports.LinkTokenSigner,
+	loginThrottle LoginThrottleServiceInterface,
+	clock ports.Clock,
+	issuer string,
+	challengeTTL time.Duration,
+) *TwoFactorService {
+	return &TwoFactorService{
+		repo:          repo,
+		userRepo:      userRepo,
+		signer:        signer,
+		loginThrottle: loginThrottle,
+		clock:         clock,
+		issuer:        issuer,
+		challengeTTL:  challengeTTL,
+	}
+}
+
+// Enroll generates a new TOTP secret for the user. It is not enforced until
+// Confirm is called with a code from it; enrolling again replaces a secret
+// that was never confirmed.
+func (service *TwoFactorService) Enroll(userID uuid.UUID) (*domain.TwoFactorEnrollment, error) {
+	user, err := service.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := service.repo.FindByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil && existing.IsEnabled() {
+		return nil, TwoFactorAlreadyEnabledErr
+	}
+
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	twoFactor := &domain.TwoFactor{
+		UserID:    userID,
+		Secret:    secret,
+		CreatedAt: service.clock.Now(),
+	}
+	if err = service.repo.Save(twoFactor); err != nil {
+		return nil, err
+	}
+
+	return &domain.TwoFactorEnrollment{
+		Secret:          secret,
+		ProvisioningURI: utils.TOTPProvisioningURI(service.issuer, user.Username, secret),
+	}, nil
+}
+
+// Confirm enables two-factor authentication once the user proves their
+// authenticator app works, and returns the recovery codes. They are only
+// stored hashed, so this is the only time they can be shown.
+func (service *TwoFactorService) Confirm(userID uuid.UUID, code string, ipAddress string) ([]string, error) {
+	twoFactor, err := service.repo.FindByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if twoFactor == nil {
+		return nil, TwoFactorNotEnrolledErr
+	}
+	if twoFactor.IsEnabled() {
+		return nil, TwoFactorAlreadyEnabledErr
+	}
+
+	now := service.clock.Now()
+	var step int64
+	err = service.verifyThrottled(userID, ipAddress, func() error {
+		var ok bool
+		if step, ok = utils.ValidateTOTP(twoFactor.Secret, code, now, totpSkew); !ok {
+			return InvalidTwoFactorCodeErr
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	twoFactor.LastUsedStep = step
+	twoFactor.EnabledAt = &now
+	if err = service.repo.Save(twoFactor); err != nil {
+		return nil, err
+	}
+
+	return service.replaceRecoveryCodes(userID)
+}
+
+// Disable turns two-factor authentication off, given a current code or a
+// recovery code.
+func (service *TwoFactorService) Disable(userID uuid.UUID, code string, ipAddress string) error {
+	twoFactor, err := service.enabledTwoFactor(userID)
+	if err != nil {
+		return err
+	}
+
+	err = service.verifyThrottled(userID, ipAddress, func() error {
+		return service.verifyCode(twoFactor, code)
+	})
+	if err != nil {
+		return err
+	}
+
+	return service.repo.Delete(userID)
+}
+
+// RegenerateRecoveryCodes replaces the user's recovery codes, given a current
+// code or one of the old recovery codes.
+func (service *TwoFactorService) RegenerateRecoveryCodes(userID uuid.UUID, code string, ipAddress string) ([]string, error) {
+	twoFactor, err := service.enabledTwoFactor(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = service.verifyThrottled(userID, ipAddress, func() error {
+		return service.verifyCode(twoFactor, code)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return service.replaceRecoveryCodes(userID)
+}
+
+// Challenge returns the challenge a user whose password was just checked has
+// to answer with a second factor, or nil when two-factor authentication is
+// not enabled for them. The challenge is bound to the password hash, so it
+// stops working if the password changes in the meantime.
+func (service *TwoFactorService) Challenge(user *domain.User) (*domain.LoginChallenge, error) {
+	twoFactor, err := service.repo.FindByUserID(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if twoFactor == nil || !twoFactor.IsEnabled() {
+		return nil, nil
+	}
+
+	expiresAt := service.clock.Now().Add(service.challengeTTL)
+	token, err := service.signer.SignLink(domain.LinkTokenClaims{
+		Purpose:   domain.LoginChallengePurpose,
+		UserID:    user.ID,
+		Value:     utils.HashToken(user.Password),
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.LoginChallenge{Token: token, ExpiresAt: expiresAt}, nil
+}
+
+// CompleteLogin answers a login challenge with a current code or a recovery
+// code, and returns the user who may now start a session. Wrong codes are
+// throttled like wrong passwords.
+func (service *TwoFactorService) CompleteLogin(challengeToken string, code string, ipAddress string) (*domain.User, error) {
+	claims, err := service.signer.VerifyLink(domain.LoginChallengePurpose, challengeToken)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", InvalidLoginChallengeErr, err)
+	}
+
+	user, err := service.userRepo.FindByID(claims.UserID)
+	if err != nil {
+		return nil, InvalidLoginChallengeErr
+	}
+
+	if utils.HashToken(user.Password) != claims.Value {
+		return nil, fmt.Errorf("%w: password has changed", InvalidLoginChallengeErr)
+	}
+
+	twoFactor, err := service.enabledTwoFactor(user.ID)
+	if err != nil {
+		if errors.Is(err, TwoFactorNotEnrolledErr) {
+			return nil, InvalidLoginChallengeErr
+		}
+		return nil, err
+	}
+
+	err = service.throttle(user, ipAddress, func() error {
+		return service.verifyCode(twoFactor, code)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// verifyThrottled runs verify for a signed-in user like throttle does, so that
+// a stolen access token cannot be used to guess codes quickly either.
+func (service *TwoFactorService) verifyThrottled(userID uuid.UUID, ipAddress string, verify func() error) error {
+	user, err := service.userRepo.FindByID(userID)
+	if err != nil {
+		return err
+	}
+
+	return service.throttle(user, ipAddress, verify)
+}
+
+// throttle runs verify unless the user's codes are throttled, and records a
+// wrong code as a failed login. Every check of a code counts towards the same
+// account, so guesses cannot be spread over several endpoints.
+func (service *TwoFactorService) throttle(user *domain.User, ipAddress string, verify func() error) error {
+	account := twoFactorLoginAccount(user)
+	if err := service.loginThrottle.Check(account, ipAddress); err != nil {
+		return err
+	}
+
+	if err := verify(); err != nil {
+		if errors.Is(err, InvalidTwoFactorCodeErr) {
+			if recordErr := service.loginThrottle.RecordFailure(account, ipAddress, user); recordErr != nil {
+				return recordErr
+			}
+		}
+		return err
+	}
+
+	return service.loginThrottle.RecordSuccess(account)
+}
+
+func (service *TwoFactorService) enabledTwoFactor(userID uuid.UUID) (*domain.TwoFactor, error) {
+	twoFactor, err := service.repo.FindByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if twoFactor == nil || !twoFactor.IsEnabled() {
+		return nil, TwoFactorNotEnrolledErr
+	}
+
+	return twoFactor, nil
+}
+
+// verifyCode accepts a TOTP code whose time step was not used before, or
+// otherwise an unused recovery code, which is then used up.
+func (service *TwoFactorService) verifyCode(twoFactor *domain.TwoFactor, code string) error {
+	now := service.clock.Now()
+
+	if step, ok := utils.ValidateTOTP(twoFactor.Secret, code, now, totpSkew); ok {
+		used, err := service.repo.TryUseStep(twoFactor.UserID, step)
+		if err != nil {
+			return err
+		}
+		if !used {
+			return InvalidTwoFactorCodeErr
+		}
+		return nil
+	}
+
+	used, err := service.repo.TryUseRecoveryCode(twoFactor.UserID, utils.HashToken(normalizeRecoveryCode(code)), now)
+	if err != nil {
+		return err
+	}
+	if !used {
+		return InvalidTwoFactorCodeErr
+	}
+
+	return nil
+}
+
+func (service *TwoFactorService) replaceRecoveryCodes(userID uuid.UUID) ([]string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	codeHashes := make([]string, 0, recoveryCodeCount)
+
+	for i := 0; i < recoveryCodeCount; i++ {
+		code, err := generateRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+
+		codes = append(codes, code)
+		codeHashes = append(codeHashes, utils.HashToken(normalizeRecoveryCode(code)))
+	}
+
+	if err := service.repo.ReplaceRecoveryCodes(userID, codeHashes); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// generateRecoveryCode returns a random code in groups of four characters,
+// such as "ABCD-EFGH-IJKL-MNOP", that is easy to write down.
+func generateRecoveryCode() (string, error) {
+	buffer := make([]byte, recoveryCodeSize)
+	if _, err := rand.Read(buffer); err != nil {
+		return "", err
+	}
+
+	encoded := recoveryCodeEncoding.EncodeToString(buffer)
+
+	groups := make([]string, 0, len(encoded)/4)
+	for start := 0; start < len(encoded); start += 4 {
+		groups = append(groups, encoded[start:min(start+4, len(encoded))])
+	}
+
+	return strings.Join(groups, "-"), nil
+}
+
+// normalizeRecoveryCode ignores case and group separators, so users can type
+// a code the way it reads best to them.
+func normalizeRecoveryCode(code string) string {
+	return strings.ToUpper(recoveryCodeSeparators.Replace(code))
+}
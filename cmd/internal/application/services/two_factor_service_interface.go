@@ -0,0 +1,15 @@
+package services
+
+import (
+	"github.com/google/uuid"
+	"swapp-go/cmd/internal/domain"
+)
+
+type TwoFactorServiceInterface interface {
+	Enroll(userID uuid.UUID) (*domain.TwoFactorEnrollment, error)
+	Confirm(userID uuid.UUID, code string, ipAddress string) ([]string, error)
+	Disable(userID uuid.UUID, code string, ipAddress string) error
+	RegenerateRecoveryCodes(userID uuid.UUID, code string, ipAddress string) ([]string, error)
+	Challenge(user *domain.User) (*domain.LoginChallenge, error)
+	CompleteLogin(challengeToken string, code string, ipAddress string) (*domain.User, error)
+}
@@ -0,0 +1,376 @@
+package services_test
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"strings"
+	testMocks "swapp-go/cmd/internal/application/mocks"
+	"swapp-go/cmd/internal/application/services"
+	"swapp-go/cmd/internal/domain"
+	"swapp-go/cmd/internal/utils"
+	"testing"
+	"time"
+)
+
+const (
+	testTwoFactorIssuer = "Swapp"
+	testChallengeTTL    = 5 * time.Minute
+	testTOTPSecret      = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+)
+
+func setupTwoFactorServiceTest() (
+	*services.TwoFactorService,
+	*testMocks.MockTwoFactorRepository,
+	*testMocks.MockUserRepository,
+	*testMocks.MockLinkTokenSigner,
+) {
+	service, mockRepo, mockUserRepo, mockSigner, _ := setupTwoFactorLoginTest()
+
+	return service, mockRepo, mockUserRepo, mockSigner
+}
+
+func setupTwoFactorLoginTest() (
+	*services.TwoFactorService,
+	*testMocks.MockTwoFactorRepository,
+	*testMocks.MockUserRepository,
+	*testMocks.MockLinkTokenSigner,
+	*testMocks.MockLoginThrottleService,
+) {
+	mockRepo := new(testMocks.MockTwoFactorRepository)
+	mockUserRepo := new(testMocks.MockUserRepository)
+	mockSigner := new(testMocks.MockLinkTokenSigner)
+	mockThrottle := new(testMocks.MockLoginThrottleService)
+
+	service := services.NewTwoFactorService(
+		mockRepo,
+		mockUserRepo,
+		mockSigner,
+		mockThrottle,
+		&testMocks.Clock{Current: testNow},
+		testTwoFactorIssuer,
+		testChallengeTTL,
+	)
+
+	return service, mockRepo, mockUserRepo, mockSigner, mockThrottle
+}
+
+func enabledTwoFactor(userID uuid.UUID) *domain.TwoFactor {
+	enabledAt := testNow.Add(-24 * time.Hour)
+
+	return &domain.TwoFactor{UserID: userID, Secret: testTOTPSecret, EnabledAt: &enabledAt, CreatedAt: enabledAt}
+}
+
+func currentTOTPCode(t *testing.T) string {
+	t.Helper()
+
+	code, err := utils.TOTPCode(testTOTPSecret, utils.TOTPStep(testNow))
+	assert.NoError(t, err)
+
+	return code
+}
+
+func TestTwoFactorService_Enroll(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		service, mockRepo, mockUserRepo, _ := setupTwoFactorServiceTest()
+		user := &domain.User{ID: uuid.New(), Username: "user"}
+
+		mockUserRepo.On("FindByID", user.ID).Return(user, nil)
+		mockRepo.On("FindByUserID", user.ID).Return(nil, nil)
+		mockRepo.On("Save", mock.MatchedBy(func(twoFactor *domain.TwoFactor) bool {
+			return twoFactor.UserID == user.ID && twoFactor.Secret != "" && !twoFactor.IsEnabled()
+		})).Return(nil).Once()
+
+		enrollment, err := service.Enroll(user.ID)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, enrollment.Secret)
+		assert.True(t, strings.HasPrefix(enrollment.ProvisioningURI, "otpauth://totp/Swapp:user?"))
+		assert.Contains(t, enrollment.ProvisioningURI, "secret="+enrollment.Secret)
+
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("already enabled", func(t *testing.T) {
+		service, mockRepo, mockUserRepo, _ := setupTwoFactorServiceTest()
+		user := &domain.User{ID: uuid.New(), Username: "user"}
+
+		mockUserRepo.On("FindByID", user.ID).Return(user, nil)
+		mockRepo.On("FindByUserID", user.ID).Return(enabledTwoFactor(user.ID), nil)
+
+		_, err := service.Enroll(user.ID)
+		assert.ErrorIs(t, err, services.TwoFactorAlreadyEnabledErr)
+		mockRepo.AssertNotCalled(t, "Save", mock.Anything)
+	})
+}
+
+func TestTwoFactorService_Confirm(t *testing.T) {
+	user := &domain.User{ID: uuid.New()}
+	account := "two-factor:" + user.ID.String()
+
+	t.Run("enables and returns recovery codes", func(t *testing.T) {
+		service, mockRepo, mockUserRepo, _, mockThrottle := setupTwoFactorLoginTest()
+		pending := &domain.TwoFactor{UserID: user.ID, Secret: testTOTPSecret, CreatedAt: testNow}
+
+		var storedHashes []string
+		mockRepo.On("FindByUserID", user.ID).Return(pending, nil)
+		mockUserRepo.On("FindByID", user.ID).Return(user, nil)
+		mockThrottle.On("Check", account, testClient.IPAddress).Return(nil).Once()
+		mockThrottle.On("RecordSuccess", account).Return(nil).Once()
+		mockRepo.On("Save", mock.MatchedBy(func(twoFactor *domain.TwoFactor) bool {
+			return twoFactor.IsEnabled() && twoFactor.LastUsedStep == utils.TOTPStep(testNow)
+		})).Return(nil).Once()
+		mockRepo.On("ReplaceRecoveryCodes", user.ID, mock.AnythingOfType("[]string")).
+			Run(func(args mock.Arguments) { storedHashes = args.Get(1).([]string) }).
+			Return(nil).Once()
+
+		codes, err := service.Confirm(user.ID, currentTOTPCode(t), testClient.IPAddress)
+		assert.NoError(t, err)
+		assert.Len(t, codes, 10)
+		assert.Len(t, storedHashes, 10)
+		assert.Regexp(t, `^[A-Z2-7]{4}(-[A-Z2-7]{4}){3}$`, codes[0])
+		assert.Equal(t, utils.HashToken(strings.ReplaceAll(codes[0], "-", "")), storedHashes[0], "only hashes are stored")
+
+		mockRepo.AssertExpectations(t)
+		mockThrottle.AssertExpectations(t)
+	})
+
+	t.Run("invalid code counts as a failure", func(t *testing.T) {
+		service, mockRepo, mockUserRepo, _, mockThrottle := setupTwoFactorLoginTest()
+
+		mockRepo.On("FindByUserID", user.ID).Return(&domain.TwoFactor{UserID: user.ID, Secret: testTOTPSecret}, nil)
+		mockUserRepo.On("FindByID", user.ID).Return(user, nil)
+		mockThrottle.On("Check", account, testClient.IPAddress).Return(nil).Once()
+		mockThrottle.On("RecordFailure", account, testClient.IPAddress, user).Return(nil).Once()
+
+		_, err := service.Confirm(user.ID, "000000", testClient.IPAddress)
+		assert.ErrorIs(t, err, services.InvalidTwoFactorCodeErr)
+		mockRepo.AssertNotCalled(t, "Save", mock.Anything)
+		mockThrottle.AssertExpectations(t)
+	})
+
+	t.Run("throttled", func(t *testing.T) {
+		service, mockRepo, mockUserRepo, _, mockThrottle := setupTwoFactorLoginTest()
+
+		mockRepo.On("FindByUserID", user.ID).Return(&domain.TwoFactor{UserID: user.ID, Secret: testTOTPSecret}, nil)
+		mockUserRepo.On("FindByID", user.ID).Return(user, nil)
+		mockThrottle.On("Check", account, testClient.IPAddress).Return(&services.LoginThrottledError{RetryAt: testNow.Add(time.Minute)})
+
+		_, err := service.Confirm(user.ID, currentTOTPCode(t), testClient.IPAddress)
+		assert.ErrorIs(t, err, services.LoginThrottledErr)
+		mockRepo.AssertNotCalled(t, "Save", mock.Anything)
+	})
+
+	t.Run("not enrolled", func(t *testing.T) {
+		service, mockRepo, _, _ := setupTwoFactorServiceTest()
+
+		mockRepo.On("FindByUserID", user.ID).Return(nil, nil)
+
+		_, err := service.Confirm(user.ID, "123456", testClient.IPAddress)
+		assert.ErrorIs(t, err, services.TwoFactorNotEnrolledErr)
+	})
+}
+
+func TestTwoFactorService_Disable(t *testing.T) {
+	user := &domain.User{ID: uuid.New()}
+	account := "two-factor:" + user.ID.String()
+
+	t.Run("with a recovery code", func(t *testing.T) {
+		service, mockRepo, mockUserRepo, _, mockThrottle := setupTwoFactorLoginTest()
+
+		mockRepo.On("FindByUserID", user.ID).Return(enabledTwoFactor(user.ID), nil)
+		mockUserRepo.On("FindByID", user.ID).Return(user, nil)
+		mockThrottle.On("Check", account, testClient.IPAddress).Return(nil).Once()
+		mockRepo.On("TryUseRecoveryCode", user.ID, utils.HashToken("ABCDEFGHIJKLMNOP"), testNow).Return(true, nil).Once()
+		mockThrottle.On("RecordSuccess", account).Return(nil).Once()
+		mockRepo.On("Delete", user.ID).Return(nil).Once()
+
+		err := service.Disable(user.ID, "abcd-efgh ijkl-mnop", testClient.IPAddress)
+		assert.NoError(t, err)
+
+		mockRepo.AssertExpectations(t)
+		mockThrottle.AssertExpectations(t)
+	})
+
+	t.Run("invalid code counts as a failure", func(t *testing.T) {
+		service, mockRepo, mockUserRepo, _, mockThrottle := setupTwoFactorLoginTest()
+
+		mockRepo.On("FindByUserID", user.ID).Return(enabledTwoFactor(user.ID), nil)
+		mockUserRepo.On("FindByID", user.ID).Return(user, nil)
+		mockThrottle.On("Check", account, testClient.IPAddress).Return(nil).Once()
+		mockRepo.On("TryUseRecoveryCode", user.ID, mock.Anything, testNow).Return(false, nil)
+		mockThrottle.On("RecordFailure", account, testClient.IPAddress, user).Return(nil).Once()
+
+		err := service.Disable(user.ID, "000000", testClient.IPAddress)
+		assert.ErrorIs(t, err, services.InvalidTwoFactorCodeErr)
+		mockRepo.AssertNotCalled(t, "Delete", mock.Anything)
+		mockThrottle.AssertExpectations(t)
+	})
+
+	t.Run("throttled", func(t *testing.T) {
+		service, mockRepo, mockUserRepo, _, mockThrottle := setupTwoFactorLoginTest()
+
+		mockRepo.On("FindByUserID", user.ID).Return(enabledTwoFactor(user.ID), nil)
+		mockUserRepo.On("FindByID", user.ID).Return(user, nil)
+		mockThrottle.On("Check", account, testClient.IPAddress).Return(&services.LoginThrottledError{RetryAt: testNow.Add(time.Minute)})
+
+		err := service.Disable(user.ID, "abcd-efgh ijkl-mnop", testClient.IPAddress)
+		assert.ErrorIs(t, err, services.LoginThrottledErr)
+		mockRepo.AssertNotCalled(t, "TryUseRecoveryCode", mock.Anything, mock.Anything, mock.Anything)
+		mockRepo.AssertNotCalled(t, "Delete", mock.Anything)
+	})
+}
+
+func TestTwoFactorService_RegenerateRecoveryCodes(t *testing.T) {
+	user := &domain.User{ID: uuid.New()}
+	account := "two-factor:" + user.ID.String()
+
+	t.Run("success", func(t *testing.T) {
+		service, mockRepo, mockUserRepo, _, mockThrottle := setupTwoFactorLoginTest()
+
+		mockRepo.On("FindByUserID", user.ID).Return(enabledTwoFactor(user.ID), nil)
+		mockUserRepo.On("FindByID", user.ID).Return(user, nil)
+		mockThrottle.On("Check", account, testClient.IPAddress).Return(nil).Once()
+		mockRepo.On("TryUseStep", user.ID, utils.TOTPStep(testNow)).Return(true, nil).Once()
+		mockThrottle.On("RecordSuccess", account).Return(nil).Once()
+		mockRepo.On("ReplaceRecoveryCodes", user.ID, mock.AnythingOfType("[]string")).Return(nil).Once()
+
+		codes, err := service.RegenerateRecoveryCodes(user.ID, currentTOTPCode(t), testClient.IPAddress)
+		assert.NoError(t, err)
+		assert.Len(t, codes, 10)
+
+		mockRepo.AssertExpectations(t)
+		mockThrottle.AssertExpectations(t)
+	})
+
+	t.Run("throttled", func(t *testing.T) {
+		service, mockRepo, mockUserRepo, _, mockThrottle := setupTwoFactorLoginTest()
+
+		mockRepo.On("FindByUserID", user.ID).Return(enabledTwoFactor(user.ID), nil)
+		mockUserRepo.On("FindByID", user.ID).Return(user, nil)
+		mockThrottle.On("Check", account, testClient.IPAddress).Return(&services.LoginThrottledError{RetryAt: testNow.Add(time.Minute)})
+
+		_, err := service.RegenerateRecoveryCodes(user.ID, currentTOTPCode(t), testClient.IPAddress)
+		assert.ErrorIs(t, err, services.LoginThrottledErr)
+		mockRepo.AssertNotCalled(t, "TryUseStep", mock.Anything, mock.Anything)
+		mockRepo.AssertNotCalled(t, "ReplaceRecoveryCodes", mock.Anything, mock.Anything)
+	})
+}
+
+func TestTwoFactorService_Challenge(t *testing.T) {
+	user := &domain.User{ID: uuid.New(), Password: "password-hash"}
+
+	t.Run("enabled", func(t *testing.T) {
+		service, mockRepo, _, mockSigner := setupTwoFactorServiceTest()
+
+		mockRepo.On("FindByUserID", user.ID).Return(enabledTwoFactor(user.ID), nil)
+		mockSigner.On("SignLink", domain.LinkTokenClaims{
+			Purpose:   domain.LoginChallengePurpose,
+			UserID:    user.ID,
+			Value:     utils.HashToken(user.Password),
+			ExpiresAt: testNow.Add(testChallengeTTL),
+		}).Return("challenge-token", nil).Once()
+
+		challenge, err := service.Challenge(user)
+		assert.NoError(t, err)
+		assert.Equal(t, "challenge-token", challenge.Token)
+		assert.Equal(t, testNow.Add(testChallengeTTL), challenge.ExpiresAt)
+	})
+
+	t.Run("pending enrollment is not enforced", func(t *testing.T) {
+		service, mockRepo, _, mockSigner := setupTwoFactorServiceTest()
+
+		mockRepo.On("FindByUserID", user.ID).Return(&domain.TwoFactor{UserID: user.ID, Secret: testTOTPSecret}, nil)
+
+		challenge, err := service.Challenge(user)
+		assert.NoError(t, err)
+		assert.Nil(t, challenge)
+		mockSigner.AssertNotCalled(t, "SignLink", mock.Anything)
+	})
+
+	t.Run("lookup failure does not skip the second factor", func(t *testing.T) {
+		service, mockRepo, _, _ := setupTwoFactorServiceTest()
+
+		mockRepo.On("FindByUserID", user.ID).Return(nil, errors.New("db down"))
+
+		challenge, err := service.Challenge(user)
+		assert.EqualError(t, err, "db down")
+		assert.Nil(t, challenge)
+	})
+}
+
+func TestTwoFactorService_CompleteLogin(t *testing.T) {
+	user := &domain.User{ID: uuid.New(), Password: "password-hash"}
+	account := "two-factor:" + user.ID.String()
+	claims := &domain.LinkTokenClaims{
+		Purpose: domain.LoginChallengePurpose,
+		UserID:  user.ID,
+		Value:   utils.HashToken(user.Password),
+	}
+
+	t.Run("success", func(t *testing.T) {
+		service, mockRepo, mockUserRepo, mockSigner, mockThrottle := setupTwoFactorLoginTest()
+
+		mockSigner.On("VerifyLink", domain.LoginChallengePurpose, "challenge-token").Return(claims, nil)
+		mockUserRepo.On("FindByID", user.ID).Return(user, nil)
+		mockRepo.On("FindByUserID", user.ID).Return(enabledTwoFactor(user.ID), nil)
+		mockThrottle.On("Check", account, testClient.IPAddress).Return(nil).Once()
+		mockRepo.On("TryUseStep", user.ID, utils.TOTPStep(testNow)).Return(true, nil).Once()
+		mockThrottle.On("RecordSuccess", account).Return(nil).Once()
+
+		loggedIn, err := service.CompleteLogin("challenge-token", currentTOTPCode(t), testClient.IPAddress)
+		assert.NoError(t, err)
+		assert.Equal(t, user, loggedIn)
+
+		mockThrottle.AssertExpectations(t)
+	})
+
+	t.Run("replayed code counts as a failure", func(t *testing.T) {
+		service, mockRepo, mockUserRepo, mockSigner, mockThrottle := setupTwoFactorLoginTest()
+
+		mockSigner.On("VerifyLink", domain.LoginChallengePurpose, "challenge-token").Return(claims, nil)
+		mockUserRepo.On("FindByID", user.ID).Return(user, nil)
+		mockRepo.On("FindByUserID", user.ID).Return(enabledTwoFactor(user.ID), nil)
+		mockThrottle.On("Check", account, testClient.IPAddress).Return(nil).Once()
+		mockRepo.On("TryUseStep", user.ID, utils.TOTPStep(testNow)).Return(false, nil).Once()
+		mockThrottle.On("RecordFailure", account, testClient.IPAddress, user).Return(nil).Once()
+
+		_, err := service.CompleteLogin("challenge-token", currentTOTPCode(t), testClient.IPAddress)
+		assert.ErrorIs(t, err, services.InvalidTwoFactorCodeErr)
+
+		mockThrottle.AssertExpectations(t)
+	})
+
+	t.Run("throttled", func(t *testing.T) {
+		service, mockRepo, mockUserRepo, mockSigner, mockThrottle := setupTwoFactorLoginTest()
+
+		mockSigner.On("VerifyLink", domain.LoginChallengePurpose, "challenge-token").Return(claims, nil)
+		mockUserRepo.On("FindByID", user.ID).Return(user, nil)
+		mockRepo.On("FindByUserID", user.ID).Return(enabledTwoFactor(user.ID), nil)
+		mockThrottle.On("Check", account, testClient.IPAddress).Return(&services.LoginThrottledError{RetryAt: testNow.Add(time.Minute)})
+
+		_, err := service.CompleteLogin("challenge-token", currentTOTPCode(t), testClient.IPAddress)
+		assert.ErrorIs(t, err, services.LoginThrottledErr)
+		mockRepo.AssertNotCalled(t, "TryUseStep", mock.Anything, mock.Anything)
+	})
+
+	t.Run("invalid challenge", func(t *testing.T) {
+		service, _, _, mockSigner := setupTwoFactorServiceTest()
+
+		mockSigner.On("VerifyLink", domain.LoginChallengePurpose, "bad-token").Return(nil, domain.InvalidLinkTokenErr)
+
+		_, err := service.CompleteLogin("bad-token", "123456", testClient.IPAddress)
+		assert.ErrorIs(t, err, services.InvalidLoginChallengeErr)
+	})
+
+	t.Run("password changed since the challenge", func(t *testing.T) {
+		service, _, mockUserRepo, mockSigner := setupTwoFactorServiceTest()
+		changedUser := &domain.User{ID: user.ID, Password: "new-password-hash"}
+
+		mockSigner.On("VerifyLink", domain.LoginChallengePurpose, "challenge-token").Return(claims, nil)
+		mockUserRepo.On("FindByID", user.ID).Return(changedUser, nil)
+
+		_, err := service.CompleteLogin("challenge-token", "123456", testClient.IPAddress)
+		assert.ErrorIs(t, err, services.InvalidLoginChallengeErr)
+	})
+}
@@ -3,17 +3,39 @@ package services
 import (
 	"errors"
 	"github.com/google/uuid"
+	"log"
 	"swapp-go/cmd/internal/application/ports"
 	"swapp-go/cmd/internal/domain"
-	"swapp-go/cmd/internal/utils"
+)
+
+var (
+	InvalidCredentialsErr = errors.New("invalid username or password")
+	IncorrectPasswordErr  = errors.New("current password is incorrect")
+	PasswordUnchangedErr  = errors.New("new password must differ from the current one")
 )
 
 type UserService struct {
-	repo ports.UserRepository
+	repo          ports.UserRepository
+	sessionRepo   ports.SessionRepository
+	passwords     PasswordServiceInterface
+	loginThrottle LoginThrottleServiceInterface
+	clock         ports.Clock
 }
 
-func NewUserService(repo ports.UserRepository) *UserService {
-	return &UserService{repo: repo}
+func NewUserService(
+	repo ports.UserRepository,
+	sessionRepo ports.SessionRepository,
+	passwords PasswordServiceInterface,
+	loginThrottle LoginThrottleServiceInterface,
+	clock ports.Clock,
+) *UserService {
+	return &UserService{
+		repo:          repo,
+		sessionRepo:   sessionRepo,
+		passwords:     passwords,
+		loginThrottle: loginThrottle,
+		clock:         clock,
+	}
 }
 
 func (userService *UserService) RegisterUser(user *domain.User) error {
@@ -27,7 +49,11 @@ func (userService *UserService) RegisterUser(user *domain.User) error {
 		return errors.New("username not available")
 	}
 
-	encryptedPassword, err := utils.HashPassword(user.Password)
+	if err := userService.passwords.Validate(user.Password, user); err != nil {
+		return err
+	}
+
+	encryptedPassword, err := userService.passwords.Hash(user.Password)
 	if err != nil {
 		return err
 	}
@@ -37,12 +63,18 @@ func (userService *UserService) RegisterUser(user *domain.User) error {
 	return userService.repo.Create(user)
 }
 
+// Update changes the given fields of a user. A new email address has to be
+// verified again.
 func (userService *UserService) Update(id uuid.UUID, fields map[string]interface{}) (*domain.User, error) {
-	_, err := userService.repo.FindByID(id)
+	user, err := userService.repo.FindByID(id)
 	if err != nil {
 		return nil, err
 	}
 
+	if email, ok := fields["email"]; ok && email != user.Email {
+		fields["email_verified_at"] = nil
+	}
+
 	updatedUser, err := userService.repo.Update(id, fields)
 	if err != nil {
 		return nil, err
@@ -67,20 +99,116 @@ func (userService *UserService) FindByEmail(email string) (*domain.User, error)
 	return userService.repo.FindByEmail(email)
 }
 
-func (userService *UserService) Authenticate(username, password string) (string, *domain.User, error) {
+// Authenticate checks a username and password coming from ipAddress. Failures
+// slow down further attempts on the account and from the address, and an
+// unknown username fails the same way as a wrong password. Suspended users are
+// only told so once they have proven who they are.
+func (userService *UserService) Authenticate(username, password, ipAddress string) (*domain.User, error) {
+	account := passwordLoginAccount(username)
+
+	if err := userService.loginThrottle.Check(account, ipAddress); err != nil {
+		return nil, err
+	}
+
 	user, err := userService.repo.FindByUsername(username)
+	if err != nil || user == nil {
+		userService.passwords.Verify(password, "")
+		return nil, userService.failLogin(account, ipAddress, nil)
+	}
+
+	if !userService.passwords.Verify(password, user.Password) {
+		return nil, userService.failLogin(account, ipAddress, user)
+	}
+
+	if err = userService.loginThrottle.RecordSuccess(account); err != nil {
+		return nil, err
+	}
+
+	if user.IsSuspended() {
+		return nil, domain.AccountSuspendedErr
+	}
+
+	userService.rehashPassword(user, password)
+
+	return user, nil
+}
+
+// ChangePassword replaces the password of a signed-in user who knows the
+// current one, and signs out their other sessions. Wrong current passwords
+// are throttled like failed logins.
+func (userService *UserService) ChangePassword(
+	userID uuid.UUID,
+	sessionID uuid.UUID,
+	currentPassword string,
+	newPassword string,
+	ipAddress string,
+) error {
+	user, err := userService.repo.FindByID(userID)
+	if err != nil {
+		return err
+	}
+
+	account := passwordChangeAccount(user)
+	if err = userService.loginThrottle.Check(account, ipAddress); err != nil {
+		return err
+	}
+
+	if !userService.passwords.Verify(currentPassword, user.Password) {
+		if err = userService.loginThrottle.RecordFailure(account, ipAddress, user); err != nil {
+			return err
+		}
+		return IncorrectPasswordErr
+	}
+
+	if err = userService.loginThrottle.RecordSuccess(account); err != nil {
+		return err
+	}
+
+	if newPassword == currentPassword {
+		return PasswordUnchangedErr
+	}
+
+	if err = userService.passwords.Validate(newPassword, user); err != nil {
+		return err
+	}
+
+	hashedPassword, err := userService.passwords.Hash(newPassword)
 	if err != nil {
-		return "", nil, errors.New("invalid username")
+		return err
 	}
 
-	if !utils.CheckPasswordHash(password, user.Password) {
-		return "", nil, errors.New("invalid credentials")
+	if _, err = userService.repo.Update(userID, map[string]interface{}{"password": hashedPassword}); err != nil {
+		return err
 	}
 
-	token, err := utils.GenerateToken(user.Email, user.ID.String())
+	return userService.sessionRepo.RevokeOthersByUser(userID, sessionID, userService.clock.Now())
+}
+
+// rehashPassword upgrades a hash made with an older, lower cost while the
+// plain password is at hand. Failing to do so does not fail the login.
+func (userService *UserService) rehashPassword(user *domain.User, password string) {
+	if !userService.passwords.NeedsRehash(user.Password) {
+		return
+	}
+
+	hashedPassword, err := userService.passwords.Hash(password)
 	if err != nil {
-		return "", nil, err
+		log.Printf("Failed to rehash password of user %s: %v", user.ID, err)
+		return
+	}
+
+	if _, err = userService.repo.Update(user.ID, map[string]interface{}{"password": hashedPassword}); err != nil {
+		log.Printf("Failed to store rehashed password of user %s: %v", user.ID, err)
+		return
+	}
+
+	user.Password = hashedPassword
+}
+
+func (userService *UserService) failLogin(account string, ipAddress string, user *domain.User) error {
+	if err := userService.loginThrottle.RecordFailure(account, ipAddress, user); err != nil {
+		return err
 	}
 
-	return token, user, nil
+	return InvalidCredentialsErr
 }
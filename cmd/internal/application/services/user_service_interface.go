@@ -12,5 +12,6 @@ type UserServiceInterface interface {
 	FindByID(id uuid.UUID) (*domain.User, error)
 	FindByEmail(email string) (*domain.User, error)
 	FindByUsername(username string) (*domain.User, error)
-	Authenticate(username, password string) (string, *domain.User, error)
+	Authenticate(username, password, ipAddress string) (*domain.User, error)
+	ChangePassword(userID, sessionID uuid.UUID, currentPassword, newPassword, ipAddress string) error
 }
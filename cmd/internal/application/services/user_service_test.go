@@ -5,10 +5,13 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
 	"swapp-go/cmd/internal/application/mocks"
 	"swapp-go/cmd/internal/application/services"
 	"swapp-go/cmd/internal/domain"
+	"swapp-go/cmd/internal/utils"
 	"testing"
+	"time"
 )
 
 var (
@@ -23,15 +26,38 @@ var (
 	updatedAddress  = "2, Main Street"
 )
 
-func setupTest() (*mocks.MockUserRepository, *services.UserService) {
-	mockRepo := new(mocks.MockUserRepository)
-	userService := services.NewUserService(mockRepo)
+func setupTest(t *testing.T) (*mocks.MockUserRepository, *services.UserService) {
+	mockRepo, _, userService := setupAuthenticateTest(t)
 	return mockRepo, userService
 }
 
+func setupAuthenticateTest(t *testing.T) (*mocks.MockUserRepository, *mocks.MockLoginThrottleService, *services.UserService) {
+	mockRepo, _, mockThrottle, userService := setupUserServiceTest(t, bcrypt.MinCost)
+	return mockRepo, mockThrottle, userService
+}
+
+func setupUserServiceTest(t *testing.T, hashCost int) (
+	*mocks.MockUserRepository,
+	*mocks.MockSessionRepository,
+	*mocks.MockLoginThrottleService,
+	*services.UserService,
+) {
+	mockRepo := new(mocks.MockUserRepository)
+	mockSessionRepo := new(mocks.MockSessionRepository)
+	mockThrottle := new(mocks.MockLoginThrottleService)
+	userService := services.NewUserService(
+		mockRepo,
+		mockSessionRepo,
+		newTestPasswordService(t, hashCost),
+		mockThrottle,
+		&mocks.Clock{Current: testNow},
+	)
+	return mockRepo, mockSessionRepo, mockThrottle, userService
+}
+
 func TestRegisterUser(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
-		mockRepo, userService := setupTest()
+		mockRepo, userService := setupTest(t)
 		user := &domain.User{
 			Username: username,
 			Email:    email,
@@ -49,8 +75,24 @@ func TestRegisterUser(t *testing.T) {
 		mockRepo.AssertExpectations(t)
 	})
 
+	t.Run("weak password", func(t *testing.T) {
+		mockRepo, userService := setupTest(t)
+		user := &domain.User{
+			Username: username,
+			Email:    email,
+			Password: blockedPassword,
+		}
+
+		mockRepo.On("FindByEmail", user.Email).Return(nil, errors.New("not found"))
+		mockRepo.On("FindByUsername", user.Username).Return(nil, errors.New("not found"))
+
+		err := userService.RegisterUser(user)
+		assert.ErrorIs(t, err, domain.WeakPasswordErr)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
 	t.Run("email already exists", func(t *testing.T) {
-		mockRepo, userService := setupTest()
+		mockRepo, userService := setupTest(t)
 		user := &domain.User{
 			Username: username,
 			Email:    email,
@@ -66,7 +108,7 @@ func TestRegisterUser(t *testing.T) {
 	})
 
 	t.Run("username already exists", func(t *testing.T) {
-		mockRepo, userService := setupTest()
+		mockRepo, userService := setupTest(t)
 		user := &domain.User{
 			Username: username,
 			Email:    email,
@@ -85,7 +127,7 @@ func TestRegisterUser(t *testing.T) {
 
 func TestUpdateUser(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
-		mockRepo, userService := setupTest()
+		mockRepo, userService := setupTest(t)
 		userID := uuid.New()
 		existingUser := &domain.User{
 			ID:       userID,
@@ -109,7 +151,10 @@ func TestUpdateUser(t *testing.T) {
 		}
 
 		mockRepo.On("FindByID", userID).Return(existingUser, nil)
-		mockRepo.On("Update", userID, updatedFields).Return(updatedUser, nil)
+		mockRepo.On("Update", userID, mock.MatchedBy(func(fields map[string]interface{}) bool {
+			verifiedAt, cleared := fields["email_verified_at"]
+			return cleared && verifiedAt == nil && fields["email"] == updatedEmail
+		})).Return(updatedUser, nil)
 
 		user, err := userService.Update(userID, updatedFields)
 		assert.NoError(t, err)
@@ -121,8 +166,22 @@ func TestUpdateUser(t *testing.T) {
 		mockRepo.AssertExpectations(t)
 	})
 
+	t.Run("unchanged email stays verified", func(t *testing.T) {
+		mockRepo, userService := setupTest(t)
+		userID := uuid.New()
+		fields := map[string]interface{}{"email": email, "address": updatedAddress}
+
+		mockRepo.On("FindByID", userID).Return(&domain.User{ID: userID, Email: email}, nil)
+		mockRepo.On("Update", userID, map[string]interface{}{"email": email, "address": updatedAddress}).
+			Return(&domain.User{ID: userID, Email: email}, nil)
+
+		_, err := userService.Update(userID, fields)
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
 	t.Run("update fails", func(t *testing.T) {
-		mockRepo, userService := setupTest()
+		mockRepo, userService := setupTest(t)
 		userID := uuid.New()
 		fields := map[string]interface{}{"username": "wrong_user"}
 
@@ -137,7 +196,7 @@ func TestUpdateUser(t *testing.T) {
 }
 
 func TestGetUserByID(t *testing.T) {
-	mockRepo, userService := setupTest()
+	mockRepo, userService := setupTest(t)
 	userID := uuid.New()
 	expectedUser := &domain.User{
 		ID:       userID,
@@ -157,7 +216,7 @@ func TestGetUserByID(t *testing.T) {
 }
 
 func TestGetUserByEmail(t *testing.T) {
-	mockRepo, userService := setupTest()
+	mockRepo, userService := setupTest(t)
 	expectedUser := &domain.User{
 		Username: username,
 		Email:    email,
@@ -175,7 +234,7 @@ func TestGetUserByEmail(t *testing.T) {
 }
 
 func TestGetUserByUsername(t *testing.T) {
-	mockRepo, userService := setupTest()
+	mockRepo, userService := setupTest(t)
 	expectedUser := &domain.User{
 		Username: username,
 		Email:    email,
@@ -194,7 +253,7 @@ func TestGetUserByUsername(t *testing.T) {
 
 func TestDeleteUser(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
-		mockRepo, userService := setupTest()
+		mockRepo, userService := setupTest(t)
 		userID := uuid.New()
 
 		mockRepo.On("Delete", userID).Return(nil)
@@ -205,7 +264,7 @@ func TestDeleteUser(t *testing.T) {
 	})
 
 	t.Run("failure", func(t *testing.T) {
-		mockRepo, userService := setupTest()
+		mockRepo, userService := setupTest(t)
 		userID := uuid.New()
 		expectedErr := errors.New("delete failed")
 
@@ -217,3 +276,173 @@ func TestDeleteUser(t *testing.T) {
 		mockRepo.AssertExpectations(t)
 	})
 }
+
+func TestAuthenticate(t *testing.T) {
+	const (
+		ipAddress = "203.0.113.7"
+		account   = "password:test_user"
+	)
+
+	hashedPassword, err := utils.HashPassword(password, bcrypt.MinCost)
+	assert.NoError(t, err)
+	user := &domain.User{ID: uuid.New(), Username: username, Password: hashedPassword}
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo, mockThrottle, userService := setupAuthenticateTest(t)
+
+		mockThrottle.On("Check", account, ipAddress).Return(nil)
+		mockRepo.On("FindByUsername", username).Return(user, nil)
+		mockThrottle.On("RecordSuccess", account).Return(nil).Once()
+
+		authenticated, err := userService.Authenticate(username, password, ipAddress)
+		assert.NoError(t, err)
+		assert.Equal(t, user, authenticated)
+		mockThrottle.AssertExpectations(t)
+	})
+
+	t.Run("suspended user", func(t *testing.T) {
+		mockRepo, mockThrottle, userService := setupAuthenticateTest(t)
+
+		suspended := &domain.User{ID: user.ID, Username: username, Password: hashedPassword, SuspendedAt: &testNow}
+
+		mockThrottle.On("Check", account, ipAddress).Return(nil)
+		mockRepo.On("FindByUsername", username).Return(suspended, nil)
+		mockThrottle.On("RecordSuccess", account).Return(nil).Once()
+
+		_, err := userService.Authenticate(username, password, ipAddress)
+		assert.ErrorIs(t, err, domain.AccountSuspendedErr)
+	})
+
+	t.Run("wrong password and unknown username fail alike", func(t *testing.T) {
+		mockRepo, mockThrottle, userService := setupAuthenticateTest(t)
+
+		mockThrottle.On("Check", mock.Anything, ipAddress).Return(nil)
+		mockRepo.On("FindByUsername", username).Return(user, nil)
+		mockRepo.On("FindByUsername", "nobody").Return(nil, errors.New("record not found"))
+		mockThrottle.On("RecordFailure", account, ipAddress, user).Return(nil).Once()
+		mockThrottle.On("RecordFailure", "password:nobody", ipAddress, (*domain.User)(nil)).Return(nil).Once()
+
+		_, wrongPasswordErr := userService.Authenticate(username, "wrong-password", ipAddress)
+		_, unknownUserErr := userService.Authenticate("nobody", password, ipAddress)
+
+		assert.ErrorIs(t, wrongPasswordErr, services.InvalidCredentialsErr)
+		assert.Equal(t, wrongPasswordErr, unknownUserErr)
+		mockThrottle.AssertExpectations(t)
+	})
+
+	t.Run("usernames are throttled case-insensitively", func(t *testing.T) {
+		_, mockThrottle, userService := setupAuthenticateTest(t)
+
+		mockThrottle.On("Check", account, ipAddress).Return(&services.LoginThrottledError{})
+
+		_, err := userService.Authenticate("Test_User", password, ipAddress)
+		assert.ErrorIs(t, err, services.LoginThrottledErr)
+	})
+}
+
+func TestAuthenticate_RehashesWeakerHash(t *testing.T) {
+	const account = "password:test_user"
+
+	mockRepo, _, mockThrottle, userService := setupUserServiceTest(t, bcrypt.MinCost+1)
+
+	weakHash, err := utils.HashPassword(password, bcrypt.MinCost)
+	assert.NoError(t, err)
+	user := &domain.User{ID: uuid.New(), Username: username, Password: weakHash}
+
+	var storedHash string
+	mockThrottle.On("Check", account, "").Return(nil)
+	mockRepo.On("FindByUsername", username).Return(user, nil)
+	mockThrottle.On("RecordSuccess", account).Return(nil)
+	mockRepo.On("Update", user.ID, mock.MatchedBy(func(fields map[string]interface{}) bool {
+		storedHash, _ = fields["password"].(string)
+		return true
+	})).Return(user, nil).Once()
+
+	authenticated, err := userService.Authenticate(username, password, "")
+	assert.NoError(t, err)
+
+	cost, err := utils.PasswordHashCost(storedHash)
+	assert.NoError(t, err)
+	assert.Equal(t, bcrypt.MinCost+1, cost)
+	assert.True(t, utils.CheckPasswordHash(password, storedHash))
+	assert.Equal(t, storedHash, authenticated.Password)
+}
+
+func TestChangePassword(t *testing.T) {
+	const (
+		ipAddress   = "203.0.113.7"
+		newPassword = "blue-Lantern"
+	)
+
+	hashedPassword, err := utils.HashPassword(password, bcrypt.MinCost)
+	assert.NoError(t, err)
+	user := &domain.User{ID: uuid.New(), Username: username, Email: email, Password: hashedPassword}
+	account := "password-change:" + user.ID.String()
+	sessionID := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo, mockSessionRepo, mockThrottle, userService := setupUserServiceTest(t, bcrypt.MinCost)
+
+		mockRepo.On("FindByID", user.ID).Return(user, nil)
+		mockThrottle.On("Check", account, ipAddress).Return(nil)
+		mockThrottle.On("RecordSuccess", account).Return(nil)
+		mockRepo.On("Update", user.ID, mock.MatchedBy(func(fields map[string]interface{}) bool {
+			hash, ok := fields["password"].(string)
+			return ok && utils.CheckPasswordHash(newPassword, hash)
+		})).Return(user, nil).Once()
+		mockSessionRepo.On("RevokeOthersByUser", user.ID, sessionID, testNow).Return(nil).Once()
+
+		err := userService.ChangePassword(user.ID, sessionID, password, newPassword, ipAddress)
+		assert.NoError(t, err)
+
+		mockRepo.AssertExpectations(t)
+		mockSessionRepo.AssertExpectations(t)
+	})
+
+	t.Run("wrong current password is throttled", func(t *testing.T) {
+		mockRepo, _, mockThrottle, userService := setupUserServiceTest(t, bcrypt.MinCost)
+
+		mockRepo.On("FindByID", user.ID).Return(user, nil)
+		mockThrottle.On("Check", account, ipAddress).Return(nil)
+		mockThrottle.On("RecordFailure", account, ipAddress, user).Return(nil).Once()
+
+		err := userService.ChangePassword(user.ID, sessionID, "wrong-password", newPassword, ipAddress)
+		assert.ErrorIs(t, err, services.IncorrectPasswordErr)
+
+		mockThrottle.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("throttled", func(t *testing.T) {
+		mockRepo, _, mockThrottle, userService := setupUserServiceTest(t, bcrypt.MinCost)
+
+		mockRepo.On("FindByID", user.ID).Return(user, nil)
+		mockThrottle.On("Check", account, ipAddress).Return(&services.LoginThrottledError{RetryAt: testNow.Add(time.Minute)})
+
+		err := userService.ChangePassword(user.ID, sessionID, password, newPassword, ipAddress)
+		assert.ErrorIs(t, err, services.LoginThrottledErr)
+	})
+
+	t.Run("rejected new passwords", func(t *testing.T) {
+		tests := []struct {
+			newPassword string
+			err         error
+		}{
+			{password, services.PasswordUnchangedErr},
+			{"short", domain.WeakPasswordErr},
+			{blockedPassword, domain.WeakPasswordErr},
+		}
+
+		for _, test := range tests {
+			mockRepo, _, mockThrottle, userService := setupUserServiceTest(t, bcrypt.MinCost)
+
+			mockRepo.On("FindByID", user.ID).Return(user, nil)
+			mockThrottle.On("Check", account, ipAddress).Return(nil)
+			mockThrottle.On("RecordSuccess", account).Return(nil)
+
+			err := userService.ChangePassword(user.ID, sessionID, password, test.newPassword, ipAddress)
+			assert.ErrorIs(t, err, test.err, test.newPassword)
+			mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		}
+	})
+}
@@ -0,0 +1,12 @@
+package config
+
+type AdminConfig struct {
+	// Emails are the verified email addresses of the users promoted to admin
+	// on startup while there is no admin, so that a new deployment has
+	// someone who can hand out roles.
+	Emails []string
+}
+
+func LoadAdminConfig() AdminConfig {
+	return AdminConfig{Emails: listFromEnv("ADMIN_EMAILS")}
+}
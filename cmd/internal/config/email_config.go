@@ -3,28 +3,91 @@ package config
 import (
 	"log"
 	"os"
-	"strconv"
+	"time"
+)
+
+const (
+	EmailDriverSMTP   = "smtp"
+	EmailDriverFile   = "file"
+	EmailDriverLog    = "log"
+	EmailDriverMemory = "memory"
+)
+
+const (
+	// SMTPSecurityStartTLS upgrades a plain connection with STARTTLS and fails
+	// if the server does not offer it.
+	SMTPSecurityStartTLS = "starttls"
+	// SMTPSecurityTLS opens a TLS connection straight away, as on port 465.
+	SMTPSecurityTLS = "tls"
+	// SMTPSecurityNone talks plain SMTP, for local relays and mail catchers.
+	SMTPSecurityNone = "none"
 )
 
 type EmailConfig struct {
-	SMTPHost string
-	SMTPPort int
+	Driver string
+	Sender string
+
+	SMTPHost     string
+	SMTPPort     int
+	SMTPSecurity string
+	// SMTPTimeout bounds dialing and the whole SMTP conversation.
+	SMTPTimeout time.Duration
+	// Username and Password may be left empty for relays that do not
+	// authenticate.
 	Username string
 	Password string
-	Sender   string
+
+	// FilePath is the mbox file the file driver appends to.
+	FilePath string
+
+	// AppURL is where deep links in emails point; AssetURL serves pictures
+	// stored with a relative URL.
+	AppURL   string
+	AssetURL string
 }
 
 func LoadEmailConfig() EmailConfig {
-	port, err := strconv.Atoi(os.Getenv("SMTP_PORT"))
-	if err != nil {
-		log.Fatalf("Invalid SMTP_PORT: %v", err)
+	emailConfig := EmailConfig{
+		Driver:       envOrDefault("EMAIL_DRIVER", EmailDriverSMTP),
+		Sender:       envOrDefault("SMTP_FROM_ADDRESS", "no-reply@localhost"),
+		SMTPHost:     os.Getenv("SMTP_HOST"),
+		SMTPSecurity: os.Getenv("SMTP_SECURITY"),
+		SMTPTimeout:  durationFromEnv("SMTP_TIMEOUT", 10*time.Second),
+		Username:     os.Getenv("SMTP_USERNAME"),
+		Password:     os.Getenv("SMTP_PASSWORD"),
+		FilePath:     envOrDefault("EMAIL_FILE_PATH", "mail.mbox"),
+		AppURL:       envOrDefault("EMAIL_APP_URL", "http://localhost:3000"),
+		AssetURL:     envOrDefault("EMAIL_ASSET_URL", "http://localhost:9000"),
+	}
+
+	switch emailConfig.Driver {
+	case EmailDriverSMTP:
+		if emailConfig.SMTPHost == "" {
+			log.Fatalf("SMTP_HOST is required for the smtp email driver")
+		}
+		emailConfig.SMTPPort = intFromEnv("SMTP_PORT", 587)
+		if emailConfig.SMTPSecurity == "" {
+			emailConfig.SMTPSecurity = defaultSMTPSecurity(emailConfig.SMTPPort)
+		}
+		switch emailConfig.SMTPSecurity {
+		case SMTPSecurityStartTLS, SMTPSecurityTLS, SMTPSecurityNone:
+		default:
+			log.Fatalf("Invalid SMTP_SECURITY: %q", emailConfig.SMTPSecurity)
+		}
+	case EmailDriverFile, EmailDriverLog, EmailDriverMemory:
+	default:
+		log.Fatalf("Invalid EMAIL_DRIVER: %q", emailConfig.Driver)
 	}
 
-	return EmailConfig{
-		SMTPHost: os.Getenv("SMTP_HOST"),
-		SMTPPort: port,
-		Username: os.Getenv("SMTP_USERNAME"),
-		Password: os.Getenv("SMTP_PASSWORD"),
-		Sender:   os.Getenv("SMTP_FROM_ADDRESS"),
+	return emailConfig
+}
+
+// defaultSMTPSecurity picks implicit TLS for the submission port reserved for
+// it and STARTTLS everywhere else.
+func defaultSMTPSecurity(port int) string {
+	if port == 465 {
+		return SMTPSecurityTLS
 	}
+
+	return SMTPSecurityStartTLS
 }
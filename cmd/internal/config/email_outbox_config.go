@@ -0,0 +1,25 @@
+package config
+
+import "time"
+
+type EmailOutboxConfig struct {
+	DispatchInterval time.Duration
+	BatchSize        int
+	MaxAttempts      int
+	BaseDelay        time.Duration
+	MaxDelay         time.Duration
+	// LeaseDuration is how long a dispatcher may take to send a message before
+	// another one retries it.
+	LeaseDuration time.Duration
+}
+
+func LoadEmailOutboxConfig() EmailOutboxConfig {
+	return EmailOutboxConfig{
+		DispatchInterval: durationFromEnv("EMAIL_OUTBOX_DISPATCH_INTERVAL", 5*time.Second),
+		BatchSize:        intFromEnv("EMAIL_OUTBOX_BATCH_SIZE", 50),
+		MaxAttempts:      intFromEnv("EMAIL_OUTBOX_MAX_ATTEMPTS", 8),
+		BaseDelay:        durationFromEnv("EMAIL_OUTBOX_BASE_DELAY", 30*time.Second),
+		MaxDelay:         durationFromEnv("EMAIL_OUTBOX_MAX_DELAY", time.Hour),
+		LeaseDuration:    durationFromEnv("EMAIL_OUTBOX_LEASE_DURATION", 5*time.Minute),
+	}
+}
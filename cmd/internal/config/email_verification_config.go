@@ -0,0 +1,18 @@
+package config
+
+import "time"
+
+type EmailVerificationConfig struct {
+	// LinkURL is the page that receives the token as its "token" query parameter.
+	LinkURL        string
+	TokenTTL       time.Duration
+	ResendInterval time.Duration
+}
+
+func LoadEmailVerificationConfig() EmailVerificationConfig {
+	return EmailVerificationConfig{
+		LinkURL:        envOrDefault("EMAIL_VERIFICATION_URL", "http://localhost:3000/verify-email"),
+		TokenTTL:       durationFromEnv("EMAIL_VERIFICATION_TTL", 48*time.Hour),
+		ResendInterval: durationFromEnv("EMAIL_VERIFICATION_RESEND_INTERVAL", 5*time.Minute),
+	}
+}
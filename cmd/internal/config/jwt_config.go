@@ -0,0 +1,22 @@
+package config
+
+import "os"
+
+type JWTConfig struct {
+	// KeysDir holds one PEM file per key, named after its key ID. Private keys
+	// can sign and verify; public keys are kept to verify tokens signed by a
+	// retired key until they expire.
+	KeysDir string
+	// ActiveKeyID selects the key new tokens are signed with. It may be left
+	// empty when the directory holds a single private key.
+	ActiveKeyID string
+	Issuer      string
+}
+
+func LoadJWTConfig() JWTConfig {
+	return JWTConfig{
+		KeysDir:     os.Getenv("JWT_KEYS_DIR"),
+		ActiveKeyID: os.Getenv("JWT_ACTIVE_KEY_ID"),
+		Issuer:      envOrDefault("JWT_ISSUER", "swapp"),
+	}
+}
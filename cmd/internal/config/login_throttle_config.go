@@ -0,0 +1,32 @@
+package config
+
+import "time"
+
+const (
+	LoginAttemptStoreDatabase = "database"
+	LoginAttemptStoreMemory   = "memory"
+)
+
+type LoginThrottleConfig struct {
+	// Store is where failed attempts are counted; the memory store only suits
+	// a single instance.
+	Store              string
+	AccountMaxFailures int
+	IPMaxFailures      int
+	BaseDelay          time.Duration
+	MaxDelay           time.Duration
+	LockoutDuration    time.Duration
+	FailureWindow      time.Duration
+}
+
+func LoadLoginThrottleConfig() LoginThrottleConfig {
+	return LoginThrottleConfig{
+		Store:              envOrDefault("LOGIN_ATTEMPT_STORE", LoginAttemptStoreDatabase),
+		AccountMaxFailures: intFromEnv("LOGIN_ACCOUNT_MAX_FAILURES", 5),
+		IPMaxFailures:      intFromEnv("LOGIN_IP_MAX_FAILURES", 50),
+		BaseDelay:          durationFromEnv("LOGIN_BACKOFF_BASE_DELAY", time.Second),
+		MaxDelay:           durationFromEnv("LOGIN_BACKOFF_MAX_DELAY", 5*time.Minute),
+		LockoutDuration:    durationFromEnv("LOGIN_LOCKOUT_DURATION", 15*time.Minute),
+		FailureWindow:      durationFromEnv("LOGIN_FAILURE_WINDOW", time.Hour),
+	}
+}
@@ -0,0 +1,13 @@
+package config
+
+type NotificationConfig struct {
+	// UnsubscribeURL receives the token of one-click unsubscribe links as its
+	// "token" query parameter, by GET from a browser or POST from a mail client.
+	UnsubscribeURL string
+}
+
+func LoadNotificationConfig() NotificationConfig {
+	return NotificationConfig{
+		UnsubscribeURL: envOrDefault("NOTIFICATION_UNSUBSCRIBE_URL", "http://localhost:9000/notifications/unsubscribe"),
+	}
+}
@@ -0,0 +1,23 @@
+package config
+
+import "os"
+
+type PasswordConfig struct {
+	MinLength           int
+	MinCharacterClasses int
+	// BlocklistFile optionally adds forbidden passwords, one per line, to the
+	// built-in list of common ones.
+	BlocklistFile string
+	// HashCost is the bcrypt cost of new hashes. Raising it upgrades older
+	// hashes as their users log in.
+	HashCost int
+}
+
+func LoadPasswordConfig() PasswordConfig {
+	return PasswordConfig{
+		MinLength:           intFromEnv("PASSWORD_MIN_LENGTH", 8),
+		MinCharacterClasses: intFromEnv("PASSWORD_MIN_CHARACTER_CLASSES", 2),
+		BlocklistFile:       os.Getenv("PASSWORD_BLOCKLIST_FILE"),
+		HashCost:            intFromEnv("PASSWORD_HASH_COST", 12),
+	}
+}
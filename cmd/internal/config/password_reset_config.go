@@ -0,0 +1,16 @@
+package config
+
+import "time"
+
+type PasswordResetConfig struct {
+	// LinkURL is the page that receives the token as its "token" query parameter.
+	LinkURL  string
+	TokenTTL time.Duration
+}
+
+func LoadPasswordResetConfig() PasswordResetConfig {
+	return PasswordResetConfig{
+		LinkURL:  envOrDefault("PASSWORD_RESET_URL", "http://localhost:3000/reset-password"),
+		TokenTTL: durationFromEnv("PASSWORD_RESET_TTL", time.Hour),
+	}
+}
@@ -3,6 +3,8 @@ package routes
 import (
 	"github.com/gin-gonic/gin"
 	"swapp-go/cmd/internal/adapters/handlers"
+	"swapp-go/cmd/internal/adapters/middleware"
+	"swapp-go/cmd/internal/domain"
 )
 
 func SetupRoutes(
@@ -11,21 +13,45 @@ func SetupRoutes(
 	itemHandler *handlers.ItemHandler,
 	swapRequestHandler *handlers.SwapRequestHandler,
 	passwordResetHandler *handlers.PasswordResetHandler,
+	sessionHandler *handlers.SessionHandler,
+	jwksHandler *handlers.JWKSHandler,
+	twoFactorHandler *handlers.TwoFactorHandler,
+	adminHandler *handlers.AdminHandler,
+	notificationHandler *handlers.NotificationHandler,
 	authMiddleware gin.HandlerFunc,
 ) {
 
 	// Public routes
 	server.POST("/users/register", userHandler.RegisterUser)
 	server.POST("/users/login", userHandler.LoginUser)
+	server.POST("/users/login/two-factor", userHandler.LoginWithTwoFactor)
+	server.POST("/users/verify-email", userHandler.VerifyEmail)
 	server.POST("/password-reset/request", passwordResetHandler.RequestReset)
 	server.POST("/password-reset/reset", passwordResetHandler.ResetPassword)
+	server.POST("/auth/refresh", sessionHandler.Refresh)
+	server.GET("/.well-known/jwks.json", jwksHandler.JWKS)
+	server.GET("/categories", itemHandler.ListCategories)
+	server.GET("/items", itemHandler.Search)
 	server.GET("/items/:id", itemHandler.FindByID)
+	server.GET("/notifications/unsubscribe", notificationHandler.ConfirmUnsubscribe)
+	server.POST("/notifications/unsubscribe", notificationHandler.Unsubscribe)
 
 	// Protected routes
 	protected := server.Group("/")
 	protected.Use(authMiddleware)
+	protected.POST("/auth/logout", sessionHandler.Logout)
 	usersGroup := protected.Group("/users")
 	{
+		usersGroup.POST("/verify-email/resend", userHandler.ResendVerification)
+		usersGroup.POST("/password", userHandler.ChangePassword)
+		usersGroup.POST("/two-factor/enroll", twoFactorHandler.Enroll)
+		usersGroup.POST("/two-factor/confirm", twoFactorHandler.Confirm)
+		usersGroup.POST("/two-factor/disable", twoFactorHandler.Disable)
+		usersGroup.POST("/two-factor/recovery-codes", twoFactorHandler.RegenerateRecoveryCodes)
+		usersGroup.GET("/notification-preferences", notificationHandler.GetPreferences)
+		usersGroup.PUT("/notification-preferences", notificationHandler.UpdatePreferences)
+		usersGroup.GET("/sessions", sessionHandler.ListSessions)
+		usersGroup.DELETE("/sessions/:id", sessionHandler.RevokeSession)
 		usersGroup.GET("/:id", userHandler.FindByID)
 		usersGroup.PATCH("/update", userHandler.Update)
 		usersGroup.DELETE("/delete", userHandler.Delete)
@@ -35,6 +61,8 @@ func SetupRoutes(
 		itemsGroup.POST("/create", itemHandler.Create)
 		itemsGroup.PUT("/update/:id", itemHandler.Update)
 		itemsGroup.DELETE("/delete/:id", itemHandler.Delete)
+		itemsGroup.PUT("/:id/pictures/order", itemHandler.ReorderPictures)
+		itemsGroup.DELETE("/:id/pictures/:pictureId", itemHandler.DeletePicture)
 	}
 	swapRequestsGroup := protected.Group("/swap-requests")
 	{
@@ -45,5 +73,28 @@ func SetupRoutes(
 		swapRequestsGroup.GET("/list-by-status/:status", swapRequestHandler.ListByStatus)
 		swapRequestsGroup.DELETE("/delete/:id", swapRequestHandler.Delete)
 		swapRequestsGroup.PATCH("/update-status/:id", swapRequestHandler.UpdateStatus)
+		swapRequestsGroup.POST("/:id/confirm", swapRequestHandler.ConfirmHandover)
+		swapRequestsGroup.POST("/:id/counter", swapRequestHandler.CounterOffer)
+		swapRequestsGroup.GET("/:id/thread", swapRequestHandler.ListThread)
+	}
+	notificationsGroup := protected.Group("/notifications")
+	{
+		notificationsGroup.GET("", notificationHandler.List)
+		notificationsGroup.POST("/read-all", notificationHandler.MarkAllRead)
+		notificationsGroup.POST("/:id/read", notificationHandler.MarkRead)
+	}
+	adminGroup := protected.Group("/admin")
+	adminGroup.Use(middleware.RequireRole(domain.RoleModerator))
+	{
+		adminGroup.GET("/users", adminHandler.ListUsers)
+		adminGroup.POST("/users/:id/suspend", adminHandler.SuspendUser)
+		adminGroup.POST("/users/:id/unsuspend", adminHandler.UnsuspendUser)
+		adminGroup.PATCH("/users/:id/role", middleware.RequireRole(domain.RoleAdmin), adminHandler.SetRole)
+		adminGroup.POST("/swap-requests/:id/cancel", adminHandler.CancelSwapRequest)
+
+		emailsGroup := adminGroup.Group("/emails", middleware.RequireRole(domain.RoleAdmin))
+		emailsGroup.GET("", adminHandler.ListEmails)
+		emailsGroup.GET("/:id", adminHandler.GetEmail)
+		emailsGroup.POST("/:id/replay", adminHandler.ReplayEmail)
 	}
 }
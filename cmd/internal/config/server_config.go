@@ -0,0 +1,13 @@
+package config
+
+type ServerConfig struct {
+	// TrustedProxies are the addresses or CIDR ranges of reverse proxies whose
+	// X-Forwarded-For header is believed when working out a client's IP. With
+	// none, the address of the connection is used, so that clients cannot pick
+	// the IP that login throttling and sessions see.
+	TrustedProxies []string
+}
+
+func LoadServerConfig() ServerConfig {
+	return ServerConfig{TrustedProxies: listFromEnv("TRUSTED_PROXIES")}
+}
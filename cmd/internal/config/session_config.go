@@ -0,0 +1,15 @@
+package config
+
+import "time"
+
+type SessionConfig struct {
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+}
+
+func LoadSessionConfig() SessionConfig {
+	return SessionConfig{
+		AccessTokenTTL:  durationFromEnv("ACCESS_TOKEN_TTL", 15*time.Minute),
+		RefreshTokenTTL: durationFromEnv("REFRESH_TOKEN_TTL", 30*24*time.Hour),
+	}
+}
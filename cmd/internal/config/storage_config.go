@@ -0,0 +1,66 @@
+package config
+
+import (
+	"log"
+	"os"
+	"strings"
+)
+
+const (
+	StorageDriverLocal  = "local"
+	StorageDriverS3     = "s3"
+	StorageDriverMemory = "memory"
+)
+
+type StorageConfig struct {
+	Driver string
+	// LocalDir is where the local driver writes blobs.
+	LocalDir string
+	// PublicURL prefixes blob keys in the URLs handed to clients. For the s3
+	// driver it may be left empty to use the bucket's own URL.
+	PublicURL string
+	S3        S3Config
+}
+
+type S3Config struct {
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	// UsePathStyle addresses objects as <endpoint>/<bucket>/<key>, which most
+	// self-hosted S3-compatible servers expect.
+	UsePathStyle bool
+}
+
+func LoadStorageConfig() StorageConfig {
+	storageConfig := StorageConfig{
+		Driver:    envOrDefault("STORAGE_DRIVER", StorageDriverLocal),
+		LocalDir:  envOrDefault("STORAGE_LOCAL_DIR", "uploads"),
+		PublicURL: strings.TrimSuffix(os.Getenv("STORAGE_PUBLIC_URL"), "/"),
+		S3: S3Config{
+			Endpoint:        strings.TrimSuffix(os.Getenv("S3_ENDPOINT"), "/"),
+			Region:          envOrDefault("S3_REGION", "us-east-1"),
+			Bucket:          os.Getenv("S3_BUCKET"),
+			AccessKeyID:     os.Getenv("S3_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("S3_SECRET_ACCESS_KEY"),
+			UsePathStyle:    boolFromEnv("S3_USE_PATH_STYLE", false),
+		},
+	}
+
+	switch storageConfig.Driver {
+	case StorageDriverLocal:
+		if storageConfig.PublicURL == "" {
+			storageConfig.PublicURL = "/uploads"
+		}
+	case StorageDriverS3:
+		if storageConfig.S3.Endpoint == "" || storageConfig.S3.Bucket == "" {
+			log.Fatalf("S3_ENDPOINT and S3_BUCKET are required for the s3 storage driver")
+		}
+	case StorageDriverMemory:
+	default:
+		log.Fatalf("Invalid STORAGE_DRIVER: %q", storageConfig.Driver)
+	}
+
+	return storageConfig
+}
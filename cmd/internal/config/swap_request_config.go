@@ -0,0 +1,20 @@
+package config
+
+import "time"
+
+type SwapRequestConfig struct {
+	// RequestTTL is how long a pending request stays open; zero disables expiry.
+	RequestTTL    time.Duration
+	SweepInterval time.Duration
+	// ReminderBefore is how long before expiry the recipient is reminded of a
+	// pending request; zero disables reminders.
+	ReminderBefore time.Duration
+}
+
+func LoadSwapRequestConfig() SwapRequestConfig {
+	return SwapRequestConfig{
+		RequestTTL:     durationFromEnv("SWAP_REQUEST_TTL", 7*24*time.Hour),
+		SweepInterval:  durationFromEnv("SWAP_REQUEST_SWEEP_INTERVAL", 15*time.Minute),
+		ReminderBefore: durationFromEnv("SWAP_REQUEST_REMINDER_BEFORE", 24*time.Hour),
+	}
+}
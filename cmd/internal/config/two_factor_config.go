@@ -0,0 +1,16 @@
+package config
+
+import "time"
+
+type TwoFactorConfig struct {
+	// Issuer is the account name authenticator apps show next to the code.
+	Issuer       string
+	ChallengeTTL time.Duration
+}
+
+func LoadTwoFactorConfig() TwoFactorConfig {
+	return TwoFactorConfig{
+		Issuer:       envOrDefault("TWO_FACTOR_ISSUER", "Swapp"),
+		ChallengeTTL: durationFromEnv("LOGIN_CHALLENGE_TTL", 5*time.Minute),
+	}
+}
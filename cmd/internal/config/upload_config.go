@@ -0,0 +1,35 @@
+package config
+
+type UploadConfig struct {
+	// MaxBytes limits the size of a single uploaded file.
+	MaxBytes int64
+	// MinDimension and MaxDimension bound the width and height of an upload,
+	// and MaxPixels its area, before it is decoded.
+	MinDimension int
+	MaxDimension int
+	MaxPixels    int
+	// PictureDimension bounds the longest side of the stored picture.
+	PictureDimension int
+	JPEGQuality      int
+	Variants         []ImageVariantConfig
+}
+
+type ImageVariantConfig struct {
+	Name         string
+	MaxDimension int
+}
+
+func LoadUploadConfig() UploadConfig {
+	return UploadConfig{
+		MaxBytes:         int64(intFromEnv("UPLOAD_MAX_BYTES", 10<<20)),
+		MinDimension:     intFromEnv("UPLOAD_MIN_DIMENSION", 64),
+		MaxDimension:     intFromEnv("UPLOAD_MAX_DIMENSION", 8192),
+		MaxPixels:        intFromEnv("UPLOAD_MAX_PIXELS", 25_000_000),
+		PictureDimension: intFromEnv("PICTURE_MAX_DIMENSION", 2048),
+		JPEGQuality:      intFromEnv("PICTURE_JPEG_QUALITY", 85),
+		Variants: []ImageVariantConfig{
+			{Name: "medium", MaxDimension: 800},
+			{Name: "thumbnail", MaxDimension: 240},
+		},
+	}
+}
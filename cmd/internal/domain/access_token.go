@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"time"
+)
+
+var InvalidAccessTokenErr = errors.New("invalid or expired access token")
+
+// AccessTokenClaims is what an access token asserts about its bearer.
+type AccessTokenClaims struct {
+	UserID    uuid.UUID
+	Email     string
+	Role      Role
+	SessionID uuid.UUID
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
+
+// JSONWebKey is the public part of a token signing key, in the shape of an
+// RFC 7517 JSON Web Key. RSA keys fill N and E, Ed25519 keys Curve and X.
+type JSONWebKey struct {
+	KeyType   string
+	KeyID     string
+	Algorithm string
+	N         string
+	E         string
+	Curve     string
+	X         string
+}
@@ -0,0 +1,12 @@
+package domain
+
+import "errors"
+
+var UnknownCategoryErr = errors.New("unknown item category")
+
+// Category is a node of the item taxonomy. Top-level categories have an empty ParentSlug.
+type Category struct {
+	Slug       string
+	Name       string
+	ParentSlug string
+}
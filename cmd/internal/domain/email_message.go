@@ -1,7 +1,92 @@
 package domain
 
+// EmailTemplate names the template an email is rendered from.
+type EmailTemplate string
+
+const (
+	EmailTemplateEmailVerification    EmailTemplate = "email_verification"
+	EmailTemplatePasswordReset        EmailTemplate = "password_reset"
+	EmailTemplateAccountLocked        EmailTemplate = "account_locked"
+	EmailTemplateSwapRequestCreated   EmailTemplate = "swap_request_created"
+	EmailTemplateSwapRequestAccepted  EmailTemplate = "swap_request_accepted"
+	EmailTemplateSwapRequestRejected  EmailTemplate = "swap_request_rejected"
+	EmailTemplateSwapRequestDisputed  EmailTemplate = "swap_request_disputed"
+	EmailTemplateSwapRequestCountered EmailTemplate = "swap_request_countered"
+	EmailTemplateSwapRequestWithdrawn EmailTemplate = "swap_request_withdrawn"
+	EmailTemplateSwapRequestCancelled EmailTemplate = "swap_request_cancelled"
+	EmailTemplateSwapRequestExpiring  EmailTemplate = "swap_request_expiring"
+	EmailTemplateSwapRequestExpired   EmailTemplate = "swap_request_expired"
+	EmailTemplateHandoverConfirmed    EmailTemplate = "handover_confirmed"
+	EmailTemplateSwapCompleted        EmailTemplate = "swap_completed"
+)
+
+// EmailTemplates lists every template, so that renderers can check at startup
+// that none is missing.
+var EmailTemplates = []EmailTemplate{
+	EmailTemplateEmailVerification,
+	EmailTemplatePasswordReset,
+	EmailTemplateAccountLocked,
+	EmailTemplateSwapRequestCreated,
+	EmailTemplateSwapRequestAccepted,
+	EmailTemplateSwapRequestRejected,
+	EmailTemplateSwapRequestDisputed,
+	EmailTemplateSwapRequestCountered,
+	EmailTemplateSwapRequestWithdrawn,
+	EmailTemplateSwapRequestCancelled,
+	EmailTemplateSwapRequestExpiring,
+	EmailTemplateSwapRequestExpired,
+	EmailTemplateHandoverConfirmed,
+	EmailTemplateSwapCompleted,
+}
+
+// EmailData fills in a template. It is stored in the outbox as JSON, so values
+// must survive a round trip: strings, numbers, booleans, and slices or maps of
+// those.
+type EmailData map[string]any
+
+// UnsubscribeURLKey is the Data key holding the one-click unsubscribe link of
+// an email, if it has one.
+const UnsubscribeURLKey = "UnsubscribeURL"
+
+// secretEmailDataKeys hold links that act as credentials: whoever has one can
+// verify the address, reset the password or change the preferences it was
+// issued for.
+var secretEmailDataKeys = []string{"VerificationURL", "ResetURL", UnsubscribeURLKey}
+
+// WithoutSecrets returns a copy of data without the links that act as
+// credentials, so that what is kept of an email once it leaves the outbox
+// cannot be used in the user's place.
+func (data EmailData) WithoutSecrets() EmailData {
+	redacted := make(EmailData, len(data))
+	for key, value := range data {
+		redacted[key] = value
+	}
+	for _, key := range secretEmailDataKeys {
+		delete(redacted, key)
+	}
+
+	return redacted
+}
+
+// NeedsSecretLink reports whether emails from template are pointless without
+// their secret link, so that they cannot be sent again once it is discarded.
+func (template EmailTemplate) NeedsSecretLink() bool {
+	return template == EmailTemplateEmailVerification || template == EmailTemplatePasswordReset
+}
+
+// EmailMessage is an email to be rendered from Template with Data.
 type EmailMessage struct {
 	Recipient string
-	Subject   string
-	Body      string
+	Template  EmailTemplate
+	Data      EmailData
+}
+
+// RenderedEmail is an email ready to be sent, with a plain-text and an HTML
+// version of the same content. UnsubscribeURL is set for emails a user can
+// opt out of.
+type RenderedEmail struct {
+	Subject        string
+	Text           string
+	HTML           string
+	UnsubscribeURL string
 }
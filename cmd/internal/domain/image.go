@@ -0,0 +1,28 @@
+package domain
+
+import "errors"
+
+var (
+	ImageTooLargeErr        = errors.New("image file is too large")
+	UnsupportedImageTypeErr = errors.New("unsupported image type, upload a JPEG, PNG or GIF file")
+	ImageDimensionsErr      = errors.New("image dimensions are out of bounds")
+	InvalidImageErr         = errors.New("image could not be decoded")
+)
+
+// ProcessedImage is an uploaded picture after validation and normalization:
+// the picture itself and its smaller variants, all in the same format and
+// without any of the metadata of the upload.
+type ProcessedImage struct {
+	ContentType string
+	Extension   string
+	Picture     EncodedImage
+	Variants    []EncodedImage
+}
+
+type EncodedImage struct {
+	// Name identifies a variant, such as "thumbnail"; it is empty for the picture itself.
+	Name    string
+	Width   int
+	Height  int
+	Content []byte
+}
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"github.com/google/uuid"
+	"time"
 )
 
 type Item struct {
@@ -11,4 +12,14 @@ type Item struct {
 	PictureURL  string
 	UserID      uuid.UUID
 	Offered     bool
+
+	CategorySlug string
+	Condition    ItemCondition
+	Tags         []string
+
+	// Pictures are ordered by Position. PictureURL mirrors the URL of the
+	// primary picture.
+	Pictures []ItemPicture
+
+	CreatedAt time.Time
 }
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+)
+
+const (
+	MaxItemTags      = 10
+	MaxItemTagLength = 30
+)
+
+var (
+	InvalidItemConditionErr = errors.New("invalid item condition")
+	InvalidItemTagErr       = errors.New("tags may only contain letters, digits, spaces and hyphens")
+	TooManyItemTagsErr      = errors.New("too many tags")
+)
+
+type ItemCondition string
+
+const (
+	ConditionNew      ItemCondition = "new"
+	ConditionLikeNew  ItemCondition = "like-new"
+	ConditionUsed     ItemCondition = "used"
+	ConditionForParts ItemCondition = "for-parts"
+)
+
+func (condition ItemCondition) IsValid() bool {
+	switch condition {
+	case ConditionNew, ConditionLikeNew, ConditionUsed, ConditionForParts:
+		return true
+	default:
+		return false
+	}
+}
+
+// NormalizeTags lowercases and trims tags, drops blanks and duplicates, and
+// rejects tags that are too long or contain unsupported characters.
+func NormalizeTags(tags []string) ([]string, error) {
+	var normalized []string
+	seen := make(map[string]bool, len(tags))
+
+	for _, tag := range tags {
+		tag = strings.Join(strings.Fields(strings.ToLower(tag)), " ")
+		if tag == "" || seen[tag] {
+			continue
+		}
+
+		if len(tag) > MaxItemTagLength {
+			return nil, InvalidItemTagErr
+		}
+		for _, r := range tag {
+			if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != ' ' && r != '-' {
+				return nil, InvalidItemTagErr
+			}
+		}
+
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+
+	if len(normalized) > MaxItemTags {
+		return nil, TooManyItemTagsErr
+	}
+
+	return normalized, nil
+}
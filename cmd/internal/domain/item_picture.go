@@ -0,0 +1,165 @@
+package domain
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"io"
+	"sort"
+	"time"
+)
+
+const MaxItemPictures = 10
+
+var (
+	ItemPictureNotFoundErr = errors.New("item picture not found")
+	TooManyItemPicturesErr = errors.New("too many item pictures")
+	InvalidPictureOrderErr = errors.New("picture order must list every picture of the item exactly once")
+)
+
+// ItemPicture is one picture of an item. Pictures are shown by ascending
+// Position and exactly one of them is the primary picture.
+type ItemPicture struct {
+	ID     uuid.UUID
+	ItemID uuid.UUID
+	URL    string
+	// StorageKey identifies the file in the blob store. It is empty for
+	// pictures that are not managed by the store.
+	StorageKey string
+	Width      int
+	Height     int
+	Position   int
+	IsPrimary  bool
+	// Variants are smaller renditions of the picture, such as thumbnails.
+	Variants  []PictureVariant
+	CreatedAt time.Time
+}
+
+// PictureVariant is a resized rendition of a picture, identified by Name.
+type PictureVariant struct {
+	Name       string
+	URL        string
+	StorageKey string
+	Width      int
+	Height     int
+}
+
+// PictureUpload is a picture file received from a client.
+type PictureUpload struct {
+	Filename    string
+	ContentType string
+	Size        int64
+	Content     io.Reader
+}
+
+// PrimaryPicture returns the primary picture of the item, or nil if it has none.
+func (item *Item) PrimaryPicture() *ItemPicture {
+	for i := range item.Pictures {
+		if item.Pictures[i].IsPrimary {
+			return &item.Pictures[i]
+		}
+	}
+
+	return nil
+}
+
+// NormalizePictures sorts the pictures, renumbers their positions from zero and
+// makes sure exactly one of them is primary, defaulting to the first one.
+func (item *Item) NormalizePictures() error {
+	if len(item.Pictures) == 0 {
+		return nil
+	}
+	if len(item.Pictures) > MaxItemPictures {
+		return TooManyItemPicturesErr
+	}
+
+	sort.SliceStable(item.Pictures, func(i, j int) bool {
+		return item.Pictures[i].Position < item.Pictures[j].Position
+	})
+
+	primaryIndex := 0
+	for i, picture := range item.Pictures {
+		if picture.IsPrimary {
+			primaryIndex = i
+			break
+		}
+	}
+
+	for i := range item.Pictures {
+		item.Pictures[i].ItemID = item.ID
+		item.Pictures[i].Position = i
+		item.Pictures[i].IsPrimary = i == primaryIndex
+	}
+
+	item.PictureURL = item.Pictures[primaryIndex].URL
+
+	return nil
+}
+
+// AddPictures appends pictures after the existing ones.
+func (item *Item) AddPictures(pictures []ItemPicture) error {
+	if len(item.Pictures)+len(pictures) > MaxItemPictures {
+		return TooManyItemPicturesErr
+	}
+
+	for _, picture := range pictures {
+		picture.Position = len(item.Pictures)
+		item.Pictures = append(item.Pictures, picture)
+	}
+
+	return item.NormalizePictures()
+}
+
+// ReorderPictures arranges the pictures in the order of pictureIDs, which must
+// list every picture of the item once. A non-nil primaryID also moves the
+// primary flag to that picture.
+func (item *Item) ReorderPictures(pictureIDs []uuid.UUID, primaryID uuid.UUID) error {
+	if len(pictureIDs) != len(item.Pictures) {
+		return InvalidPictureOrderErr
+	}
+
+	positions := make(map[uuid.UUID]int, len(pictureIDs))
+	for position, id := range pictureIDs {
+		if _, duplicate := positions[id]; duplicate {
+			return InvalidPictureOrderErr
+		}
+		positions[id] = position
+	}
+
+	if primaryID != uuid.Nil {
+		if _, ok := positions[primaryID]; !ok {
+			return ItemPictureNotFoundErr
+		}
+	}
+
+	for i := range item.Pictures {
+		position, ok := positions[item.Pictures[i].ID]
+		if !ok {
+			return InvalidPictureOrderErr
+		}
+		item.Pictures[i].Position = position
+		if primaryID != uuid.Nil {
+			item.Pictures[i].IsPrimary = item.Pictures[i].ID == primaryID
+		}
+	}
+
+	return item.NormalizePictures()
+}
+
+// RemovePicture drops a picture from the item and returns it. If it was the
+// primary picture, the first remaining picture becomes primary.
+func (item *Item) RemovePicture(pictureID uuid.UUID) (*ItemPicture, error) {
+	for i, picture := range item.Pictures {
+		if picture.ID != pictureID {
+			continue
+		}
+
+		item.Pictures = append(item.Pictures[:i:i], item.Pictures[i+1:]...)
+		if len(item.Pictures) == 0 {
+			item.PictureURL = ""
+		}
+
+		return &picture, item.NormalizePictures()
+	}
+
+	return nil, ItemPictureNotFoundErr
+}
@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	DefaultItemSearchLimit = 20
+	MaxItemSearchLimit     = 100
+)
+
+var (
+	InvalidItemSortErr     = errors.New("invalid item sort order")
+	InvalidSearchCursorErr = errors.New("invalid search cursor")
+)
+
+type ItemSort string
+
+const (
+	ItemSortNewest   ItemSort = "newest"
+	ItemSortOldest   ItemSort = "oldest"
+	ItemSortNameAsc  ItemSort = "name_asc"
+	ItemSortNameDesc ItemSort = "name_desc"
+)
+
+func (sort ItemSort) IsValid() bool {
+	switch sort {
+	case ItemSortNewest, ItemSortOldest, ItemSortNameAsc, ItemSortNameDesc:
+		return true
+	default:
+		return false
+	}
+}
+
+// ItemSearchQuery describes a page of items to browse. Nil filters are ignored;
+// Cursor is the opaque NextCursor of the previous page.
+type ItemSearchQuery struct {
+	Keywords      string
+	OwnerID       *uuid.UUID
+	Offered       *bool
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	Sort          ItemSort
+	Cursor        string
+	Limit         int
+
+	// CategorySlug matches the category and all of its descendants.
+	CategorySlug string
+	Conditions   []ItemCondition
+	// Tags must all be present on an item for it to match.
+	Tags []string
+}
+
+// Normalize fills in the default sort order and clamps the page size.
+func (query *ItemSearchQuery) Normalize() error {
+	if query.Sort == "" {
+		query.Sort = ItemSortNewest
+	}
+	if !query.Sort.IsValid() {
+		return InvalidItemSortErr
+	}
+
+	if query.Limit <= 0 {
+		query.Limit = DefaultItemSearchLimit
+	}
+	if query.Limit > MaxItemSearchLimit {
+		query.Limit = MaxItemSearchLimit
+	}
+
+	for _, condition := range query.Conditions {
+		if !condition.IsValid() {
+			return InvalidItemConditionErr
+		}
+	}
+
+	tags, err := NormalizeTags(query.Tags)
+	if err != nil {
+		return err
+	}
+	query.Tags = tags
+
+	return nil
+}
+
+// ItemSearchResult is one page of items; NextCursor is empty on the last page.
+type ItemSearchResult struct {
+	Items      []Item
+	NextCursor string
+}
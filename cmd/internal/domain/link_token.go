@@ -0,0 +1,22 @@
+package domain
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"time"
+)
+
+const EmailVerificationPurpose = "email_verification"
+
+var InvalidLinkTokenErr = errors.New("invalid or expired link token")
+
+// LinkTokenClaims is what a token embedded in a link mailed to a user asserts.
+// A token is only accepted for the Purpose it was signed for, and Value binds
+// it to state that must not have changed when it is used, such as the email
+// address being verified. A zero ExpiresAt means the token does not expire.
+type LinkTokenClaims struct {
+	Purpose   string
+	UserID    uuid.UUID
+	Value     string
+	ExpiresAt time.Time
+}
@@ -0,0 +1,17 @@
+package domain
+
+import "time"
+
+// LoginAttempts counts the recent failed logins of an account or a network
+// address. While LockedUntil lies ahead, further attempts are refused without
+// checking the credentials.
+type LoginAttempts struct {
+	Key           string
+	Failures      int
+	LastFailureAt time.Time
+	LockedUntil   *time.Time
+}
+
+func (attempts *LoginAttempts) IsLocked(now time.Time) bool {
+	return attempts.LockedUntil != nil && now.Before(*attempts.LockedUntil)
+}
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	DefaultNotificationListLimit = 20
+	MaxNotificationListLimit     = 100
+)
+
+// NotificationType names the swap event a notification is about. It uses the
+// name of the email template sent for the same event, e.g.
+// "swap_request_accepted".
+type NotificationType string
+
+// Notification is an entry in a user's in-app inbox. Unlike emails, every swap
+// event is recorded regardless of the user's notification preferences.
+type Notification struct {
+	ID              uuid.UUID
+	UserID          uuid.UUID
+	Type            NotificationType
+	SwapRequestID   uuid.UUID
+	ReferenceNumber string
+	ReadAt          *time.Time
+	CreatedAt       time.Time
+}
+
+// NewSwapNotification records that the event behind template happened to
+// swapRequest, for userID.
+func NewSwapNotification(userID uuid.UUID, template EmailTemplate, swapRequest *SwapRequest, now time.Time) *Notification {
+	return &Notification{
+		ID:              uuid.New(),
+		UserID:          userID,
+		Type:            NotificationType(template),
+		SwapRequestID:   swapRequest.ID,
+		ReferenceNumber: swapRequest.ReferenceNumber,
+		CreatedAt:       now,
+	}
+}
+
+func (notification *Notification) IsRead() bool {
+	return notification.ReadAt != nil
+}
+
+// NotificationQuery selects a page of a user's notifications, newest first.
+type NotificationQuery struct {
+	UnreadOnly bool
+	Limit      int
+	Offset     int
+}
+
+// Normalize clamps the page size.
+func (query *NotificationQuery) Normalize() {
+	if query.Limit <= 0 {
+		query.Limit = DefaultNotificationListLimit
+	}
+	if query.Limit > MaxNotificationListLimit {
+		query.Limit = MaxNotificationListLimit
+	}
+	if query.Offset < 0 {
+		query.Offset = 0
+	}
+}
@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+// UnsubscribePurpose marks link tokens that turn off one notification event
+// for one user. They do not expire, so links in old emails keep working.
+const UnsubscribePurpose = "unsubscribe"
+
+var InvalidNotificationEventErr = errors.New("invalid notification event")
+
+// NotificationEvent is a kind of swap email that users can opt out of. Emails
+// not tied to an event, such as security notices or handover updates, are
+// always sent.
+type NotificationEvent string
+
+const (
+	NotifyNewRequest   NotificationEvent = "new_request"
+	NotifyAccepted     NotificationEvent = "accepted"
+	NotifyRejected     NotificationEvent = "rejected"
+	NotifyCancelled    NotificationEvent = "cancelled"
+	NotifyExpiringSoon NotificationEvent = "expiring_soon"
+)
+
+var NotificationEvents = []NotificationEvent{
+	NotifyNewRequest,
+	NotifyAccepted,
+	NotifyRejected,
+	NotifyCancelled,
+	NotifyExpiringSoon,
+}
+
+func (event NotificationEvent) IsValid() bool {
+	for _, known := range NotificationEvents {
+		if event == known {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NotificationEventOf returns the event an email template announces, if users
+// can opt out of it.
+func NotificationEventOf(template EmailTemplate) (NotificationEvent, bool) {
+	switch template {
+	case EmailTemplateSwapRequestCreated, EmailTemplateSwapRequestCountered:
+		return NotifyNewRequest, true
+	case EmailTemplateSwapRequestAccepted:
+		return NotifyAccepted, true
+	case EmailTemplateSwapRequestRejected:
+		return NotifyRejected, true
+	case EmailTemplateSwapRequestCancelled, EmailTemplateSwapRequestWithdrawn:
+		return NotifyCancelled, true
+	case EmailTemplateSwapRequestExpiring:
+		return NotifyExpiringSoon, true
+	default:
+		return "", false
+	}
+}
+
+// NotificationPreferences tells for each event whether the user wants to be
+// emailed about it. Events missing from the map are enabled, so that users
+// receive new kinds of notifications until they opt out.
+type NotificationPreferences map[NotificationEvent]bool
+
+// Allows reports whether the user wants emails about event.
+func (preferences NotificationPreferences) Allows(event NotificationEvent) bool {
+	enabled, ok := preferences[event]
+	return !ok || enabled
+}
+
+// All returns the preference for every known event.
+func (preferences NotificationPreferences) All() NotificationPreferences {
+	all := make(NotificationPreferences, len(NotificationEvents))
+	for _, event := range NotificationEvents {
+		all[event] = preferences.Allows(event)
+	}
+
+	return all
+}
+
+// OptOuts encodes the disabled events as a comma-separated list, in the order
+// of NotificationEvents, for storage.
+func (preferences NotificationPreferences) OptOuts() string {
+	var optOuts []string
+	for _, event := range NotificationEvents {
+		if !preferences.Allows(event) {
+			optOuts = append(optOuts, string(event))
+		}
+	}
+
+	return strings.Join(optOuts, ",")
+}
+
+// ParseNotificationOptOuts decodes a list written by OptOuts. Unknown events
+// are ignored.
+func ParseNotificationOptOuts(optOuts string) NotificationPreferences {
+	preferences := make(NotificationPreferences)
+	for _, value := range strings.Split(optOuts, ",") {
+		if event := NotificationEvent(value); event.IsValid() {
+			preferences[event] = false
+		}
+	}
+
+	return preferences
+}
@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"time"
+)
+
+type OutboxStatus string
+
+const (
+	OutboxPending OutboxStatus = "pending"
+	OutboxSent    OutboxStatus = "sent"
+	// OutboxDead marks a message that used up its delivery attempts. It stays
+	// in the outbox until an admin replays it.
+	OutboxDead OutboxStatus = "dead"
+)
+
+const (
+	DefaultOutboxListLimit = 50
+	MaxOutboxListLimit     = 200
+)
+
+var InvalidOutboxStatusErr = errors.New("invalid outbox status")
+
+func (status OutboxStatus) IsValid() bool {
+	switch status {
+	case OutboxPending, OutboxSent, OutboxDead:
+		return true
+	default:
+		return false
+	}
+}
+
+// OutboxMessage is an email waiting in the outbox, together with its delivery
+// state.
+type OutboxMessage struct {
+	ID uuid.UUID
+	EmailMessage
+	Status        OutboxStatus
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+	CreatedAt     time.Time
+	SentAt        *time.Time
+}
+
+// NewOutboxMessage queues email for delivery as soon as possible.
+func NewOutboxMessage(email EmailMessage, now time.Time) *OutboxMessage {
+	return &OutboxMessage{
+		ID:            uuid.New(),
+		EmailMessage:  email,
+		Status:        OutboxPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}
+}
+
+// OutboxQuery selects a page of outbox messages, newest first. A nil status
+// lists every message.
+type OutboxQuery struct {
+	Status *OutboxStatus
+	Limit  int
+	Offset int
+}
+
+// Normalize checks the filter and clamps the page size.
+func (query *OutboxQuery) Normalize() error {
+	if query.Status != nil && !query.Status.IsValid() {
+		return InvalidOutboxStatusErr
+	}
+
+	if query.Limit <= 0 {
+		query.Limit = DefaultOutboxListLimit
+	}
+	if query.Limit > MaxOutboxListLimit {
+		query.Limit = MaxOutboxListLimit
+	}
+	if query.Offset < 0 {
+		query.Offset = 0
+	}
+
+	return nil
+}
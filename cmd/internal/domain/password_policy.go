@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MaxPasswordBytes is the longest password bcrypt can hash; longer ones
+// would be truncated silently.
+const MaxPasswordBytes = 72
+
+var WeakPasswordErr = errors.New("password does not meet the password policy")
+
+// PasswordPolicyError lists every rule a password breaks. It unwraps to
+// WeakPasswordErr.
+type PasswordPolicyError struct {
+	Violations []string
+}
+
+func (err *PasswordPolicyError) Error() string {
+	return fmt.Sprintf("%v: %s", WeakPasswordErr, strings.Join(err.Violations, "; "))
+}
+
+func (err *PasswordPolicyError) Unwrap() error {
+	return WeakPasswordErr
+}
+
+// PasswordPolicy sets what a new password has to look like. Character
+// classes are lowercase and uppercase letters, digits and everything else.
+type PasswordPolicy struct {
+	MinLength           int
+	MinCharacterClasses int
+}
+
+// Check returns a PasswordPolicyError unless password follows the policy and
+// differs from the username and email address of its owner. Whether it is a
+// commonly used password is checked separately.
+func (policy PasswordPolicy) Check(password string, username string, email string) error {
+	var violations []string
+
+	if utf8.RuneCountInString(password) < policy.MinLength {
+		violations = append(violations, fmt.Sprintf("must be at least %d characters long", policy.MinLength))
+	}
+
+	if len(password) > MaxPasswordBytes {
+		violations = append(violations, fmt.Sprintf("must be at most %d bytes long", MaxPasswordBytes))
+	}
+
+	if characterClasses(password) < policy.MinCharacterClasses {
+		violations = append(violations, fmt.Sprintf(
+			"must mix at least %d of lowercase letters, uppercase letters, digits and symbols",
+			policy.MinCharacterClasses,
+		))
+	}
+
+	if password != "" && (strings.EqualFold(password, username) || strings.EqualFold(password, email)) {
+		violations = append(violations, "must not be your username or email address")
+	}
+
+	if len(violations) > 0 {
+		return &PasswordPolicyError{Violations: violations}
+	}
+
+	return nil
+}
+
+func characterClasses(password string) int {
+	var lower, upper, digit, other bool
+
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			other = true
+		}
+	}
+
+	classes := 0
+	for _, present := range []bool{lower, upper, digit, other} {
+		if present {
+			classes++
+		}
+	}
+
+	return classes
+}
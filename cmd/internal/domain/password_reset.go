@@ -5,8 +5,15 @@ import (
 	"time"
 )
 
+// PasswordReset is a pending password reset. Only the hash of the token mailed
+// to the user is stored, and a user has at most one pending reset.
 type PasswordReset struct {
-	Token     string
+	TokenHash string
 	UserID    uuid.UUID
+	CreatedAt time.Time
 	ExpiresAt time.Time
 }
+
+func (reset *PasswordReset) IsExpired(now time.Time) bool {
+	return !now.Before(reset.ExpiresAt)
+}
@@ -0,0 +1,38 @@
+package domain
+
+import "errors"
+
+var InvalidRoleErr = errors.New("invalid role")
+
+// Role is what a user may do beyond managing their own account. Roles are
+// ordered: every role includes the permissions of those below it.
+type Role string
+
+const (
+	RoleUser      Role = "user"
+	RoleModerator Role = "moderator"
+	RoleAdmin     Role = "admin"
+)
+
+var roleRanks = map[Role]int{
+	RoleUser:      1,
+	RoleModerator: 2,
+	RoleAdmin:     3,
+}
+
+func (role Role) IsValid() bool {
+	_, ok := roleRanks[role]
+	return ok
+}
+
+// Includes reports whether role grants at least the permissions of required.
+// Unknown roles grant nothing.
+func (role Role) Includes(required Role) bool {
+	return role.IsValid() && roleRanks[role] >= roleRanks[required]
+}
+
+// Outranks reports whether role is strictly above other, which is needed to
+// act on another user's account.
+func (role Role) Outranks(other Role) bool {
+	return role.IsValid() && roleRanks[role] > roleRanks[other]
+}
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Session is a login on one device. It is renewed with a refresh token that
+// changes on every use; only the hash of the current token is kept.
+type Session struct {
+	ID               uuid.UUID
+	UserID           uuid.UUID
+	RefreshTokenHash string
+	UserAgent        string
+	IPAddress        string
+	CreatedAt        time.Time
+	LastUsedAt       time.Time
+	ExpiresAt        time.Time
+	RevokedAt        *time.Time
+}
+
+// SessionClient describes the device a session is used from.
+type SessionClient struct {
+	UserAgent string
+	IPAddress string
+}
+
+// AuthTokens are the credentials handed out when a session starts or is refreshed.
+type AuthTokens struct {
+	SessionID             uuid.UUID
+	AccessToken           string
+	AccessTokenExpiresAt  time.Time
+	RefreshToken          string
+	RefreshTokenExpiresAt time.Time
+}
+
+// IsActive reports whether the session can still be used at the given time.
+func (session *Session) IsActive(now time.Time) bool {
+	return session.RevokedAt == nil && now.Before(session.ExpiresAt)
+}
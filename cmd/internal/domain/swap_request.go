@@ -1,6 +1,9 @@
 package domain
 
-import "github.com/google/uuid"
+import (
+	"github.com/google/uuid"
+	"time"
+)
 
 type SwapRequestStatus string
 
@@ -9,6 +12,10 @@ const (
 	StatusAccepted  SwapRequestStatus = "accepted"
 	StatusRejected  SwapRequestStatus = "rejected"
 	StatusCancelled SwapRequestStatus = "cancelled"
+	StatusCompleted SwapRequestStatus = "completed"
+	StatusDisputed  SwapRequestStatus = "disputed"
+	StatusCountered SwapRequestStatus = "countered"
+	StatusExpired   SwapRequestStatus = "expired"
 )
 
 type SwapRequest struct {
@@ -19,4 +26,122 @@ type SwapRequest struct {
 	RequestedItemID uuid.UUID
 	SenderID        uuid.UUID
 	RecipientID     uuid.UUID
+
+	// OfferedItemIDs and RequestedItemIDs hold every item of a bundle. For
+	// single-item requests they contain OfferedItemID and RequestedItemID.
+	OfferedItemIDs   []uuid.UUID
+	RequestedItemIDs []uuid.UUID
+
+	SenderConfirmed    bool
+	RecipientConfirmed bool
+
+	// ThreadID is the ID of the request that opened the negotiation; ParentID
+	// points at the request this one counters, if any.
+	ThreadID uuid.UUID
+	ParentID *uuid.UUID
+
+	// ExpiresAt is when a pending request lapses; nil means it never does.
+	// ReminderSentAt is when the recipient was reminded that it is about to.
+	ExpiresAt      *time.Time
+	ReminderSentAt *time.Time
+	CreatedAt      time.Time
+}
+
+// CounterOffer is a recipient's alternative proposal, expressed from the point
+// of view of the recipient: what they would give and what they would take.
+type CounterOffer struct {
+	ReferenceNumber  string
+	OfferedItemIDs   []uuid.UUID
+	RequestedItemIDs []uuid.UUID
+}
+
+// PartyOf returns the role the given user plays in the swap request.
+func (request *SwapRequest) PartyOf(userID uuid.UUID) (SwapParty, bool) {
+	switch userID {
+	case request.SenderID:
+		return PartySender, true
+	case request.RecipientID:
+		return PartyRecipient, true
+	default:
+		return "", false
+	}
+}
+
+// IsOverdue reports whether the request is still pending past its expiry time.
+func (request *SwapRequest) IsOverdue(now time.Time) bool {
+	return request.Status == StatusPending && request.ExpiresAt != nil && !now.Before(*request.ExpiresAt)
+}
+
+// HandoverConfirmed reports whether both parties have confirmed the exchange of items.
+func (request *SwapRequest) HandoverConfirmed() bool {
+	return request.SenderConfirmed && request.RecipientConfirmed
+}
+
+// ConfirmHandover records that party has handed over and received the items.
+func (request *SwapRequest) ConfirmHandover(party SwapParty) error {
+	if request.Status != StatusAccepted {
+		return &StatusTransitionError{From: request.Status, To: StatusCompleted, Party: party, Err: InvalidStatusTransitionErr}
+	}
+
+	switch party {
+	case PartySender:
+		if request.SenderConfirmed {
+			return HandoverAlreadyConfirmedErr
+		}
+		request.SenderConfirmed = true
+	case PartyRecipient:
+		if request.RecipientConfirmed {
+			return HandoverAlreadyConfirmedErr
+		}
+		request.RecipientConfirmed = true
+	default:
+		return NotSwapRequestParticipantErr
+	}
+
+	return nil
+}
+
+// Counter moves the request to countered on behalf of party and returns the
+// counter request, in which the original recipient becomes the sender. Items
+// left unset in offer default to those of the original request.
+func (request *SwapRequest) Counter(party SwapParty, offer CounterOffer) (*SwapRequest, error) {
+	if err := ValidateTransition(request.Status, StatusCountered, party); err != nil {
+		return nil, err
+	}
+
+	offeredItemIDs := mergeItemIDs(uuid.Nil, offer.OfferedItemIDs)
+	if len(offeredItemIDs) == 0 {
+		offeredItemIDs = request.AllRequestedItemIDs()
+	}
+
+	requestedItemIDs := mergeItemIDs(uuid.Nil, offer.RequestedItemIDs)
+	if len(requestedItemIDs) == 0 {
+		requestedItemIDs = request.AllOfferedItemIDs()
+	}
+
+	if sameItemIDs(offeredItemIDs, request.AllRequestedItemIDs()) && sameItemIDs(requestedItemIDs, request.AllOfferedItemIDs()) {
+		return nil, CounterOfferUnchangedErr
+	}
+
+	parentID := request.ID
+
+	counter := &SwapRequest{
+		Status:           StatusPending,
+		ReferenceNumber:  offer.ReferenceNumber,
+		OfferedItemIDs:   offeredItemIDs,
+		RequestedItemIDs: requestedItemIDs,
+		SenderID:         request.RecipientID,
+		RecipientID:      request.SenderID,
+		ThreadID:         request.ThreadID,
+		ParentID:         &parentID,
+	}
+	counter.NormalizeItems()
+
+	if err := counter.ValidateItems(); err != nil {
+		return nil, err
+	}
+
+	request.Status = StatusCountered
+
+	return counter, nil
 }
@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"errors"
+	"github.com/google/uuid"
+)
+
+// MaxSwapBundleItems caps how many items can sit on either side of a swap request.
+const MaxSwapBundleItems = 10
+
+var (
+	MissingSwapItemsErr     = errors.New("a swap request needs at least one offered and one requested item")
+	TooManySwapItemsErr     = errors.New("too many items in swap request")
+	OverlappingSwapItemsErr = errors.New("an item cannot be both offered and requested")
+)
+
+// NormalizeItems merges the single-item fields into the item lists, drops
+// duplicates and points OfferedItemID and RequestedItemID at the first item of
+// each list so single-item clients keep working.
+func (request *SwapRequest) NormalizeItems() {
+	request.OfferedItemIDs = request.AllOfferedItemIDs()
+	request.RequestedItemIDs = request.AllRequestedItemIDs()
+
+	request.OfferedItemID = firstItemID(request.OfferedItemIDs)
+	request.RequestedItemID = firstItemID(request.RequestedItemIDs)
+}
+
+// AllOfferedItemIDs returns every offered item, whether or not the request has been normalized.
+func (request *SwapRequest) AllOfferedItemIDs() []uuid.UUID {
+	return mergeItemIDs(request.OfferedItemID, request.OfferedItemIDs)
+}
+
+// AllRequestedItemIDs returns every requested item, whether or not the request has been normalized.
+func (request *SwapRequest) AllRequestedItemIDs() []uuid.UUID {
+	return mergeItemIDs(request.RequestedItemID, request.RequestedItemIDs)
+}
+
+// ValidateItems checks the shape of the bundle once it has been normalized.
+func (request *SwapRequest) ValidateItems() error {
+	if len(request.OfferedItemIDs) == 0 || len(request.RequestedItemIDs) == 0 {
+		return MissingSwapItemsErr
+	}
+
+	if len(request.OfferedItemIDs) > MaxSwapBundleItems || len(request.RequestedItemIDs) > MaxSwapBundleItems {
+		return TooManySwapItemsErr
+	}
+
+	for _, offeredID := range request.OfferedItemIDs {
+		if containsItemID(request.RequestedItemIDs, offeredID) {
+			return OverlappingSwapItemsErr
+		}
+	}
+
+	return nil
+}
+
+// IsBundle reports whether more than one item sits on either side of the request.
+func (request *SwapRequest) IsBundle() bool {
+	return len(request.OfferedItemIDs) > 1 || len(request.RequestedItemIDs) > 1
+}
+
+func mergeItemIDs(single uuid.UUID, list []uuid.UUID) []uuid.UUID {
+	merged := make([]uuid.UUID, 0, len(list)+1)
+
+	if single != uuid.Nil {
+		merged = append(merged, single)
+	}
+
+	for _, id := range list {
+		if id != uuid.Nil && !containsItemID(merged, id) {
+			merged = append(merged, id)
+		}
+	}
+
+	return merged
+}
+
+func sameItemIDs(first, second []uuid.UUID) bool {
+	if len(first) != len(second) {
+		return false
+	}
+
+	for _, id := range first {
+		if !containsItemID(second, id) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func containsItemID(list []uuid.UUID, id uuid.UUID) bool {
+	for _, candidate := range list {
+		if candidate == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+func firstItemID(list []uuid.UUID) uuid.UUID {
+	if len(list) == 0 {
+		return uuid.Nil
+	}
+
+	return list[0]
+}
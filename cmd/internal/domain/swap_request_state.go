@@ -0,0 +1,134 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+// SwapParty identifies who is driving a status transition.
+type SwapParty string
+
+const (
+	PartySender    SwapParty = "sender"
+	PartyRecipient SwapParty = "recipient"
+	PartySystem    SwapParty = "system"
+	// PartyModerator is staff stepping into a swap the parties cannot settle.
+	PartyModerator SwapParty = "moderator"
+)
+
+var (
+	InvalidStatusErr             = errors.New("invalid swap request status")
+	InvalidStatusTransitionErr   = errors.New("invalid swap request status transition")
+	TransitionNotPermittedErr    = errors.New("party is not permitted to perform this transition")
+	NotSwapRequestParticipantErr = errors.New("user is not part of this swap request")
+	HandoverAlreadyConfirmedErr  = errors.New("handover already confirmed")
+	CounterOfferUnchangedErr     = errors.New("counter offer must change at least one item")
+	SwapRequestExpiredErr        = errors.New("swap request has expired")
+	CounterOfferRequiredErr      = errors.New("swap requests can only be countered with a counter offer")
+)
+
+// StatusTransitionError describes a rejected transition. It unwraps to either
+// InvalidStatusTransitionErr or TransitionNotPermittedErr.
+type StatusTransitionError struct {
+	From  SwapRequestStatus
+	To    SwapRequestStatus
+	Party SwapParty
+	Err   error
+}
+
+func (e *StatusTransitionError) Error() string {
+	return fmt.Sprintf("cannot move swap request from %s to %s as %s: %v", e.From, e.To, e.Party, e.Err)
+}
+
+func (e *StatusTransitionError) Unwrap() error {
+	return e.Err
+}
+
+// swapRequestTransitions lists, for every status, the statuses it may move to
+// and the parties allowed to trigger each move. PartySystem is held to its
+// lists like everyone else: it expires requests that were left unanswered and
+// completes swaps whose handover both parties confirmed.
+var swapRequestTransitions = map[SwapRequestStatus]map[SwapRequestStatus][]SwapParty{
+	StatusPending: {
+		StatusAccepted:  {PartyRecipient},
+		StatusRejected:  {PartyRecipient},
+		StatusCancelled: {PartySender, PartyModerator},
+		StatusCountered: {PartyRecipient},
+		StatusExpired:   {PartySystem},
+	},
+	StatusAccepted: {
+		StatusCompleted: {PartySystem},
+		StatusDisputed:  {PartySender, PartyRecipient},
+		StatusCancelled: {PartySender, PartyRecipient, PartyModerator},
+	},
+	StatusDisputed: {
+		StatusCancelled: {PartyModerator},
+	},
+	StatusRejected:  {},
+	StatusCancelled: {},
+	StatusCompleted: {},
+	StatusCountered: {},
+	StatusExpired:   {},
+}
+
+func (status SwapRequestStatus) IsValid() bool {
+	_, ok := swapRequestTransitions[status]
+	return ok
+}
+
+// IsTerminal reports whether no further transitions are possible from status.
+func (status SwapRequestStatus) IsTerminal() bool {
+	return len(swapRequestTransitions[status]) == 0
+}
+
+// ReleasesItems reports whether reaching status frees the items locked by the request.
+func (status SwapRequestStatus) ReleasesItems() bool {
+	switch status {
+	case StatusRejected, StatusCancelled, StatusCountered, StatusExpired:
+		return true
+	default:
+		return false
+	}
+}
+
+// ValidateTransition checks that party may move a request from one status to another.
+func ValidateTransition(from, to SwapRequestStatus, party SwapParty) error {
+	if !to.IsValid() {
+		return InvalidStatusErr
+	}
+
+	allowed, ok := swapRequestTransitions[from][to]
+	if !ok {
+		return &StatusTransitionError{From: from, To: to, Party: party, Err: InvalidStatusTransitionErr}
+	}
+
+	for _, allowedParty := range allowed {
+		if allowedParty == party {
+			return nil
+		}
+	}
+
+	return &StatusTransitionError{From: from, To: to, Party: party, Err: TransitionNotPermittedErr}
+}
+
+// TransitionTo moves the request to the given status on behalf of party.
+func (request *SwapRequest) TransitionTo(status SwapRequestStatus, party SwapParty) error {
+	if err := ValidateTransition(request.Status, status, party); err != nil {
+		return err
+	}
+
+	request.Status = status
+
+	return nil
+}
+
+// Withdraw cancels a pending request on behalf of its sender. Unlike a
+// cancellation through TransitionTo, it is refused once the recipient has
+// answered the request.
+func (request *SwapRequest) Withdraw() error {
+	if request.Status != StatusPending {
+		return &StatusTransitionError{From: request.Status, To: StatusCancelled, Party: PartySender, Err: InvalidStatusTransitionErr}
+	}
+
+	return request.TransitionTo(StatusCancelled, PartySender)
+}
@@ -0,0 +1,120 @@
+package domain_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"swapp-go/cmd/internal/domain"
+	"testing"
+)
+
+func TestValidateTransition(t *testing.T) {
+	testCases := []struct {
+		name     string
+		from     domain.SwapRequestStatus
+		to       domain.SwapRequestStatus
+		party    domain.SwapParty
+		expected error
+	}{
+		{"recipient accepts", domain.StatusPending, domain.StatusAccepted, domain.PartyRecipient, nil},
+		{"recipient rejects", domain.StatusPending, domain.StatusRejected, domain.PartyRecipient, nil},
+		{"recipient counters", domain.StatusPending, domain.StatusCountered, domain.PartyRecipient, nil},
+		{"sender cancels pending", domain.StatusPending, domain.StatusCancelled, domain.PartySender, nil},
+		{"moderator cancels pending", domain.StatusPending, domain.StatusCancelled, domain.PartyModerator, nil},
+		{"recipient cancels accepted", domain.StatusAccepted, domain.StatusCancelled, domain.PartyRecipient, nil},
+		{"sender disputes", domain.StatusAccepted, domain.StatusDisputed, domain.PartySender, nil},
+		{"moderator cancels disputed", domain.StatusDisputed, domain.StatusCancelled, domain.PartyModerator, nil},
+		{"sender cannot accept", domain.StatusPending, domain.StatusAccepted, domain.PartySender, domain.TransitionNotPermittedErr},
+		{"recipient cannot cancel pending", domain.StatusPending, domain.StatusCancelled, domain.PartyRecipient, domain.TransitionNotPermittedErr},
+		{"sender cannot expire", domain.StatusPending, domain.StatusExpired, domain.PartySender, domain.TransitionNotPermittedErr},
+		{"recipient cannot complete", domain.StatusAccepted, domain.StatusCompleted, domain.PartyRecipient, domain.TransitionNotPermittedErr},
+		{"moderator cannot accept", domain.StatusPending, domain.StatusAccepted, domain.PartyModerator, domain.TransitionNotPermittedErr},
+		{"sender cannot cancel disputed", domain.StatusDisputed, domain.StatusCancelled, domain.PartySender, domain.TransitionNotPermittedErr},
+		{"system expires", domain.StatusPending, domain.StatusExpired, domain.PartySystem, nil},
+		{"system completes", domain.StatusAccepted, domain.StatusCompleted, domain.PartySystem, nil},
+		{"system cannot accept", domain.StatusPending, domain.StatusAccepted, domain.PartySystem, domain.TransitionNotPermittedErr},
+		{"system cannot cancel", domain.StatusAccepted, domain.StatusCancelled, domain.PartySystem, domain.TransitionNotPermittedErr},
+		{"system cannot cancel disputed", domain.StatusDisputed, domain.StatusCancelled, domain.PartySystem, domain.TransitionNotPermittedErr},
+		{"disputed swap cannot complete", domain.StatusDisputed, domain.StatusCompleted, domain.PartySystem, domain.InvalidStatusTransitionErr},
+		{"system cannot skip statuses", domain.StatusPending, domain.StatusCompleted, domain.PartySystem, domain.InvalidStatusTransitionErr},
+		{"system cannot leave terminal status", domain.StatusCompleted, domain.StatusCancelled, domain.PartySystem, domain.InvalidStatusTransitionErr},
+		{"back to pending", domain.StatusAccepted, domain.StatusPending, domain.PartyRecipient, domain.InvalidStatusTransitionErr},
+		{"out of a terminal status", domain.StatusRejected, domain.StatusAccepted, domain.PartyRecipient, domain.InvalidStatusTransitionErr},
+		{"unknown status", domain.StatusPending, domain.SwapRequestStatus("lost"), domain.PartySystem, domain.InvalidStatusErr},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := domain.ValidateTransition(testCase.from, testCase.to, testCase.party)
+			if testCase.expected == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, testCase.expected)
+		})
+	}
+}
+
+func TestSwapRequest_TransitionTo(t *testing.T) {
+	t.Run("moves to the new status", func(t *testing.T) {
+		request := &domain.SwapRequest{Status: domain.StatusPending}
+
+		assert.NoError(t, request.TransitionTo(domain.StatusAccepted, domain.PartyRecipient))
+		assert.Equal(t, domain.StatusAccepted, request.Status)
+	})
+
+	t.Run("keeps the status of a rejected transition", func(t *testing.T) {
+		request := &domain.SwapRequest{Status: domain.StatusPending}
+
+		err := request.TransitionTo(domain.StatusAccepted, domain.PartySender)
+
+		var transitionErr *domain.StatusTransitionError
+		if assert.ErrorAs(t, err, &transitionErr) {
+			assert.Equal(t, domain.StatusPending, transitionErr.From)
+			assert.Equal(t, domain.StatusAccepted, transitionErr.To)
+			assert.Equal(t, domain.PartySender, transitionErr.Party)
+		}
+		assert.Equal(t, domain.StatusPending, request.Status)
+	})
+}
+
+func TestSwapRequest_Withdraw(t *testing.T) {
+	t.Run("cancels a pending request", func(t *testing.T) {
+		request := &domain.SwapRequest{Status: domain.StatusPending}
+
+		assert.NoError(t, request.Withdraw())
+		assert.Equal(t, domain.StatusCancelled, request.Status)
+	})
+
+	t.Run("refuses an answered request", func(t *testing.T) {
+		for _, status := range []domain.SwapRequestStatus{
+			domain.StatusAccepted,
+			domain.StatusDisputed,
+			domain.StatusCompleted,
+			domain.StatusCountered,
+		} {
+			request := &domain.SwapRequest{Status: status}
+
+			assert.ErrorIs(t, request.Withdraw(), domain.InvalidStatusTransitionErr, status)
+			assert.Equal(t, status, request.Status)
+		}
+	})
+}
+
+func TestSwapRequestStatus_IsTerminal(t *testing.T) {
+	for _, status := range []domain.SwapRequestStatus{
+		domain.StatusRejected,
+		domain.StatusCancelled,
+		domain.StatusCompleted,
+		domain.StatusCountered,
+		domain.StatusExpired,
+	} {
+		assert.True(t, status.IsTerminal(), status)
+	}
+
+	for _, status := range []domain.SwapRequestStatus{
+		domain.StatusPending,
+		domain.StatusAccepted,
+		domain.StatusDisputed,
+	} {
+		assert.False(t, status.IsTerminal(), status)
+	}
+}
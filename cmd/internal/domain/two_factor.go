@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+const LoginChallengePurpose = "login_challenge"
+
+// TwoFactor holds a user's TOTP secret. It is pending until the user confirms
+// the enrollment with a first code, and only enabled settings are enforced.
+type TwoFactor struct {
+	UserID uuid.UUID
+	Secret string
+	// LastUsedStep is the TOTP time step of the last accepted code, so that a
+	// code cannot be used twice.
+	LastUsedStep int64
+	EnabledAt    *time.Time
+	CreatedAt    time.Time
+}
+
+func (twoFactor *TwoFactor) IsEnabled() bool {
+	return twoFactor.EnabledAt != nil
+}
+
+// TwoFactorEnrollment is what a user needs to add the secret to an
+// authenticator app.
+type TwoFactorEnrollment struct {
+	Secret          string
+	ProvisioningURI string
+}
+
+// LoginChallenge is handed out instead of tokens when a password was correct
+// but a second factor is still required.
+type LoginChallenge struct {
+	Token     string
+	ExpiresAt time.Time
+}
@@ -1,6 +1,15 @@
 package domain
 
-import "github.com/google/uuid"
+import (
+	"errors"
+	"github.com/google/uuid"
+	"time"
+)
+
+var (
+	EmailNotVerifiedErr = errors.New("email address is not verified")
+	AccountSuspendedErr = errors.New("account is suspended")
+)
 
 type User struct {
 	ID       uuid.UUID
@@ -9,4 +18,22 @@ type User struct {
 	Email    string
 	Phone    *string
 	Address  *string
+	// EmailVerifiedAt is set once the user proves they own Email; changing the
+	// address clears it. VerificationSentAt is when the last link was mailed.
+	EmailVerifiedAt    *time.Time
+	VerificationSentAt *time.Time
+	Role               Role
+	// SuspendedAt is set while a moderator keeps the user from signing in.
+	SuspendedAt *time.Time
+	// NotificationPreferences selects the swap emails the user receives.
+	NotificationPreferences NotificationPreferences
+}
+
+// IsEmailVerified reports whether the user's current email address is verified.
+func (user *User) IsEmailVerified() bool {
+	return user.EmailVerifiedAt != nil
+}
+
+func (user *User) IsSuspended() bool {
+	return user.SuspendedAt != nil
 }
@@ -0,0 +1,33 @@
+package domain
+
+const (
+	DefaultUserListLimit = 50
+	MaxUserListLimit     = 200
+)
+
+// UserListQuery selects a page of users for moderation. Nil filters are ignored.
+type UserListQuery struct {
+	Role      *Role
+	Suspended *bool
+	Limit     int
+	Offset    int
+}
+
+// Normalize checks the filters and clamps the page size.
+func (query *UserListQuery) Normalize() error {
+	if query.Role != nil && !query.Role.IsValid() {
+		return InvalidRoleErr
+	}
+
+	if query.Limit <= 0 {
+		query.Limit = DefaultUserListLimit
+	}
+	if query.Limit > MaxUserListLimit {
+		query.Limit = MaxUserListLimit
+	}
+	if query.Offset < 0 {
+		query.Offset = 0
+	}
+
+	return nil
+}
@@ -2,8 +2,8 @@ package utils
 
 import "golang.org/x/crypto/bcrypt"
 
-func HashPassword(password string) (string, error) {
-	encryptedPwd, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+func HashPassword(password string, cost int) (string, error) {
+	encryptedPwd, err := bcrypt.GenerateFromPassword([]byte(password), cost)
 
 	return string(encryptedPwd), err
 }
@@ -13,3 +13,8 @@ func CheckPasswordHash(password, hash string) bool {
 
 	return err == nil
 }
+
+// PasswordHashCost returns the bcrypt cost a hash was made with.
+func PasswordHashCost(hash string) (int, error) {
+	return bcrypt.Cost([]byte(hash))
+}
@@ -2,6 +2,7 @@ package utils_test
 
 import (
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
 	"swapp-go/cmd/internal/utils"
 	"testing"
 )
@@ -9,14 +10,14 @@ import (
 var password = "super_secure_password123"
 
 func TestHashPassword(t *testing.T) {
-	hashedPassword, err := utils.HashPassword(password)
+	hashedPassword, err := utils.HashPassword(password, bcrypt.MinCost)
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, hashedPassword)
 }
 
 func TestCheckPasswordHash(t *testing.T) {
-	hashedPassword, _ := utils.HashPassword(password)
+	hashedPassword, _ := utils.HashPassword(password, bcrypt.MinCost)
 
 	isValid := utils.CheckPasswordHash(password, hashedPassword)
 	assert.True(t, isValid, "Password should be valid")
@@ -26,8 +27,19 @@ func TestCheckPasswordHash(t *testing.T) {
 }
 
 func TestHashPasswordIsDifferentEachTime(t *testing.T) {
-	hash1, _ := utils.HashPassword(password)
-	hash2, _ := utils.HashPassword(password)
+	hash1, _ := utils.HashPassword(password, bcrypt.MinCost)
+	hash2, _ := utils.HashPassword(password, bcrypt.MinCost)
 
 	assert.NotEqual(t, hash1, hash2, "Each hash should be unique due to salting")
 }
+
+func TestPasswordHashCost(t *testing.T) {
+	hashedPassword, _ := utils.HashPassword(password, bcrypt.MinCost+1)
+
+	cost, err := utils.PasswordHashCost(hashedPassword)
+	assert.NoError(t, err)
+	assert.Equal(t, bcrypt.MinCost+1, cost)
+
+	_, err = utils.PasswordHashCost("invalid_hash")
+	assert.Error(t, err)
+}
@@ -0,0 +1,22 @@
+package utils_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"swapp-go/cmd/internal/utils"
+	"testing"
+)
+
+func TestGenerateRandomToken(t *testing.T) {
+	first, err := utils.GenerateRandomToken(32)
+	assert.NoError(t, err)
+	assert.Len(t, first, 43)
+
+	second, err := utils.GenerateRandomToken(32)
+	assert.NoError(t, err)
+	assert.NotEqual(t, first, second)
+}
+
+func TestHashToken(t *testing.T) {
+	assert.Equal(t, "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824", utils.HashToken("hello"))
+	assert.NotEqual(t, utils.HashToken("hello"), utils.HashToken("hello!"))
+}
@@ -0,0 +1,71 @@
+package utils_test
+
+import (
+	"encoding/base32"
+	"github.com/stretchr/testify/assert"
+	"swapp-go/cmd/internal/utils"
+	"testing"
+	"time"
+)
+
+// rfc6238Secret is the SHA-1 key of the RFC 6238 test vectors.
+var rfc6238Secret = base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+
+func TestTOTPCode(t *testing.T) {
+	// The RFC lists 8 digit codes; ours are their last 6 digits.
+	vectors := map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	}
+
+	for unix, expected := range vectors {
+		code, err := utils.TOTPCode(rfc6238Secret, utils.TOTPStep(time.Unix(unix, 0)))
+		assert.NoError(t, err)
+		assert.Equal(t, expected, code, unix)
+	}
+}
+
+func TestValidateTOTP(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+
+	step, ok := utils.ValidateTOTP(rfc6238Secret, "050471", now, 1)
+	assert.True(t, ok)
+	assert.Equal(t, utils.TOTPStep(now), step)
+
+	previous, err := utils.TOTPCode(rfc6238Secret, utils.TOTPStep(now)-1)
+	assert.NoError(t, err)
+	step, ok = utils.ValidateTOTP(rfc6238Secret, previous, now, 1)
+	assert.True(t, ok, "a code from the previous step is accepted")
+	assert.Equal(t, utils.TOTPStep(now)-1, step)
+
+	_, ok = utils.ValidateTOTP(rfc6238Secret, previous, now, 0)
+	assert.False(t, ok)
+
+	_, ok = utils.ValidateTOTP(rfc6238Secret, "000000", now, 1)
+	assert.False(t, ok)
+
+	_, ok = utils.ValidateTOTP(rfc6238Secret, "12345", now, 1)
+	assert.False(t, ok)
+
+	_, ok = utils.ValidateTOTP("not base32!", "050471", now, 1)
+	assert.False(t, ok)
+}
+
+func TestGenerateTOTPSecret(t *testing.T) {
+	secret, err := utils.GenerateTOTPSecret()
+	assert.NoError(t, err)
+	assert.Len(t, secret, 32)
+
+	_, err = utils.TOTPCode(secret, 1)
+	assert.NoError(t, err)
+}
+
+func TestTOTPProvisioningURI(t *testing.T) {
+	uri := utils.TOTPProvisioningURI("Swapp", "jack smith", "JBSWY3DPEHPK3PXP")
+
+	assert.Equal(t, "otpauth://totp/Swapp:jack%20smith?algorithm=SHA1&digits=6&issuer=Swapp&period=30&secret=JBSWY3DPEHPK3PXP", uri)
+}
@@ -1,17 +1,26 @@
 package main
 
 import (
+	"context"
 	"github.com/gin-gonic/gin"
 	"log"
 	"os"
 	"swapp-go/cmd/internal/adapters/handlers"
+	"swapp-go/cmd/internal/adapters/infrastructure/clock"
 	"swapp-go/cmd/internal/adapters/infrastructure/email"
+	"swapp-go/cmd/internal/adapters/infrastructure/imaging"
+	"swapp-go/cmd/internal/adapters/infrastructure/passwords"
+	"swapp-go/cmd/internal/adapters/infrastructure/storage"
+	"swapp-go/cmd/internal/adapters/infrastructure/tokens"
 	"swapp-go/cmd/internal/adapters/middleware"
 	gormRepo "swapp-go/cmd/internal/adapters/persistence/gorm"
+	"swapp-go/cmd/internal/adapters/persistence/memory"
 	modelsPkg "swapp-go/cmd/internal/adapters/persistence/models"
+	"swapp-go/cmd/internal/application/ports"
 	"swapp-go/cmd/internal/application/services"
 	"swapp-go/cmd/internal/config"
 	"swapp-go/cmd/internal/config/routes"
+	"swapp-go/cmd/internal/domain"
 	"swapp-go/cmd/internal/validators"
 )
 
@@ -22,54 +31,278 @@ func main() {
 	migrate()
 
 	router := gin.Default()
+	if err := router.SetTrustedProxies(config.LoadServerConfig().TrustedProxies); err != nil {
+		log.Fatalf("invalid TRUSTED_PROXIES: %v", err)
+	}
 	db := config.GetDB()
 
+	systemClock := clock.NewSystemClock()
+
+	emailConfig := config.LoadEmailConfig()
+	emailRenderer, err := email.NewTemplateRenderer(emailConfig.AppURL, emailConfig.AssetURL)
+	if err != nil {
+		log.Fatalf("failed to load email templates: %v", err)
+	}
+	deliveryService := newEmailDeliveryService(emailConfig, emailRenderer)
+
+	outboxRepo := gormRepo.NewOutboxGormRepository(db)
+	emailOutboxService := services.NewEmailOutboxService(outboxRepo, systemClock)
+	var emailService ports.EmailService = emailOutboxService
+
+	loginThrottleConfig := config.LoadLoginThrottleConfig()
+	loginThrottleService := services.NewLoginThrottleService(
+		newLoginAttemptStore(loginThrottleConfig),
+		emailService,
+		systemClock,
+		services.LoginThrottlePolicy{
+			AccountMaxFailures: loginThrottleConfig.AccountMaxFailures,
+			IPMaxFailures:      loginThrottleConfig.IPMaxFailures,
+			BaseDelay:          loginThrottleConfig.BaseDelay,
+			MaxDelay:           loginThrottleConfig.MaxDelay,
+			LockoutDuration:    loginThrottleConfig.LockoutDuration,
+			FailureWindow:      loginThrottleConfig.FailureWindow,
+		},
+	)
+
+	passwordConfig := config.LoadPasswordConfig()
+	passwordBlocklist, err := passwords.NewBlocklist(passwordConfig.BlocklistFile)
+	if err != nil {
+		log.Fatalf("failed to load password blocklist: %v", err)
+	}
+	passwordService, err := services.NewPasswordService(
+		domain.PasswordPolicy{
+			MinLength:           passwordConfig.MinLength,
+			MinCharacterClasses: passwordConfig.MinCharacterClasses,
+		},
+		passwordBlocklist,
+		passwordConfig.HashCost,
+	)
+	if err != nil {
+		log.Fatalf("failed to set up password hashing: %v", err)
+	}
+
 	userRepo := gormRepo.NewUserGormRepository(db)
-	userService := services.NewUserService(userRepo)
-	userHandler := handlers.NewUserHandler(userService)
 
+	keyRing, err := tokens.LoadKeyRing(config.LoadJWTConfig(), systemClock)
+	if err != nil {
+		log.Fatalf("failed to load JWT signing keys: %v", err)
+	}
+	jwksHandler := handlers.NewJWKSHandler(keyRing)
+
+	sessionConfig := config.LoadSessionConfig()
+	sessionRepo := gormRepo.NewSessionGormRepository(db)
+	sessionService := services.NewSessionService(
+		sessionRepo,
+		userRepo,
+		keyRing,
+		systemClock,
+		sessionConfig.AccessTokenTTL,
+		sessionConfig.RefreshTokenTTL,
+	)
+	sessionHandler := handlers.NewSessionHandler(sessionService)
+
+	userService := services.NewUserService(userRepo, sessionRepo, passwordService, loginThrottleService, systemClock)
+
+	verificationConfig := config.LoadEmailVerificationConfig()
+	verificationService := services.NewEmailVerificationService(
+		userRepo,
+		keyRing,
+		emailService,
+		systemClock,
+		verificationConfig.LinkURL,
+		verificationConfig.TokenTTL,
+		verificationConfig.ResendInterval,
+	)
+
+	twoFactorConfig := config.LoadTwoFactorConfig()
+	twoFactorRepo := gormRepo.NewTwoFactorGormRepository(db)
+	twoFactorService := services.NewTwoFactorService(
+		twoFactorRepo,
+		userRepo,
+		keyRing,
+		loginThrottleService,
+		systemClock,
+		twoFactorConfig.Issuer,
+		twoFactorConfig.ChallengeTTL,
+	)
+	twoFactorHandler := handlers.NewTwoFactorHandler(twoFactorService)
+
+	userHandler := handlers.NewUserHandler(userService, sessionService, verificationService, twoFactorService)
+
+	passwordResetConfig := config.LoadPasswordResetConfig()
 	passwordResetRepo := gormRepo.NewPasswordResetGormRepository(db)
-	passwordResetService := services.NewPasswordResetService(passwordResetRepo)
-	passwordResetHandler := handlers.NewPasswordResetHandler(passwordResetService, userService)
+	passwordResetService := services.NewPasswordResetService(
+		passwordResetRepo,
+		userRepo,
+		sessionRepo,
+		passwordService,
+		emailService,
+		systemClock,
+		passwordResetConfig.LinkURL,
+		passwordResetConfig.TokenTTL,
+	)
+	passwordResetHandler := handlers.NewPasswordResetHandler(passwordResetService)
+
+	storageConfig := config.LoadStorageConfig()
+	blobStore := newBlobStore(storageConfig, systemClock)
+	imageProcessor := imaging.NewImageProcessor(config.LoadUploadConfig())
 
 	itemRepo := gormRepo.NewItemGormRepository(db)
-	itemService := services.NewItemService(itemRepo)
+	categoryRepo := gormRepo.NewCategoryGormRepository(db)
+	itemService := services.NewItemService(itemRepo, categoryRepo, blobStore, imageProcessor)
 	itemHandler := handlers.NewItemHandler(itemService)
 
-	emailConfig := config.LoadEmailConfig()
-	var emailService = email.NewSmtpEmailService(emailConfig)
+	unitOfWork := gormRepo.NewGormUnitOfWork(db)
+
+	swapRequestConfig := config.LoadSwapRequestConfig()
+	notificationConfig := config.LoadNotificationConfig()
 
 	swapRequestRepo := gormRepo.NewSwapRequestGormRepository(db)
-	swapRequestService := services.NewSwapRequestService(swapRequestRepo, userRepo, itemRepo, emailService)
+	swapRequestService := services.NewSwapRequestService(
+		swapRequestRepo,
+		userRepo,
+		itemRepo,
+		unitOfWork,
+		systemClock,
+		swapRequestConfig.RequestTTL,
+		swapRequestConfig.ReminderBefore,
+		keyRing,
+		notificationConfig.UnsubscribeURL,
+	)
 	swapRequestHandler := handlers.NewSwapRequestHandler(swapRequestService)
 
+	notificationRepo := gormRepo.NewNotificationGormRepository(db)
+	notificationService := services.NewNotificationService(notificationRepo, systemClock)
+	notificationPreferenceService := services.NewNotificationPreferenceService(userRepo, keyRing)
+	notificationHandler := handlers.NewNotificationHandler(notificationService, notificationPreferenceService)
+
+	adminService := services.NewAdminService(userRepo, sessionRepo, systemClock)
+	adminHandler := handlers.NewAdminHandler(adminService, swapRequestService, emailOutboxService)
+
+	emailOutboxConfig := config.LoadEmailOutboxConfig()
+	emailDispatcher := services.NewEmailDispatcher(
+		outboxRepo,
+		deliveryService,
+		systemClock,
+		services.EmailDispatchPolicy{
+			BatchSize:     emailOutboxConfig.BatchSize,
+			MaxAttempts:   emailOutboxConfig.MaxAttempts,
+			BaseDelay:     emailOutboxConfig.BaseDelay,
+			MaxDelay:      emailOutboxConfig.MaxDelay,
+			LeaseDuration: emailOutboxConfig.LeaseDuration,
+		},
+		emailOutboxConfig.DispatchInterval,
+	)
+	go emailDispatcher.Run(context.Background())
+
+	if swapRequestConfig.SweepInterval > 0 {
+		swapRequestSweeper := services.NewSwapRequestSweeper(swapRequestService, swapRequestConfig.SweepInterval)
+		go swapRequestSweeper.Run(context.Background())
+	}
+
 	routes.SetupRoutes(
 		router,
 		userHandler,
 		itemHandler,
 		swapRequestHandler,
 		passwordResetHandler,
-		middleware.JwtAuthMiddleware(os.Getenv("JWT_SECRET")),
+		sessionHandler,
+		jwksHandler,
+		twoFactorHandler,
+		adminHandler,
+		notificationHandler,
+		middleware.JwtAuthMiddleware(keyRing, sessionService),
 	)
 
-	router.Static("/uploads", "./uploads")
+	if storageConfig.Driver == config.StorageDriverLocal {
+		router.Static(storageConfig.PublicURL, storageConfig.LocalDir)
+	}
 
-	err := router.Run(":9000")
+	err = router.Run(":9000")
 	if err != nil {
 		log.Fatal(err)
 		return
 	}
 }
 
+func newBlobStore(storageConfig config.StorageConfig, clock ports.Clock) ports.BlobStore {
+	switch storageConfig.Driver {
+	case config.StorageDriverS3:
+		return storage.NewS3BlobStore(storageConfig.S3, storageConfig.PublicURL, clock)
+	case config.StorageDriverMemory:
+		return storage.NewMemoryBlobStore(storageConfig.PublicURL)
+	default:
+		return storage.NewLocalBlobStore(storageConfig.LocalDir, storageConfig.PublicURL)
+	}
+}
+
+// newEmailDeliveryService picks the adapter the outbox dispatcher delivers
+// through.
+func newEmailDeliveryService(emailConfig config.EmailConfig, renderer ports.EmailRenderer) ports.EmailService {
+	switch emailConfig.Driver {
+	case config.EmailDriverFile:
+		return email.NewFileEmailService(emailConfig.FilePath, emailConfig.Sender, renderer)
+	case config.EmailDriverLog:
+		return email.NewLogEmailService(os.Stdout, emailConfig.Sender, renderer)
+	case config.EmailDriverMemory:
+		return email.NewMemoryEmailService(emailConfig.Sender, renderer)
+	default:
+		return email.NewSmtpEmailService(emailConfig, renderer)
+	}
+}
+
+func newLoginAttemptStore(loginThrottleConfig config.LoginThrottleConfig) ports.LoginAttemptStore {
+	if loginThrottleConfig.Store == config.LoginAttemptStoreMemory {
+		return memory.NewLoginAttemptMemoryStore()
+	}
+
+	return gormRepo.NewLoginAttemptGormStore(config.GetDB())
+}
+
 func migrate() {
+	if err := gormRepo.DiscardLegacyPasswordResets(config.DB); err != nil {
+		log.Fatalf("failed to discard legacy password resets: %v", err)
+	}
+
 	models := []interface{}{
 		&modelsPkg.UserModel{},
 		&modelsPkg.PasswordResetModel{},
+		&modelsPkg.SessionModel{},
+		&modelsPkg.TwoFactorModel{},
+		&modelsPkg.RecoveryCodeModel{},
+		&modelsPkg.LoginAttemptModel{},
+		&modelsPkg.OutboxMessageModel{},
+		&modelsPkg.NotificationModel{},
+		&modelsPkg.CategoryModel{},
 		&modelsPkg.ItemModel{},
+		&modelsPkg.ItemTagModel{},
+		&modelsPkg.ItemPictureModel{},
+		&modelsPkg.ItemPictureVariantModel{},
 		&modelsPkg.SwapRequestModel{},
+		&modelsPkg.SwapRequestItemModel{},
 	}
 
 	if err := config.DB.AutoMigrate(models...); err != nil {
 		log.Fatalf("failed to migrate models: %v", err)
 	}
+
+	if err := gormRepo.EnsureItemSearchIndex(config.DB); err != nil {
+		log.Fatalf("failed to create item search index: %v", err)
+	}
+
+	if err := gormRepo.SeedCategories(config.DB); err != nil {
+		log.Fatalf("failed to seed categories: %v", err)
+	}
+
+	if err := gormRepo.BackfillItemPictures(config.DB); err != nil {
+		log.Fatalf("failed to backfill item pictures: %v", err)
+	}
+
+	promoted, err := gormRepo.PromoteAdmins(config.DB, config.LoadAdminConfig().Emails)
+	if err != nil {
+		log.Fatalf("failed to promote admins: %v", err)
+	}
+	if promoted > 0 {
+		log.Printf("Promoted %d users to admin", promoted)
+	}
 }